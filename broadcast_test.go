@@ -0,0 +1,106 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestBroadcastMaxMin tests BroadcastMax and BroadcastMin when
+// comparing a vector to a scalar, a vector to a vector of length 1,
+// and a matrix to a vector
+func TestBroadcastMaxMin(t *testing.T) {
+	const tests int = 10 // Number of random tests to run
+	const rows, cols int = 4, 5
+	rand.Seed(time.Now().UnixNano())
+
+	cases := []struct {
+		name         string
+		aShape       []int
+		bShape       []int
+		rightPattern []byte
+
+		// index returns the index of b compared to index i of a
+		index func(i int) int
+	}{
+		{"vector-scalar", []int{cols}, []int{}, nil,
+			func(int) int { return 0 }},
+		{"vector-vector", []int{cols}, []int{1}, []byte{0},
+			func(int) int { return 0 }},
+		{"matrix-vector", []int{rows, cols}, []int{cols}, []byte{0},
+			func(i int) int { return i % cols }},
+	}
+
+	for _, c := range cases {
+		for i := 0; i < tests; i++ {
+			aBacking := randF64(tensor.ProdInts(c.aShape), -5, 5)
+			bSize := tensor.ProdInts(c.bShape)
+			if len(c.bShape) == 0 {
+				bSize = 1
+			}
+			bBacking := randF64(bSize, -5, 5)
+
+			g := G.NewGraph()
+			aTensor := tensor.NewDense(tensor.Float64, c.aShape,
+				tensor.WithBacking(append([]float64{}, aBacking...)))
+			a := G.NewTensor(g, tensor.Float64, len(c.aShape),
+				G.WithValue(aTensor), G.WithName("a"))
+
+			var b *G.Node
+			if len(c.bShape) == 0 {
+				b = G.NewScalar(g, tensor.Float64, G.WithValue(bBacking[0]),
+					G.WithName("b"))
+			} else {
+				bTensor := tensor.NewDense(tensor.Float64, c.bShape,
+					tensor.WithBacking(append([]float64{}, bBacking...)))
+				b = G.NewTensor(g, tensor.Float64, len(c.bShape),
+					G.WithValue(bTensor), G.WithName("b"))
+			}
+
+			max, err := BroadcastMax(a, b, nil, c.rightPattern)
+			if err != nil {
+				t.Fatalf("%v: %v", c.name, err)
+			}
+			min, err := BroadcastMin(a, b, nil, c.rightPattern)
+			if err != nil {
+				t.Fatalf("%v: %v", c.name, err)
+			}
+			var maxVal, minVal G.Value
+			G.Read(max, &maxVal)
+			G.Read(min, &minVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatalf("%v: %v", c.name, err)
+			}
+			vm.Close()
+
+			if !maxVal.Shape().Eq(tensor.Shape(c.aShape)) {
+				t.Errorf("%v: expected output shape %v but got %v", c.name,
+					c.aShape, maxVal.Shape())
+				continue
+			}
+
+			maxData := maxVal.Data().([]float64)
+			minData := minVal.Data().([]float64)
+			for j := range aBacking {
+				bElem := bBacking[c.index(j)]
+
+				maxTarget := math.Max(aBacking[j], bElem)
+				if maxData[j] != maxTarget {
+					t.Errorf("%v: incorrect max at index %d \nexpected: %v "+
+						"\nreceived: %v", c.name, j, maxTarget, maxData[j])
+				}
+				minTarget := math.Min(aBacking[j], bElem)
+				if minData[j] != minTarget {
+					t.Errorf("%v: incorrect min at index %d \nexpected: %v "+
+						"\nreceived: %v", c.name, j, minTarget, minData[j])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// Categorical is a categorical distribution parameterized by
+// unnormalized log probabilities (logits), which may hold a batch of
+// categorical distributions simultaneously. The last axis of the
+// logits tensor holds the categories, and all other axes are batch
+// dimensions. For example, if the logits have shape (2, 3), then the
+// Categorical holds 2 distributions over 3 categories.
+//
+// Categorical does not implement the Distribution interface, since
+// its samples are integer category indices. It is used, for example,
+// as the mixing distribution of a Mixture.
+type Categorical struct {
+	logits *G.Node
+
+	seed uint64
+}
+
+// NewCategorical returns a new Categorical with logits logits, whose
+// last axis holds the categories.
+func NewCategorical(logits *G.Node, seed uint64) (*Categorical, error) {
+	if logits.Dims() < 1 {
+		return nil, gop.ShapeErrorf("newCategorical: expected logits to "+
+			"have at least 1 dimension but got %v", logits.Dims())
+	}
+	if logits.Dtype() != tensor.Float64 && logits.Dtype() != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newCategorical: data type %v "+
+			"unsupported", logits.Dtype())
+	}
+
+	return &Categorical{
+		logits: logits,
+		seed:   seed,
+	}, nil
+}
+
+// Logits returns the logits of the receiver
+func (c *Categorical) Logits() *G.Node { return c.logits }
+
+// NumCategories returns the number of categories of the receiver
+func (c *Categorical) NumCategories() int {
+	return c.logits.Shape()[c.logits.Dims()-1]
+}
+
+// Shape returns the number of distributions stored by the receiver,
+// which is the shape of the logits less the category dimension
+func (c *Categorical) Shape() tensor.Shape {
+	return c.logits.Shape()[:c.logits.Dims()-1].Clone()
+}
+
+// Dtype returns the type that the receiver operates on
+func (c *Categorical) Dtype() tensor.Dtype { return c.logits.Dtype() }
+
+// LogProbs returns the normalized log probabilities of each category,
+// with the same shape as the logits
+func (c *Categorical) LogProbs() (*G.Node, error) {
+	logProbs, err := gop.LogSoftmax(c.logits, -1)
+	if err != nil {
+		return nil, fmt.Errorf("logProbs: %w", err)
+	}
+
+	return logProbs, nil
+}
+
+// Probs returns the probabilities of each category, with the same
+// shape as the logits
+func (c *Categorical) Probs() (*G.Node, error) {
+	logProbs, err := c.LogProbs()
+	if err != nil {
+		return nil, fmt.Errorf("probs: %w", err)
+	}
+
+	return G.Exp(logProbs)
+}
+
+// Entropy returns the entropy of the distribution(s) stored by the
+// receiver, -Σ softmax(logits) * log_softmax(logits), which has the
+// same shape as the receiver. The entropy is computed from the stable
+// log-softmax rather than by re-logging the probabilities, and
+// categories with zero probability, such as those masked with a logit
+// of -inf, contribute zero rather than NaN.
+func (c *Categorical) Entropy() (*G.Node, error) {
+	logProbs, err := c.LogProbs()
+	if err != nil {
+		return nil, fmt.Errorf("entropy: %w", err)
+	}
+	probs, err := G.Exp(logProbs)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: %w", err)
+	}
+
+	// Replace log probabilities of -inf by the lowest finite value, so
+	// that zero-probability categories contribute 0 * lowest = 0 rather
+	// than 0 * -inf = NaN
+	var lowest interface{} = -math.MaxFloat64
+	if c.Dtype() == tensor.Float32 {
+		lowest = float32(-math.MaxFloat32)
+	}
+	logProbs, err = gop.ClampMin(logProbs, lowest, false)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: could not guard zero "+
+			"probabilities: %w", err)
+	}
+
+	terms, err := G.HadamardProd(probs, logProbs)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: %w", err)
+	}
+	sum, err := gop.ReduceAdd(terms, -1, true)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: could not sum over categories: %w",
+			err)
+	}
+
+	return G.Neg(sum)
+}
+
+// Sample samples m category indices from the receiver. The returned
+// node is of type tensor.Int and has shape (m, c.Shape()...), even
+// when m == 1, which is consistent with the Normal. To sample a one-hot
+// encoding of the categories instead, use SampleOneHot. This operation
+// is not differentiable.
+func (c *Categorical) Sample(m int) (*G.Node, error) {
+	samples, err := c.sample(m, false)
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	return samples, nil
+}
+
+// SampleOneHot samples m categories from the receiver and returns
+// their one-hot encoding. The returned node has the same data type as
+// the logits and shape (m, c.Shape()..., c.NumCategories()), even when
+// m == 1. A receiver sampled with SampleOneHot draws the same
+// categories as one sampled with Sample under the same seed. This
+// operation is not differentiable.
+func (c *Categorical) SampleOneHot(m int) (*G.Node, error) {
+	samples, err := c.sample(m, true)
+	if err != nil {
+		return nil, fmt.Errorf("sampleOneHot: %w", err)
+	}
+
+	return samples, nil
+}
+
+// SampleMode returns the index of the most probable category of each
+// distribution stored by the receiver, as a deterministic sample. This
+// is useful for deterministic rollouts when evaluating policies. The
+// returned node is of type tensor.Int and has shape c.Shape(). Ties
+// are broken in favour of the lowest category index.
+func (c *Categorical) SampleMode() (*G.Node, error) {
+	mode, err := gop.Argmax(c.logits, -1)
+	if err != nil {
+		return nil, fmt.Errorf("sampleMode: %w", err)
+	}
+
+	return mode, nil
+}
+
+// sample samples m categories from the receiver, returning either the
+// category indices or a one-hot encoding of the categories.
+func (c *Categorical) sample(m int, oneHot bool) (*G.Node, error) {
+	probs, err := c.Probs()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := newCategoricalSampleOp(c.Dtype(), c.seed, m, oneHot,
+		probs.Shape()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return G.ApplyOp(op, probs)
+}
@@ -0,0 +1,365 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestCategorical tests that the Probs and LogProbs of a batch of
+// Categoricals are the softmax and log-softmax of the logits, and that
+// Sample draws categories in the correct proportions.
+func TestCategorical(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 10               // Number of tests to run
+	const dists int = 3                // Number of distributions
+	const k int = 4                    // Number of categories
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		logits := make([]float64, dists*k)
+		for j := range logits {
+			logits[j] = (rand.Float64() - 0.5) * 10.0
+		}
+
+		g := G.NewGraph()
+		logitsT := tensor.NewDense(tensor.Float64, []int{dists, k},
+			tensor.WithBacking(logits))
+		logitsNode := G.NewMatrix(g, tensor.Float64, G.WithValue(logitsT),
+			G.WithName("logits"))
+
+		c, err := NewCategorical(logitsNode, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !c.Shape().Eq(tensor.Shape{dists}) {
+			t.Errorf("expected shape %v but got %v", tensor.Shape{dists},
+				c.Shape())
+		}
+
+		probs, err := c.Probs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		logProbs, err := c.LogProbs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var probsVal, logProbsVal G.Value
+		G.Read(probs, &probsVal)
+		G.Read(logProbs, &logProbsVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < dists; j++ {
+			sum := 0.0
+			for _, l := range logits[j*k : (j+1)*k] {
+				sum += math.Exp(l)
+			}
+
+			for c := 0; c < k; c++ {
+				index := j*k + c
+				target := math.Exp(logits[index]) / sum
+
+				computed := probsVal.Data().([]float64)[index]
+				if math.Abs(computed-target) > threshold {
+					t.Errorf("probs: expected: %v received: %v", target,
+						computed)
+				}
+
+				computed = logProbsVal.Data().([]float64)[index]
+				if math.Abs(computed-math.Log(target)) > threshold {
+					t.Errorf("logProbs: expected: %v received: %v",
+						math.Log(target), computed)
+				}
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestCategoricalSample tests that Sample draws categories in
+// proportion to their probabilities.
+func TestCategoricalSample(t *testing.T) {
+	const samples int = 5000       // Number of samples to draw
+	const threshold float64 = 0.03 // Tolerance on the sampled proportion
+
+	probs := []float64{0.1, 0.2, 0.7}
+	logits := make([]float64, len(probs))
+	for i := range probs {
+		logits[i] = math.Log(probs[i])
+	}
+
+	g := G.NewGraph()
+	logitsT := tensor.NewDense(tensor.Float64, []int{len(logits)},
+		tensor.WithBacking(logits))
+	logitsNode := G.NewVector(g, tensor.Float64, G.WithValue(logitsT),
+		G.WithName("logits"))
+
+	c, err := NewCategorical(logitsNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sample, err := c.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sampleVal.Shape().Eq(tensor.Shape{samples}) {
+		t.Errorf("expected sample shape %v but got %v",
+			tensor.Shape{samples}, sampleVal.Shape())
+	}
+
+	counts := make([]int, len(probs))
+	for _, s := range sampleVal.Data().([]int) {
+		counts[s]++
+	}
+	for i := range counts {
+		proportion := float64(counts[i]) / float64(samples)
+		if math.Abs(proportion-probs[i]) > threshold {
+			t.Errorf("expected proportion %v of category %v but got %v",
+				probs[i], i, proportion)
+		}
+	}
+}
+
+// TestCategoricalSampleOneHot tests that SampleOneHot returns one-hot
+// encodings which argmax to the same categories that Sample draws under
+// the same seed, for both a batch of samples and a single sample.
+func TestCategoricalSampleOneHot(t *testing.T) {
+	const samples int = 50 // Number of samples to draw
+	const batch int = 3    // Number of distributions
+	const categories int = 4
+	rand.Seed(time.Now().UnixNano())
+
+	for _, m := range []int{samples, 1} {
+		seed := uint64(time.Now().UnixNano())
+		logits := make([]float64, batch*categories)
+		for i := range logits {
+			logits[i] = rand.NormFloat64()
+		}
+
+		g := G.NewGraph()
+		logitsT := tensor.NewDense(tensor.Float64, []int{batch, categories},
+			tensor.WithBacking(logits))
+		logitsNode := G.NewMatrix(g, tensor.Float64, G.WithValue(logitsT),
+			G.WithName("logits"))
+
+		indexCategorical, err := NewCategorical(logitsNode, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		oneHotCategorical, err := NewCategorical(logitsNode, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		indices, err := indexCategorical.Sample(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		oneHot, err := oneHotCategorical.SampleOneHot(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var indicesVal, oneHotVal G.Value
+		G.Read(indices, &indicesVal)
+		G.Read(oneHot, &oneHotVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		expected := tensor.Shape{m, batch, categories}
+		if !oneHotVal.Shape().Eq(expected) ||
+			len(oneHotVal.Shape()) != len(expected) {
+			t.Fatalf("expected one-hot shape %v but got %v", expected,
+				oneHotVal.Shape())
+		}
+		if oneHotVal.Dtype() != tensor.Float64 {
+			t.Errorf("expected one-hot data type %v but got %v",
+				tensor.Float64, oneHotVal.Dtype())
+		}
+
+		encoded := oneHotVal.Data().([]float64)
+		for i, index := range indicesVal.Data().([]int) {
+			row := encoded[i*categories : (i+1)*categories]
+
+			argmax, sum := 0, 0.0
+			for j := range row {
+				sum += row[j]
+				if row[j] > row[argmax] {
+					argmax = j
+				}
+			}
+			if sum != 1 {
+				t.Errorf("expected one-hot encoding but got %v", row)
+			}
+			if argmax != index {
+				t.Errorf("sample %v: one-hot %v does not encode category %v",
+					i, row, index)
+			}
+		}
+	}
+}
+
+// TestCategoricalEntropy tests the Entropy of a batch of Categoricals
+// against a manual computation, including categories masked out with
+// very negative or infinite logits.
+func TestCategoricalEntropy(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 10               // Number of tests to run
+	const dists int = 3                // Number of distributions
+	const k int = 4                    // Number of categories
+	rand.Seed(time.Now().UnixNano())
+
+	// masks are the logits used to mask out category 0 of each
+	// distribution
+	masks := []struct {
+		masked bool
+		logit  float64
+	}{
+		{false, 0},
+		{true, -1e9},
+		{true, math.Inf(-1)},
+	}
+
+	for i := 0; i < tests; i++ {
+		for _, mask := range masks {
+			logits := make([]float64, dists*k)
+			for j := range logits {
+				logits[j] = (rand.Float64() - 0.5) * 10.0
+				if mask.masked && j%k == 0 {
+					logits[j] = mask.logit
+				}
+			}
+
+			g := G.NewGraph()
+			logitsT := tensor.NewDense(tensor.Float64, []int{dists, k},
+				tensor.WithBacking(append([]float64{}, logits...)))
+			logitsNode := G.NewMatrix(g, tensor.Float64,
+				G.WithValue(logitsT), G.WithName("logits"))
+
+			c, err := NewCategorical(logitsNode,
+				uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entropy, err := c.Entropy()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var entropyVal G.Value
+			G.Read(entropy, &entropyVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			if !entropyVal.Shape().Eq(tensor.Shape{dists}) {
+				t.Errorf("expected shape %v but got %v",
+					tensor.Shape{dists}, entropyVal.Shape())
+			}
+
+			for j := 0; j < dists; j++ {
+				// Compute the target entropy over only the unmasked
+				// categories
+				row := logits[j*k : (j+1)*k]
+				if mask.masked {
+					row = row[1:]
+				}
+				sum := 0.0
+				for _, l := range row {
+					sum += math.Exp(l)
+				}
+				target := 0.0
+				for _, l := range row {
+					p := math.Exp(l) / sum
+					target -= p * math.Log(p)
+				}
+
+				computed := entropyVal.Data().([]float64)[j]
+				if math.IsNaN(computed) || math.IsInf(computed, 0) {
+					t.Errorf("mask %v: expected finite entropy but got %v",
+						mask.logit, computed)
+				} else if math.Abs(computed-target) > threshold {
+					t.Errorf("entropy: expected: %v received: %v", target,
+						computed)
+				}
+			}
+		}
+	}
+}
+
+// TestCategoricalSampleMode tests that SampleMode returns the most
+// probable category of each distribution on every run of the graph
+func TestCategoricalSampleMode(t *testing.T) {
+	const runs int = 3 // Number of times to run the graph
+	logits := []float64{
+		0.1, 2.0, -1.0, 0.5,
+		3.0, 3.0, 1.0, math.Inf(-1),
+		-2.0, -1.0, -3.0, -0.5,
+	}
+	target := []int{1, 0, 3}
+
+	g := G.NewGraph()
+	logitsT := tensor.NewDense(tensor.Float64, []int{3, 4},
+		tensor.WithBacking(logits))
+	logitsNode := G.NewMatrix(g, tensor.Float64, G.WithValue(logitsT),
+		G.WithName("logits"))
+
+	c, err := NewCategorical(logitsNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mode, err := c.SampleMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var modeVal G.Value
+	G.Read(mode, &modeVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	for i := 0; i < runs; i++ {
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !modeVal.Shape().Eq(c.Shape()) {
+			t.Errorf("expected shape %v but got %v", c.Shape(),
+				modeVal.Shape())
+		}
+		computed := modeVal.Data().([]int)
+		for j := range target {
+			if computed[j] != target[j] {
+				t.Errorf("run %v: expected mode %v but got %v", i, target,
+					computed)
+				break
+			}
+		}
+		vm.Reset()
+	}
+}
@@ -0,0 +1,345 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// Dirichlet is a Dirichlet distribution over the probability simplex,
+// which may hold a batch of Dirichlet distributions simultaneously.
+// The last axis of the concentration tensor is the event dimension,
+// and all other axes are batch dimensions. For example, if the
+// concentration has shape (2, 3), then the Dirichlet holds 2
+// distributions over the 3-category simplex.
+//
+// Any input to any method of the Dirichlet must have the same shape
+// as the concentration, except for possibly a batch dimension, which
+// is dimension 0 always.
+type Dirichlet struct {
+	concentration *G.Node
+
+	seed uint64
+}
+
+// NewDirichlet returns a new Dirichlet with concentration parameters
+// concentration, whose last axis is the event dimension.
+func NewDirichlet(concentration *G.Node, seed uint64) (*Dirichlet, error) {
+	if concentration.Dims() < 1 {
+		return nil, gop.ShapeErrorf("newDirichlet: expected concentration "+
+			"to have at least 1 dimension but got %v", concentration.Dims())
+	}
+	if concentration.Dtype() != tensor.Float64 &&
+		concentration.Dtype() != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newDirichlet: data type %v unsupported",
+			concentration.Dtype())
+	}
+	if k := concentration.Shape()[concentration.Dims()-1]; k < 2 {
+		return nil, gop.ShapeErrorf("newDirichlet: expected at least 2 "+
+			"categories but got %v", k)
+	}
+
+	return &Dirichlet{
+		concentration: concentration,
+		seed:          seed,
+	}, nil
+}
+
+// Prob calculates the probability density of x.
+func (d *Dirichlet) Prob(x *G.Node) (*G.Node, error) {
+	logProb, err := d.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("prob: %w", err)
+	}
+
+	return G.Exp(logProb)
+}
+
+// LogProb calculates the log probability density of x, which must lie
+// on the simplex along its last axis. If x has a value when LogProb is
+// called, then this is checked and an error returned if x does not lie
+// on the simplex. The event dimension of x is reduced, so that the
+// returned node has the shape of x less its last axis.
+func (d *Dirichlet) LogProb(x *G.Node) (*G.Node, error) {
+	batch, err := d.isBatch(x)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	if err := validateSimplex(x); err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	// log(p(x)) = Σ (α_j - 1) log(x_j) - log(B(α))
+	one := constant(x.Graph(), d.Dtype(), 1.0)
+	alphaMinusOne := G.Must(G.Sub(d.concentration, one))
+	logX := G.Must(G.Log(x))
+
+	var logProb *G.Node
+	if batch {
+		logProb = G.Must(G.BroadcastHadamardProd(logX, alphaMinusOne, nil,
+			[]byte{0}))
+	} else {
+		logProb = G.Must(G.HadamardProd(logX, alphaMinusOne))
+	}
+	logProb = G.Must(sumLast(logProb))
+
+	logB, err := d.logNormalizer()
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	if batch && !logB.IsScalar() {
+		return G.BroadcastSub(logProb, logB, nil, []byte{0})
+	}
+	return G.Sub(logProb, logB)
+}
+
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, α / Σα. The returned node has shape (1, d.Shape()...).
+func (d *Dirichlet) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(d)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// Cdf is not supported for the Dirichlet and always returns an error
+func (d *Dirichlet) Cdf(x *G.Node) (*G.Node, error) {
+	return nil, gop.UnsupportedOpErrorf("cdf: not supported for the " +
+		"Dirichlet")
+}
+
+// Sf is not supported for the Dirichlet and always returns an error
+func (d *Dirichlet) Sf(x *G.Node) (*G.Node, error) {
+	return nil, gop.UnsupportedOpErrorf("sf: not supported for the " +
+		"Dirichlet")
+}
+
+// Shape returns the number of distributions stored by the receiver,
+// which is the shape of the concentration less the event dimension
+func (d *Dirichlet) Shape() tensor.Shape {
+	return d.concentration.Shape()[:d.concentration.Dims()-1].Clone()
+}
+
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (d *Dirichlet) NumDistributions() int {
+	return tensor.ProdInts(d.Shape())
+}
+
+// Mean returns the mean of the distribution(s) stored by the
+// receiver, α / Σα
+func (d *Dirichlet) Mean() *G.Node {
+	alpha0 := G.Must(sumLast(d.concentration))
+
+	return G.Must(divLast(d.concentration, alpha0))
+}
+
+// Variance returns the element-wise variance of the distribution(s)
+// stored by the receiver, α̃(1 - α̃) / (Σα + 1), where α̃ is the mean.
+func (d *Dirichlet) Variance() *G.Node {
+	one := constant(d.concentration.Graph(), d.Dtype(), 1.0)
+	mean := d.Mean()
+	variance := G.Must(G.HadamardProd(mean, G.Must(G.Sub(one, mean))))
+
+	alpha0 := G.Must(sumLast(d.concentration))
+	alpha0 = G.Must(G.Add(alpha0, one))
+
+	return G.Must(divLast(variance, alpha0))
+}
+
+// StdDev returns the element-wise standard deviation of the
+// distribution(s) stored by the receiver
+func (d *Dirichlet) StdDev() *G.Node {
+	return G.Must(G.Sqrt(d.Variance()))
+}
+
+// Entropy returns the entropy of the distribution(s) stored by the
+// receiver:
+//
+//		log(B(α)) + (α_0 - K)ψ(α_0) - Σ (α_j - 1)ψ(α_j)
+//
+// where α_0 = Σα, K is the number of categories, and ψ is the
+// digamma function.
+func (d *Dirichlet) Entropy() (*G.Node, error) {
+	graph := d.concentration.Graph()
+	one := constant(graph, d.Dtype(), 1.0)
+	k := constant(graph, d.Dtype(),
+		float64(d.concentration.Shape()[d.concentration.Dims()-1]))
+
+	logB, err := d.logNormalizer()
+	if err != nil {
+		return nil, fmt.Errorf("entropy: %w", err)
+	}
+
+	alpha0 := G.Must(sumLast(d.concentration))
+	digammaAlpha0, err := gop.Digamma(alpha0)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: %w", err)
+	}
+	term := G.Must(G.HadamardProd(G.Must(G.Sub(alpha0, k)), digammaAlpha0))
+
+	digammaAlpha, err := gop.Digamma(d.concentration)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: %w", err)
+	}
+	sum := G.Must(G.Sub(d.concentration, one))
+	sum = G.Must(G.HadamardProd(sum, digammaAlpha))
+	sum = G.Must(sumLast(sum))
+
+	entropy := G.Must(G.Add(logB, term))
+	return G.Sub(entropy, sum)
+}
+
+// HasRsample returns whether the receiver supports reparameterized
+// sample -- false for the Dirichlet.
+func (d *Dirichlet) HasRsample() bool { return false }
+
+// Dtype returns the type that the receiver operates on
+func (d *Dirichlet) Dtype() tensor.Dtype { return d.concentration.Dtype() }
+
+// Rsample is not supported for the Dirichlet and always returns an
+// error
+func (d *Dirichlet) Rsample(m int) (*G.Node, error) {
+	return nil, gop.UnsupportedOpErrorf("rsample: not supported for the " +
+		"Dirichlet")
+}
+
+// Sample samples m samples from the receiver by normalizing
+// independent samples from Gamma(α_j, 1) distributions. The returned
+// node has shape (m, d.Shape()..., k), where k is the number of
+// categories, even when m == 1. This operation is not differentiable.
+func (d *Dirichlet) Sample(m int) (*G.Node, error) {
+	rate := full(d.concentration.Graph(), d.Dtype(),
+		d.concentration.Shape(), 1.0, "rate")
+
+	samples, err := GammaSample(d.concentration, rate, d.seed, m)
+	if err != nil {
+		return nil, fmt.Errorf("sample: could not sample from gamma: %w",
+			err)
+	}
+
+	samples, err = divLast(samples, G.Must(sumLast(samples)))
+	if err != nil {
+		return nil, fmt.Errorf("sample: could not normalize samples: %w",
+			err)
+	}
+
+	return samples, nil
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., d.Shape()..., k), where k is the number of
+// categories. This operation is not differentiable.
+func (d *Dirichlet) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(d.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// logNormalizer returns the log of the multivariate beta function of
+// the concentration, log(B(α)) = Σ log(Γ(α_j)) - log(Γ(Σα))
+func (d *Dirichlet) logNormalizer() (*G.Node, error) {
+	lgammaAlpha, err := gop.Lgamma(d.concentration)
+	if err != nil {
+		return nil, fmt.Errorf("logNormalizer: %w", err)
+	}
+
+	lgammaAlpha0, err := gop.Lgamma(G.Must(sumLast(d.concentration)))
+	if err != nil {
+		return nil, fmt.Errorf("logNormalizer: %w", err)
+	}
+
+	return G.Sub(G.Must(sumLast(lgammaAlpha)), lgammaAlpha0)
+}
+
+// isBatch returns whether x is a batch of samples to calculate some
+// method on. It returns an error if x has an invalid shape.
+func (d *Dirichlet) isBatch(x *G.Node) (bool, error) {
+	shape := d.concentration.Shape()
+	if x.Shape().Eq(shape) {
+		return false, nil
+	} else if x.Dims() == shape.Dims()+1 &&
+		tensor.Shape(x.Shape()[1:]).Eq(shape) {
+		return true, nil
+	}
+
+	msg := "expected shape to match concentration shape %v at all " +
+		"dimensions except batch (dim 0) but got x shape %v"
+	return false, gop.ShapeErrorf(msg, shape, x.Shape())
+}
+
+// sumLast sums x along its last axis
+func sumLast(x *G.Node) (*G.Node, error) {
+	return G.Sum(x, x.Dims()-1)
+}
+
+// divLast divides x by s, broadcasting s along the last axis of x.
+// The shape of s must be the shape of x less its last axis.
+func divLast(x, s *G.Node) (*G.Node, error) {
+	s, err := G.Reshape(s, append(s.Shape().Clone(), 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return G.BroadcastHadamardDiv(x, s, nil, []byte{byte(x.Dims() - 1)})
+}
+
+// validateSimplex returns an error if x has a value which does not lie
+// on the probability simplex along its last axis, within some
+// tolerance. If x does not yet have a value, nil is returned.
+func validateSimplex(x *G.Node) error {
+	if x.Value() == nil {
+		return nil
+	}
+
+	t, ok := x.Value().(tensor.Tensor)
+	if !ok {
+		return fmt.Errorf("expected x to be a tensor but got %T", x.Value())
+	}
+	if v, ok := t.(tensor.View); ok && v.IsMaterializable() {
+		t = v.Materialize()
+	}
+
+	var data []float64
+	tol := 1e-6
+	switch backing := t.Data().(type) {
+	case []float64:
+		data = backing
+	case []float32:
+		tol = 1e-4
+		data = make([]float64, len(backing))
+		for i := range backing {
+			data[i] = float64(backing[i])
+		}
+	default:
+		return fmt.Errorf("data type %v unsupported", t.Dtype())
+	}
+
+	k := t.Shape()[t.Dims()-1]
+	for i := 0; i < len(data); i += k {
+		sum := 0.0
+		for _, v := range data[i : i+k] {
+			if v < -tol {
+				return fmt.Errorf("x does not lie on the simplex: "+
+					"negative element %v", v)
+			}
+			sum += v
+		}
+		if math.Abs(sum-1.0) > tol {
+			return fmt.Errorf("x does not lie on the simplex: elements "+
+				"sum to %v", sum)
+		}
+	}
+
+	return nil
+}
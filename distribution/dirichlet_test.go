@@ -0,0 +1,298 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/stat/distmv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// dirichletEntropy returns the entropy of a Dirichlet distribution
+// with concentration alpha
+func dirichletEntropy(alpha []float64) float64 {
+	alpha0 := 0.0
+	logB := 0.0
+	for _, a := range alpha {
+		alpha0 += a
+		lgamma, _ := math.Lgamma(a)
+		logB += lgamma
+	}
+	lgamma, _ := math.Lgamma(alpha0)
+	logB -= lgamma
+
+	entropy := logB + (alpha0-float64(len(alpha)))*mathext.Digamma(alpha0)
+	for _, a := range alpha {
+		entropy -= (a - 1) * mathext.Digamma(a)
+	}
+
+	return entropy
+}
+
+// randomSimplex returns a random point on the k-category simplex
+func randomSimplex(k int) []float64 {
+	x := make([]float64, k)
+	sum := 0.0
+	for i := range x {
+		x[i] = 0.01 + rand.Float64()
+		sum += x[i]
+	}
+	for i := range x {
+		x[i] /= sum
+	}
+
+	return x
+}
+
+// TestDirichlet tests the LogProb, Prob, Mean, Variance, and Entropy
+// methods of a 3-category Dirichlet with a batch of 2 distributions
+// against gonum's Dirichlet.
+func TestDirichlet(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 10               // Number of tests to run
+	const dists int = 2                // Number of distributions
+	const k int = 3                    // Number of categories
+	const batch int = 4                // Number of inputs to LogProb
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		alpha := make([]float64, dists*k)
+		for j := range alpha {
+			alpha[j] = 0.1 + rand.Float64()*5.0
+		}
+
+		in := make([]float64, 0, batch*dists*k)
+		for j := 0; j < batch*dists; j++ {
+			in = append(in, randomSimplex(k)...)
+		}
+
+		g := G.NewGraph()
+		alphaT := tensor.NewDense(tensor.Float64, []int{dists, k},
+			tensor.WithBacking(alpha))
+		alphaNode := G.NewMatrix(g, tensor.Float64, G.WithValue(alphaT),
+			G.WithName("concentration"))
+
+		d, err := NewDirichlet(alphaNode, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !d.Shape().Eq(tensor.Shape{dists}) {
+			t.Errorf("expected shape %v but got %v", tensor.Shape{dists},
+				d.Shape())
+		}
+
+		inT := tensor.NewDense(tensor.Float64, []int{batch, dists, k},
+			tensor.WithBacking(in))
+		x := G.NewTensor(g, tensor.Float64, 3, G.WithValue(inT),
+			G.WithName("x"))
+
+		logProb, err := d.LogProb(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prob, err := d.Prob(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entropy, err := d.Entropy()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Ensure gradients can be computed with respect to the
+		// concentration
+		if _, err := G.Grad(G.Must(G.Sum(logProb)), alphaNode); err != nil {
+			t.Fatal(err)
+		}
+
+		var logProbVal, probVal, meanVal, varianceVal, entropyVal G.Value
+		G.Read(logProb, &logProbVal)
+		G.Read(prob, &probVal)
+		G.Read(d.Mean(), &meanVal)
+		G.Read(d.Variance(), &varianceVal)
+		G.Read(entropy, &entropyVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < dists; j++ {
+			a := alpha[j*k : (j+1)*k]
+			target := distmv.NewDirichlet(a, nil)
+
+			alpha0 := 0.0
+			for _, v := range a {
+				alpha0 += v
+			}
+			mean := target.Mean(nil)
+			for c := 0; c < k; c++ {
+				computed := meanVal.Data().([]float64)[j*k+c]
+				if math.Abs(computed-mean[c]) > threshold {
+					t.Errorf("mean: expected: %v received: %v", mean[c],
+						computed)
+				}
+
+				variance := mean[c] * (1 - mean[c]) / (alpha0 + 1)
+				computed = varianceVal.Data().([]float64)[j*k+c]
+				if math.Abs(computed-variance) > threshold {
+					t.Errorf("variance: expected: %v received: %v",
+						variance, computed)
+				}
+			}
+
+			targetEntropy := dirichletEntropy(a)
+			computed := entropyVal.Data().([]float64)[j]
+			if math.Abs(computed-targetEntropy) > threshold {
+				t.Errorf("entropy: expected: %v received: %v",
+					targetEntropy, computed)
+			}
+
+			for b := 0; b < batch; b++ {
+				start := (b*dists + j) * k
+				point := in[start : start+k]
+
+				targetLogProb := target.LogProb(point)
+				computed := logProbVal.Data().([]float64)[b*dists+j]
+				if math.Abs(computed-targetLogProb) > threshold {
+					t.Errorf("logProb: expected: %v received: %v",
+						targetLogProb, computed)
+				}
+
+				targetProb := target.Prob(point)
+				computed = probVal.Data().([]float64)[b*dists+j]
+				if math.Abs(computed-targetProb) > threshold {
+					t.Errorf("prob: expected: %v received: %v",
+						targetProb, computed)
+				}
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestDirichletSample tests that samples from a single 3-category
+// Dirichlet have the correct shape and lie on the simplex.
+func TestDirichletSample(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const k int = 3                    // Number of categories
+	const samples int = 20             // Number of samples to draw
+
+	g := G.NewGraph()
+	alphaT := tensor.NewDense(tensor.Float64, []int{k},
+		tensor.WithBacking([]float64{0.5, 1.0, 3.0}))
+	alpha := G.NewVector(g, tensor.Float64, G.WithValue(alphaT),
+		G.WithName("concentration"))
+
+	d, err := NewDirichlet(alpha, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sample, err := d.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	single, err := d.Sample(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var singleVal G.Value
+	G.Read(single, &singleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sampleVal.Shape().Eq(tensor.Shape{samples, k}) {
+		t.Errorf("expected sample shape %v but got %v",
+			tensor.Shape{samples, k}, sampleVal.Shape())
+	}
+	if !singleVal.Shape().Eq(tensor.Shape{1, k}) {
+		t.Errorf("expected sample shape %v but got %v", tensor.Shape{1, k},
+			singleVal.Shape())
+	}
+
+	data := sampleVal.Data().([]float64)
+	for i := 0; i < samples; i++ {
+		sum := 0.0
+		for _, v := range data[i*k : (i+1)*k] {
+			if v < 0 {
+				t.Errorf("sampled negative value %v", v)
+			}
+			sum += v
+		}
+		if math.Abs(sum-1.0) > threshold {
+			t.Errorf("expected sample to sum to 1 but got %v", sum)
+		}
+	}
+}
+
+// TestDirichletSimplex tests that LogProb returns an error when its
+// input does not lie on the simplex.
+func TestDirichletSimplex(t *testing.T) {
+	g := G.NewGraph()
+	alphaT := tensor.NewDense(tensor.Float64, []int{3},
+		tensor.WithBacking([]float64{1.0, 2.0, 3.0}))
+	alpha := G.NewVector(g, tensor.Float64, G.WithValue(alphaT),
+		G.WithName("concentration"))
+
+	d, err := NewDirichlet(alpha, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := [][]float64{
+		{0.5, 0.5, 0.5},
+		{1.2, -0.1, -0.1},
+	}
+	for _, in := range inputs {
+		inT := tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking(in))
+		x := G.NewVector(g, tensor.Float64, G.WithValue(inT))
+
+		if _, err := d.LogProb(x); err == nil {
+			t.Errorf("expected error for input %v off the simplex", in)
+		}
+	}
+
+	// A batch of inputs on the simplex should be accepted
+	in := []float64{0.2, 0.3, 0.5, 0.6, 0.3, 0.1}
+	inT := tensor.NewDense(tensor.Float64, []int{2, 3},
+		tensor.WithBacking(in))
+	x := G.NewMatrix(g, tensor.Float64, G.WithValue(inT))
+
+	logProb, err := d.LogProb(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logProbVal G.Value
+	G.Read(logProb, &logProbVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	target := distmv.NewDirichlet([]float64{1.0, 2.0, 3.0}, nil)
+	for i := 0; i < 2; i++ {
+		targetLogProb := target.LogProb(in[i*3 : (i+1)*3])
+		computed := logProbVal.Data().([]float64)[i]
+		if math.Abs(computed-targetLogProb) > 0.000001 {
+			t.Errorf("logProb: expected: %v received: %v", targetLogProb,
+				computed)
+		}
+	}
+}
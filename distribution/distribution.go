@@ -2,10 +2,29 @@
 package distribution
 
 import (
+	"github.com/samuelfneumann/gop"
 	G "gorgonia.org/gorgonia"
 	"gorgonia.org/tensor"
 )
 
+// ValidateArgs determines whether the arguments to distribution methods
+// are validated when the computational graph is run. Validation is off
+// by default, since it requires an extra pass over each argument. When
+// on, running a graph containing, for example, a Quantile of a
+// probability outside (0, 1) results in an error rather than silently
+// producing NaN or ±Inf.
+var ValidateArgs = false
+
+// validateProbs returns p unchanged if ValidateArgs is false, and
+// otherwise returns a node that checks that each element of p is a
+// probability within (0, 1) when the graph is run
+func validateProbs(p *G.Node) (*G.Node, error) {
+	if !ValidateArgs {
+		return p, nil
+	}
+	return gop.CheckProbs(p)
+}
+
 // Quantiler is a Distribution that can return the inverse of the CDF
 // function, sometimes called the quantile function.
 type Quantiler interface {
@@ -27,9 +46,23 @@ type Distribution interface {
 	// distribution
 	Cdf(*G.Node) (*G.Node, error)
 
+	// Sf returns the survival function, P(X > x), of the node. For
+	// univariate distributions, this is the complementary cumulative
+	// distribution function 1 - Cdf. The shape of the node is treated
+	// in the same way as for Cdf.
+	Sf(*G.Node) (*G.Node, error)
+
 	Entropy() (*G.Node, error)
+
+	// Shape returns the batch shape of the distribution, where each
+	// element of the batch is an independent distribution
 	Shape() tensor.Shape
 
+	// NumDistributions returns the number of independent
+	// distributions held, which is the product of the dimensions of
+	// Shape
+	NumDistributions() int
+
 	// LogProb returns the log of the probability density of
 	// mass of the node. The shape of the node must be
 	// compatible with the shape of the distribution.
@@ -54,6 +87,11 @@ type Distribution interface {
 	// distribution
 	Prob(*G.Node) (*G.Node, error)
 
+	// LogProbAtMean returns the log of the probability density or
+	// mass of the distribution evaluated at its own mean, with a
+	// leading batch dimension of size 1
+	LogProbAtMean() (*G.Node, error)
+
 	Mean() *G.Node
 	StdDev() *G.Node
 	Variance() *G.Node
@@ -68,6 +106,13 @@ type Distribution interface {
 	// function is differentiable.
 	Rsample(samples int) (*G.Node, error)
 
+	// SampleShape is like Sample, but returns samples with the
+	// arbitrary leading sample shape shape, rather than a single
+	// batch dimension. The returned node has shape
+	// (shape..., drawShape...), where drawShape is the shape of a
+	// single draw from the distribution.
+	SampleShape(shape ...int) (*G.Node, error)
+
 	// Returns whether the distribution has reparameterized samples or
 	// not
 	HasRsample() bool
@@ -0,0 +1,335 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// CompareCdfToGonum checks the Cdf method of d against the reference
+// CDF gonumCDF at each of points. Each point is broadcast to every
+// distribution held by d, and so every distribution in d should have
+// the same parameters as the distribution which gonumCDF describes.
+//
+// CompareCdfToGonum runs the graph of d, and so should be called
+// after all other computations on the graph have been set up.
+func CompareCdfToGonum(t *testing.T, d Distribution,
+	gonumCDF func(float64) float64, points []float64) {
+	t.Helper()
+	compareToReference(t, d, "cdf", d.Cdf, gonumCDF, points)
+}
+
+// CompareSfToGonum checks the Sf method of d against the reference
+// survival function gonumSurvival at each of points, in the same way
+// that CompareCdfToGonum checks the Cdf method.
+func CompareSfToGonum(t *testing.T, d Distribution,
+	gonumSurvival func(float64) float64, points []float64) {
+	t.Helper()
+	compareToReference(t, d, "sf", d.Sf, gonumSurvival, points)
+}
+
+// compareToReference checks the method f of d, named name, against the
+// reference function at each of points. Each point is broadcast to
+// every distribution held by d.
+func compareToReference(t *testing.T, d Distribution, name string,
+	f func(*G.Node) (*G.Node, error), reference func(float64) float64,
+	points []float64) {
+	t.Helper()
+
+	// Threshold to consider floats equal
+	dt := d.Mean().Dtype()
+	threshold := 0.000001
+	if dt == tensor.Float32 {
+		threshold = 0.0001
+	}
+
+	shape := append([]int{len(points)}, d.Shape()...)
+	size := tensor.ProdInts(d.Shape())
+
+	// Construct the batch of inputs, where each batch element holds a
+	// single point for all distributions
+	backing64 := make([]float64, len(points)*size)
+	for i := range backing64 {
+		backing64[i] = points[i/size]
+	}
+	var inT *tensor.Dense
+	if dt == tensor.Float32 {
+		backing32 := make([]float32, len(backing64))
+		for i := range backing64 {
+			backing32[i] = float32(backing64[i])
+		}
+		inT = tensor.NewDense(dt, shape, tensor.WithBacking(backing32))
+	} else {
+		inT = tensor.NewDense(dt, shape, tensor.WithBacking(backing64))
+	}
+
+	g := d.Mean().Graph()
+	in := G.NewTensor(g, dt, inT.Dims(), G.WithValue(inT),
+		G.WithName(name+"Input"))
+
+	out, err := f(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal G.Value
+	G.Read(out, &outVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	var computed []float64
+	switch data := outVal.Data().(type) {
+	case []float64:
+		computed = data
+	case []float32:
+		computed = make([]float64, len(data))
+		for i := range data {
+			computed[i] = float64(data[i])
+		}
+	}
+
+	if len(computed) != len(backing64) {
+		t.Fatalf("expected %v %v values but got %v", len(backing64), name,
+			len(computed))
+	}
+
+	for i := range computed {
+		target := reference(backing64[i])
+		if math.Abs(computed[i]-target) > threshold {
+			t.Errorf("%v: expected: %v received: %v for input: %v", name,
+				target, computed[i], backing64[i])
+		}
+	}
+}
+
+// CheckRsample checks that the Rsample method of d produces samples of
+// the correct shape both when a single sample is drawn and when
+// multiple samples are drawn, and that gradients of the samples can be
+// computed with respect to params. A single sample is expected to keep
+// its batch dimension of size 1.
+//
+// CheckRsample creates a new tape machine on the graph of d, and so
+// should be called after all other computations on the graph have been
+// set up.
+func CheckRsample(t *testing.T, d Distribution, params ...*G.Node) {
+	t.Helper()
+	const samples int = 3 // Number of samples to draw when m > 1
+
+	oneSample, err := d.Rsample(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manySamples, err := d.Rsample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var oneVal, manyVal G.Value
+	G.Read(oneSample, &oneVal)
+	G.Read(manySamples, &manyVal)
+
+	loss := G.Must(G.Add(G.Must(G.Sum(oneSample)),
+		G.Must(G.Sum(manySamples))))
+	if _, err := G.Grad(loss, params...); err != nil {
+		t.Fatal(err)
+	}
+
+	vm := G.NewTapeMachine(oneSample.Graph())
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	single := tensor.Shape(append([]int{1}, d.Shape()...))
+	if !oneVal.Shape().Eq(single) {
+		t.Errorf("rsample(1): expected shape %v but got %v", single,
+			oneVal.Shape())
+	}
+
+	expected := tensor.Shape(append([]int{samples}, d.Shape()...))
+	if !manyVal.Shape().Eq(expected) {
+		t.Errorf("rsample(%v): expected shape %v but got %v", samples,
+			expected, manyVal.Shape())
+	}
+
+	for i, param := range params {
+		if grad, err := param.Grad(); err != nil {
+			t.Errorf("could not get gradient of parameter %v: %v", i, err)
+		} else if !grad.Shape().Eq(param.Shape()) {
+			t.Errorf("expected gradient of parameter %v to have shape %v "+
+				"but got %v", i, param.Shape(), grad.Shape())
+		}
+	}
+}
+
+// CheckSample checks that the Sample method of d produces samples of
+// the correct shape, and that no gradient flows through the samples to
+// params. The gradient of the sum of the samples and params with
+// respect to each of params is expected to be 1 everywhere, the
+// gradient of the sum of the param itself.
+//
+// CheckSample creates a new tape machine on the graph of d, and so
+// should be called after all other computations on the graph have been
+// set up.
+func CheckSample(t *testing.T, d Distribution, params ...*G.Node) {
+	t.Helper()
+	const samples int = 3 // Number of samples to draw
+
+	sample, err := d.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	loss := G.Must(G.Sum(sample))
+	for _, param := range params {
+		loss = G.Must(G.Add(loss, G.Must(G.Sum(param))))
+	}
+	if _, err := G.Grad(loss, params...); err != nil {
+		t.Fatal(err)
+	}
+
+	vm := G.NewTapeMachine(sample.Graph())
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := tensor.Shape(append([]int{samples}, d.Shape()...))
+	if !sampleVal.Shape().Eq(expected) {
+		t.Errorf("sample(%v): expected shape %v but got %v", samples,
+			expected, sampleVal.Shape())
+	}
+
+	for i, param := range params {
+		grad, err := param.Grad()
+		if err != nil {
+			t.Errorf("could not get gradient of parameter %v: %v", i, err)
+			continue
+		}
+
+		var data []float64
+		switch g := grad.Data().(type) {
+		case []float64:
+			data = g
+		case []float32:
+			for _, v := range g {
+				data = append(data, float64(v))
+			}
+		}
+		for j, v := range data {
+			if v != 1 {
+				t.Errorf("expected no gradient through the samples to "+
+					"parameter %v but got gradient %v at index %v", i, v-1, j)
+			}
+		}
+	}
+}
+
+// CheckQuantileRoundTrip checks that the Quantile method of q is the
+// inverse of its Cdf method. A batch of samples is drawn from q, and
+// an error is returned if Quantile(Cdf(x)) differs from any sample x
+// by more than tolerance, relative to the magnitude of x when
+// |x| > 1. This gives every Quantiler a standard correctness check.
+//
+// CheckQuantileRoundTrip creates a new tape machine on the graph of q,
+// and so should be called after all other computations on the graph
+// have been set up.
+func CheckQuantileRoundTrip(q Quantiler, samples int,
+	tolerance float64) error {
+	x, err := q.Sample(samples)
+	if err != nil {
+		return fmt.Errorf("checkQuantileRoundTrip: could not sample: %v",
+			err)
+	}
+	cdf, err := q.Cdf(x)
+	if err != nil {
+		return fmt.Errorf("checkQuantileRoundTrip: could not compute "+
+			"cdf: %v", err)
+	}
+	roundTrip, err := q.Quantile(cdf)
+	if err != nil {
+		return fmt.Errorf("checkQuantileRoundTrip: could not compute "+
+			"quantile: %v", err)
+	}
+
+	var xVal, roundTripVal G.Value
+	G.Read(x, &xVal)
+	G.Read(roundTrip, &roundTripVal)
+
+	vm := G.NewTapeMachine(x.Graph())
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		return fmt.Errorf("checkQuantileRoundTrip: could not run graph: %v",
+			err)
+	}
+
+	toF64 := func(v G.Value) []float64 {
+		switch data := v.Data().(type) {
+		case []float64:
+			return data
+		case []float32:
+			out := make([]float64, len(data))
+			for i := range data {
+				out[i] = float64(data[i])
+			}
+			return out
+		}
+		return nil
+	}
+	xData, roundTripData := toF64(xVal), toF64(roundTripVal)
+	if xData == nil || len(xData) != len(roundTripData) {
+		return fmt.Errorf("checkQuantileRoundTrip: expected %v values of "+
+			"type []float64 or []float32 but got %T and %T", xVal.Size(),
+			xVal.Data(), roundTripVal.Data())
+	}
+
+	for i := range xData {
+		scale := math.Max(1, math.Abs(xData[i]))
+		diff := math.Abs(roundTripData[i] - xData[i])
+		if diff > tolerance*scale {
+			return fmt.Errorf("checkQuantileRoundTrip: expected "+
+				"Quantile(Cdf(%v)) = %v but got %v", xData[i], xData[i],
+				roundTripData[i])
+		}
+	}
+
+	return nil
+}
+
+// newRandomVectors returns one float64 vector of length size on graph
+// g for each of names, as well as the backing of each vector. The
+// elements at each index are drawn by calling draw with a slice
+// holding one element per vector, in the order of names, so that the
+// element of one vector may depend on those of the vectors before it.
+func newRandomVectors(g *G.ExprGraph, size int, names []string,
+	draw func(x []float64)) ([]*G.Node, [][]float64) {
+	backings := make([][]float64, len(names))
+	for i := range backings {
+		backings[i] = make([]float64, size)
+	}
+
+	x := make([]float64, len(names))
+	for j := 0; j < size; j++ {
+		draw(x)
+		for i := range backings {
+			backings[i][j] = x[i]
+		}
+	}
+
+	vectors := make([]*G.Node, len(names))
+	for i, name := range names {
+		vectors[i] = G.NewVector(g, tensor.Float64, G.WithName(name),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(backings[i]))))
+	}
+
+	return vectors, backings
+}
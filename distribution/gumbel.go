@@ -0,0 +1,305 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// eulerGamma is the Euler-Mascheroni constant
+const eulerGamma float64 = 0.57721566490153286060651209008240243104215933593992
+
+// Gumbel is a univariate Gumbel distribution (also called the type-I
+// generalized extreme value distribution), which may hold a batch of
+// Gumbel distributions simultaneously. The location and scale tensors
+// are treated element-wise in the same way as the mean and standard
+// deviation tensors of the Normal, and so any input to any method of
+// the Gumbel must have a shape consistent with that of the Gumbel, as
+// described in the documentation for Normal.
+type Gumbel struct {
+	loc   *G.Node
+	scale *G.Node
+
+	seed uint64
+}
+
+// NewGumbel returns a new Gumbel with location loc and scale scale.
+func NewGumbel(loc, scale *G.Node, seed uint64) (*Gumbel, error) {
+	if !loc.Shape().Eq(scale.Shape()) {
+		return nil, gop.ShapeErrorf("newGumbel: expected loc and scale "+
+			"to have the same shape but got %v and %v", loc.Shape(),
+			scale.Shape())
+	}
+	if loc.Dtype() != scale.Dtype() {
+		return nil, gop.DtypeErrorf("newGumbel: expected loc and scale "+
+			"to have the same data type but got %v and %v", loc.Dtype(),
+			scale.Dtype())
+	} else if loc.Dtype() != tensor.Float64 &&
+		loc.Dtype() != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newGumbel: data type %v unsupported",
+			loc.Dtype())
+	}
+
+	var err error
+	if loc.IsScalar() {
+		loc, err = G.Reshape(loc, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newGumbel: could not expand loc to "+
+				"shape (1): %w", err)
+		}
+		scale, err = G.Reshape(scale, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newGumbel: could not expand scale to "+
+				"shape (1): %w", err)
+		}
+	}
+
+	return &Gumbel{
+		loc:   loc,
+		scale: scale,
+		seed:  seed,
+	}, nil
+}
+
+// Prob calculates the probability density of x. The shape of x is
+// treated in the same way as the Normal's Prob() method.
+func (g *Gumbel) Prob(x *G.Node) (*G.Node, error) {
+	logProb, err := g.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("prob: %w", err)
+	}
+
+	return G.Exp(logProb)
+}
+
+// LogProb calculates the log probability density of x. The shape of
+// x is treated in the same way as the Normal's Prob() method.
+func (g *Gumbel) LogProb(x *G.Node) (*G.Node, error) {
+	z, err := g.standardize(x)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	// log(p(x)) = -(z + exp(-z)) - log(scale)
+	expNegZ := G.Must(G.Exp(G.Must(G.Neg(z))))
+	logProb := G.Must(G.Neg(G.Must(G.Add(z, expNegZ))))
+
+	lnScale := G.Must(G.Log(g.scale))
+	if isBatch(z, g.Shape()) {
+		logProb = G.Must(G.BroadcastSub(logProb, lnScale, nil, []byte{0}))
+	} else {
+		logProb = G.Must(G.Sub(logProb, lnScale))
+	}
+
+	return logProb, nil
+}
+
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, loc + γ⋅scale. The returned node has shape
+// (1, g.Shape()...).
+func (g *Gumbel) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(g)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// Cdf computes the cumulative distribution function of x. The shape
+// of x is treated in the same way as the Normal's Prob() method.
+func (g *Gumbel) Cdf(x *G.Node) (*G.Node, error) {
+	z, err := g.standardize(x)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	// cdf(x) = exp(-exp(-z))
+	cdf := G.Must(G.Exp(G.Must(G.Neg(z))))
+	cdf = G.Must(G.Exp(G.Must(G.Neg(cdf))))
+
+	return cdf, nil
+}
+
+// Sf computes the survival function, 1 - Cdf(x), of x. The survival
+// function is computed as -expm1(-exp(-z)), which does not lose
+// precision in the right tail. The shape of x is treated in the same
+// way as the Normal's Prob() method.
+func (g *Gumbel) Sf(x *G.Node) (*G.Node, error) {
+	z, err := g.standardize(x)
+	if err != nil {
+		return nil, fmt.Errorf("sf: %w", err)
+	}
+
+	// sf(x) = 1 - exp(-exp(-z)) = -expm1(-exp(-z))
+	sf := G.Must(G.Exp(G.Must(G.Neg(z))))
+	sf = G.Must(gop.Expm1(G.Must(G.Neg(sf))))
+
+	return G.Neg(sf)
+}
+
+// Quantile computes the inverse cumulative distribution function at
+// probability p. The shape of p is treated in the same way as the
+// Normal's Prob() method.
+func (g *Gumbel) Quantile(p *G.Node) (*G.Node, error) {
+	p, err := fixShape(p, g.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	p, err = validateProbs(p)
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	// quantile(p) = loc - scale * log(-log(p))
+	w := G.Must(G.Log(p))
+	w = G.Must(G.Log(G.Must(G.Neg(w))))
+	w = G.Must(G.Neg(w))
+
+	return g.affine(w)
+}
+
+// Shape returns the number of distributions stored by the receiver
+func (g *Gumbel) Shape() tensor.Shape {
+	return g.loc.Shape()
+}
+
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (g *Gumbel) NumDistributions() int {
+	return tensor.ProdInts(g.Shape())
+}
+
+// Mean returns the mean of the distribution(s) stored by the
+// receiver, loc + γ⋅scale, where γ is the Euler-Mascheroni constant
+func (g *Gumbel) Mean() *G.Node {
+	gamma := constant(g.loc.Graph(), g.Dtype(), eulerGamma)
+	mean := G.Must(G.HadamardProd(g.scale, gamma))
+
+	return G.Must(G.Add(g.loc, mean))
+}
+
+// Variance returns the variance of the distribution(s) stored by the
+// receiver
+func (g *Gumbel) Variance() *G.Node {
+	c := constant(g.loc.Graph(), g.Dtype(), math.Pi*math.Pi/6.0)
+	variance := G.Must(G.Square(g.scale))
+
+	return G.Must(G.HadamardProd(variance, c))
+}
+
+// StdDev returns the standard deviation of the distribution(s) stored
+// by the receiver
+func (g *Gumbel) StdDev() *G.Node {
+	c := constant(g.loc.Graph(), g.Dtype(), math.Pi/math.Sqrt(6.0))
+
+	return G.Must(G.HadamardProd(g.scale, c))
+}
+
+// Entropy returns the entropy of the distribution(s) stored by the
+// receiver, log(scale) + γ + 1, where γ is the Euler-Mascheroni
+// constant
+func (g *Gumbel) Entropy() (*G.Node, error) {
+	c := constant(g.loc.Graph(), g.Dtype(), eulerGamma+1.0)
+	entropy := G.Must(G.Log(g.scale))
+
+	return G.Add(entropy, c)
+}
+
+// HasRsample returns whether the receiver supports reparameterized
+// sample -- true for the Gumbel.
+func (g *Gumbel) HasRsample() bool { return true }
+
+// Dtype returns the type that the receiver operates on
+func (g *Gumbel) Dtype() tensor.Dtype { return g.loc.Dtype() }
+
+// Rsample samples m samples from the receiver using reparameterized
+// sampling through the inverse CDF. The returned node has shape
+// (m, g.Shape()...), even when m == 1, which is consistent with the
+// Normal. This is a differentiable operation.
+func (g *Gumbel) Rsample(m int) (*G.Node, error) {
+	graph := g.loc.Graph()
+	low := full(graph, g.Dtype(), g.Shape(), 0.0, "low")
+	high := full(graph, g.Dtype(), g.Shape(), 1.0, "high")
+
+	u, err := UniformSample(low, high, g.seed, m)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: could not sample from "+
+			"standard uniform: %w", err)
+	}
+
+	// Standard Gumbel noise: -log(-log(u))
+	w := G.Must(G.Log(u))
+	w = G.Must(G.Log(G.Must(G.Neg(w))))
+	w = G.Must(G.Neg(w))
+
+	out, err := reparameterize(w, g.loc, g.scale)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	return out, nil
+}
+
+// Sample samples m samples from the receiver. The samples are drawn
+// through the inverse CDF as in Rsample, but the gradient is stopped
+// at the samples, so that the parameters of the receiver receive no
+// gradient through them. This operation is not differentiable.
+func (g *Gumbel) Sample(m int) (*G.Node, error) {
+	samples, err := g.Rsample(m)
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	return gop.StopGradient(samples)
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., g.Shape()...). This operation is not
+// differentiable.
+func (g *Gumbel) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(g.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// standardize returns (x - loc) / scale. The shape of x is treated in
+// the same way as the Normal's Prob() method.
+func (g *Gumbel) standardize(x *G.Node) (*G.Node, error) {
+	x, err := fixShape(x, g.Shape())
+	if err != nil {
+		return nil, err
+	}
+
+	if isBatch(x, g.Shape()) {
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, g.loc, nil, batchDim))
+		x = G.Must(G.BroadcastHadamardDiv(x, g.scale, nil, batchDim))
+	} else {
+		x = G.Must(G.Sub(x, g.loc))
+		x = G.Must(G.HadamardDiv(x, g.scale))
+	}
+
+	return x, nil
+}
+
+// affine returns loc + scale * w, the inverse of standardize
+func (g *Gumbel) affine(w *G.Node) (*G.Node, error) {
+	if isBatch(w, g.Shape()) {
+		batchDim := []byte{0}
+		w = G.Must(G.BroadcastHadamardProd(w, g.scale, nil, batchDim))
+		w = G.Must(G.BroadcastAdd(w, g.loc, nil, batchDim))
+	} else {
+		w = G.Must(G.HadamardProd(w, g.scale))
+		w = G.Must(G.Add(w, g.loc))
+	}
+
+	return w, nil
+}
@@ -0,0 +1,531 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// drawGumbelParams draws the location and scale of a random Gumbel
+func drawGumbelParams(x []float64) {
+	const scale float64 = 2.0
+	const scaleOffset float64 = 0.001
+
+	x[0] = (rand.Float64() - 0.5) * scale
+	x[1] = (math.Exp(rand.Float64()) + scaleOffset) * scale
+}
+
+// TestGumbel tests the Prob, LogProb, Cdf, and Quantile methods of the
+// Gumbel on random vector parameters and batches of inputs against
+// gonum's GumbelRight distribution.
+func TestGumbel(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const minSize int = 1              // Minimum number of distributions
+	const maxSize int = 10             // Maximum number of distributions
+	const minBatch int = 1             // Minimum batch size
+	const maxBatch int = 10            // Maximum batch size
+	rand.Seed(time.Now().UnixNano())
+
+	type method struct {
+		name   string
+		f      func(*Gumbel, *G.Node) (*G.Node, error)
+		target func(distuv.GumbelRight, float64) float64
+		input  func() float64
+	}
+	methods := []method{
+		{
+			name:   "Prob",
+			f:      (*Gumbel).Prob,
+			target: distuv.GumbelRight.Prob,
+			input:  func() float64 { return (rand.Float64() - 0.5) * 6.0 },
+		},
+		{
+			name:   "LogProb",
+			f:      (*Gumbel).LogProb,
+			target: distuv.GumbelRight.LogProb,
+			input:  func() float64 { return (rand.Float64() - 0.5) * 6.0 },
+		},
+		{
+			name:   "Cdf",
+			f:      (*Gumbel).Cdf,
+			target: distuv.GumbelRight.CDF,
+			input:  func() float64 { return (rand.Float64() - 0.5) * 6.0 },
+		},
+		{
+			name:   "Quantile",
+			f:      (*Gumbel).Quantile,
+			target: distuv.GumbelRight.Quantile,
+			input:  func() float64 { return 0.001 + rand.Float64()*0.998 },
+		},
+	}
+
+	for _, m := range methods {
+		for i := 0; i < tests; i++ {
+			size := minSize + rand.Intn(maxSize-minSize)
+			batch := minBatch + rand.Intn(maxBatch-minBatch)
+
+			g := G.NewGraph()
+			params, backing := newRandomVectors(g, size,
+				[]string{"loc", "scale"}, drawGumbelParams)
+			gumbel, err := NewGumbel(params[0], params[1],
+				uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			loc, scale := backing[0], backing[1]
+
+			// Construct the input and target
+			inBacking := make([]float64, batch*size)
+			target := make([]float64, batch*size)
+			for j := range inBacking {
+				inBacking[j] = m.input()
+				dist := distuv.GumbelRight{
+					Mu:   loc[j%size],
+					Beta: scale[j%size],
+				}
+				target[j] = m.target(dist, inBacking[j])
+			}
+			inT := tensor.NewDense(
+				tensor.Float64,
+				[]int{batch, size},
+				tensor.WithBacking(inBacking),
+			)
+			in := G.NewMatrix(g, tensor.Float64, G.WithValue(inT),
+				G.WithName("input"))
+
+			out, err := m.f(gumbel, in)
+			if err != nil {
+				t.Error(err)
+			}
+			var outVal G.Value
+			G.Read(out, &outVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Error(err)
+			}
+
+			outData := outVal.Data().([]float64)
+			for j := range target {
+				if math.Abs(outData[j]-target[j]) > threshold {
+					t.Errorf("%v: expected: %v received: %v for input: %v",
+						m.name, target[j], outData[j], inBacking[j])
+				}
+			}
+
+			vm.Close()
+		}
+	}
+}
+
+// TestGumbelMoments tests the Mean, Variance, StdDev, and Entropy
+// methods of the Gumbel on random vector parameters against gonum's
+// GumbelRight distribution.
+func TestGumbelMoments(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const minSize int = 1              // Minimum number of distributions
+	const maxSize int = 10             // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := minSize + rand.Intn(maxSize-minSize)
+
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"loc", "scale"}, drawGumbelParams)
+		gumbel, err := NewGumbel(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		loc, scale := backing[0], backing[1]
+
+		entropy, err := gumbel.Entropy()
+		if err != nil {
+			t.Error(err)
+		}
+		nodes := []*G.Node{gumbel.Mean(), gumbel.Variance(), gumbel.StdDev(),
+			entropy}
+		names := []string{"Mean", "Variance", "StdDev", "Entropy"}
+		values := make([]G.Value, len(nodes))
+		for j := range nodes {
+			G.Read(nodes[j], &values[j])
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Error(err)
+		}
+
+		for j := 0; j < size; j++ {
+			dist := distuv.GumbelRight{Mu: loc[j], Beta: scale[j]}
+			targets := []float64{dist.Mean(), dist.Variance(), dist.StdDev(),
+				dist.Entropy()}
+
+			for k := range targets {
+				computed := values[k].Data().([]float64)[j]
+				if math.Abs(computed-targets[k]) > threshold {
+					t.Errorf("%v: expected: %v received: %v", names[k],
+						targets[k], computed)
+				}
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestGumbelRsample tests that Rsample produces samples of the correct
+// shape which differ on consecutive runs of the graph and through
+// which gradients can be computed.
+func TestGumbelRsample(t *testing.T) {
+	const threshold float64 = 0.00000001 // Threshold to consider floats equal
+	const size int = 5                   // Number of distributions
+	const samples int = 7                // Number of samples to draw
+
+	g := G.NewGraph()
+	params, _ := newRandomVectors(g, size,
+		[]string{"loc", "scale"}, drawGumbelParams)
+	gumbel, err := NewGumbel(params[0], params[1],
+		uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sample, err := gumbel.Rsample(samples)
+	if err != nil {
+		t.Error(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	loss := G.Must(G.Sum(sample))
+	if _, err := G.Grad(loss, gumbel.loc, gumbel.scale); err != nil {
+		t.Error(err)
+	}
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Error(err)
+	}
+	sample1, err := G.CloneValue(sampleVal)
+	if err != nil {
+		t.Error(err)
+	}
+	vm.Reset()
+	if err := vm.RunAll(); err != nil {
+		t.Error(err)
+	}
+
+	if !sample1.Shape().Eq(tensor.Shape{samples, size}) {
+		t.Errorf("expected sample shape %v but got %v",
+			tensor.Shape{samples, size}, sample1.Shape())
+	}
+
+	sample1Data := sample1.Data().([]float64)
+	sample2Data := sampleVal.Data().([]float64)
+	for i := range sample1Data {
+		if math.Abs(sample1Data[i]-sample2Data[i]) <= threshold {
+			t.Error("consecutive runs sampled the same data")
+		}
+	}
+
+	vm.Close()
+}
+
+// TestGumbelCdfGonum tests the Cdf method of the Gumbel against
+// gonum's CDF for the GumbelRight distribution at random points.
+func TestGumbelCdfGonum(t *testing.T) {
+	const tests int = 15  // Number of tests to run
+	const points int = 20 // Number of points to check per test
+	const scaleOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		loc := (rand.Float64() - 0.5) * 2.0
+		scale := math.Exp(rand.Float64()) + scaleOffset
+
+		g := G.NewGraph()
+		locNode := G.NewScalar(g, tensor.Float64, G.WithValue(loc),
+			G.WithName("loc"))
+		scaleNode := G.NewScalar(g, tensor.Float64, G.WithValue(scale),
+			G.WithName("scale"))
+
+		gumbel, err := NewGumbel(locNode, scaleNode,
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		in := make([]float64, points)
+		for j := range in {
+			in[j] = loc + (rand.Float64()-0.5)*6.0*scale
+		}
+
+		target := distuv.GumbelRight{Mu: loc, Beta: scale}
+		CompareCdfToGonum(t, gumbel, target.CDF, in)
+	}
+}
+
+// TestGumbelSf tests the Sf method of the Gumbel against 1 - Cdf using
+// gonum's GumbelRight survival function in the bulk of the
+// distribution, and against -expm1(-exp(-z)) far in the right tail,
+// where 1 - Cdf rounds to 0.
+func TestGumbelSf(t *testing.T) {
+	const tolerance float64 = 1e-6 // Relative tolerance in the tail
+	const tests int = 15           // Number of tests to run
+	const points int = 20          // Number of points to check per test
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		loc := (rand.Float64() - 0.5) * 2.0
+		scale := math.Exp(rand.Float64()) + 0.001
+
+		g := G.NewGraph()
+		locNode := G.NewScalar(g, tensor.Float64, G.WithValue(loc),
+			G.WithName("loc"))
+		scaleNode := G.NewScalar(g, tensor.Float64, G.WithValue(scale),
+			G.WithName("scale"))
+
+		gumbel, err := NewGumbel(locNode, scaleNode,
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		in := make([]float64, points)
+		for j := range in {
+			in[j] = loc + (rand.Float64()-0.5)*6.0*scale
+		}
+
+		target := distuv.GumbelRight{Mu: loc, Beta: scale}
+		CompareSfToGonum(t, gumbel, target.Survival, in)
+	}
+
+	// Points between 40 and 100 scales above the location
+	z := make([]float64, points)
+	for j := range z {
+		z[j] = 40.0 + rand.Float64()*60.0
+	}
+
+	g := G.NewGraph()
+	loc := G.NewScalar(g, tensor.Float64, G.WithValue(0.0),
+		G.WithName("loc"))
+	scale := G.NewScalar(g, tensor.Float64, G.WithValue(1.0),
+		G.WithName("scale"))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{points}, tensor.WithBacking(z))),
+		G.WithName("x"))
+
+	gumbel, err := NewGumbel(loc, scale, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sf, err := gumbel.Sf(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sfVal G.Value
+	G.Read(sf, &sfVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	standard := distuv.GumbelRight{Mu: 0.0, Beta: 1.0}
+	for j, computed := range sfVal.Data().([]float64) {
+		target := -math.Expm1(-math.Exp(-z[j]))
+		if naive := standard.Survival(z[j]); naive != 0 {
+			t.Errorf("expected 1 - cdf to round to 0 at %v but got %v",
+				z[j], naive)
+		}
+		if math.Abs(computed-target)/target > tolerance {
+			t.Errorf("expected: %v received: %v at %v", target, computed,
+				z[j])
+		}
+	}
+}
+
+// TestGumbelRsampleShape tests that Rsample on a Gumbel produces
+// samples of the correct shape for both a single sample and a batch of
+// samples, and that gradients can be computed through the samples.
+func TestGumbelRsampleShape(t *testing.T) {
+	const tests int = 10  // Number of tests to run
+	const maxSize int = 5 // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, _ := newRandomVectors(g, 1+rand.Intn(maxSize),
+			[]string{"loc", "scale"}, drawGumbelParams)
+		gumbel, err := NewGumbel(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		CheckRsample(t, gumbel, gumbel.loc, gumbel.scale)
+	}
+}
+
+// TestGumbelSample tests that Sample on a Gumbel produces samples of
+// the correct shape, and that no gradient flows through the samples
+// to the location and scale
+func TestGumbelSample(t *testing.T) {
+	const tests int = 10  // Number of tests to run
+	const maxSize int = 5 // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, _ := newRandomVectors(g, 1+rand.Intn(maxSize),
+			[]string{"loc", "scale"}, drawGumbelParams)
+		gumbel, err := NewGumbel(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		CheckSample(t, gumbel, gumbel.loc, gumbel.scale)
+	}
+}
+
+// TestGumbelSoftmaxSample tests that GumbelSoftmaxSample returns
+// probability vectors which approach a one-hot encoding as the
+// temperature decreases, and that its gradient with respect to the
+// logits is correct
+func TestGumbelSoftmaxSample(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const maxBatch int = 10            // Maximum number of distributions
+	const maxCategories int = 6        // Maximum number of categories
+	const oneHotThreshold float64 = 0.99
+	taus := []float64{1.0, 0.1, 0.01, 0.0001}
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		batch := 1 + rand.Intn(maxBatch)
+		k := 2 + rand.Intn(maxCategories-1)
+		seed := uint64(time.Now().UnixNano())
+
+		logitsBacking := make([]float64, batch*k)
+		weights := make([]float64, batch*k)
+		for j := range logitsBacking {
+			logitsBacking[j] = (rand.Float64() - 0.5) * 4
+			weights[j] = (rand.Float64() - 0.5) * 4
+		}
+
+		// Maximum probability of each distribution for the previous tau
+		prevMax := make([]float64, batch)
+
+		// Gap between the two largest perturbed logits of each
+		// distribution, computed from the samples with the first tau
+		gaps := make([]float64, batch)
+		for _, tau := range taus {
+			g := G.NewGraph()
+			logits := G.NewMatrix(g, tensor.Float64, G.WithName("logits"),
+				G.WithValue(tensor.NewDense(tensor.Float64, []int{batch, k},
+					tensor.WithBacking(append([]float64{},
+						logitsBacking...)))))
+			w := G.NewMatrix(g, tensor.Float64, G.WithName("w"),
+				G.WithValue(tensor.NewDense(tensor.Float64, []int{batch, k},
+					tensor.WithBacking(append([]float64{}, weights...)))))
+
+			sample, err := GumbelSoftmaxSample(logits, tau, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sampleVal G.Value
+			G.Read(sample, &sampleVal)
+
+			loss := G.Must(G.Sum(G.Must(G.HadamardProd(sample, w))))
+			grad, err := G.Grad(loss, logits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gradVal G.Value
+			G.Read(grad[0], &gradVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			if !sampleVal.Shape().Eq(tensor.Shape{batch, k}) {
+				t.Fatalf("expected shape %v but got %v",
+					tensor.Shape{batch, k}, sampleVal.Shape())
+			}
+
+			y := sampleVal.Data().([]float64)
+			gradData := gradVal.Data().([]float64)
+			for b := 0; b < batch; b++ {
+				row := y[b*k : (b+1)*k]
+				sum, max, weighted := 0.0, 0.0, 0.0
+				for c, p := range row {
+					sum += p
+					max = math.Max(max, p)
+					weighted += weights[b*k+c] * p
+				}
+				if math.Abs(sum-1.0) > threshold {
+					t.Errorf("tau %v: expected probabilities to sum to 1 "+
+						"but got %v", tau, sum)
+				}
+
+				// Since the same noise is used for each tau, the
+				// largest probability should increase as tau decreases
+				if max < prevMax[b]-threshold {
+					t.Errorf("tau %v: expected maximum probability to "+
+						"increase from %v but got %v", tau, prevMax[b], max)
+				}
+				prevMax[b] = max
+
+				if tau == taus[0] {
+					// log(row[c]) * tau is the perturbed logit of
+					// category c less a constant shared by the row
+					first, second := math.Inf(-1), math.Inf(-1)
+					for _, p := range row {
+						l := math.Log(p) * tau
+						if l > first {
+							first, second = l, first
+						} else if l > second {
+							second = l
+						}
+					}
+					gaps[b] = first - second
+				}
+
+				// The gradient of Σ w * softmax((logits + g) / tau) is
+				// y * (w - Σ w * y) / tau
+				for c, p := range row {
+					target := p * (weights[b*k+c] - weighted) / tau
+					if math.Abs(gradData[b*k+c]-target) > threshold*(1/tau) {
+						t.Errorf("tau %v: incorrect gradient \nexpected: %v "+
+							"\nreceived: %v", tau, target, gradData[b*k+c])
+					}
+				}
+			}
+		}
+
+		// With the smallest temperature, the samples should be
+		// approximately one-hot, unless the noise happened to make the
+		// two largest perturbed logits nearly tie
+		tau := taus[len(taus)-1]
+		for b, max := range prevMax {
+			if gaps[b] > 100*tau && max < oneHotThreshold {
+				t.Errorf("expected sample to be approximately one-hot for "+
+					"tau %v but got maximum probability %v with logit gap "+
+					"%v", tau, max, gaps[b])
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// HalfNormal is a univariate half-normal distribution, the
+// distribution of the absolute value of a zero-mean Normal, which may
+// hold a batch of half-normal distributions simultaneously. The scale
+// tensor is treated element-wise in the same way as the standard
+// deviation tensor of the Normal, and so any input to any method of
+// the HalfNormal must have a shape consistent with that of the
+// HalfNormal, as described in the documentation for Normal.
+//
+// The HalfNormal has support [0, ∞), and so its Prob is exactly 0 and
+// its LogProb exactly -Inf for negative inputs. It is commonly used as
+// a prior on scale parameters.
+type HalfNormal struct {
+	normal *Normal
+}
+
+var _ Quantiler = (*HalfNormal)(nil)
+
+// NewHalfNormal returns a new HalfNormal, which is the distribution of
+// |X| for X ~ 𝒩(0, stddev²).
+func NewHalfNormal(stddev *G.Node, seed uint64) (*HalfNormal, error) {
+	var err error
+	if stddev.IsScalar() {
+		stddev, err = G.Reshape(stddev, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newHalfNormal: could not expand "+
+				"stddev to shape (1): %w", err)
+		}
+	}
+
+	mean := full(stddev.Graph(), stddev.Dtype(), stddev.Shape(), 0.0,
+		"zeroMean")
+	normal, err := NewNormal(mean, stddev, seed)
+	if err != nil {
+		return nil, fmt.Errorf("newHalfNormal: %w", err)
+	}
+
+	return &HalfNormal{normal}, nil
+}
+
+// Prob calculates the probability density of x, which is 0 for
+// negative x. The shape of x is treated in the same way as the
+// Normal's Prob() method.
+func (h *HalfNormal) Prob(x *G.Node) (*G.Node, error) {
+	logProb, err := h.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("prob: %w", err)
+	}
+
+	return G.Exp(logProb)
+}
+
+// LogProb calculates the log probability density of x, which is that
+// of the underlying Normal plus log(2) for x ≥ 0, and -Inf otherwise.
+// The shape of x is treated in the same way as the Normal's Prob()
+// method.
+func (h *HalfNormal) LogProb(x *G.Node) (*G.Node, error) {
+	x, err := fixShape(x, h.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	logProb, err := h.normal.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+	lnTwo := constant(x.Graph(), h.Dtype(), math.Ln2)
+	logProb = G.Must(G.Add(logProb, lnTwo))
+
+	zero := constant(x.Graph(), h.Dtype(), 0.0)
+	inSupport, err := G.Gte(x, zero, true)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: could not compute support: %w", err)
+	}
+
+	logProb, err = maskSupport(logProb, inSupport)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, σ√(2/π). The returned node has shape (1, h.Shape()...).
+func (h *HalfNormal) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(h)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// Cdf computes the cumulative distribution function of x, 2Φ(x) - 1,
+// which is 0 for negative x. The shape of x is treated in the same way
+// as the Normal's Prob() method.
+func (h *HalfNormal) Cdf(x *G.Node) (*G.Node, error) {
+	cdf, err := h.normal.Cdf(x)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	one := constant(cdf.Graph(), h.Dtype(), 1.0)
+	two := constant(cdf.Graph(), h.Dtype(), 2.0)
+	cdf = G.Must(G.Sub(G.Must(G.HadamardProd(two, cdf)), one))
+
+	var zero interface{} = 0.0
+	if h.Dtype() == tensor.Float32 {
+		zero = float32(0.0)
+	}
+
+	return gop.ClampMin(cdf, zero, false)
+}
+
+// Sf computes the survival function, 1 - Cdf(x), of x. The survival
+// function is computed as twice the survival function of the
+// underlying Normal, so that it does not lose precision in the right
+// tail. The shape of x is treated in the same way as the Normal's
+// Prob() method.
+func (h *HalfNormal) Sf(x *G.Node) (*G.Node, error) {
+	sf, err := h.normal.Sf(x)
+	if err != nil {
+		return nil, fmt.Errorf("sf: %w", err)
+	}
+
+	two := constant(sf.Graph(), h.Dtype(), 2.0)
+	sf = G.Must(G.HadamardProd(two, sf))
+
+	var max interface{} = 1.0
+	if h.Dtype() == tensor.Float32 {
+		max = float32(1.0)
+	}
+
+	return gop.ClampMax(sf, max, false)
+}
+
+// Quantile computes the inverse cumulative distribution function at
+// probability p, which is the quantile of the underlying Normal at
+// (1 + p) / 2. The shape of p is treated in the same way as the
+// Normal's Prob() method.
+func (h *HalfNormal) Quantile(p *G.Node) (*G.Node, error) {
+	p, err := fixShape(p, h.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	p, err = validateProbs(p)
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	one := constant(p.Graph(), h.Dtype(), 1.0)
+	half := constant(p.Graph(), h.Dtype(), 0.5)
+	p = G.Must(G.HadamardProd(G.Must(G.Add(one, p)), half))
+
+	return h.normal.Quantile(p)
+}
+
+// Shape returns the number of distributions stored by the receiver
+func (h *HalfNormal) Shape() tensor.Shape {
+	return h.normal.Shape()
+}
+
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (h *HalfNormal) NumDistributions() int {
+	return tensor.ProdInts(h.Shape())
+}
+
+// Scale returns the standard deviation σ of the underlying zero-mean
+// Normal, which differs from the standard deviation of the receiver
+func (h *HalfNormal) Scale() *G.Node {
+	return h.normal.StdDev()
+}
+
+// Mean returns the mean of the distribution(s) stored by the
+// receiver, σ√(2/π)
+func (h *HalfNormal) Mean() *G.Node {
+	c := constant(h.Scale().Graph(), h.Dtype(), math.Sqrt(2/math.Pi))
+
+	return G.Must(G.HadamardProd(h.Scale(), c))
+}
+
+// Variance returns the variance of the distribution(s) stored by the
+// receiver, σ²(1 - 2/π)
+func (h *HalfNormal) Variance() *G.Node {
+	c := constant(h.Scale().Graph(), h.Dtype(), 1-2/math.Pi)
+	variance := G.Must(G.Square(h.Scale()))
+
+	return G.Must(G.HadamardProd(variance, c))
+}
+
+// StdDev returns the standard deviation of the distribution(s) stored
+// by the receiver
+func (h *HalfNormal) StdDev() *G.Node {
+	c := constant(h.Scale().Graph(), h.Dtype(), math.Sqrt(1-2/math.Pi))
+
+	return G.Must(G.HadamardProd(h.Scale(), c))
+}
+
+// Entropy returns the entropy of the distribution(s) stored by the
+// receiver, log(σ√(πe/2))
+func (h *HalfNormal) Entropy() (*G.Node, error) {
+	c := constant(h.Scale().Graph(), h.Dtype(),
+		0.5*math.Log(math.Pi*math.E/2))
+	entropy := G.Must(G.Log(h.Scale()))
+
+	return G.Add(entropy, c)
+}
+
+// HasRsample returns whether the receiver supports reparameterized
+// sample -- true for the HalfNormal.
+func (h *HalfNormal) HasRsample() bool { return true }
+
+// Dtype returns the type that the receiver operates on
+func (h *HalfNormal) Dtype() tensor.Dtype { return h.normal.Dtype() }
+
+// Rsample samples m samples from the receiver using reparameterized
+// sampling, as the absolute value of reparameterized samples from the
+// underlying Normal. The returned node has shape (m, h.Shape()...),
+// even when m == 1, which is consistent with the Normal. This is a
+// differentiable operation.
+func (h *HalfNormal) Rsample(m int) (*G.Node, error) {
+	samples, err := h.normal.Rsample(m)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	return G.Abs(samples)
+}
+
+// Sample samples m samples from the receiver, as the absolute value of
+// samples from the underlying Normal. This operation is not
+// differentiable.
+func (h *HalfNormal) Sample(m int) (*G.Node, error) {
+	samples, err := h.normal.Sample(m)
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	return G.Abs(samples)
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., h.Shape()...). This operation is not
+// differentiable.
+func (h *HalfNormal) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(h.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
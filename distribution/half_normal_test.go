@@ -0,0 +1,268 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// drawHalfNormalParams draws the scale of a random HalfNormal
+func drawHalfNormalParams(x []float64) {
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+
+	x[0] = (math.Exp(rand.Float64()) + stdOffset) * scale
+}
+
+// TestHalfNormal tests the Prob, LogProb, Cdf, Sf, and Quantile
+// methods of the HalfNormal on random scales and batches of inputs,
+// including negative inputs outside of the support, against a
+// reference computed from gonum's Normal distribution
+func TestHalfNormal(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const maxSize int = 10             // Maximum number of distributions
+	const maxBatch int = 10            // Maximum batch size
+	rand.Seed(time.Now().UnixNano())
+
+	// The reference density of |X| for X ~ 𝒩(0, σ²) is twice that of
+	// X on [0, ∞) and 0 elsewhere
+	logProb := func(n distuv.Normal, x float64) float64 {
+		if x < 0 {
+			return math.Inf(-1)
+		}
+		return n.LogProb(x) + math.Ln2
+	}
+	cdf := func(n distuv.Normal, x float64) float64 {
+		return math.Max(2*n.CDF(x)-1, 0)
+	}
+
+	type method struct {
+		name   string
+		f      func(*HalfNormal, *G.Node) (*G.Node, error)
+		target func(distuv.Normal, float64) float64
+		input  func(float64) float64
+	}
+	anywhere := func(s float64) float64 {
+		return (rand.Float64() - 0.3) * 4 * s
+	}
+	methods := []method{
+		{
+			name: "Prob",
+			f:    (*HalfNormal).Prob,
+			target: func(n distuv.Normal, x float64) float64 {
+				return math.Exp(logProb(n, x))
+			},
+			input: anywhere,
+		},
+		{
+			name:   "LogProb",
+			f:      (*HalfNormal).LogProb,
+			target: logProb,
+			input:  anywhere,
+		},
+		{
+			name:   "Cdf",
+			f:      (*HalfNormal).Cdf,
+			target: cdf,
+			input:  anywhere,
+		},
+		{
+			name: "Sf",
+			f:    (*HalfNormal).Sf,
+			target: func(n distuv.Normal, x float64) float64 {
+				return 1 - cdf(n, x)
+			},
+			input: anywhere,
+		},
+		{
+			name: "Quantile",
+			f:    (*HalfNormal).Quantile,
+			target: func(n distuv.Normal, p float64) float64 {
+				return n.Quantile((1 + p) / 2)
+			},
+			input: func(float64) float64 { return 0.001 + rand.Float64()*0.998 },
+		},
+	}
+
+	for _, m := range methods {
+		for i := 0; i < tests; i++ {
+			size := 1 + rand.Intn(maxSize)
+			batch := 1 + rand.Intn(maxBatch)
+
+			g := G.NewGraph()
+			params, backing := newRandomVectors(g, size,
+				[]string{"stddev"}, drawHalfNormalParams)
+			h, err := NewHalfNormal(params[0],
+				uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stddev := backing[0]
+
+			inBacking := make([]float64, batch*size)
+			target := make([]float64, batch*size)
+			for j := range inBacking {
+				n := distuv.Normal{Mu: 0, Sigma: stddev[j%size]}
+				inBacking[j] = m.input(stddev[j%size])
+				target[j] = m.target(n, inBacking[j])
+			}
+			in := G.NewMatrix(g, tensor.Float64, G.WithName("input"),
+				G.WithValue(tensor.NewDense(tensor.Float64, []int{batch, size},
+					tensor.WithBacking(inBacking))))
+
+			out, err := m.f(h, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var outVal G.Value
+			G.Read(out, &outVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			for j, v := range outVal.Data().([]float64) {
+				if math.IsInf(target[j], -1) && math.IsInf(v, -1) {
+					continue
+				}
+				if math.Abs(v-target[j]) > threshold {
+					t.Errorf("%v: expected: %v received: %v for input: %v",
+						m.name, target[j], v, inBacking[j])
+				}
+			}
+		}
+	}
+}
+
+// TestHalfNormalSupport tests that the Prob of the HalfNormal is
+// exactly 0, and the LogProb exactly -Inf, for negative inputs, and
+// that samples are non-negative
+func TestHalfNormalSupport(t *testing.T) {
+	const samples int = 200 // Number of samples to draw
+	const size int = 5      // Number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	g := G.NewGraph()
+	params, _ := newRandomVectors(g, size,
+		[]string{"stddev"}, drawHalfNormalParams)
+	h, err := NewHalfNormal(params[0], uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inBacking := make([]float64, size)
+	for i := range inBacking {
+		inBacking[i] = -rand.Float64() - 1e-6
+	}
+	in := G.NewVector(g, tensor.Float64, G.WithName("input"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+			tensor.WithBacking(inBacking))))
+
+	prob, err := h.Prob(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logProb, err := h.LogProb(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample, err := h.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var probVal, logProbVal, sampleVal G.Value
+	G.Read(prob, &probVal)
+	G.Read(logProb, &logProbVal)
+	G.Read(sample, &sampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range inBacking {
+		if p := probVal.Data().([]float64)[i]; p != 0 {
+			t.Errorf("prob: expected 0 but got %v for input %v", p,
+				inBacking[i])
+		}
+		if lp := logProbVal.Data().([]float64)[i]; !math.IsInf(lp, -1) {
+			t.Errorf("logProb: expected -Inf but got %v for input %v", lp,
+				inBacking[i])
+		}
+	}
+
+	if !sampleVal.Shape().Eq(tensor.Shape{samples, size}) {
+		t.Errorf("expected sample shape %v but got %v",
+			tensor.Shape{samples, size}, sampleVal.Shape())
+	}
+	for _, s := range sampleVal.Data().([]float64) {
+		if s < 0 {
+			t.Errorf("expected non-negative sample but got %v", s)
+		}
+	}
+}
+
+// TestHalfNormalMoments tests the Mean, Variance, StdDev, and Entropy
+// of the HalfNormal against their closed forms
+func TestHalfNormalMoments(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const maxSize int = 10             // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := 1 + rand.Intn(maxSize)
+
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"stddev"}, drawHalfNormalParams)
+		h, err := NewHalfNormal(params[0],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stddev := backing[0]
+
+		entropy, err := h.Entropy()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := []*G.Node{h.Mean(), h.Variance(), h.StdDev(), entropy}
+		names := []string{"Mean", "Variance", "StdDev", "Entropy"}
+		values := make([]G.Value, len(nodes))
+		for j := range nodes {
+			G.Read(nodes[j], &values[j])
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		for j, s := range stddev {
+			targets := []float64{
+				s * math.Sqrt(2/math.Pi),
+				s * s * (1 - 2/math.Pi),
+				s * math.Sqrt(1-2/math.Pi),
+				0.5*math.Log(math.Pi*s*s/2) + 0.5,
+			}
+			for k := range targets {
+				computed := values[k].Data().([]float64)[j]
+				if math.Abs(computed-targets[k]) > threshold {
+					t.Errorf("%v: expected: %v received: %v", names[k],
+						targets[k], computed)
+				}
+			}
+		}
+	}
+}
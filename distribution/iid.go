@@ -5,6 +5,7 @@ import (
 
 	"github.com/samuelfneumann/gop"
 	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
 )
 
 // Event dims always taken from right...
@@ -23,89 +24,261 @@ func (i *IID) SetDims(dims int) {
 	i.dims = dims
 }
 
+// Shape returns the batch shape of the receiver, which is the shape of
+// the underlying distribution less the trailing dims event dimensions
+func (i *IID) Shape() tensor.Shape {
+	shape := i.Distribution.Shape()
+	if i.checkDims() != nil {
+		return shape
+	}
+	return shape[:len(shape)-i.dims].Clone()
+}
+
+// BatchShape returns the batch shape of the receiver, and is
+// equivalent to Shape. Each element of the batch is an independent
+// distribution over events of shape EventShape.
+func (i *IID) BatchShape() tensor.Shape {
+	return i.Shape()
+}
+
+// NumDistributions returns the number of independent distributions
+// stored by the receiver, which is the product of the dimensions of
+// its batch shape. Unlike for the underlying distribution, the event
+// dimensions do not contribute to the number of distributions.
+func (i *IID) NumDistributions() int {
+	return tensor.ProdInts(i.BatchShape())
+}
+
+// EventShape returns the shape of a single event of the receiver,
+// which is the trailing dims dimensions of the shape of the
+// underlying distribution
+func (i *IID) EventShape() tensor.Shape {
+	shape := i.Distribution.Shape()
+	if i.checkDims() != nil {
+		return tensor.Shape{}
+	}
+	return shape[len(shape)-i.dims:].Clone()
+}
+
+// Mean returns the element-wise mean of the underlying distribution.
+// Since the components of each event of the receiver are independent,
+// the mean of an event is the vector of the means of its components,
+// and so the returned node has the shape of the underlying
+// distribution, (i.Shape()..., i.EventShape()...).
+func (i *IID) Mean() *G.Node {
+	return i.Distribution.Mean()
+}
+
+// Variance returns the element-wise variance of the underlying
+// distribution. Since the components of each event of the receiver
+// are independent, the covariance of an event is diagonal, and the
+// returned node holds its diagonal. The returned node has the shape of
+// the underlying distribution, (i.Shape()..., i.EventShape()...).
+//
+// Unlike Entropy, which is additive over independent components and
+// so is summed over the event dimensions, the variance is not reduced.
+func (i *IID) Variance() *G.Node {
+	return i.Distribution.Variance()
+}
+
+// StdDev returns the element-wise standard deviation of the underlying
+// distribution. The returned node has the same shape as that returned
+// by Variance.
+func (i *IID) StdDev() *G.Node {
+	return i.Distribution.StdDev()
+}
+
+// Sample samples m samples from the receiver. Each sample is an
+// independent draw from each element of the underlying distribution,
+// and so the returned node has shape (m, i.Shape()..., i.EventShape()...),
+// where the event dimensions are those reduced by Prob and LogProb,
+// even when m == 1. This operation is not differentiable.
+func (i *IID) Sample(m int) (*G.Node, error) {
+	if err := i.checkDims(); err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	samples, err := i.Distribution.Sample(m)
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	return samples, nil
+}
+
+// Rsample samples m reparameterized samples from the receiver. The
+// shape of the returned node is the same as that of Sample. This
+// operation is differentiable.
+func (i *IID) Rsample(m int) (*G.Node, error) {
+	if err := i.checkDims(); err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	samples, err := i.Distribution.Rsample(m)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	return samples, nil
+}
+
+// SampleShape samples prod(shape) samples from the receiver. The
+// returned node has shape (shape..., i.Shape()..., i.EventShape()...).
+// This operation is not differentiable.
+func (i *IID) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(i.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// checkDims returns an error if the receiver reinterprets more
+// dimensions as event dimensions than the underlying distribution has
+func (i *IID) checkDims() error {
+	if i.dims < 0 || i.dims > len(i.Distribution.Shape()) {
+		return gop.ShapeErrorf("cannot reinterpret %v dimensions of a "+
+			"distribution with shape %v as event dimensions", i.dims,
+			i.Distribution.Shape())
+	}
+
+	return nil
+}
+
+// eventAxes returns the trailing axes which are reinterpreted as
+// event dimensions
+func (i *IID) eventAxes() []int {
+	axes := make([]int, i.dims)
+	for j := range axes {
+		axes[j] = -(j + 1)
+	}
+	return axes
+}
+
+// Prob computes the joint probability density of x, which is the
+// product of the densities of the underlying distribution over the
+// event dimensions. Given an input of shape (a, i.Shape()...,
+// i.EventShape()...), the returned node has shape (a, i.Shape()...);
+// the event dimensions are removed, but a batch dimension of length 1
+// is not.
 func (i *IID) Prob(x *G.Node) (*G.Node, error) {
 	if x.Dims() < i.dims {
-		return nil, fmt.Errorf("prob: expected dims >= %v but got %v", i.dims,
+		return nil, gop.ShapeErrorf("prob: expected dims >= %v but got %v", i.dims,
 			x.Dims())
 	}
 
 	x, err := i.Distribution.Prob(x)
 	if err != nil {
-		return nil, fmt.Errorf("prob: could not compute iid prob: %v", err)
+		return nil, fmt.Errorf("prob: could not compute iid prob: %w", err)
 	}
 
-	// Combine event dims
-	for j := 0; j < i.dims; j++ {
-		x, err = gop.ReduceProd(x, x.Dims()-1, true)
-		if err != nil {
-			return nil, fmt.Errorf("prob: could not combine event dims: %v",
-				err)
-		}
+	// Combine event dims, keeping the other dimensions so that the
+	// output does not depend on whether the batch has length 1
+	x, err = gop.ReduceProdAxes(x, i.eventAxes(), true)
+	if err != nil {
+		return nil, fmt.Errorf("prob: could not combine event dims: %w", err)
 	}
 
 	return x, nil
 }
 
+// LogProb computes the joint log probability density of x, which is
+// the sum of the log densities of the underlying distribution over
+// the event dimensions. The returned node has the same shape as that
+// returned by Prob.
 func (i *IID) LogProb(x *G.Node) (*G.Node, error) {
 	if x.Dims() < i.dims {
-		return nil, fmt.Errorf("logProb: expected dims >= %v but got %v", i.dims,
+		return nil, gop.ShapeErrorf("logProb: expected dims >= %v but got %v", i.dims,
 			x.Dims())
 	}
 
 	x, err := i.Distribution.LogProb(x)
 	if err != nil {
-		return nil, fmt.Errorf("logProb: could not compute iid prob: %v", err)
+		return nil, fmt.Errorf("logProb: could not compute iid prob: %w", err)
 	}
 
 	// Combine event dims
-	for j := 0; j < i.dims; j++ {
-		x, err = gop.ReduceAdd(x, x.Dims()-1, true)
-		if err != nil {
-			return nil, fmt.Errorf("logProb: could not combine event dims: %v",
-				err)
-		}
+	x, err = gop.ReduceAddAxes(x, i.eventAxes(), true)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: could not combine event dims: %w", err)
 	}
 
 	return x, nil
 }
 
+// LogProbAtMean returns the log probability density of the receiver
+// at its element-wise mean, summed over the event dimensions. The
+// returned node has the same shape as that returned by LogProb for a
+// batch holding a single sample.
+func (i *IID) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(i)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
 func (i *IID) Entropy() (*G.Node, error) {
 	x, err := i.Distribution.Entropy()
 	if err != nil {
 		return nil, fmt.Errorf("entropy: could not take entropy of each "+
-			"i.i.d. variable: %v", err)
+			"i.i.d. variable: %w", err)
 	}
 
 	// Combine event dims
-	for j := 0; j < i.dims; j++ {
-		x, err = gop.ReduceAdd(x, x.Dims()-1, true)
-		if err != nil {
-			return nil, fmt.Errorf("entropy: could not combine event dims: %v",
-				err)
-		}
+	x, err = gop.ReduceAddAxes(x, i.eventAxes(), true)
+	if err != nil {
+		return nil, fmt.Errorf("entropy: could not combine event dims: %w", err)
 	}
 
 	return x, nil
 }
 
+// Cdf computes the joint cumulative distribution function, P(X <= x),
+// of x, which is the product of the cumulative distribution functions
+// of the underlying distribution over the event dimensions. The
+// returned node has the same shape as that returned by Prob.
 func (i *IID) Cdf(x *G.Node) (*G.Node, error) {
 	if x.Dims() < i.dims {
-		return nil, fmt.Errorf("cdf: expected dims >= %v but got %v", i.dims,
+		return nil, gop.ShapeErrorf("cdf: expected dims >= %v but got %v", i.dims,
 			x.Dims())
 	}
 
 	x, err := i.Distribution.Cdf(x)
 	if err != nil {
-		return nil, fmt.Errorf("cdf: could not compute iid cdf: %v", err)
+		return nil, fmt.Errorf("cdf: could not compute iid cdf: %w", err)
+	}
+
+	// Combine event dims
+	x, err = gop.ReduceProdAxes(x, i.eventAxes(), true)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: could not combine event dims: %w", err)
+	}
+
+	return x, nil
+}
+
+// Sf computes the joint survival function, P(X > x), of x, which is
+// the product of the survival functions of the underlying distribution
+// over the event dimensions. When there are event dimensions, this is
+// not equal to 1 - Cdf(x).
+func (i *IID) Sf(x *G.Node) (*G.Node, error) {
+	if x.Dims() < i.dims {
+		return nil, gop.ShapeErrorf("sf: expected dims >= %v but got %v", i.dims,
+			x.Dims())
+	}
+
+	x, err := i.Distribution.Sf(x)
+	if err != nil {
+		return nil, fmt.Errorf("sf: could not compute iid sf: %w", err)
 	}
 
 	// Combine event dims
-	for j := 0; j < i.dims; j++ {
-		x, err = gop.ReduceProd(x, x.Dims()-1, true)
-		if err != nil {
-			return nil, fmt.Errorf("cdf: could not combine event dims: %v",
-				err)
-		}
+	x, err = gop.ReduceProdAxes(x, i.eventAxes(), true)
+	if err != nil {
+		return nil, fmt.Errorf("sf: could not combine event dims: %w", err)
 	}
 
 	return x, nil
@@ -1,7 +1,10 @@
 package distribution
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/samuelfneumann/gop"
 	"gonum.org/v1/gonum/mat"
 	mv "gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/gonum/stat/distuv"
 	G "gorgonia.org/gorgonia"
 	"gorgonia.org/tensor"
 )
@@ -107,3 +111,345 @@ func TestIIDProb(t *testing.T) {
 
 	vm.Close()
 }
+
+// TestIIDEventDims tests that an IID-wrapped Normal with one event
+// dimension sums log probabilities over the last axis, reports the
+// correct batch and event shapes, and produces samples of the
+// expected shape.
+func TestIIDEventDims(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const batchSize int = 5            // Number of inputs to LogProb
+	const samples int = 4              // Number of samples to draw
+	shape := []int{2, 3}
+	numDists := tensor.ProdInts(shape)
+
+	meanBacking := make([]float64, numDists)
+	stdBacking := make([]float64, numDists)
+	for r := range meanBacking {
+		meanBacking[r] = rand.Float64() - 0.5
+		stdBacking[r] = 0.5 + rand.Float64()
+	}
+	dataSlice := make([]float64, numDists*batchSize)
+	for r := range dataSlice {
+		dataSlice[r] = (rand.Float64() - 0.5) * 4.0
+	}
+
+	g := G.NewGraph()
+	meanT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(meanBacking))
+	stdT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(stdBacking))
+	dataT := tensor.NewDense(tensor.Float64, append([]int{batchSize},
+		shape...), tensor.WithBacking(dataSlice))
+
+	mean := G.NewTensor(g, tensor.Float64, meanT.Dims(), G.WithValue(meanT),
+		G.WithName(gop.Unique("mean")))
+	std := G.NewTensor(g, tensor.Float64, stdT.Dims(), G.WithValue(stdT),
+		G.WithName(gop.Unique("std")))
+	data := G.NewTensor(g, tensor.Float64, dataT.Dims(), G.WithValue(dataT),
+		G.WithName(gop.Unique("input")))
+
+	n, err := NewNormal(mean, std, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewIID(n, 1)
+
+	if !i.Shape().Eq(tensor.Shape{2}) {
+		t.Errorf("expected batch shape (2) but got %v", i.Shape())
+	}
+	if !i.EventShape().Eq(tensor.Shape{3}) {
+		t.Errorf("expected event shape (3) but got %v", i.EventShape())
+	}
+
+	logProb, err := i.LogProb(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample, err := i.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsample, err := i.Rsample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logProbVal, sampleVal, rsampleVal G.Value
+	G.Read(logProb, &logProbVal)
+	G.Read(sample, &sampleVal)
+	G.Read(rsample, &rsampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !logProbVal.Shape().Eq(tensor.Shape{batchSize, 2}) {
+		t.Errorf("expected log prob shape %v but got %v",
+			tensor.Shape{batchSize, 2}, logProbVal.Shape())
+	}
+
+	computed := logProbVal.Data().([]float64)
+	for b := 0; b < batchSize; b++ {
+		for d := 0; d < 2; d++ {
+			target := 0.0
+			for e := 0; e < 3; e++ {
+				index := b*numDists + d*3 + e
+				dist := distuv.Normal{
+					Mu:    meanBacking[d*3+e],
+					Sigma: stdBacking[d*3+e],
+				}
+				target += dist.LogProb(dataSlice[index])
+			}
+
+			if math.Abs(computed[b*2+d]-target) > threshold {
+				t.Errorf("expected log prob: %v received: %v", target,
+					computed[b*2+d])
+			}
+		}
+	}
+
+	expected := tensor.Shape{samples, 2, 3}
+	if !sampleVal.Shape().Eq(expected) {
+		t.Errorf("expected sample shape %v but got %v", expected,
+			sampleVal.Shape())
+	}
+	if !rsampleVal.Shape().Eq(expected) {
+		t.Errorf("expected rsample shape %v but got %v", expected,
+			rsampleVal.Shape())
+	}
+}
+
+// TestIIDOutputShape tests that Prob, LogProb, Cdf, and Sf remove the
+// event dimensions of their input, and only those dimensions, so that
+// their outputs have shape (a, i.Shape()...) for a batched input and
+// i.Shape() for an unbatched input.
+func TestIIDOutputShape(t *testing.T) {
+	shape := []int{2, 3}
+
+	for dims := 0; dims <= len(shape); dims++ {
+		for _, batch := range []int{0, 1, 5} {
+			inShape := shape
+			if batch > 0 {
+				inShape = append([]int{batch}, shape...)
+			}
+
+			g := G.NewGraph()
+			mean := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithShape(shape...), G.WithInit(G.Zeroes()),
+				G.WithName("mean"))
+			std := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithShape(shape...), G.WithInit(G.Ones()),
+				G.WithName("std"))
+			x := G.NewTensor(g, tensor.Float64, len(inShape),
+				G.WithShape(inShape...), G.WithInit(G.Zeroes()),
+				G.WithName("x"))
+
+			n, err := NewNormal(mean, std, uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			i := NewIID(n, dims)
+
+			expected := i.Shape().Clone()
+			if batch > 0 {
+				expected = append(tensor.Shape{batch}, expected...)
+			}
+
+			methods := []func(*G.Node) (*G.Node, error){
+				i.Prob, i.LogProb, i.Cdf, i.Sf,
+			}
+			names := []string{"Prob", "LogProb", "Cdf", "Sf"}
+			for j, method := range methods {
+				out, err := method(x)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !out.Shape().Eq(expected) {
+					t.Errorf("%v: expected shape %v with %v event dims and "+
+						"input shape %v but got %v", names[j], expected,
+						dims, inShape, out.Shape())
+				}
+			}
+		}
+	}
+}
+
+// TestIIDSf tests that the Sf method of an IID-wrapped Normal with one
+// event dimension is the product of the survival functions over the
+// last axis.
+func TestIIDSf(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const batchSize int = 5            // Number of inputs to Sf
+	shape := []int{2, 3}
+	numDists := tensor.ProdInts(shape)
+
+	meanBacking := make([]float64, numDists)
+	stdBacking := make([]float64, numDists)
+	for r := range meanBacking {
+		meanBacking[r] = rand.Float64() - 0.5
+		stdBacking[r] = 0.5 + rand.Float64()
+	}
+	dataSlice := make([]float64, numDists*batchSize)
+	for r := range dataSlice {
+		dataSlice[r] = (rand.Float64() - 0.5) * 4.0
+	}
+
+	g := G.NewGraph()
+	meanT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(meanBacking))
+	stdT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(stdBacking))
+	dataT := tensor.NewDense(tensor.Float64, append([]int{batchSize},
+		shape...), tensor.WithBacking(append([]float64{}, dataSlice...)))
+
+	mean := G.NewTensor(g, tensor.Float64, meanT.Dims(), G.WithValue(meanT),
+		G.WithName(gop.Unique("mean")))
+	std := G.NewTensor(g, tensor.Float64, stdT.Dims(), G.WithValue(stdT),
+		G.WithName(gop.Unique("std")))
+	data := G.NewTensor(g, tensor.Float64, dataT.Dims(), G.WithValue(dataT),
+		G.WithName(gop.Unique("input")))
+
+	n, err := NewNormal(mean, std, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewIID(n, 1)
+
+	sf, err := i.Sf(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sfVal G.Value
+	G.Read(sf, &sfVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sfVal.Shape().Eq(tensor.Shape{batchSize, 2}) {
+		t.Errorf("expected sf shape %v but got %v",
+			tensor.Shape{batchSize, 2}, sfVal.Shape())
+	}
+
+	computed := sfVal.Data().([]float64)
+	for b := 0; b < batchSize; b++ {
+		for d := 0; d < 2; d++ {
+			target := 1.0
+			for e := 0; e < 3; e++ {
+				index := b*numDists + d*3 + e
+				dist := distuv.Normal{
+					Mu:    meanBacking[d*3+e],
+					Sigma: stdBacking[d*3+e],
+				}
+				target *= dist.Survival(dataSlice[index])
+			}
+
+			if math.Abs(computed[b*2+d]-target) > threshold {
+				t.Errorf("expected sf: %v received: %v", target,
+					computed[b*2+d])
+			}
+		}
+	}
+}
+
+// TestIIDMoments tests that the Mean, Variance, and StdDev of an IID
+// have the shape of the underlying distribution and equal its
+// element-wise statistics.
+func TestIIDMoments(t *testing.T) {
+	shape := []int{2, 3}
+	numDists := tensor.ProdInts(shape)
+
+	meanBacking := make([]float64, numDists)
+	stdBacking := make([]float64, numDists)
+	for r := range meanBacking {
+		meanBacking[r] = rand.Float64() - 0.5
+		stdBacking[r] = 0.5 + rand.Float64()
+	}
+
+	g := G.NewGraph()
+	meanT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(meanBacking))
+	stdT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(stdBacking))
+	mean := G.NewTensor(g, tensor.Float64, meanT.Dims(), G.WithValue(meanT),
+		G.WithName(gop.Unique("mean")))
+	std := G.NewTensor(g, tensor.Float64, stdT.Dims(), G.WithValue(stdT),
+		G.WithName(gop.Unique("std")))
+
+	n, err := NewNormal(mean, std, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for dims := 0; dims <= len(shape); dims++ {
+		i := NewIID(n, dims)
+
+		nodes := []*G.Node{i.Mean(), i.Variance(), i.StdDev()}
+		names := []string{"Mean", "Variance", "StdDev"}
+		for j := range nodes {
+			if !nodes[j].Shape().Eq(n.Shape()) {
+				t.Errorf("%v: expected shape %v with %v event dims but "+
+					"got %v", names[j], n.Shape(), dims, nodes[j].Shape())
+			}
+		}
+
+		if i.Mean() != n.Mean() || i.StdDev() != n.StdDev() {
+			t.Errorf("expected IID to forward Mean and StdDev to the " +
+				"underlying distribution")
+		}
+	}
+}
+
+// TestIIDErrorTypes tests that the ShapeError returned for an input of
+// invalid shape can be recovered with errors.As through an IID, the
+// distribution it wraps, and LogLikelihood
+func TestIIDErrorTypes(t *testing.T) {
+	g := G.NewGraph()
+	loc := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("loc"),
+		G.WithInit(G.Zeroes()))
+	scale := G.NewVector(g, tensor.Float64, G.WithShape(3),
+		G.WithName("scale"), G.WithInit(G.Ones()))
+	gumbel, err := NewGumbel(loc, scale, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := NewIID(gumbel, 1)
+
+	// The Gumbel cannot evaluate x, and the IID cannot evaluate the
+	// scalar y, since it has fewer dimensions than event dimensions
+	x := G.NewMatrix(g, tensor.Float64, G.WithShape(4, 2), G.WithName("x"),
+		G.WithInit(G.Zeroes()))
+	y := G.NewScalar(g, tensor.Float64, G.WithName("y"))
+
+	methods := map[string]func(*G.Node) (*G.Node, error){
+		"Gumbel.LogProb":  gumbel.LogProb,
+		"Gumbel.Cdf":      gumbel.Cdf,
+		"Gumbel.Quantile": gumbel.Quantile,
+		"Prob":            i.Prob,
+		"LogProb":         i.LogProb,
+		"Cdf":             i.Cdf,
+		"Sf":              i.Sf,
+		"LogLikelihood": func(x *G.Node) (*G.Node, error) {
+			return LogLikelihood(i, x)
+		},
+	}
+	for name, method := range methods {
+		for _, in := range []*G.Node{x, y} {
+			if strings.HasPrefix(name, "Gumbel") && in == y {
+				continue
+			}
+
+			_, err := method(in)
+			var shapeErr *gop.ShapeError
+			if !errors.As(err, &shapeErr) {
+				t.Errorf("%v: expected ShapeError for input shape %v but "+
+					"got %v", name, in.Shape(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package distribution
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+)
+
+// crossEntropySamples is the number of samples used to estimate the
+// cross entropy between two distributions when no closed form KL
+// divergence is registered for them
+const crossEntropySamples int = 1000
+
+// KLFunc computes the KL divergence KL(p || q) between two
+// distributions
+type KLFunc func(p, q Distribution) (*G.Node, error)
+
+// klKey is the pair of distribution types that a KLFunc is registered
+// for
+type klKey struct {
+	p, q reflect.Type
+}
+
+// klRegistry holds the closed form KL divergences between pairs of
+// distribution types
+var klRegistry = map[klKey]KLFunc{}
+
+func init() {
+	RegisterKL((*Normal)(nil), (*Normal)(nil), klNormalNormal)
+}
+
+// RegisterKL registers f as the KL divergence KL(p || q) for any
+// distributions with the same types as p and q. Only the types of p
+// and q are used, and so they may be nil pointers of the appropriate
+// type. Registering a KLFunc for a pair of types which already has one
+// replaces the previous KLFunc.
+func RegisterKL(p, q Distribution, f KLFunc) {
+	klRegistry[klKey{reflect.TypeOf(p), reflect.TypeOf(q)}] = f
+}
+
+// KL computes the KL divergence KL(p || q) using the KLFunc registered
+// for the types of p and q. An error is returned if no KLFunc is
+// registered for these types.
+func KL(p, q Distribution) (*G.Node, error) {
+	f, ok := klRegistry[klKey{reflect.TypeOf(p), reflect.TypeOf(q)}]
+	if !ok {
+		return nil, gop.UnsupportedOpErrorf("kl: no KL divergence registered between "+
+			"%T and %T", p, q)
+	}
+
+	if !p.Shape().Eq(q.Shape()) {
+		return nil, gop.ShapeErrorf("kl: expected distributions to have the "+
+			"same shape but got %v and %v", p.Shape(), q.Shape())
+	}
+
+	kl, err := f(p, q)
+	if err != nil {
+		return nil, fmt.Errorf("kl: %w", err)
+	}
+
+	return kl, nil
+}
+
+// CrossEntropy computes the cross entropy between p and q:
+//
+//		H(p, q) = -E_p[log(q(x))] = H(p) + KL(p || q)
+//
+// If a KL divergence is registered between the types of p and q, the
+// cross entropy is computed in closed form from the entropy of p and
+// the KL divergence. Otherwise, the cross entropy is estimated using
+// samples drawn from p, in which case the returned node is not
+// differentiable with respect to the parameters of p.
+func CrossEntropy(p, q Distribution) (*G.Node, error) {
+	if !p.Shape().Eq(q.Shape()) {
+		return nil, gop.ShapeErrorf("crossEntropy: expected distributions to "+
+			"have the same shape but got %v and %v", p.Shape(), q.Shape())
+	}
+
+	if _, ok := klRegistry[klKey{reflect.TypeOf(p), reflect.TypeOf(q)}]; !ok {
+		crossEntropy, err := monteCarloCrossEntropy(p, q, crossEntropySamples)
+		if err != nil {
+			return nil, fmt.Errorf("crossEntropy: %w", err)
+		}
+		return crossEntropy, nil
+	}
+
+	entropy, err := p.Entropy()
+	if err != nil {
+		return nil, fmt.Errorf("crossEntropy: %w", err)
+	}
+
+	kl, err := KL(p, q)
+	if err != nil {
+		return nil, fmt.Errorf("crossEntropy: %w", err)
+	}
+
+	return G.Add(entropy, kl)
+}
+
+// monteCarloCrossEntropy estimates the cross entropy between p and q
+// as -E_p[log(q(x))] using n samples drawn from p
+func monteCarloCrossEntropy(p, q Distribution, n int) (*G.Node, error) {
+	samples, err := p.Sample(n)
+	if err != nil {
+		return nil, fmt.Errorf("could not sample: %w", err)
+	}
+
+	logProb, err := q.LogProb(samples)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute log probability: %w", err)
+	}
+
+	crossEntropy, err := G.Mean(logProb, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not average over samples: %w", err)
+	}
+	crossEntropy, err = G.Neg(crossEntropy)
+	if err != nil {
+		return nil, err
+	}
+
+	return G.Reshape(crossEntropy, p.Shape().Clone())
+}
+
+// klNormalNormal computes the KL divergence between two Normals:
+//
+//		KL(p || q) = log(σ_q / σ_p) + (σ_p² + (μ_p - μ_q)²) / (2σ_q²) - ½
+func klNormalNormal(p, q Distribution) (*G.Node, error) {
+	pMean, pStddev := p.Mean(), p.StdDev()
+	qMean, qStddev := q.Mean(), q.StdDev()
+
+	g := pMean.Graph()
+	dt := pMean.Dtype()
+	half := constant(g, dt, 0.5)
+	two := constant(g, dt, 2.0)
+
+	logRatio := G.Must(G.Log(G.Must(G.HadamardDiv(qStddev, pStddev))))
+
+	diff := G.Must(G.Sub(pMean, qMean))
+	numerator := G.Must(G.Add(G.Must(G.Square(pStddev)),
+		G.Must(G.Square(diff))))
+	denominator := G.Must(G.HadamardProd(two, G.Must(G.Square(qStddev))))
+
+	kl := G.Must(G.Add(logRatio, G.Must(G.HadamardDiv(numerator,
+		denominator))))
+	return G.Sub(kl, half)
+}
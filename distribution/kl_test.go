@@ -0,0 +1,199 @@
+package distribution
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// newVectorNormal returns a Normal on graph g with means means and
+// standard deviations stddevs
+func newVectorNormal(t *testing.T, g *G.ExprGraph, means,
+	stddevs []float64) *Normal {
+	mean := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{len(means)}, tensor.WithBacking(means))),
+		G.WithName(gop.Unique("mean")))
+	stddev := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{len(stddevs)}, tensor.WithBacking(stddevs))),
+		G.WithName(gop.Unique("stddev")))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
+
+// normalCrossEntropy returns the cross entropy between two univariate
+// Normals
+func normalCrossEntropy(pMean, pStddev, qMean, qStddev float64) float64 {
+	diff := pMean - qMean
+	return math.Log(qStddev*math.Sqrt(2*math.Pi)) +
+		(pStddev*pStddev+diff*diff)/(2*qStddev*qStddev)
+}
+
+func TestCrossEntropyNormal(t *testing.T) {
+	const threshold float64 = 1e-8 // Threshold to consider floats equal
+	const tests int = 15           // Number of tests to run
+	const size int = 5             // Number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		pMeans, pStddevs := make([]float64, size), make([]float64, size)
+		qMeans, qStddevs := make([]float64, size), make([]float64, size)
+		for j := 0; j < size; j++ {
+			pMeans[j] = (rand.Float64() - 0.5) * 4.0
+			qMeans[j] = (rand.Float64() - 0.5) * 4.0
+			pStddevs[j] = math.Exp(rand.Float64() - 0.5)
+			qStddevs[j] = math.Exp(rand.Float64() - 0.5)
+		}
+
+		g := G.NewGraph()
+		p := newVectorNormal(t, g, pMeans, pStddevs)
+		q := newVectorNormal(t, g, qMeans, qStddevs)
+
+		crossEntropy, err := CrossEntropy(p, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entropy, err := p.Entropy()
+		if err != nil {
+			t.Fatal(err)
+		}
+		kl, err := KL(p, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var crossEntropyVal, entropyVal, klVal G.Value
+		G.Read(crossEntropy, &crossEntropyVal)
+		G.Read(entropy, &entropyVal)
+		G.Read(kl, &klVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		crossEntropyData := crossEntropyVal.Data().([]float64)
+		entropyData := entropyVal.Data().([]float64)
+		klData := klVal.Data().([]float64)
+		for j := 0; j < size; j++ {
+			sum := entropyData[j] + klData[j]
+			if math.Abs(crossEntropyData[j]-sum) > threshold {
+				t.Errorf("expected cross entropy to equal entropy + KL "+
+					"\nexpected: %v \nreceived: %v", sum, crossEntropyData[j])
+			}
+
+			target := normalCrossEntropy(pMeans[j], pStddevs[j], qMeans[j],
+				qStddevs[j])
+			if math.Abs(crossEntropyData[j]-target) > threshold {
+				t.Errorf("incorrect cross entropy \nexpected: %v "+
+					"\nreceived: %v", target, crossEntropyData[j])
+			}
+		}
+	}
+}
+
+func TestCrossEntropyMonteCarlo(t *testing.T) {
+	const threshold float64 = 0.2 // Threshold to consider floats equal
+	const samples int = 5000      // Number of samples in the estimate
+	const size int = 3            // Number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	pMeans, pStddevs := make([]float64, size), make([]float64, size)
+	qMeans, qStddevs := make([]float64, size), make([]float64, size)
+	for j := 0; j < size; j++ {
+		pMeans[j] = rand.Float64() - 0.5
+		qMeans[j] = rand.Float64() - 0.5
+		pStddevs[j] = 0.5 + rand.Float64()
+		qStddevs[j] = 0.5 + rand.Float64()
+	}
+
+	g := G.NewGraph()
+	p := newVectorNormal(t, g, pMeans, pStddevs)
+	q := newVectorNormal(t, g, qMeans, qStddevs)
+
+	crossEntropy, err := monteCarloCrossEntropy(p, q, samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var crossEntropyVal G.Value
+	G.Read(crossEntropy, &crossEntropyVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !crossEntropyVal.Shape().Eq(p.Shape()) {
+		t.Fatalf("expected cross entropy to have shape %v but got %v",
+			p.Shape(), crossEntropyVal.Shape())
+	}
+
+	for j, estimate := range crossEntropyVal.Data().([]float64) {
+		target := normalCrossEntropy(pMeans[j], pStddevs[j], qMeans[j],
+			qStddevs[j])
+		if math.Abs(estimate-target) > threshold {
+			t.Errorf("incorrect cross entropy estimate \nexpected: %v "+
+				"\nreceived: %v", target, estimate)
+		}
+	}
+}
+
+func TestKLUnregistered(t *testing.T) {
+	g := G.NewGraph()
+	p := newVectorNormal(t, g, []float64{0, 1}, []float64{1, 2})
+
+	loc := G.NewVector(g, tensor.Float64, G.WithShape(2), G.WithName("loc"),
+		G.WithInit(G.Zeroes()))
+	scale := G.NewVector(g, tensor.Float64, G.WithShape(2),
+		G.WithName("scale"), G.WithInit(G.Ones()))
+	q, err := NewGumbel(loc, scale, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := KL(p, q); err == nil {
+		t.Error("expected an error computing an unregistered KL divergence")
+	}
+}
+
+// TestKLErrorTypes tests that KL and CrossEntropy return a ShapeError
+// for distributions of different shapes, and that KL returns an
+// UnsupportedOpError when no KL divergence is registered
+func TestKLErrorTypes(t *testing.T) {
+	g := G.NewGraph()
+	p := newVectorNormal(t, g, []float64{0, 1}, []float64{1, 2})
+	q := newVectorNormal(t, g, []float64{0, 1, 2}, []float64{1, 2, 3})
+
+	var shapeErr *gop.ShapeError
+	if _, err := KL(p, q); !errors.As(err, &shapeErr) {
+		t.Errorf("kl: expected ShapeError but got %v", err)
+	}
+	if _, err := CrossEntropy(p, q); !errors.As(err, &shapeErr) {
+		t.Errorf("crossEntropy: expected ShapeError but got %v", err)
+	}
+
+	loc := G.NewVector(g, tensor.Float64, G.WithShape(2), G.WithName("loc"),
+		G.WithInit(G.Zeroes()))
+	scale := G.NewVector(g, tensor.Float64, G.WithShape(2),
+		G.WithName("scale"), G.WithInit(G.Ones()))
+	gumbel, err := NewGumbel(loc, scale, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opErr *gop.UnsupportedOpError
+	if _, err := KL(p, gumbel); !errors.As(err, &opErr) {
+		t.Errorf("kl: expected UnsupportedOpError but got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/chewxy/math32"
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// LogLikelihood returns the total log-likelihood of data under the
+// distribution d. The shape of data is treated in the same way as
+// the LogProb() method of d, and the log probabilities of all
+// samples in the batch are summed to produce a scalar.
+//
+// LogLikelihood is the natural objective for maximum-likelihood
+// fitting of the parameters of d.
+func LogLikelihood(d Distribution, data *G.Node) (*G.Node, error) {
+	logProb, err := d.LogProb(data)
+	if err != nil {
+		return nil, fmt.Errorf("logLikelihood: could not compute log "+
+			"probability: %w", err)
+	}
+
+	out, err := G.Sum(logProb)
+	if err != nil {
+		return nil, fmt.Errorf("logLikelihood: could not sum log "+
+			"probabilities: %w", err)
+	}
+
+	return out, nil
+}
+
+// MeanLogLikelihood is like LogLikelihood, but divides the total
+// log-likelihood by the number of samples in the batch.
+func MeanLogLikelihood(d Distribution, data *G.Node) (*G.Node, error) {
+	logProb, err := d.LogProb(data)
+	if err != nil {
+		return nil, fmt.Errorf("meanLogLikelihood: could not compute log "+
+			"probability: %w", err)
+	}
+
+	out, err := G.Sum(logProb)
+	if err != nil {
+		return nil, fmt.Errorf("meanLogLikelihood: could not sum log "+
+			"probabilities: %w", err)
+	}
+
+	// Each sample in the batch has one log probability per
+	// distribution stored in d
+	batchSize := logProb.Shape().TotalSize() / tensor.ProdInts(d.Shape())
+
+	var n *G.Node
+	switch out.Dtype() {
+	case tensor.Float64:
+		n = out.Graph().Constant(G.NewF64(float64(batchSize)))
+	case tensor.Float32:
+		n = out.Graph().Constant(G.NewF32(float32(batchSize)))
+	default:
+		return nil, gop.DtypeErrorf("meanLogLikelihood: data type %v "+
+			"unsupported", out.Dtype())
+	}
+
+	out, err = G.HadamardDiv(out, n)
+	if err != nil {
+		return nil, fmt.Errorf("meanLogLikelihood: could not divide by "+
+			"batch size: %w", err)
+	}
+
+	return out, nil
+}
+
+// FitNormalMLE returns the closed-form maximum-likelihood estimates of
+// the mean and standard deviation of a Normal given data. The first
+// dimension of data is taken to be the batch dimension, so that the
+// returned mean and stddev have the shape of data less dimension 0. If
+// data is 1-D, then the returned mean and stddev have shape (1), the
+// shape of a Normal holding a single distribution.
+// The returned standard deviation is the biased (maximum-likelihood)
+// estimate, which divides by the number of samples rather than the
+// number of samples less one.
+//
+// FitNormalMLE is useful for initializing the parameters of a Normal
+// before fitting it with LogLikelihood.
+func FitNormalMLE(data tensor.Tensor) (mean, stddev tensor.Tensor,
+	err error) {
+	if data.Dims() == 0 || data.Size() == 0 {
+		return nil, nil, gop.ShapeErrorf("fitNormalMLE: cannot fit to data "+
+			"with shape %v", data.Shape())
+	}
+
+	// Materialize data so that the backing slice can be used directly
+	// in the case that data is a view
+	if v, ok := data.(tensor.View); ok && v.IsMaterializable() {
+		data = v.Materialize()
+	}
+
+	n := data.Shape()[0]
+	shape := data.Shape().Clone()[1:]
+	if len(shape) == 0 {
+		shape = tensor.Shape{1}
+	}
+	size := tensor.ProdInts(shape)
+
+	switch x := data.Data().(type) {
+	case []float64:
+		meanBacking := make([]float64, size)
+		stdBacking := make([]float64, size)
+		for i, v := range x {
+			meanBacking[i%size] += v
+		}
+		for i := range meanBacking {
+			meanBacking[i] /= float64(n)
+		}
+		for i, v := range x {
+			diff := v - meanBacking[i%size]
+			stdBacking[i%size] += diff * diff
+		}
+		for i := range stdBacking {
+			stdBacking[i] = math.Sqrt(stdBacking[i] / float64(n))
+		}
+
+		mean = tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		stddev = tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stdBacking))
+
+	case []float32:
+		meanBacking := make([]float32, size)
+		stdBacking := make([]float32, size)
+		for i, v := range x {
+			meanBacking[i%size] += v
+		}
+		for i := range meanBacking {
+			meanBacking[i] /= float32(n)
+		}
+		for i, v := range x {
+			diff := v - meanBacking[i%size]
+			stdBacking[i%size] += diff * diff
+		}
+		for i := range stdBacking {
+			stdBacking[i] = math32.Sqrt(stdBacking[i] / float32(n))
+		}
+
+		mean = tensor.NewDense(tensor.Float32, shape,
+			tensor.WithBacking(meanBacking))
+		stddev = tensor.NewDense(tensor.Float32, shape,
+			tensor.WithBacking(stdBacking))
+
+	default:
+		return nil, nil, gop.DtypeErrorf("fitNormalMLE: data type %v "+
+			"unsupported", data.Dtype())
+	}
+
+	return mean, stddev, nil
+}
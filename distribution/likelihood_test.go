@@ -0,0 +1,244 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestLogLikelihood tests that the LogLikelihood and MeanLogLikelihood
+// of a scalar Normal are correctly computed and that their gradients
+// are zero at the maximum-likelihood estimate of the mean and
+// standard deviation.
+func TestLogLikelihood(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 10               // Number of tests to run
+	const minSize int = 2              // Minimum number of data points
+	const maxSize int = 50             // Maximum number of data points
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := minSize + rand.Intn(maxSize-minSize)
+		loc := (rand.Float64() - 0.5) * 4.0
+		scale := math.Exp(rand.Float64())
+
+		// Generate the data and compute its mean and biased stddev,
+		// which are the MLE of the Normal's parameters
+		data := make([]float64, size)
+		dataMean := 0.0
+		for j := range data {
+			data[j] = loc + scale*rand.NormFloat64()
+			dataMean += data[j]
+		}
+		dataMean /= float64(size)
+
+		dataStd := 0.0
+		for j := range data {
+			dataStd += math.Pow(data[j]-dataMean, 2)
+		}
+		dataStd = math.Sqrt(dataStd / float64(size))
+
+		// Target log-likelihood
+		target := distuv.Normal{Mu: dataMean, Sigma: dataStd}
+		targetLL := 0.0
+		for j := range data {
+			targetLL += target.LogProb(data[j])
+		}
+
+		g := G.NewGraph()
+		mean := G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+			G.WithValue(dataMean))
+		stddev := G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+			G.WithValue(dataStd))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Error(err)
+		}
+
+		dataT := tensor.NewDense(
+			tensor.Float64,
+			[]int{size},
+			tensor.WithBacking(data),
+		)
+		x := G.NewVector(g, tensor.Float64, G.WithValue(dataT),
+			G.WithName("data"))
+
+		ll, err := LogLikelihood(n, x)
+		if err != nil {
+			t.Error(err)
+		}
+		var llVal G.Value
+		G.Read(ll, &llVal)
+
+		mll, err := MeanLogLikelihood(n, x)
+		if err != nil {
+			t.Error(err)
+		}
+		var mllVal G.Value
+		G.Read(mll, &mllVal)
+
+		grad, err := G.Grad(ll, mean, stddev)
+		if err != nil {
+			t.Error(err)
+		}
+		var meanGrad, stddevGrad G.Value
+		G.Read(grad[0], &meanGrad)
+		G.Read(grad[1], &stddevGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Error(err)
+		}
+
+		if !llVal.Shape().IsScalar() {
+			t.Errorf("expected log-likelihood to be a scalar but got "+
+				"shape %v", llVal.Shape())
+		}
+		if math.Abs(llVal.Data().(float64)-targetLL) > threshold {
+			t.Errorf("expected log-likelihood: %v received: %v", targetLL,
+				llVal.Data().(float64))
+		}
+		if math.Abs(mllVal.Data().(float64)-targetLL/float64(size)) >
+			threshold {
+			t.Errorf("expected mean log-likelihood: %v received: %v",
+				targetLL/float64(size), mllVal.Data().(float64))
+		}
+
+		// The gradient should be zero at the MLE
+		if math.Abs(meanGrad.Data().(float64)) > threshold {
+			t.Errorf("expected zero gradient w.r.t. mean at MLE but got %v",
+				meanGrad.Data().(float64))
+		}
+		if math.Abs(stddevGrad.Data().(float64)) > threshold {
+			t.Errorf("expected zero gradient w.r.t. stddev at MLE but got %v",
+				stddevGrad.Data().(float64))
+		}
+
+		vm.Close()
+	}
+}
+
+// TestFitNormalMLE tests that FitNormalMLE computes the correct mean
+// and biased standard deviation of known data, and that these
+// estimates are the point at which the gradient of the
+// MeanLogLikelihood is zero.
+func TestFitNormalMLE(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+
+	// Two distributions with 4 samples each
+	data := tensor.NewDense(
+		tensor.Float64,
+		[]int{4, 2},
+		tensor.WithBacking([]float64{
+			1.0, -2.0,
+			2.0, 0.0,
+			3.0, 2.0,
+			6.0, 4.0,
+		}),
+	)
+	targetMean := []float64{3.0, 1.0}
+	targetStd := []float64{math.Sqrt(3.5), math.Sqrt(5.0)}
+
+	mean, stddev, err := FitNormalMLE(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !mean.Shape().Eq(tensor.Shape{2}) {
+		t.Errorf("expected mean shape (2) but got %v", mean.Shape())
+	}
+	if !stddev.Shape().Eq(tensor.Shape{2}) {
+		t.Errorf("expected stddev shape (2) but got %v", stddev.Shape())
+	}
+	for i := range targetMean {
+		if math.Abs(mean.Data().([]float64)[i]-targetMean[i]) > threshold {
+			t.Errorf("expected mean: %v received: %v", targetMean[i],
+				mean.Data().([]float64)[i])
+		}
+		if math.Abs(stddev.Data().([]float64)[i]-targetStd[i]) > threshold {
+			t.Errorf("expected stddev: %v received: %v", targetStd[i],
+				stddev.Data().([]float64)[i])
+		}
+	}
+
+	// Ensure the gradient of the mean log-likelihood is zero at the MLE
+	g := G.NewGraph()
+	meanNode := G.NewVector(g, tensor.Float64, G.WithValue(mean),
+		G.WithName("mean"))
+	stddevNode := G.NewVector(g, tensor.Float64, G.WithValue(stddev),
+		G.WithName("stddev"))
+	x := G.NewMatrix(g, tensor.Float64, G.WithValue(data),
+		G.WithName("data"))
+
+	n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mll, err := MeanLogLikelihood(n, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grad, err := G.Grad(mll, meanNode, stddevNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meanGrad, stddevGrad G.Value
+	G.Read(grad[0], &meanGrad)
+	G.Read(grad[1], &stddevGrad)
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range targetMean {
+		if math.Abs(meanGrad.Data().([]float64)[i]) > threshold {
+			t.Errorf("expected zero gradient w.r.t. mean at MLE but got %v",
+				meanGrad.Data().([]float64)[i])
+		}
+		if math.Abs(stddevGrad.Data().([]float64)[i]) > threshold {
+			t.Errorf("expected zero gradient w.r.t. stddev at MLE but got %v",
+				stddevGrad.Data().([]float64)[i])
+		}
+	}
+
+	vm.Close()
+}
+
+// TestFitNormalMLEVector tests that FitNormalMLE returns a mean and
+// standard deviation of shape (1) when fit to 1-D data
+func TestFitNormalMLEVector(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+
+	data := tensor.NewDense(tensor.Float64, []int{4},
+		tensor.WithBacking([]float64{1.0, 2.0, 3.0, 6.0}))
+	targetMean := 3.0
+	targetStd := math.Sqrt(3.5)
+
+	mean, stddev, err := FitNormalMLE(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !mean.Shape().Eq(tensor.Shape{1}) {
+		t.Errorf("expected mean shape (1) but got %v", mean.Shape())
+	}
+	if !stddev.Shape().Eq(tensor.Shape{1}) {
+		t.Errorf("expected stddev shape (1) but got %v", stddev.Shape())
+	}
+	if math.Abs(mean.Data().([]float64)[0]-targetMean) > threshold {
+		t.Errorf("expected mean: %v received: %v", targetMean,
+			mean.Data().([]float64)[0])
+	}
+	if math.Abs(stddev.Data().([]float64)[0]-targetStd) > threshold {
+		t.Errorf("expected stddev: %v received: %v", targetStd,
+			stddev.Data().([]float64)[0])
+	}
+}
@@ -0,0 +1,381 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// Mixture is a mixture of distributions, where the probability of a
+// sample being drawn from each component distribution is given by a
+// Categorical mixing distribution. All components must have the same
+// shape, which is the shape of the Mixture, and each element of the
+// Mixture is treated as a separate mixture distribution in the same
+// way that the Normal treats each element of its mean and standard
+// deviation as a separate distribution.
+//
+// The mixing Categorical must have one category per component. It
+// may either hold a single distribution, in which case the same
+// mixing weights are used for every element of the Mixture, or it may
+// have the same shape as the components, in which case each element
+// of the Mixture has its own mixing weights.
+type Mixture struct {
+	mixing     *Categorical
+	components []Distribution
+}
+
+// broadcastFn is a Gorgonia broadcast operation, such as
+// G.BroadcastAdd
+type broadcastFn func(a, b *G.Node, leftPattern,
+	rightPattern []byte) (*G.Node, error)
+
+// NewMixture returns a new Mixture with mixing distribution mixing
+// over components components.
+func NewMixture(mixing *Categorical, components []Distribution) (*Mixture,
+	error) {
+	if len(components) == 0 {
+		return nil, fmt.Errorf("newMixture: expected at least one component")
+	}
+	if len(components) != mixing.NumCategories() {
+		return nil, fmt.Errorf("newMixture: expected one mixing category "+
+			"per component but got %v categories and %v components",
+			mixing.NumCategories(), len(components))
+	}
+
+	shape := components[0].Shape()
+	for i, c := range components {
+		if !c.Shape().Eq(shape) {
+			return nil, gop.ShapeErrorf("newMixture: expected all "+
+				"components to have shape %v but component %v has shape "+
+				"%v", shape, i, c.Shape())
+		}
+		if c.Mean().Dtype() != mixing.Dtype() {
+			return nil, gop.DtypeErrorf("newMixture: expected all "+
+				"components to have data type %v but component %v has data "+
+				"type %v", mixing.Dtype(), i, c.Mean().Dtype())
+		}
+	}
+
+	if len(mixing.Shape()) != 0 && !mixing.Shape().Eq(shape) {
+		return nil, gop.ShapeErrorf("newMixture: expected mixing "+
+			"distribution to hold a single distribution or have shape %v "+
+			"but got %v",
+			shape, mixing.Shape())
+	}
+
+	return &Mixture{
+		mixing:     mixing,
+		components: components,
+	}, nil
+}
+
+// Prob calculates the probability density of x. The shape of x is
+// treated in the same way as the LogProb() method of the components.
+func (m *Mixture) Prob(x *G.Node) (*G.Node, error) {
+	logProb, err := m.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("prob: %w", err)
+	}
+
+	return G.Exp(logProb)
+}
+
+// LogProb calculates the log probability density of x:
+//
+//		log(p(x)) = log(Σ_i exp(log(w_i) + log(p_i(x))))
+//
+// where w_i is the mixing weight and p_i the density of component i.
+// The shape of x is treated in the same way as the LogProb() method of
+// the components.
+func (m *Mixture) LogProb(x *G.Node) (*G.Node, error) {
+	logProbs := make([]*G.Node, len(m.components))
+	for i, c := range m.components {
+		var err error
+		logProbs[i], err = c.LogProb(x)
+		if err != nil {
+			return nil, fmt.Errorf("logProb: could not compute log "+
+				"probability of component %v: %w", i, err)
+		}
+	}
+
+	stacked, err := stack(logProbs)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	logWeights, err := m.mixing.LogProbs()
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	stacked, err = m.broadcastWeights(stacked, logWeights, G.BroadcastAdd)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: could not add log mixing "+
+			"weights: %w", err)
+	}
+
+	logProb := gop.LogSumExp(stacked, 1)
+	return G.Reshape(logProb, logProbs[0].Shape().Clone())
+}
+
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, the weighted mean of the components. Note that the
+// mean of a mixture need not be a mode and may lie in a region of low
+// density. The returned node has shape (1, m.Shape()...).
+func (m *Mixture) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(m)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// Cdf computes the cumulative distribution function of x, which is
+// the weighted sum of the Cdf of each component. The shape of x is
+// treated in the same way as the Cdf() method of the components.
+func (m *Mixture) Cdf(x *G.Node) (*G.Node, error) {
+	cdfs := make([]*G.Node, len(m.components))
+	for i, c := range m.components {
+		var err error
+		cdfs[i], err = c.Cdf(x)
+		if err != nil {
+			return nil, fmt.Errorf("cdf: could not compute cdf of "+
+				"component %v: %w", i, err)
+		}
+	}
+
+	cdf, err := m.weightedSum(cdfs)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	return cdf, nil
+}
+
+// Sf computes the survival function of x, which is the weighted sum of
+// the Sf of each component. The shape of x is treated in the same way
+// as the Sf() method of the components.
+func (m *Mixture) Sf(x *G.Node) (*G.Node, error) {
+	sfs := make([]*G.Node, len(m.components))
+	for i, c := range m.components {
+		var err error
+		sfs[i], err = c.Sf(x)
+		if err != nil {
+			return nil, fmt.Errorf("sf: could not compute survival "+
+				"function of component %v: %w", i, err)
+		}
+	}
+
+	sf, err := m.weightedSum(sfs)
+	if err != nil {
+		return nil, fmt.Errorf("sf: %w", err)
+	}
+
+	return sf, nil
+}
+
+// Shape returns the number of distributions stored by the receiver
+func (m *Mixture) Shape() tensor.Shape {
+	return m.components[0].Shape()
+}
+
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (m *Mixture) NumDistributions() int {
+	return tensor.ProdInts(m.Shape())
+}
+
+// Mean returns the mean of the distribution(s) stored by the
+// receiver, which is the weighted mean of the component means
+func (m *Mixture) Mean() *G.Node {
+	means := make([]*G.Node, len(m.components))
+	for i, c := range m.components {
+		means[i] = c.Mean()
+	}
+
+	return G.Must(m.weightedSum(means))
+}
+
+// Variance returns the variance of the distribution(s) stored by the
+// receiver, computed using the law of total variance:
+//
+//		Var[X] = Σ_i w_i (Var_i[X] + E_i[X]²) - E[X]²
+func (m *Mixture) Variance() *G.Node {
+	moments := make([]*G.Node, len(m.components))
+	for i, c := range m.components {
+		moments[i] = G.Must(G.Add(c.Variance(), G.Must(G.Square(c.Mean()))))
+	}
+
+	secondMoment := G.Must(m.weightedSum(moments))
+	return G.Must(G.Sub(secondMoment, G.Must(G.Square(m.Mean()))))
+}
+
+// StdDev returns the standard deviation of the distribution(s) stored
+// by the receiver
+func (m *Mixture) StdDev() *G.Node {
+	return G.Must(G.Sqrt(m.Variance()))
+}
+
+// Entropy is not supported for the Mixture, since it has no closed
+// form, and always returns an error
+func (m *Mixture) Entropy() (*G.Node, error) {
+	return nil, gop.UnsupportedOpErrorf("entropy: not supported for the " +
+		"Mixture")
+}
+
+// HasRsample returns whether the receiver supports reparameterized
+// sample -- false for the Mixture.
+func (m *Mixture) HasRsample() bool { return false }
+
+// Dtype returns the type that the receiver operates on
+func (m *Mixture) Dtype() tensor.Dtype { return m.mixing.Dtype() }
+
+// Rsample is not supported for the Mixture and always returns an
+// error
+func (m *Mixture) Rsample(n int) (*G.Node, error) {
+	return nil, gop.UnsupportedOpErrorf("rsample: not supported for the " +
+		"Mixture")
+}
+
+// Sample samples n samples from the receiver by first drawing a
+// component from the mixing distribution, and then drawing a sample
+// from that component. This operation is not differentiable.
+func (m *Mixture) Sample(n int) (*G.Node, error) {
+	samples := make([]*G.Node, len(m.components))
+	for i, c := range m.components {
+		var err error
+		samples[i], err = c.Sample(n)
+		if err != nil {
+			return nil, fmt.Errorf("sample: could not sample component "+
+				"%v: %w", i, err)
+		}
+	}
+
+	stacked, err := stack(samples)
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	// Sample the components to use, one-hot encoded. If the mixing
+	// distribution holds a single distribution, then the component of
+	// each element of the Mixture is sampled separately.
+	var components *G.Node
+	if len(m.mixing.Shape()) == 0 {
+		components, err = m.mixing.sample(stacked.Shape()[0], true)
+	} else {
+		components, err = m.mixing.sample(n, true)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sample: could not sample components: %w",
+			err)
+	}
+	components, err = G.Reshape(components, stacked.Shape().Clone())
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	out := G.Must(G.HadamardProd(stacked, components))
+	out = G.Must(G.Sum(out, 1))
+
+	return G.Reshape(out, samples[0].Shape().Clone())
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., drawShape...), where drawShape is the shape of
+// a single sample. This operation is not differentiable.
+func (m *Mixture) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(m.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// weightedSum returns the sum of values weighted by the mixing
+// weights
+func (m *Mixture) weightedSum(values []*G.Node) (*G.Node, error) {
+	stacked, err := stack(values)
+	if err != nil {
+		return nil, err
+	}
+
+	weights, err := m.mixing.Probs()
+	if err != nil {
+		return nil, err
+	}
+
+	stacked, err = m.broadcastWeights(stacked, weights,
+		G.BroadcastHadamardProd)
+	if err != nil {
+		return nil, fmt.Errorf("could not weight components: %w", err)
+	}
+
+	sum := G.Must(G.Sum(stacked, 1))
+	return G.Reshape(sum, values[0].Shape().Clone())
+}
+
+// broadcastWeights applies f to stacked, a matrix with one column per
+// component, and weights, the (log) mixing weights, broadcasting the
+// weights to each row of stacked.
+func (m *Mixture) broadcastWeights(stacked, weights *G.Node,
+	f broadcastFn) (*G.Node, error) {
+	if weights.Dims() == 1 {
+		return f(stacked, weights, nil, []byte{0})
+	}
+
+	// Each element of the Mixture has its own weights, so repeat the
+	// weights over each batch of rows of stacked
+	k := len(m.components)
+	p := weights.Shape().TotalSize() / k
+	weights, err := G.Reshape(weights, []int{p, k})
+	if err != nil {
+		return nil, err
+	}
+
+	n := stacked.Shape()[0]
+	if n%p != 0 {
+		return nil, gop.ShapeErrorf("cannot broadcast weights of shape %v to "+
+			"%v rows", weights.Shape(), n)
+	}
+	stacked, err = G.Reshape(stacked, []int{n / p, p, k})
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := f(stacked, weights, nil, []byte{0})
+	if err != nil {
+		return nil, err
+	}
+
+	return G.Reshape(out, []int{n, k})
+}
+
+// stack flattens each of values and stacks the results as columns of
+// a matrix. All values must have the same shape.
+func stack(values []*G.Node) (*G.Node, error) {
+	shape := values[0].Shape()
+	size := shape.TotalSize()
+
+	columns := make([]*G.Node, len(values))
+	for i, v := range values {
+		if !v.Shape().Eq(shape) {
+			return nil, fmt.Errorf("stack: expected all values to have "+
+				"shape %v but got %v", shape, v.Shape())
+		}
+
+		var err error
+		columns[i], err = G.Reshape(v, []int{size, 1})
+		if err != nil {
+			return nil, fmt.Errorf("stack: %w", err)
+		}
+	}
+
+	if len(columns) == 1 {
+		return columns[0], nil
+	}
+	return G.Concat(1, columns...)
+}
@@ -0,0 +1,235 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/samuelfneumann/gop"
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// newNormalMixture returns a two-component mixture of scalar Normals
+// with mixing weights weights on graph g
+func newNormalMixture(t *testing.T, g *G.ExprGraph, weights, means,
+	stddevs []float64) *Mixture {
+	components := make([]Distribution, len(means))
+	for i := range means {
+		mean := G.NewScalar(g, tensor.Float64, G.WithValue(means[i]),
+			G.WithName(gop.Unique("mean")))
+		stddev := G.NewScalar(g, tensor.Float64, G.WithValue(stddevs[i]),
+			G.WithName(gop.Unique("stddev")))
+
+		var err error
+		components[i], err = NewNormal(mean, stddev,
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logitsBacking := make([]float64, len(weights))
+	for i := range weights {
+		logitsBacking[i] = math.Log(weights[i])
+	}
+	logitsT := tensor.NewDense(tensor.Float64, []int{len(weights)},
+		tensor.WithBacking(logitsBacking))
+	logits := G.NewVector(g, tensor.Float64, G.WithValue(logitsT),
+		G.WithName("logits"))
+
+	mixing, err := NewCategorical(logits, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mixture, err := NewMixture(mixing, components)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return mixture
+}
+
+// TestMixture tests the Prob, LogProb, Mean, and Variance of a
+// two-component Normal mixture against hand-computed values.
+func TestMixture(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+
+	weights := []float64{0.3, 0.7}
+	means := []float64{-1.0, 2.0}
+	stddevs := []float64{0.5, 1.5}
+	points := []float64{-2.0, -1.0, 0.0, 0.5, 2.0, 4.0}
+
+	// Hand-computed densities w_1 N(x; -1, 0.5) + w_2 N(x; 2, 1.5)
+	target := make([]float64, len(points))
+	for i, x := range points {
+		for j := range weights {
+			z := (x - means[j]) / stddevs[j]
+			target[i] += weights[j] * math.Exp(-0.5*z*z) /
+				(stddevs[j] * math.Sqrt(2*math.Pi))
+		}
+	}
+	targetMean := 0.3*-1.0 + 0.7*2.0
+	targetVariance := 0.3*(0.25+1.0) + 0.7*(2.25+4.0) -
+		targetMean*targetMean
+
+	g := G.NewGraph()
+	mixture := newNormalMixture(t, g, weights, means, stddevs)
+
+	inT := tensor.NewDense(tensor.Float64, []int{len(points)},
+		tensor.WithBacking(points))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(inT), G.WithName("x"))
+
+	prob, err := mixture.Prob(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logProb, err := mixture.LogProb(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var probVal, logProbVal, meanVal, varianceVal G.Value
+	G.Read(prob, &probVal)
+	G.Read(logProb, &logProbVal)
+	G.Read(mixture.Mean(), &meanVal)
+	G.Read(mixture.Variance(), &varianceVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !probVal.Shape().Eq(tensor.Shape{len(points), 1}) {
+		t.Errorf("expected prob shape %v but got %v",
+			tensor.Shape{len(points), 1}, probVal.Shape())
+	}
+
+	for i := range points {
+		computed := probVal.Data().([]float64)[i]
+		if math.Abs(computed-target[i]) > threshold {
+			t.Errorf("prob: expected: %v received: %v at %v", target[i],
+				computed, points[i])
+		}
+
+		computed = logProbVal.Data().([]float64)[i]
+		if math.Abs(computed-math.Log(target[i])) > threshold {
+			t.Errorf("logProb: expected: %v received: %v at %v",
+				math.Log(target[i]), computed, points[i])
+		}
+	}
+
+	if computed := meanVal.Data().([]float64)[0]; math.Abs(computed-
+		targetMean) > threshold {
+		t.Errorf("mean: expected: %v received: %v", targetMean, computed)
+	}
+	if computed := varianceVal.Data().([]float64)[0]; math.Abs(computed-
+		targetVariance) > threshold {
+		t.Errorf("variance: expected: %v received: %v", targetVariance,
+			computed)
+	}
+}
+
+// TestMixtureSf tests the Sf method of a two-component Normal mixture
+// against the weighted sum of the survival functions of the
+// components.
+func TestMixtureSf(t *testing.T) {
+	weights := []float64{0.4, 0.6}
+	means := []float64{-1.0, 2.0}
+	stddevs := []float64{0.5, 1.5}
+	points := []float64{-3.0, -1.0, 0.0, 0.5, 2.0, 6.0}
+
+	g := G.NewGraph()
+	mixture := newNormalMixture(t, g, weights, means, stddevs)
+
+	reference := func(x float64) float64 {
+		sf := 0.0
+		for i := range weights {
+			normal := distuv.Normal{Mu: means[i], Sigma: stddevs[i]}
+			sf += weights[i] * normal.Survival(x)
+		}
+		return sf
+	}
+	CompareSfToGonum(t, mixture, reference, points)
+}
+
+// TestMixtureSample tests that samples from a two-component Normal
+// mixture with well-separated components are drawn from each
+// component in proportion to the mixing weights.
+func TestMixtureSample(t *testing.T) {
+	const samples int = 2000       // Number of samples to draw
+	const threshold float64 = 0.05 // Tolerance on the sampled proportion
+	rand.Seed(time.Now().UnixNano())
+
+	weights := []float64{0.3, 0.7}
+	means := []float64{-100.0, 100.0}
+	stddevs := []float64{1.0, 1.0}
+
+	g := G.NewGraph()
+	mixture := newNormalMixture(t, g, weights, means, stddevs)
+
+	sample, err := mixture.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sampleVal.Shape().Eq(tensor.Shape{samples, 1}) {
+		t.Errorf("expected sample shape %v but got %v",
+			tensor.Shape{samples, 1}, sampleVal.Shape())
+	}
+
+	first := 0
+	for _, v := range sampleVal.Data().([]float64) {
+		if v < 0 {
+			first++
+		}
+	}
+	proportion := float64(first) / float64(samples)
+	if math.Abs(proportion-weights[0]) > threshold {
+		t.Errorf("expected proportion %v of samples from the first "+
+			"component but got %v", weights[0], proportion)
+	}
+}
+
+// TestMixtureShape tests that NewMixture returns an error when the
+// components have differing shapes.
+func TestMixtureShape(t *testing.T) {
+	g := G.NewGraph()
+	scalar := G.NewScalar(g, tensor.Float64, G.WithValue(1.0))
+	vecT := tensor.NewDense(tensor.Float64, []int{2},
+		tensor.WithBacking([]float64{1.0, 1.0}))
+	vec := G.NewVector(g, tensor.Float64, G.WithValue(vecT))
+
+	n1, err := NewNormal(scalar, scalar, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n2, err := NewNormal(vec, vec, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logitsT := tensor.NewDense(tensor.Float64, []int{2},
+		tensor.WithBacking([]float64{0.0, 0.0}))
+	logits := G.NewVector(g, tensor.Float64, G.WithValue(logitsT))
+	mixing, err := NewCategorical(logits, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewMixture(mixing, []Distribution{n1, n2}); err == nil {
+		t.Error("expected error for components with different shapes")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 
+	"golang.org/x/exp/rand"
+
 	"github.com/chewxy/math32"
 	"github.com/samuelfneumann/gop"
 	G "gorgonia.org/gorgonia"
@@ -30,7 +32,11 @@ import (
 //
 // The shape of the mean and standard deviation tensors consitutie
 // the shape of the Normal. E.g. if the mean has shape (3, 2, 5), then
-// so does the Normal.
+// so does the Normal, which holds 3 ⋅ 2 ⋅ 5 = 30 distributions, as
+// returned by NumDistributions. Each of these distributions is
+// univariate. To treat some dimensions as the components of a single
+// multivariate event, wrap the Normal in an IID, whose BatchShape and
+// EventShape methods separate the two.
 //
 // Any input to any method of the Normal must have a shape that is
 // consistent with the shape of the Normal. That is, the input must
@@ -43,52 +49,125 @@ import (
 // 		1. (n_1, n_2, ..., n_M)
 // 		2. (a, n_1, n_2, ..., n_M) for ∀a ∈ ℕ-{0}
 //
+// If the Normal holds a single distribution, that is it has shape (1),
+// then an input of any shape is legal and its elements are treated as
+// i.i.d. samples from the distribution. Such inputs are flattened into
+// a batch, so that the result has shape (a, 1) where a is the number of
+// elements in the input.
+//
 // Normal supports the following data types:
 type Normal struct {
 	mean    *G.Node
+	meanIn  *G.Node // The mean passed to the constructor
 	meanVal G.Value
 
 	stddev    *G.Node
+	stddevIn  *G.Node // The stddev passed to the constructor
 	stddevVal G.Value
 
-	seed uint64
+	consts *normalConsts
+
+	source rand.Source
 }
 
-// NewNormal returns a new Normal.
+var _ Quantiler = (*Normal)(nil)
+
+// NewNormal returns a new Normal, whose samples are drawn using a
+// source seeded with seed. The stddev is validated as in
+// NewNormalWithSource().
 func NewNormal(mean, stddev *G.Node, seed uint64) (*Normal, error) {
+	normal, err := NewNormalWithSource(mean, stddev, rand.NewSource(seed))
+	if err != nil {
+		return nil, fmt.Errorf("newNormal: %w", err)
+	}
+	return normal, nil
+}
+
+// NormalFromTensors returns a new Normal in graph g, whose mean and
+// standard deviation are input nodes with values mean and stddev. This
+// is a convenience for constructing a Normal from data without first
+// constructing nodes. The tensors are used as the values of the nodes
+// without being copied. Samples are drawn using a source seeded with
+// seed, as in NewNormal().
+func NormalFromTensors(g *G.ExprGraph, mean, stddev tensor.Tensor,
+	seed uint64) (*Normal, error) {
+	meanNode, err := nodeFromTensor(g, mean, "mean")
+	if err != nil {
+		return nil, fmt.Errorf("normalFromTensors: %w", err)
+	}
+	stddevNode, err := nodeFromTensor(g, stddev, "stddev")
+	if err != nil {
+		return nil, fmt.Errorf("normalFromTensors: %w", err)
+	}
+
+	normal, err := NewNormal(meanNode, stddevNode, seed)
+	if err != nil {
+		return nil, fmt.Errorf("normalFromTensors: %w", err)
+	}
+	return normal, nil
+}
+
+// NewNormalWithSource returns a new Normal, whose samples are drawn
+// using source. All sampling nodes created by the Normal share source,
+// as may other distributions, so that all sampling can be driven by a
+// single deterministic source.
+//
+// If stddev already has a value, such as an input node constructed
+// with G.WithValue, then an error is returned if any of its elements
+// are not positive. If stddev is computed from other nodes, then it
+// has no value until its graph is run, and so it cannot be validated
+// here. In this case, validation is deferred to the caller, and a
+// non-positive stddev results in NaNs in the outputs of the Normal.
+func NewNormalWithSource(mean, stddev *G.Node, source rand.Source) (*Normal,
+	error) {
+	if source == nil {
+		return nil, fmt.Errorf("newNormalWithSource: nil source")
+	}
+	if mean.Graph() != stddev.Graph() {
+		return nil, fmt.Errorf("newNormalWithSource: expected mean and " +
+			"stddev to be on the same graph")
+	}
 	if !mean.Shape().Eq(stddev.Shape()) {
-		return nil, fmt.Errorf("newNormal: expected mean and stddev to "+
-			"have the same shape but got %v and %v", mean.Shape(),
+		return nil, gop.ShapeErrorf("newNormalWithSource: expected mean "+
+			"and stddev to have the same shape but got %v and %v", mean.Shape(),
 			stddev.Shape())
 	}
 	if mean.Dtype() != stddev.Dtype() {
-		return nil, fmt.Errorf("newNormal: expected mean and stddev to "+
-			"have the same data type but got %v and %v", mean.Dtype(),
-			stddev.Dtype())
+		return nil, gop.DtypeErrorf("newNormalWithSource: expected mean "+
+			"and stddev to have the same data type but got %v and %v",
+			mean.Dtype(), stddev.Dtype())
 	} else if mean.Dtype() != tensor.Float64 &&
 		mean.Dtype() != tensor.Float32 {
-		return nil, fmt.Errorf("newNormal: data type %v unsupported",
-			mean.Dtype())
+		return nil, gop.DtypeErrorf("newNormalWithSource: data type %v "+
+			"unsupported", mean.Dtype())
+	}
+	if err := checkPositive(stddev); err != nil {
+		return nil, fmt.Errorf("newNormalWithSource: %w", err)
 	}
 
+	meanIn, stddevIn := mean, stddev
+
 	var err error
 	if mean.IsScalar() {
 		mean, err = G.Reshape(mean, []int{1})
 		if err != nil {
-			return nil, fmt.Errorf("newNormal: could not expand mean to "+
-				"shape (1): %v", err)
+			return nil, fmt.Errorf("newNormalWithSource: could not expand "+
+				"mean to shape (1): %w", err)
 		}
 		stddev, err = G.Reshape(stddev, []int{1})
 		if err != nil {
-			return nil, fmt.Errorf("newNormal: could not expand stddev to "+
-				"shape (1): %v", err)
+			return nil, fmt.Errorf("newNormalWithSource: could not expand "+
+				"stddev to shape (1): %w", err)
 		}
 	}
 
 	normal := &Normal{
-		mean:   mean,
-		stddev: stddev,
-		seed:   seed,
+		mean:     mean,
+		meanIn:   meanIn,
+		stddev:   stddev,
+		stddevIn: stddevIn,
+		consts:   normalConstants(mean.Graph(), mean.Dtype()),
+		source:   source,
 	}
 
 	G.Read(normal.mean, &normal.meanVal)
@@ -100,10 +179,11 @@ func NewNormal(mean, stddev *G.Node, seed uint64) (*Normal, error) {
 // Prob calculates the probability density of x.
 //
 // If the receiver's mean and standard deviation nodes are scalars, then
-// if x is a vector, this function treats it as a batch of values to
-// compute the probability density of. In this case, the density
-// will be calculated element-wise for each value in the vector x with
-// the same mean and standard deviation.
+// if x is a vector or a tensor of any shape, this function treats it as
+// a batch of values to compute the probability density of. In this
+// case, the density will be calculated element-wise for each value in
+// x with the same mean and standard deviation, and the result has shape
+// (a, 1), where a is the number of elements in x.
 //
 // If the mean and standard deviation of the receiver are tensors,
 // then the receiver is assumed to hold N normal distributions,
@@ -129,19 +209,18 @@ func NewNormal(mean, stddev *G.Node, seed uint64) (*Normal, error) {
 func (n *Normal) Prob(x *G.Node) (*G.Node, error) {
 	x, err := n.fixShape(x)
 	if err != nil {
-		return nil, fmt.Errorf("prob: %v", err)
+		return nil, fmt.Errorf("prob: %w", err)
 	}
 
-	two := x.Graph().Constant(G.NewF64(2.0))
-	negativeHalf := x.Graph().Constant(G.NewF64(-0.5))
-	rootTwoPi := x.Graph().Constant(G.NewF64(math.Sqrt(math.Pi * 2.)))
+	negativeHalf := n.consts.negativeHalf
+	rootTwoPi := n.consts.rootTwoPi
 
 	if n.isBatch(x) {
 		// Calculate probability of batch
 		batchDim := []byte{0}
 		x = G.Must(G.BroadcastSub(x, n.mean, nil, batchDim))
 		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
-		x = G.Must(G.Pow(x, two))
+		x = G.Must(gop.Square(x))
 		x = G.Must(G.HadamardProd(negativeHalf, x))
 		x = G.Must(G.Exp(x))
 		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
@@ -150,7 +229,7 @@ func (n *Normal) Prob(x *G.Node) (*G.Node, error) {
 		// Calculate probability of single sample
 		x = G.Must(G.Sub(x, n.mean))
 		x = G.Must(G.HadamardDiv(x, n.stddev))
-		x = G.Must(G.Pow(x, two))
+		x = G.Must(gop.Square(x))
 		x = G.Must(G.HadamardProd(negativeHalf, x))
 		x = G.Must(G.Exp(x))
 		x = G.Must(G.HadamardDiv(x, n.stddev))
@@ -165,28 +244,18 @@ func (n *Normal) Prob(x *G.Node) (*G.Node, error) {
 func (n *Normal) LogProb(x *G.Node) (*G.Node, error) {
 	x, err := n.fixShape(x)
 	if err != nil {
-		return nil, fmt.Errorf("logProb: %v", err)
+		return nil, fmt.Errorf("logProb: %w", err)
 	}
 
-	var two, negativeHalf, lnRootTwoPi *G.Node
-	if n.Dtype() == tensor.Float64 {
-		two = x.Graph().Constant(G.NewF64(2.0))
-		negativeHalf = x.Graph().Constant(G.NewF64(-0.5))
-		lnRootTwoPi = x.Graph().Constant(G.NewF64(math.Log(math.Sqrt(
-			math.Pi * 2.))))
-	} else {
-		two = x.Graph().Constant(G.NewF32(2.0))
-		negativeHalf = x.Graph().Constant(G.NewF32(-0.5))
-		lnRootTwoPi = x.Graph().Constant(G.NewF32(math32.Log(math32.Sqrt(
-			math32.Pi * 2.))))
-	}
+	negativeHalf := n.consts.negativeHalf
+	lnRootTwoPi := n.consts.lnRootTwoPi
 
 	if n.isBatch(x) {
 		// Calculate probability of batch
 		batchDim := []byte{0}
 		x = G.Must(G.BroadcastSub(x, n.mean, nil, batchDim))
 		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
-		x = G.Must(G.Pow(x, two))
+		x = G.Must(gop.Square(x))
 		x = G.Must(G.HadamardProd(negativeHalf, x))
 		lnStd := G.Must(G.Log(n.stddev))
 		x = G.Must(G.BroadcastSub(x, lnStd, nil, batchDim))
@@ -195,7 +264,7 @@ func (n *Normal) LogProb(x *G.Node) (*G.Node, error) {
 		// Calculate probability of single sample
 		x = G.Must(G.Sub(x, n.mean))
 		x = G.Must(G.HadamardDiv(x, n.stddev))
-		x = G.Must(G.Pow(x, two))
+		x = G.Must(gop.Square(x))
 		x = G.Must(G.HadamardProd(negativeHalf, x))
 		lnStd := G.Must(G.Log(n.stddev))
 		x = G.Must(G.Sub(x, lnStd))
@@ -205,31 +274,113 @@ func (n *Normal) LogProb(x *G.Node) (*G.Node, error) {
 	return x, nil
 }
 
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, -log(σ√(2π)). The returned node has shape
+// (1, n.Shape()...).
+func (n *Normal) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(n)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// LogProbFused is like LogProb, but computes the log probability of x
+// and its gradients with a single operation rather than a chain of
+// element-wise operations, reducing the number of nodes in the graph
+// and intermediate allocations. The shape of x is treated in the same
+// way as the Prob() method.
+func (n *Normal) LogProbFused(x *G.Node) (*G.Node, error) {
+	x, err := n.fixShape(x)
+	if err != nil {
+		return nil, fmt.Errorf("logProbFused: %w", err)
+	}
+
+	op, err := newNormalLogProbOp(n.Dtype(), n.Shape(), x.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("logProbFused: %w", err)
+	}
+
+	return G.ApplyOp(op, n.mean, n.stddev, x)
+}
+
+// Score computes the score function of the receiver with respect to
+// its mean at x, ∇_μ log(p(x)) = (x-μ)/σ², as an explicit expression
+// rather than by differentiating LogProb. This is useful for
+// likelihood-ratio (REINFORCE) gradient estimators. The shape of x is
+// treated in the same way as the Prob() method.
+func (n *Normal) Score(x *G.Node) (*G.Node, error) {
+	x, err := n.fixShape(x)
+	if err != nil {
+		return nil, fmt.Errorf("score: %w", err)
+	}
+
+	variance := n.Variance()
+	if n.isBatch(x) {
+		// Calculate the score of batch
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, n.mean, nil, batchDim))
+		x = G.Must(G.BroadcastHadamardDiv(x, variance, nil, batchDim))
+	} else {
+		// Calculate the score of a single observation
+		x = G.Must(G.Sub(x, n.mean))
+		x = G.Must(G.HadamardDiv(x, variance))
+	}
+
+	return x, nil
+}
+
+// StdDevScore computes the score function of the receiver with
+// respect to its standard deviation at x,
+// ∇_σ log(p(x)) = ((x-μ)²/σ² - 1)/σ, as an explicit expression rather
+// than by differentiating LogProb. The shape of x is treated in the
+// same way as the Prob() method.
+func (n *Normal) StdDevScore(x *G.Node) (*G.Node, error) {
+	x, err := n.fixShape(x)
+	if err != nil {
+		return nil, fmt.Errorf("stdDevScore: %w", err)
+	}
+
+	one := n.consts.one
+	if n.isBatch(x) {
+		// Calculate the score of batch
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, n.mean, nil, batchDim))
+		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
+		x = G.Must(gop.Square(x))
+		x = G.Must(G.Sub(x, one))
+		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
+	} else {
+		// Calculate the score of a single observation
+		x = G.Must(G.Sub(x, n.mean))
+		x = G.Must(G.HadamardDiv(x, n.stddev))
+		x = G.Must(gop.Square(x))
+		x = G.Must(G.Sub(x, one))
+		x = G.Must(G.HadamardDiv(x, n.stddev))
+	}
+
+	return x, nil
+}
+
 // Cdf computes the cumulative distribution function of x. The shape
 // of x is treated in the same way as the Prob() method.
 func (n *Normal) Cdf(x *G.Node) (*G.Node, error) {
 	x, err := n.fixShape(x)
 	if err != nil {
-		return nil, fmt.Errorf("cdf: %v", err)
+		return nil, fmt.Errorf("cdf: %w", err)
 	}
 
 	if x.IsScalar() {
 		x, err = G.Reshape(x, []int{1})
 		if err != nil {
-			return nil, fmt.Errorf("cdf: could not reshape x: %v", err)
+			return nil, fmt.Errorf("cdf: could not reshape x: %w", err)
 		}
 	}
 
-	var rootTwo, one, half *G.Node
-	if n.Dtype() == tensor.Float64 {
-		rootTwo = x.Graph().Constant(G.NewF64(math.Sqrt(2.0)))
-		one = x.Graph().Constant(G.NewF64(1.0))
-		half = x.Graph().Constant(G.NewF64(0.5))
-	} else {
-		rootTwo = x.Graph().Constant(G.NewF32(math32.Sqrt(2.0)))
-		one = x.Graph().Constant(G.NewF32(1.0))
-		half = x.Graph().Constant(G.NewF32(0.5))
-	}
+	rootTwo := n.consts.rootTwo
+	one := n.consts.one
+	half := n.consts.half
 
 	if n.isBatch(x) {
 		// Calculate probability of batch
@@ -253,33 +404,105 @@ func (n *Normal) Cdf(x *G.Node) (*G.Node, error) {
 	return x, nil
 }
 
+// Sf computes the survival function, 1 - Cdf(x), of x. The survival
+// function is computed directly as 0.5⋅erfc((x-μ)/(σ√2)) rather than
+// from the Cdf, so that it does not lose precision in the right tail.
+// The shape of x is treated in the same way as the Prob() method.
+func (n *Normal) Sf(x *G.Node) (*G.Node, error) {
+	x, err := n.fixShape(x)
+	if err != nil {
+		return nil, fmt.Errorf("sf: %w", err)
+	}
+
+	if x.IsScalar() {
+		x, err = G.Reshape(x, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("sf: could not reshape x: %w", err)
+		}
+	}
+
+	rootTwo := n.consts.rootTwo
+	half := n.consts.half
+
+	if n.isBatch(x) {
+		// Calculate survival function of batch
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, n.mean, nil, batchDim))
+		x = G.Must(G.HadamardDiv(x, rootTwo))
+		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
+		x = G.Must(gop.Erfc(x))
+		x = G.Must(G.HadamardProd(half, x))
+	} else {
+		// Calculate the survival function of a single observation
+		x = G.Must(G.Sub(x, n.mean))
+		x = G.Must(G.HadamardDiv(x, rootTwo))
+		x = G.Must(G.HadamardDiv(x, n.stddev))
+		x = G.Must(gop.Erfc(x))
+		x = G.Must(G.HadamardProd(half, x))
+	}
+
+	return x, nil
+}
+
+// LogCdf computes the log of the cumulative distribution function of
+// x. The log Cdf is computed directly using gop.LogNdtr rather than
+// from the Cdf, so that it remains finite for x many standard
+// deviations below the mean, where the Cdf underflows. The shape of x
+// is treated in the same way as the Prob() method.
+func (n *Normal) LogCdf(x *G.Node) (*G.Node, error) {
+	x, err := n.fixShape(x)
+	if err != nil {
+		return nil, fmt.Errorf("logCdf: %w", err)
+	}
+
+	if x.IsScalar() {
+		x, err = G.Reshape(x, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("logCdf: could not reshape x: %w", err)
+		}
+	}
+
+	if n.isBatch(x) {
+		// Calculate the log cdf of batch
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, n.mean, nil, batchDim))
+		x = G.Must(G.BroadcastHadamardDiv(x, n.stddev, nil, batchDim))
+		x = G.Must(gop.LogNdtr(x))
+	} else {
+		// Calculate the log cdf of a single observation
+		x = G.Must(G.Sub(x, n.mean))
+		x = G.Must(G.HadamardDiv(x, n.stddev))
+		x = G.Must(gop.LogNdtr(x))
+	}
+
+	return x, nil
+}
+
 // Quantile computes the inverse cumulative distribution function at
 // probability p. The shape of p is treated in the same way as the
 // Prob() method.
 func (n *Normal) Quantile(p *G.Node) (*G.Node, error) {
 	p, err := n.fixShape(p)
 	if err != nil {
-		return nil, fmt.Errorf("prob: %v", err)
+		return nil, fmt.Errorf("quantile: %w", err)
 	}
 
 	if p.IsScalar() {
 		p, err = G.Reshape(p, []int{1})
 		if err != nil {
-			return nil, fmt.Errorf("cdfinv: could not reshape x: %v", err)
+			return nil, fmt.Errorf("quantile: could not reshape p: %w", err)
 		}
 	}
 
-	var rootTwo, one, two *G.Node
-	if n.Dtype() == tensor.Float64 {
-		rootTwo = p.Graph().Constant(G.NewF64(math.Sqrt(2.0)))
-		one = p.Graph().Constant(G.NewF64(1.0))
-		two = p.Graph().Constant(G.NewF64(2.0))
-	} else {
-		rootTwo = p.Graph().Constant(G.NewF32(math32.Sqrt(2.0)))
-		one = p.Graph().Constant(G.NewF32(1.0))
-		two = p.Graph().Constant(G.NewF32(2.0))
+	p, err = validateProbs(p)
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
 	}
 
+	rootTwo := n.consts.rootTwo
+	one := n.consts.one
+	two := n.consts.two
+
 	if n.isBatch(p) {
 		// Calculate probability of batch
 		batchDim := []byte{0}
@@ -302,16 +525,33 @@ func (n *Normal) Quantile(p *G.Node) (*G.Node, error) {
 	return p, nil
 }
 
+// CanEvaluate returns an error describing why x is of an invalid shape
+// to be used as input to the methods of the receiver, such as Prob(),
+// or nil if x is of a legal shape, as described in the documentation
+// for Normal. This allows inputs to be validated before any nodes are
+// added to the graph.
+func (n *Normal) CanEvaluate(x *G.Node) error {
+	if err := checkShape(x, n.Shape()); err != nil {
+		return fmt.Errorf("canEvaluate: %w", err)
+	}
+	return nil
+}
+
 // Shape returns the number of distributions stored by the receiver
 func (n *Normal) Shape() tensor.Shape {
 	return n.mean.Shape()
 }
 
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (n *Normal) NumDistributions() int {
+	return tensor.ProdInts(n.Shape())
+}
+
 // Variance returns the variance of the distribution(s) stored by the
 // receiver
 func (n *Normal) Variance() *G.Node {
-	two := n.mean.Graph().Constant(G.NewF64(2.0))
-	return G.Must(G.Pow(n.stddev, two))
+	return G.Must(gop.Square(n.stddev))
 }
 
 // StdDev returns the standard deviation of the distribution(s)
@@ -326,21 +566,42 @@ func (n *Normal) Mean() *G.Node {
 	return n.mean
 }
 
+// MeanValue returns a copy of the current value of the receiver's mean,
+// with shape n.Shape(). The mean must have a value, such as an input
+// node constructed with G.WithValue or a node whose graph has already
+// been run, otherwise an error is returned.
+func (n *Normal) MeanValue() (tensor.Tensor, error) {
+	mean, err := valueOf(n.mean, n.meanIn, n.meanVal)
+	if err != nil {
+		return nil, fmt.Errorf("meanValue: %w", err)
+	}
+	return mean, nil
+}
+
+// StdDevValue returns a copy of the current value of the receiver's
+// standard deviation, with shape n.Shape(), in the same way as
+// MeanValue()
+func (n *Normal) StdDevValue() (tensor.Tensor, error) {
+	stddev, err := valueOf(n.stddev, n.stddevIn, n.stddevVal)
+	if err != nil {
+		return nil, fmt.Errorf("stdDevValue: %w", err)
+	}
+	return stddev, nil
+}
+
 // Entropy returns the entropy of the distribution(s) stored by the
 // receiver
 func (n *Normal) Entropy() (*G.Node, error) {
-	var half, twoPi, two *G.Node
+	var half, twoPi *G.Node
 	if n.Dtype() == tensor.Float64 {
 		half = n.mean.Graph().Constant(G.NewF64(0.5))
 		twoPi = n.mean.Graph().Constant(G.NewF64(math.Pi * 2.0))
-		two = n.mean.Graph().Constant(G.NewF64(2.0))
 	} else {
 		half = n.mean.Graph().Constant(G.NewF32(0.5))
 		twoPi = n.mean.Graph().Constant(G.NewF32(math32.Pi * 2.0))
-		two = n.mean.Graph().Constant(G.NewF32(2.0))
 	}
 
-	entropy := G.Must(G.Pow(n.stddev, two))
+	entropy := G.Must(gop.Square(n.stddev))
 	entropy = G.Must(G.HadamardProd(entropy, twoPi))
 	entropy = G.Must(G.Log(entropy))
 	entropy = G.Must(G.HadamardProd(half, entropy))
@@ -357,99 +618,255 @@ func (n *Normal) HasRsample() bool { return true }
 func (n *Normal) Dtype() tensor.Dtype { return n.mean.Dtype() }
 
 // Rsample samples m samples from the receiver using reparameterized
-// sampling. This is a differentiable operation.
+// sampling. The returned node has shape (m, n.Shape()...), even when
+// m == 1, which is consistent with Sample(). This is a differentiable
+// operation.
 func (n *Normal) Rsample(m int) (*G.Node, error) {
-	size := tensor.ProdInts(n.mean.Shape())
-
-	zeroMeanT := tensor.NewDense(
-		n.Dtype(),
-		n.mean.Shape(),
-		tensor.WithBacking(make([]float64, size)),
-	)
-	zeroMean := G.NewTensor(
-		n.mean.Graph(),
-		zeroMeanT.Dtype(),
-		zeroMeanT.Dims(),
-		G.WithValue(zeroMeanT),
-		G.WithName(gop.Unique("zeroMean")),
-	)
-
-	var ones interface{}
-	if n.Dtype() == tensor.Float64 {
-		ones = ones64(size)
-	} else {
-		ones = ones32(size)
-	}
-	unitStddevT := tensor.NewDense(
-		n.Dtype(),
-		n.stddev.Shape(),
-		tensor.WithBacking(ones),
-	)
-	unitStddev := G.NewTensor(
-		n.stddev.Graph(),
-		unitStddevT.Dtype(),
-		unitStddevT.Dims(),
-		G.WithValue(unitStddevT),
-		G.WithName(gop.Unique("unitStddev")),
-	)
-	stdNormal, err := NormalSample(zeroMean, unitStddev, n.seed,
-		m)
+	stdNormal, err := n.stdNormal(m)
 	if err != nil {
-		return nil, fmt.Errorf("rsample: could not sample from "+
-			"standard normal: %v", err)
+		return nil, fmt.Errorf("rsample: %w", err)
 	}
 
 	// Reparameterization trick
-	var out *G.Node
-	if m > 1 {
-		out = G.Must(G.BroadcastHadamardProd(stdNormal, n.stddev, nil,
-			[]byte{0}))
-		out = G.Must(G.BroadcastAdd(out, n.mean, nil, []byte{0}))
-		return out, nil
-	} else {
-		// First remove batch dimension 0
-		stdNormal = G.Must(G.Reshape(stdNormal, n.Shape()))
+	out, err := reparameterize(stdNormal, n.mean, n.stddev)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	return out, nil
+}
+
+// RsampleAntithetic samples m samples from the receiver using
+// reparameterized sampling with antithetic variates. For even m, m/2
+// samples of standard normal noise z are drawn and paired with -z, so
+// that the first m/2 samples are mean + stddev * z and the last m/2
+// samples are mean - stddev * z. The paired samples are negatively
+// correlated, which reduces the variance of gradient estimates
+// computed from them. The returned node has shape (m, n.Shape()...).
+// This is a differentiable operation.
+func (n *Normal) RsampleAntithetic(m int) (*G.Node, error) {
+	if m <= 0 || m%2 != 0 {
+		return nil, fmt.Errorf("rsampleAntithetic: expected a positive, "+
+			"even number of samples but got %v", m)
+	}
+
+	stdNormal, err := n.stdNormal(m / 2)
+	if err != nil {
+		return nil, fmt.Errorf("rsampleAntithetic: %w", err)
+	}
+
+	negStdNormal, err := G.Neg(stdNormal)
+	if err != nil {
+		return nil, fmt.Errorf("rsampleAntithetic: could not negate "+
+			"noise: %w", err)
+	}
+	stdNormal, err = G.Concat(0, stdNormal, negStdNormal)
+	if err != nil {
+		return nil, fmt.Errorf("rsampleAntithetic: could not pair "+
+			"noise: %w", err)
+	}
+
+	// Reparameterization trick
+	out, err := reparameterize(stdNormal, n.mean, n.stddev)
+	if err != nil {
+		return nil, fmt.Errorf("rsampleAntithetic: %w", err)
+	}
+
+	return out, nil
+}
 
-		out = G.Must(G.HadamardProd(stdNormal, n.stddev))
-		out = G.Must(G.Add(out, n.mean))
+// stdNormal returns m samples of standard normal noise drawn using the
+// receiver's source, with shape (m, n.Shape()...)
+func (n *Normal) stdNormal(m int) (*G.Node, error) {
+	graph := n.mean.Graph()
+	zeroMean := full(graph, n.Dtype(), n.Shape(), 0.0, "zeroMean")
+	unitStddev := full(graph, n.Dtype(), n.Shape(), 1.0, "unitStddev")
+
+	stdNormal, err := NormalSampleWithSource(zeroMean, unitStddev, n.source,
+		m)
+	if err != nil {
+		return nil, fmt.Errorf("could not sample from standard normal: %w",
+			err)
+	}
+
+	return stdNormal, nil
+}
+
+// RsampleWithNoise returns mean + stddev * noise, where noise is an
+// externally supplied node of standard normal noise. This allows
+// callers to manage the generation of noise themselves, for example to
+// reuse a single noise node across many samples rather than allocating
+// new sampling nodes with each call to Rsample(). The shape of noise is
+// treated in the same way as the Prob() method, so that noise may be a
+// batch of noise samples with batch dimension 0. This is a
+// differentiable operation.
+func (n *Normal) RsampleWithNoise(noise *G.Node) (*G.Node, error) {
+	noise, err := n.fixShape(noise)
+	if err != nil {
+		return nil, fmt.Errorf("rsampleWithNoise: %w", err)
+	}
+	if noise.Dtype() != n.Dtype() {
+		return nil, fmt.Errorf("rsampleWithNoise: expected noise to have "+
+			"data type %v but got %v", n.Dtype(), noise.Dtype())
+	}
+
+	if n.isBatch(noise) {
+		out, err := reparameterize(noise, n.mean, n.stddev)
+		if err != nil {
+			return nil, fmt.Errorf("rsampleWithNoise: %w", err)
+		}
 		return out, nil
 	}
+
+	out := G.Must(G.HadamardProd(noise, n.stddev))
+	return G.Add(out, n.mean)
 }
 
 // Sample samples m samples from the receiver. This operation is
 // not differentiable
 func (n *Normal) Sample(m int) (*G.Node, error) {
-	return NormalSample(n.mean, n.stddev, n.seed, m)
+	return NormalSampleWithSource(n.mean, n.stddev, n.source, m)
+}
+
+// SampleMode returns the mode of the distribution(s) stored by the
+// receiver, which is the mean, as a deterministic sample. This is
+// useful for deterministic rollouts when evaluating policies, and the
+// returned node, unlike those of Sample(), has shape n.Shape() with no
+// batch dimension.
+func (n *Normal) SampleMode() (*G.Node, error) {
+	return n.mean, nil
+}
+
+// SampleValues samples m samples from the receiver and returns them as
+// a concrete tensor of shape (m, n.Shape()...), without the caller
+// needing to build and run a graph. The samples are drawn in a new
+// graph using the current values of the receiver's mean and standard
+// deviation, and so these must have values, such as input nodes
+// constructed with G.WithValue or nodes whose graph has already been
+// run. The samples are drawn using the receiver's source.
+func (n *Normal) SampleValues(m int) (tensor.Tensor, error) {
+	meanVal, err := n.MeanValue()
+	if err != nil {
+		return nil, fmt.Errorf("sampleValues: %w", err)
+	}
+	stddevVal, err := n.StdDevValue()
+	if err != nil {
+		return nil, fmt.Errorf("sampleValues: %w", err)
+	}
+
+	g := G.NewGraph()
+	mean := G.NodeFromAny(g, meanVal, G.WithName("mean"))
+	stddev := G.NodeFromAny(g, stddevVal, G.WithName("stddev"))
+
+	samples, err := NormalSampleWithSource(mean, stddev, n.source, m)
+	if err != nil {
+		return nil, fmt.Errorf("sampleValues: %w", err)
+	}
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		return nil, fmt.Errorf("sampleValues: could not run graph: %w",
+			err)
+	}
+
+	return samples.Value().(tensor.Tensor), nil
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., n.Shape()...), similar to PyTorch's
+// sample(sample_shape). This operation is not differentiable.
+func (n *Normal) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(n.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// Expand returns a new Normal with shape shape, whose mean and
+// standard deviation are those of the receiver broadcast to shape by
+// tiling. This allows a single Normal, such as a prior, to be expanded
+// to the size of a minibatch without constructing new parameter nodes.
+// The shape of the receiver must be broadcastable to shape following
+// NumPy's broadcasting rules, and a scalar Normal, which has shape
+// (1), may be expanded to any shape. The returned Normal shares the
+// source of the receiver.
+func (n *Normal) Expand(shape tensor.Shape) (Distribution, error) {
+	if len(shape) == 0 {
+		return nil, gop.ShapeErrorf("expand: cannot expand to scalar shape")
+	}
+	for _, dim := range shape {
+		if dim <= 0 {
+			return nil, gop.ShapeErrorf("expand: expected shape dimensions to "+
+				"be > 0 but got %v", shape)
+		}
+	}
+
+	mean, err := expand(n.mean, shape)
+	if err != nil {
+		return nil, fmt.Errorf("expand: mean: %w", err)
+	}
+	stddev, err := expand(n.stddev, shape)
+	if err != nil {
+		return nil, fmt.Errorf("expand: stddev: %w", err)
+	}
+
+	normal, err := NewNormalWithSource(mean, stddev, n.source)
+	if err != nil {
+		return nil, fmt.Errorf("expand: %w", err)
+	}
+
+	return normal, nil
+}
+
+// Reseed reseeds the source used by the receiver to draw samples, so
+// that running the graph again after reseeding the receiver with the
+// seed it was created with reproduces the samples drawn on the first
+// run. Since the source may be shared, this also reseeds any other
+// distributions or sampling nodes which share the receiver's source.
+func (n *Normal) Reseed(seed uint64) {
+	n.source.Seed(seed)
 }
 
 // isBatch returns whether x is a batch of samples to calculate some
 // method on
 func (n *Normal) isBatch(x *G.Node) bool {
-	return !x.Shape().Eq(n.mean.Shape())
+	return isBatch(x, n.Shape())
 }
 
 // fixShape adjusts the shape of x so that it can be used in some
 // method. It returns an error indicating if x is of an invalid shape
 // which could not be adjusted.
 func (n *Normal) fixShape(x *G.Node) (*G.Node, error) {
-	if x.IsScalar() && n.mean.Shape()[0] == 1 {
-		return G.Reshape(x, []int{1})
-
-	} else if len(x.Shape()) == 1 && n.mean.Shape()[0] == 1 {
-		// When distribution shape was inputted as a scalar, then a
-		// vector input x indicates a batch of samples -> reshape
-		// so batch dims = 0 and shape of samples = dim 1
-		return G.Reshape(x, []int{x.Shape()[0], 1})
+	return fixShape(x, n.Shape())
+}
 
-	} else if n.isBatch(x) && !tensor.Shape(x.Shape()[1:]).Eq(n.Shape()) {
-		msg := "expected shape to match distribution shape %v at all " +
-			"dimensions except batch (dim 0) but got x shape %v"
-		return nil, fmt.Errorf(msg, n.Shape(), x.Shape())
+// normalConsts holds the constant nodes shared by the methods of the
+// Normal which compute the probability density and cumulative
+// distribution functions and their inverse
+type normalConsts struct {
+	negativeHalf *G.Node
+	half         *G.Node
+	one          *G.Node
+	two          *G.Node
+	rootTwo      *G.Node // √2
+	rootTwoPi    *G.Node // √(2π)
+	lnRootTwoPi  *G.Node // log(√(2π))
+}
 
-	} else if !n.isBatch(x) && !n.Shape().Eq(x.Shape()) {
-		msg := "expected shape to match distribution shape %v but got %v"
-		return nil, fmt.Errorf(msg, n.Shape(), x.Shape())
+// normalConstants returns the constants used by the Normal, added to
+// graph g with data type dt. These are created once per Normal so that
+// repeated calls to its methods do not add new constants to the graph.
+func normalConstants(g *G.ExprGraph, dt tensor.Dtype) *normalConsts {
+	return &normalConsts{
+		negativeHalf: constant(g, dt, -0.5),
+		half:         constant(g, dt, 0.5),
+		one:          constant(g, dt, 1.0),
+		two:          constant(g, dt, 2.0),
+		rootTwo:      constant(g, dt, math.Sqrt2),
+		rootTwoPi:    constant(g, dt, math.Sqrt(2*math.Pi)),
+		lnRootTwoPi:  constant(g, dt, math.Log(math.Sqrt(2*math.Pi))),
 	}
-
-	return x, nil
 }
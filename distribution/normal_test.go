@@ -1,14 +1,18 @@
 package distribution
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	rand "math/rand"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/samuelfneumann/gop"
 	expRand "golang.org/x/exp/rand"
 
+	"gonum.org/v1/gonum/stat"
 	"gonum.org/v1/gonum/stat/distuv"
 	G "gorgonia.org/gorgonia"
 	"gorgonia.org/tensor"
@@ -1881,3 +1885,2999 @@ func TestNormalQuantileTensor(t *testing.T) {
 		vm.Close()
 	}
 }
+
+// TestNormalQuantiler tests calling the Quantile method of a Normal
+// through the Quantiler interface against gonum's normal quantile
+// function.
+func TestNormalQuantiler(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold at which floats are equal
+	const size int = 5                // Number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	meanBacking := make([]float64, size)
+	stddevBacking := make([]float64, size)
+	probBacking := make([]float64, size)
+	for j := range meanBacking {
+		meanBacking[j] = (rand.Float64() - 0.5) * 2.0
+		stddevBacking[j] = math.Exp(rand.Float64())
+		probBacking[j] = 0.01 + rand.Float64()*0.98
+	}
+
+	g := G.NewGraph()
+	mean := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{size}, tensor.WithBacking(meanBacking))),
+		G.WithName("mean"))
+	stddev := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{size}, tensor.WithBacking(stddevBacking))),
+		G.WithName("stddev"))
+	prob := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{size}, tensor.WithBacking(probBacking))),
+		G.WithName("prob"))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var q Quantiler = n
+	quantile, err := q.Quantile(prob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var quantileVal G.Value
+	G.Read(quantile, &quantileVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for j := 0; j < size; j++ {
+		target := distuv.Normal{Mu: meanBacking[j], Sigma: stddevBacking[j]}
+		expected := target.Quantile(probBacking[j])
+		computed := quantileVal.Data().([]float64)[j]
+		if math.Abs(computed-expected) > threshold {
+			t.Errorf("expected: %v received: %v for prob: %v", expected,
+				computed, probBacking[j])
+		}
+	}
+}
+
+// TestNormalQuantileValidate tests that the Quantile of probabilities
+// outside (0, 1) results in an error when ValidateArgs is true, and
+// only then
+func TestNormalQuantileValidate(t *testing.T) {
+	defer func(validate bool) { ValidateArgs = validate }(ValidateArgs)
+
+	tests := []struct {
+		prob      float64
+		validate  bool
+		expectErr bool
+	}{
+		{0.5, false, false},
+		{0.5, true, false},
+		{1.5, false, false},
+		{1.5, true, true},
+		{0.0, true, true},
+		{1.0, true, true},
+		{-0.5, true, true},
+	}
+
+	for _, test := range tests {
+		ValidateArgs = test.validate
+
+		g := G.NewGraph()
+		mean := G.NewVector(g, tensor.Float64, G.WithShape(2),
+			G.WithName("mean"), G.WithInit(G.Zeroes()))
+		stddev := G.NewVector(g, tensor.Float64, G.WithShape(2),
+			G.WithName("stddev"), G.WithInit(G.Ones()))
+		prob := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+			tensor.Float64, []int{2}, tensor.WithBacking([]float64{0.25,
+				test.prob}))), G.WithName("prob"))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := n.Quantile(prob); err != nil {
+			t.Fatal(err)
+		}
+
+		vm := G.NewTapeMachine(g)
+		err = vm.RunAll()
+		vm.Close()
+		if test.expectErr && err == nil {
+			t.Errorf("expected error computing quantile of %v with "+
+				"validation", test.prob)
+		} else if !test.expectErr && err != nil {
+			t.Errorf("unexpected error computing quantile of %v with "+
+				"validation %v: %v", test.prob, test.validate, err)
+		}
+	}
+}
+
+// TestNormalCdfGonum tests the Cdf method of the Normal against
+// gonum's CDF for the normal distribution at random points.
+func TestNormalCdfGonum(t *testing.T) {
+	const tests int = 15  // Number of tests to run
+	const points int = 20 // Number of points to check per test
+	const maxSize int = 5 // Maximum number of distributions
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := 1 + rand.Intn(maxSize)
+		mean := (rand.Float64() - 0.5) * scale
+		stddev := (math.Exp(rand.Float64()) + stdOffset) * scale
+
+		g := G.NewGraph()
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		for j := 0; j < size; j++ {
+			meanBacking[j] = mean
+			stddevBacking[j] = stddev
+		}
+		meanT := tensor.NewDense(tensor.Float64, []int{size},
+			tensor.WithBacking(meanBacking))
+		stddevT := tensor.NewDense(tensor.Float64, []int{size},
+			tensor.WithBacking(stddevBacking))
+		meanNode := G.NewVector(g, tensor.Float64, G.WithValue(meanT),
+			G.WithName("mean"))
+		stddevNode := G.NewVector(g, tensor.Float64, G.WithValue(stddevT),
+			G.WithName("stddev"))
+
+		n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		in := make([]float64, points)
+		for j := range in {
+			in[j] = mean + (rand.Float64()-0.5)*4.0*stddev
+		}
+
+		target := distuv.Normal{Mu: mean, Sigma: stddev}
+		CompareCdfToGonum(t, n, target.CDF, in)
+	}
+}
+
+// TestNormalSfGonum tests the Sf method of the Normal against 1 - Cdf
+// using gonum's normal survival function in the bulk of the
+// distribution.
+func TestNormalSfGonum(t *testing.T) {
+	const tests int = 15  // Number of tests to run
+	const points int = 20 // Number of points to check per test
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		mean := (rand.Float64() - 0.5) * scale
+		stddev := (math.Exp(rand.Float64()) + stdOffset) * scale
+
+		g := G.NewGraph()
+		meanNode := G.NewScalar(g, tensor.Float64, G.WithValue(mean),
+			G.WithName("mean"))
+		stddevNode := G.NewScalar(g, tensor.Float64, G.WithValue(stddev),
+			G.WithName("stddev"))
+
+		n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		in := make([]float64, points)
+		for j := range in {
+			in[j] = mean + (rand.Float64()-0.5)*4.0*stddev
+		}
+
+		target := distuv.Normal{Mu: mean, Sigma: stddev}
+		CompareSfToGonum(t, n, target.Survival, in)
+	}
+}
+
+// TestNormalSfTail tests the Sf method of the Normal far in the right
+// tail, where 1 - Cdf rounds to 0, against the complementary error
+// function.
+func TestNormalSfTail(t *testing.T) {
+	const tolerance float64 = 1e-6 // Relative tolerance
+	const size int = 10            // Number of points to check
+	rand.Seed(time.Now().UnixNano())
+
+	mean := (rand.Float64() - 0.5) * 2.0
+	stddev := math.Exp(rand.Float64())
+
+	// Points between 10 and 30 standard deviations above the mean
+	sigmas := make([]float64, size)
+	in := make([]float64, size)
+	for j := range in {
+		sigmas[j] = 10.0 + rand.Float64()*20.0
+		in[j] = mean + sigmas[j]*stddev
+	}
+
+	g := G.NewGraph()
+	meanNode := G.NewScalar(g, tensor.Float64, G.WithValue(mean),
+		G.WithName("mean"))
+	stddevNode := G.NewScalar(g, tensor.Float64, G.WithValue(stddev),
+		G.WithName("stddev"))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{size}, tensor.WithBacking(in))),
+		G.WithName("x"))
+
+	n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sf, err := n.Sf(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdf, err := n.Cdf(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sfVal, cdfVal G.Value
+	G.Read(sf, &sfVal)
+	G.Read(cdf, &cdfVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for j := 0; j < size; j++ {
+		target := 0.5 * math.Erfc(sigmas[j]/math.Sqrt2)
+
+		if naive := 1 - cdfVal.Data().([]float64)[j]; naive != 0 {
+			t.Errorf("expected 1 - cdf to round to 0 at %v standard "+
+				"deviations but got %v", sigmas[j], naive)
+		}
+
+		computed := sfVal.Data().([]float64)[j]
+		if math.Abs(computed-target)/target > tolerance {
+			t.Errorf("expected: %v received: %v at %v standard deviations",
+				target, computed, sigmas[j])
+		}
+	}
+}
+
+// TestNormalRsampleShape tests that Rsample on a Normal produces
+// samples of the correct shape for both a single sample and a batch of
+// samples, and that gradients can be computed through the samples.
+func TestNormalRsampleShape(t *testing.T) {
+	const tests int = 10      // Number of tests to run
+	const maxDims int = 3     // Maximum number of dims in mean/stddev
+	const maxDimSize int = 5  // Maximum size of each dim in mean/stddev
+	const scale float64 = 2.0 // Scale of the mean and stddev
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		dims := 1 + rand.Intn(maxDims)
+		shape := randInt(dims, 1, maxDimSize+1)
+		size := tensor.ProdInts(shape)
+
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		for j := range meanBacking {
+			meanBacking[j] = (rand.Float64() - 0.5) * scale
+			stddevBacking[j] = math.Exp(rand.Float64()) * scale
+		}
+
+		g := G.NewGraph()
+		meanT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		mean := G.NewTensor(g, tensor.Float64, meanT.Dims(),
+			G.WithValue(meanT), G.WithName("mean"))
+		stddevT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))
+		stddev := G.NewTensor(g, tensor.Float64, stddevT.Dims(),
+			G.WithValue(stddevT), G.WithName("stddev"))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		CheckRsample(t, n, mean, stddev)
+	}
+}
+
+// TestNormalRsampleMoments tests that the mean and variance of many
+// reparameterized samples from a multi-dimensional Normal converge to
+// the mean and variance of the Normal, and that a single sample keeps
+// its batch dimension.
+func TestNormalRsampleMoments(t *testing.T) {
+	const threshold float64 = 0.1 // Threshold relative to the stddev
+	const samples int = 10000     // Number of samples to draw
+	const scale float64 = 2.0     // Scale of the mean and stddev
+	shape := []int{2, 3}
+	size := tensor.ProdInts(shape)
+	rand.Seed(time.Now().UnixNano())
+
+	meanBacking := make([]float64, size)
+	stddevBacking := make([]float64, size)
+	for j := range meanBacking {
+		meanBacking[j] = (rand.Float64() - 0.5) * scale
+		stddevBacking[j] = math.Exp(rand.Float64()) * scale
+	}
+
+	g := G.NewGraph()
+	meanT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(meanBacking))
+	mean := G.NewTensor(g, tensor.Float64, meanT.Dims(), G.WithValue(meanT),
+		G.WithName("mean"))
+	stddevT := tensor.NewDense(tensor.Float64, shape,
+		tensor.WithBacking(stddevBacking))
+	stddev := G.NewTensor(g, tensor.Float64, stddevT.Dims(),
+		G.WithValue(stddevT), G.WithName("stddev"))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	single, err := n.Rsample(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := tensor.Shape(append([]int{1}, shape...))
+	if !single.Shape().Eq(expected) {
+		t.Errorf("rsample(1): expected shape %v but got %v", expected,
+			single.Shape())
+	}
+
+	sample, err := n.Rsample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := sampleVal.Data().([]float64)
+	for j := 0; j < size; j++ {
+		sum, sumSquares := 0.0, 0.0
+		for i := 0; i < samples; i++ {
+			v := data[i*size+j]
+			sum += v
+			sumSquares += v * v
+		}
+		sampleMean := sum / float64(samples)
+		sampleVariance := sumSquares/float64(samples) - sampleMean*sampleMean
+
+		if math.Abs(sampleMean-meanBacking[j]) > threshold*stddevBacking[j] {
+			t.Errorf("mean: expected: %v received: %v", meanBacking[j],
+				sampleMean)
+		}
+
+		variance := stddevBacking[j] * stddevBacking[j]
+		if math.Abs(sampleVariance-variance) > threshold*variance {
+			t.Errorf("variance: expected: %v received: %v", variance,
+				sampleVariance)
+		}
+	}
+}
+
+// TestNormalSampleStatistics tests that the empirical mean and
+// standard deviation along the batch axis of many samples drawn with
+// both Rsample and Sample match the parameters of the Normal. The
+// tolerance is a number of standard errors of each estimate.
+//
+// Gorgonia hashes slice indices as single bytes, so ReduceAlong can
+// merge distinct rows of axes longer than 256 elements. The number of
+// samples is kept below this limit.
+func TestNormalSampleStatistics(t *testing.T) {
+	const samples int = 256    // Number of samples to draw
+	const standardErrors = 5.0 // Number of standard errors to tolerate
+	const scale float64 = 2.0  // Scale of the mean and stddev
+	shape := []int{3, 2}
+	size := tensor.ProdInts(shape)
+	rand.Seed(time.Now().UnixNano())
+
+	meanBacking := make([]float64, size)
+	stddevBacking := make([]float64, size)
+	for j := range meanBacking {
+		meanBacking[j] = (rand.Float64() - 0.5) * scale
+		stddevBacking[j] = math.Exp(rand.Float64()) * scale
+	}
+
+	for _, reparameterized := range []bool{true, false} {
+		g := G.NewGraph()
+		meanT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		mean := G.NewTensor(g, tensor.Float64, meanT.Dims(),
+			G.WithValue(meanT), G.WithName("mean"))
+		stddevT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))
+		stddev := G.NewTensor(g, tensor.Float64, stddevT.Dims(),
+			G.WithValue(stddevT), G.WithName("stddev"))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var sample *G.Node
+		if reparameterized {
+			sample, err = n.Rsample(samples)
+		} else {
+			sample, err = n.Sample(samples)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Compute the empirical mean and standard deviation along the
+		// batch axis
+		sampleMean, err := gop.ReduceMean(sample, 0, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deviation := G.Must(G.BroadcastSub(sample, sampleMean, nil,
+			[]byte{0}))
+		sampleVariance, err := gop.ReduceMean(G.Must(G.Square(deviation)), 0,
+			true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sampleStddev := G.Must(G.Sqrt(sampleVariance))
+
+		var meanVal, stddevVal G.Value
+		G.Read(sampleMean, &meanVal)
+		G.Read(sampleStddev, &stddevVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < size; j++ {
+			// Standard errors of the sample mean and standard deviation
+			meanError := stddevBacking[j] / math.Sqrt(float64(samples))
+			stddevError := stddevBacking[j] / math.Sqrt(2*float64(samples))
+
+			computed := meanVal.Data().([]float64)[j]
+			if math.Abs(computed-meanBacking[j]) > standardErrors*meanError {
+				t.Errorf("mean (rsample=%v): expected: %v received: %v",
+					reparameterized, meanBacking[j], computed)
+			}
+
+			computed = stddevVal.Data().([]float64)[j]
+			if math.Abs(computed-stddevBacking[j]) >
+				standardErrors*stddevError {
+				t.Errorf("stddev (rsample=%v): expected: %v received: %v",
+					reparameterized, stddevBacking[j], computed)
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestNormalReseed tests that reseeding a Normal between runs of a
+// graph results in identical samples being drawn on each run
+func TestNormalReseed(t *testing.T) {
+	const size int = 5    // Number of distributions
+	const samples int = 3 // Number of samples to draw
+
+	seed := uint64(time.Now().UnixNano())
+
+	g := G.NewGraph()
+	meanT := tensor.NewDense(tensor.Float64, []int{size},
+		tensor.WithBacking(ones(size)))
+	mean := G.NewVector(g, tensor.Float64, G.WithValue(meanT),
+		G.WithName("mean"))
+	stddevT := tensor.NewDense(tensor.Float64, []int{size},
+		tensor.WithBacking(ones(size)))
+	stddev := G.NewVector(g, tensor.Float64, G.WithValue(stddevT),
+		G.WithName("stddev"))
+
+	normal, err := NewNormal(mean, stddev, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sample, err := normal.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsample, err := normal.Rsample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal, rsampleVal G.Value
+	G.Read(sample, &sampleVal)
+	G.Read(rsample, &rsampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+
+	var first [][]float64
+	for run := 0; run < 3; run++ {
+		normal.Reseed(seed)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		current := [][]float64{
+			append([]float64{}, sampleVal.Data().([]float64)...),
+			append([]float64{}, rsampleVal.Data().([]float64)...),
+		}
+		if first == nil {
+			first = current
+		} else {
+			for i := range first {
+				for j := range first[i] {
+					if first[i][j] != current[i][j] {
+						t.Errorf("run %v: expected %v but got %v after "+
+							"reseeding", run, first[i][j], current[i][j])
+					}
+				}
+			}
+		}
+
+		vm.Reset()
+	}
+}
+
+// TestNormalSharedNodes tests that repeated calls to Prob, LogProb, and
+// Cdf share constant nodes, so that the graph grows sublinearly in the
+// number of calls, and that they work for float32 Normals.
+func TestNormalSharedNodes(t *testing.T) {
+	const size int = 3 // Number of distributions
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		g := G.NewGraph()
+		mean := G.NewVector(g, dt, G.WithShape(size), G.WithName("mean"),
+			G.WithInit(G.Zeroes()))
+		stddev := G.NewVector(g, dt, G.WithShape(size),
+			G.WithName("stddev"), G.WithInit(G.Ones()))
+		normal, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// density builds the same density expressions on x
+		density := func(x *G.Node) {
+			if _, err := normal.Prob(x); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := normal.LogProb(x); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := normal.Cdf(x); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		x := G.NewVector(g, dt, G.WithShape(size), G.WithName("x"),
+			G.WithInit(G.Ones()))
+		before := len(g.AllNodes())
+		density(x)
+		first := len(g.AllNodes()) - before
+
+		// Building the same expressions again should not add any nodes
+		before = len(g.AllNodes())
+		density(x)
+		if added := len(g.AllNodes()) - before; added != 0 {
+			t.Errorf("%v: expected no new nodes when rebuilding the same "+
+				"expressions but got %v", dt, added)
+		}
+
+		// Building the expressions on a new input should reuse the
+		// constant nodes
+		y := G.NewVector(g, dt, G.WithShape(size), G.WithName("y"),
+			G.WithInit(G.Ones()))
+		before = len(g.AllNodes())
+		density(y)
+		if second := len(g.AllNodes()) - before; second >= first {
+			t.Errorf("%v: expected fewer than %v new nodes for new input "+
+				"but got %v", dt, first, second)
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatalf("%v: %v", dt, err)
+		}
+		vm.Close()
+	}
+}
+
+// normalLogProbGrads builds a graph which computes the log probability
+// of x under a Normal with mean mean and standard deviation stddev, as
+// well as the gradient of the sum of the log probability with respect
+// to the mean, standard deviation, and x. If fused is true, then
+// LogProbFused() is used, otherwise LogProb() is used. The returned
+// values are the log probability and the three gradients.
+func normalLogProbGrads(t *testing.T, meanT, stddevT, xT *tensor.Dense,
+	fused bool) []G.Value {
+	g := G.NewGraph()
+	mean := G.NewTensor(g, meanT.Dtype(), meanT.Dims(),
+		G.WithValue(meanT.Clone()), G.WithName("mean"))
+	stddev := G.NewTensor(g, stddevT.Dtype(), stddevT.Dims(),
+		G.WithValue(stddevT.Clone()), G.WithName("stddev"))
+	x := G.NewTensor(g, xT.Dtype(), xT.Dims(), G.WithValue(xT.Clone()),
+		G.WithName("x"))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logProb *G.Node
+	if fused {
+		logProb, err = n.LogProbFused(x)
+	} else {
+		logProb, err = n.LogProb(x)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grads, err := G.Grad(G.Must(G.Sum(logProb)), mean, stddev, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([]G.Value, 1+len(grads))
+	G.Read(logProb, &values[0])
+	for i := range grads {
+		G.Read(grads[i], &values[i+1])
+	}
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range values {
+		values[i] = values[i].(tensor.Tensor).Clone().(G.Value)
+	}
+	return values
+}
+
+// TestNormalLogProbFused tests that the LogProbFused method of the
+// Normal and its gradients with respect to the mean, standard
+// deviation, and input match those of the LogProb method, on both
+// single inputs and batches of inputs.
+func TestNormalLogProbFused(t *testing.T) {
+	const threshold = 0.000001 // Threshold for floats to be considered equal
+	const tests int = 10       // Number of tests to run
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+
+	const minSize int = 1    // Minimum number of dims in mean/stddev
+	const maxSize int = 4    // Maximum number of dims in mean/stddev
+	const minDimSize int = 1 // Minimum size of each dim in mean/stddev
+	const maxDimSize int = 5 // Maximum size of each dim in mean/stddev
+	const maxBatchSize int = 5
+
+	rand.Seed(time.Now().UnixNano())
+	names := []string{"logProb", "mean grad", "stddev grad", "x grad"}
+
+	for i := 0; i < tests; i++ {
+		dims := minSize + rand.Intn(maxSize-minSize)
+		shape := randInt(dims, minDimSize, maxDimSize)
+		numDists := tensor.ProdInts(shape)
+
+		// Test both single inputs and batches of inputs
+		xShape := shape
+		if i%2 == 1 {
+			xShape = append([]int{1 + rand.Intn(maxBatchSize)}, shape...)
+		}
+
+		meanBacking := make([]float64, numDists)
+		stddevBacking := make([]float64, numDists)
+		for r := 0; r < numDists; r++ {
+			meanBacking[r] = (rand.Float64() - 0.5) * scale
+			stddevBacking[r] = (math.Exp(rand.Float64()) + stdOffset) * scale
+		}
+		xBacking := make([]float64, tensor.ProdInts(xShape))
+		for r := range xBacking {
+			xBacking[r] = (rand.Float64() - 0.5) * scale * 3.0
+		}
+
+		meanT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		stddevT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))
+		xT := tensor.NewDense(tensor.Float64, xShape,
+			tensor.WithBacking(xBacking))
+
+		expected := normalLogProbGrads(t, meanT, stddevT, xT, false)
+		computed := normalLogProbGrads(t, meanT, stddevT, xT, true)
+
+		for v := range expected {
+			expectedData := expected[v].Data().([]float64)
+			computedData := computed[v].Data().([]float64)
+
+			if len(expectedData) != len(computedData) {
+				t.Errorf("%v: expected %v elements but got %v", names[v],
+					len(expectedData), len(computedData))
+				continue
+			}
+			for j := range expectedData {
+				if math.Abs(expectedData[j]-computedData[j]) > threshold {
+					t.Errorf("%v: expected: %v, received: %v", names[v],
+						expectedData[j], computedData[j])
+				}
+			}
+		}
+	}
+}
+
+// TestNormalLogProbGrad tests that the gradients of the log
+// probability of the Normal with respect to its mean and standard
+// deviation match the analytic score function:
+//
+//		∂/∂μ log(p(x)) = (x - μ) / σ²
+//		∂/∂σ log(p(x)) = ((x - μ)² - σ²) / σ³
+//
+// summed over the batch dimension, on both single inputs and batches of
+// inputs.
+func TestNormalLogProbGrad(t *testing.T) {
+	const threshold = 0.000001 // Threshold for floats to be considered equal
+	const tests int = 10       // Number of tests to run
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+
+	const minSize int = 1    // Minimum number of dims in mean/stddev
+	const maxSize int = 4    // Maximum number of dims in mean/stddev
+	const minDimSize int = 1 // Minimum size of each dim in mean/stddev
+	const maxDimSize int = 5 // Maximum size of each dim in mean/stddev
+	const maxBatchSize int = 5
+
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		dims := minSize + rand.Intn(maxSize-minSize)
+		shape := randInt(dims, minDimSize, maxDimSize)
+		numDists := tensor.ProdInts(shape)
+
+		// Test both single inputs and batches of inputs
+		xShape := shape
+		if i%2 == 1 {
+			xShape = append([]int{1 + rand.Intn(maxBatchSize)}, shape...)
+		}
+
+		meanBacking := make([]float64, numDists)
+		stddevBacking := make([]float64, numDists)
+		for r := 0; r < numDists; r++ {
+			meanBacking[r] = (rand.Float64() - 0.5) * scale
+			stddevBacking[r] = (math.Exp(rand.Float64()) + stdOffset) * scale
+		}
+		xBacking := make([]float64, tensor.ProdInts(xShape))
+		for r := range xBacking {
+			xBacking[r] = (rand.Float64() - 0.5) * scale * 3.0
+		}
+
+		// Compute the analytic score function, summed over the batch
+		expectedMean := make([]float64, numDists)
+		expectedStddev := make([]float64, numDists)
+		for r, x := range xBacking {
+			j := r % numDists
+			mu, sigma := meanBacking[j], stddevBacking[j]
+			expectedMean[j] += (x - mu) / (sigma * sigma)
+			expectedStddev[j] += ((x-mu)*(x-mu) - sigma*sigma) /
+				(sigma * sigma * sigma)
+		}
+
+		g := G.NewGraph()
+		meanT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		mean := G.NewTensor(g, tensor.Float64, meanT.Dims(),
+			G.WithValue(meanT), G.WithName("mean"))
+		stddevT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))
+		stddev := G.NewTensor(g, tensor.Float64, stddevT.Dims(),
+			G.WithValue(stddevT), G.WithName("stddev"))
+
+		// Copy the input, since operations may overwrite it
+		xT := tensor.NewDense(tensor.Float64, xShape,
+			tensor.WithBacking(append([]float64{}, xBacking...)))
+		x := G.NewTensor(g, tensor.Float64, xT.Dims(), G.WithValue(xT),
+			G.WithName("x"))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		logProb, err := n.LogProb(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loss := G.Must(G.Sum(logProb))
+
+		grads, err := G.Grad(loss, mean, stddev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var meanGrad, stddevGrad G.Value
+		G.Read(grads[0], &meanGrad)
+		G.Read(grads[1], &stddevGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !meanGrad.Shape().Eq(tensor.Shape(shape)) {
+			t.Errorf("expected mean gradient shape %v but got %v", shape,
+				meanGrad.Shape())
+		}
+		if !stddevGrad.Shape().Eq(tensor.Shape(shape)) {
+			t.Errorf("expected stddev gradient shape %v but got %v", shape,
+				stddevGrad.Shape())
+		}
+
+		for j := 0; j < numDists; j++ {
+			computed := meanGrad.Data().([]float64)[j]
+			if math.Abs(computed-expectedMean[j]) > threshold {
+				t.Errorf("mean gradient: expected: %v, received: %v",
+					expectedMean[j], computed)
+			}
+
+			computed = stddevGrad.Data().([]float64)[j]
+			if math.Abs(computed-expectedStddev[j]) > threshold {
+				t.Errorf("stddev gradient: expected: %v, received: %v",
+					expectedStddev[j], computed)
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestNormalCdfGrad tests that the gradients of the Cdf of the Normal
+// with respect to its input, mean, and standard deviation match the
+// analytic derivatives:
+//
+//	∂/∂x Φ((x - μ) / σ) = p(x)
+//	∂/∂μ Φ((x - μ) / σ) = -p(x)
+//	∂/∂σ Φ((x - μ) / σ) = -p(x) (x - μ) / σ
+//
+// where the parameter gradients are summed over the batch dimension, on
+// both single inputs and batches of inputs.
+func TestNormalCdfGrad(t *testing.T) {
+	const threshold = 0.000001 // Threshold for floats to be considered equal
+	const tests int = 10       // Number of tests to run
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+
+	const minSize int = 1    // Minimum number of dims in mean/stddev
+	const maxSize int = 4    // Maximum number of dims in mean/stddev
+	const minDimSize int = 1 // Minimum size of each dim in mean/stddev
+	const maxDimSize int = 5 // Maximum size of each dim in mean/stddev
+	const maxBatchSize int = 5
+
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		dims := minSize + rand.Intn(maxSize-minSize)
+		shape := randInt(dims, minDimSize, maxDimSize)
+		numDists := tensor.ProdInts(shape)
+
+		// Test both single inputs and batches of inputs, including
+		// batches of a single input
+		xShape := shape
+		if i%2 == 1 {
+			xShape = append([]int{1 + (i/2)%maxBatchSize}, shape...)
+		}
+
+		meanBacking := make([]float64, numDists)
+		stddevBacking := make([]float64, numDists)
+		for r := 0; r < numDists; r++ {
+			meanBacking[r] = (rand.Float64() - 0.5) * scale
+			stddevBacking[r] = (math.Exp(rand.Float64()) + stdOffset) * scale
+		}
+		xBacking := make([]float64, tensor.ProdInts(xShape))
+		for r := range xBacking {
+			xBacking[r] = (rand.Float64() - 0.5) * scale * 3.0
+		}
+
+		// Compute the analytic derivatives
+		expectedX := make([]float64, len(xBacking))
+		expectedMean := make([]float64, numDists)
+		expectedStddev := make([]float64, numDists)
+		for r, x := range xBacking {
+			j := r % numDists
+			mu, sigma := meanBacking[j], stddevBacking[j]
+			pdf := distuv.Normal{Mu: mu, Sigma: sigma}.Prob(x)
+			expectedX[r] = pdf
+			expectedMean[j] -= pdf
+			expectedStddev[j] -= pdf * (x - mu) / sigma
+		}
+
+		g := G.NewGraph()
+		meanT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		mean := G.NewTensor(g, tensor.Float64, meanT.Dims(),
+			G.WithValue(meanT), G.WithName("mean"))
+		stddevT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))
+		stddev := G.NewTensor(g, tensor.Float64, stddevT.Dims(),
+			G.WithValue(stddevT), G.WithName("stddev"))
+
+		// Copy the input, since operations may overwrite it
+		xT := tensor.NewDense(tensor.Float64, xShape,
+			tensor.WithBacking(append([]float64{}, xBacking...)))
+		x := G.NewTensor(g, tensor.Float64, xT.Dims(), G.WithValue(xT),
+			G.WithName("x"))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cdf, err := n.Cdf(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loss := G.Must(G.Sum(cdf))
+
+		grads, err := G.Grad(loss, x, mean, stddev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var xGrad, meanGrad, stddevGrad G.Value
+		G.Read(grads[0], &xGrad)
+		G.Read(grads[1], &meanGrad)
+		G.Read(grads[2], &stddevGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		checkGrad(t, "cdf", "x", xGrad, xShape, expectedX, threshold)
+		checkGrad(t, "cdf", "mean", meanGrad, shape, expectedMean,
+			threshold)
+		checkGrad(t, "cdf", "stddev", stddevGrad, shape, expectedStddev,
+			threshold)
+	}
+}
+
+// TestNormalQuantileGrad tests that the gradients of the Quantile of
+// the Normal, q = μ + σz for z = √2 erf⁻¹(2p - 1), with respect to its
+// input probability, mean, and standard deviation match the analytic
+// derivatives:
+//
+//	∂q/∂p = 1 / p(q)
+//	∂q/∂μ = 1
+//	∂q/∂σ = z
+//
+// where the parameter gradients are summed over the batch dimension, on
+// both single inputs and batches of inputs.
+func TestNormalQuantileGrad(t *testing.T) {
+	const threshold = 0.000001 // Threshold for floats to be considered equal
+	const tests int = 10       // Number of tests to run
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+	const minProb float64 = 0.02 // Probabilities in [minProb, 1-minProb]
+
+	const minSize int = 1    // Minimum number of dims in mean/stddev
+	const maxSize int = 4    // Maximum number of dims in mean/stddev
+	const minDimSize int = 1 // Minimum size of each dim in mean/stddev
+	const maxDimSize int = 5 // Maximum size of each dim in mean/stddev
+	const maxBatchSize int = 5
+
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		dims := minSize + rand.Intn(maxSize-minSize)
+		shape := randInt(dims, minDimSize, maxDimSize)
+		numDists := tensor.ProdInts(shape)
+
+		// Test both single inputs and batches of inputs, including
+		// batches of a single input
+		pShape := shape
+		if i%2 == 1 {
+			pShape = append([]int{1 + (i/2)%maxBatchSize}, shape...)
+		}
+
+		meanBacking := make([]float64, numDists)
+		stddevBacking := make([]float64, numDists)
+		for r := 0; r < numDists; r++ {
+			meanBacking[r] = (rand.Float64() - 0.5) * scale
+			stddevBacking[r] = (math.Exp(rand.Float64()) + stdOffset) * scale
+		}
+		pBacking := make([]float64, tensor.ProdInts(pShape))
+		for r := range pBacking {
+			pBacking[r] = minProb + rand.Float64()*(1-2*minProb)
+		}
+
+		// Compute the analytic derivatives
+		expectedP := make([]float64, len(pBacking))
+		expectedMean := make([]float64, numDists)
+		expectedStddev := make([]float64, numDists)
+		for r, p := range pBacking {
+			j := r % numDists
+			dist := distuv.Normal{Mu: meanBacking[j], Sigma: stddevBacking[j]}
+			q := dist.Quantile(p)
+			expectedP[r] = 1 / dist.Prob(q)
+			expectedMean[j]++
+			expectedStddev[j] += (q - meanBacking[j]) / stddevBacking[j]
+		}
+
+		g := G.NewGraph()
+		meanT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))
+		mean := G.NewTensor(g, tensor.Float64, meanT.Dims(),
+			G.WithValue(meanT), G.WithName("mean"))
+		stddevT := tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))
+		stddev := G.NewTensor(g, tensor.Float64, stddevT.Dims(),
+			G.WithValue(stddevT), G.WithName("stddev"))
+
+		// Copy the input, since operations may overwrite it
+		pT := tensor.NewDense(tensor.Float64, pShape,
+			tensor.WithBacking(append([]float64{}, pBacking...)))
+		p := G.NewTensor(g, tensor.Float64, pT.Dims(), G.WithValue(pT),
+			G.WithName("p"))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		quantile, err := n.Quantile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loss := G.Must(G.Sum(quantile))
+
+		grads, err := G.Grad(loss, p, mean, stddev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pGrad, meanGrad, stddevGrad G.Value
+		G.Read(grads[0], &pGrad)
+		G.Read(grads[1], &meanGrad)
+		G.Read(grads[2], &stddevGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		checkGrad(t, "quantile", "p", pGrad, pShape, expectedP, threshold)
+		checkGrad(t, "quantile", "mean", meanGrad, shape, expectedMean,
+			threshold)
+		checkGrad(t, "quantile", "stddev", stddevGrad, shape,
+			expectedStddev, threshold)
+	}
+}
+
+// checkGrad checks that the gradient grad of method with respect to
+// param has shape shape and matches expected to within a tolerance
+// relative to max(1, |expected|)
+func checkGrad(t *testing.T, method, param string, grad G.Value,
+	shape []int, expected []float64, tolerance float64) {
+	t.Helper()
+
+	if !grad.Shape().Eq(tensor.Shape(shape)) {
+		t.Errorf("%v: expected %v gradient shape %v but got %v", method,
+			param, shape, grad.Shape())
+		return
+	}
+
+	var computed []float64
+	switch data := grad.Data().(type) {
+	case float64:
+		computed = []float64{data}
+	case []float64:
+		computed = data
+	}
+	for j := range expected {
+		if math.Abs(computed[j]-expected[j]) >
+			tolerance*math.Max(1, math.Abs(expected[j])) {
+			t.Errorf("%v: %v gradient: expected: %v, received: %v",
+				method, param, expected[j], computed[j])
+		}
+	}
+}
+
+func TestNormalLogCdfGonum(t *testing.T) {
+	const tests int = 15  // Number of tests to run
+	const points int = 20 // Number of points to check per test
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		mean := (rand.Float64() - 0.5) * scale
+		stddev := (math.Exp(rand.Float64()) + stdOffset) * scale
+
+		g := G.NewGraph()
+		meanNode := G.NewScalar(g, tensor.Float64, G.WithValue(mean),
+			G.WithName("mean"))
+		stddevNode := G.NewScalar(g, tensor.Float64, G.WithValue(stddev),
+			G.WithName("stddev"))
+
+		n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		in := make([]float64, points)
+		for j := range in {
+			in[j] = mean + (rand.Float64()-0.5)*4.0*stddev
+		}
+
+		target := distuv.Normal{Mu: mean, Sigma: stddev}
+		logCdf := func(x float64) float64 { return math.Log(target.CDF(x)) }
+		compareToReference(t, n, "logCdf", n.LogCdf, logCdf, in)
+	}
+}
+
+// TestNormalLogCdfTail tests the log Cdf far in the left tail, where
+// the Cdf underflows, against the asymptotic expansion of log(Φ(z))
+func TestNormalLogCdfTail(t *testing.T) {
+	const tolerance float64 = 1e-8 // Relative tolerance
+	const size int = 10            // Number of points to check
+	rand.Seed(time.Now().UnixNano())
+
+	mean := (rand.Float64() - 0.5) * 2.0
+	stddev := math.Exp(rand.Float64())
+
+	// Points between 40 and 100 standard deviations below the mean
+	sigmas := make([]float64, size)
+	in := make([]float64, size)
+	for j := range in {
+		sigmas[j] = 40.0 + rand.Float64()*60.0
+		in[j] = mean - sigmas[j]*stddev
+	}
+
+	g := G.NewGraph()
+	meanNode := G.NewScalar(g, tensor.Float64, G.WithValue(mean),
+		G.WithName("mean"))
+	stddevNode := G.NewScalar(g, tensor.Float64, G.WithValue(stddev),
+		G.WithName("stddev"))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(tensor.NewDense(
+		tensor.Float64, []int{size}, tensor.WithBacking(in))),
+		G.WithName("x"))
+
+	n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logCdf, err := n.LogCdf(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdf, err := n.Cdf(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logCdfVal, cdfVal G.Value
+	G.Read(logCdf, &logCdfVal)
+	G.Read(cdf, &cdfVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for j := 0; j < size; j++ {
+		z2 := sigmas[j] * sigmas[j]
+		series := 1 - 1/z2 + 3/(z2*z2) - 15/(z2*z2*z2)
+		target := -0.5*z2 - math.Log(sigmas[j]*math.Sqrt(2*math.Pi)) +
+			math.Log(series)
+
+		if naive := math.Log(cdfVal.Data().([]float64)[j]); !math.IsInf(
+			naive, -1) {
+			t.Errorf("expected log(cdf) to underflow at %v standard "+
+				"deviations but got %v", sigmas[j], naive)
+		}
+
+		computed := logCdfVal.Data().([]float64)[j]
+		if math.Abs(computed-target)/math.Abs(target) > tolerance {
+			t.Errorf("expected: %v received: %v at %v standard deviations",
+				target, computed, sigmas[j])
+		}
+	}
+}
+
+// TestNormalNumDistributions tests that the NumDistributions of scalar,
+// vector, and tensor Normals is the number of elements of the mean,
+// and that the NumDistributions, BatchShape, and EventShape of an IID
+// wrapping the Normal separate the batch and event dimensions
+func TestNormalNumDistributions(t *testing.T) {
+	tests := []struct {
+		shape       []int        // Shape of the mean and stddev
+		dims        int          // Number of event dims of the IID
+		numDists    int          // Expected NumDistributions of the Normal
+		batch       tensor.Shape // Expected BatchShape of the IID
+		event       tensor.Shape // Expected EventShape of the IID
+		iidNumDists int          // Expected NumDistributions of the IID
+	}{
+		{[]int{}, 0, 1, tensor.Shape{1}, tensor.Shape{}, 1},
+		{[]int{}, 1, 1, tensor.Shape{}, tensor.Shape{1}, 1},
+		{[]int{4}, 0, 4, tensor.Shape{4}, tensor.Shape{}, 4},
+		{[]int{4}, 1, 4, tensor.Shape{}, tensor.Shape{4}, 1},
+		{[]int{2, 3, 4}, 1, 24, tensor.Shape{2, 3}, tensor.Shape{4}, 6},
+		{[]int{2, 3, 4}, 2, 24, tensor.Shape{2}, tensor.Shape{3, 4}, 2},
+		{[]int{2, 3, 4}, 3, 24, tensor.Shape{}, tensor.Shape{2, 3, 4}, 1},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		var mean, stddev *G.Node
+		if len(test.shape) == 0 {
+			mean = G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+				G.WithValue(0.0))
+			stddev = G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+				G.WithValue(1.0))
+		} else {
+			mean = G.NewTensor(g, tensor.Float64, len(test.shape),
+				G.WithShape(test.shape...), G.WithName("mean"),
+				G.WithInit(G.Zeroes()))
+			stddev = G.NewTensor(g, tensor.Float64, len(test.shape),
+				G.WithShape(test.shape...), G.WithName("stddev"),
+				G.WithInit(G.Ones()))
+		}
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n.NumDistributions() != test.numDists {
+			t.Errorf("shape %v: expected %v distributions but got %v",
+				test.shape, test.numDists, n.NumDistributions())
+		}
+
+		i := NewIID(n, test.dims)
+		if !i.BatchShape().Eq(test.batch) {
+			t.Errorf("shape %v dims %v: expected batch shape %v but got %v",
+				test.shape, test.dims, test.batch, i.BatchShape())
+		}
+		if !i.EventShape().Eq(test.event) {
+			t.Errorf("shape %v dims %v: expected event shape %v but got %v",
+				test.shape, test.dims, test.event, i.EventShape())
+		}
+		if i.NumDistributions() != test.iidNumDists {
+			t.Errorf("shape %v dims %v: expected %v distributions but got "+
+				"%v", test.shape, test.dims, test.iidNumDists,
+				i.NumDistributions())
+		}
+	}
+}
+
+// TestNormalSampleShape tests that SampleShape returns samples with
+// the sample shape prepended to the shape of the Normal, and that the
+// individual draws differ
+func TestNormalSampleShape(t *testing.T) {
+	tests := []struct {
+		sampleShape []int // Leading sample shape
+		distShape   []int // Shape of the mean and stddev
+	}{
+		{[]int{4, 5}, []int{3}},
+		{[]int{4, 5}, []int{2, 3}},
+		{[]int{6}, []int{3}},
+		{[]int{2, 1, 3}, []int{1}},
+		{[]int{1}, []int{3}},
+		{[]int{}, []int{2, 3}},
+		{[]int{1, 1}, []int{1}},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		mean := G.NewTensor(g, tensor.Float64, len(test.distShape),
+			G.WithShape(test.distShape...), G.WithName("mean"),
+			G.WithInit(G.Zeroes()))
+		stddev := G.NewTensor(g, tensor.Float64, len(test.distShape),
+			G.WithShape(test.distShape...), G.WithName("stddev"),
+			G.WithInit(G.Ones()))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		samples, err := n.SampleShape(test.sampleShape...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var samplesVal G.Value
+		G.Read(samples, &samplesVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		expected := append(append([]int{}, test.sampleShape...),
+			test.distShape...)
+		if !samplesVal.Shape().Eq(tensor.Shape(expected)) {
+			t.Errorf("sample shape %v: expected shape %v but got %v",
+				test.sampleShape, expected, samplesVal.Shape())
+		}
+
+		// Each draw of each distribution should be distinct
+		seen := make(map[float64]bool)
+		for _, v := range samplesVal.Data().([]float64) {
+			if seen[v] {
+				t.Errorf("sample shape %v: expected distinct draws but "+
+					"got %v multiple times", test.sampleShape, v)
+			}
+			seen[v] = true
+		}
+	}
+
+	// Non-positive sample dimensions are invalid
+	g := G.NewGraph()
+	mean := G.NewVector(g, tensor.Float64, G.WithShape(3),
+		G.WithName("mean"), G.WithInit(G.Zeroes()))
+	stddev := G.NewVector(g, tensor.Float64, G.WithShape(3),
+		G.WithName("stddev"), G.WithInit(G.Ones()))
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.SampleShape(2, 0); err == nil {
+		t.Error("expected error for sample shape with a zero dimension")
+	}
+}
+
+// TestNormalSampleShapeSingleDraw tests that SampleShape draws exactly
+// prod(shape) samples from the source of the Normal when prod(shape)
+// is 1, so that consecutive runs see consecutive draws of the source
+func TestNormalSampleShapeSingleDraw(t *testing.T) {
+	const runs int = 3 // Number of times to run the graph
+	seed := uint64(time.Now().UnixNano())
+
+	newNormal := func(g *G.ExprGraph) *Normal {
+		mean := G.NewVector(g, tensor.Float64, G.WithShape(1),
+			G.WithName("mean"), G.WithInit(G.Zeroes()))
+		stddev := G.NewVector(g, tensor.Float64, G.WithShape(1),
+			G.WithName("stddev"), G.WithInit(G.Ones()))
+		n, err := NewNormal(mean, stddev, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// Draw all samples at once from a Normal with the same seed
+	g := G.NewGraph()
+	all, err := newNormal(g).Sample(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+	target := all.Value().Data().([]float64)
+
+	g = G.NewGraph()
+	samples, err := newNormal(g).SampleShape()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samplesVal G.Value
+	G.Read(samples, &samplesVal)
+
+	vm = G.NewTapeMachine(g)
+	defer vm.Close()
+	for i := 0; i < runs; i++ {
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		if !samplesVal.Shape().Eq(tensor.Shape{1}) {
+			t.Fatalf("expected shape %v but got %v", tensor.Shape{1},
+				samplesVal.Shape())
+		}
+		if got := samplesVal.Data().([]float64)[0]; got != target[i] {
+			t.Errorf("run %v: expected draw %v but got %v", i, target[i],
+				got)
+		}
+		vm.Reset()
+	}
+}
+
+// TestNormalLogProbAtMean tests that the log probability density of
+// the Normal at its mean is -log(σ√(2π)) on random parameters
+func TestNormalLogProbAtMean(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const maxDims int = 3              // Maximum dimensions of parameters
+	const maxDimSize int = 5           // Maximum size of each dimension
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		for j := range meanBacking {
+			meanBacking[j] = (rand.Float64() - 0.5) * scale
+			stddevBacking[j] = (math.Exp(rand.Float64()) + stdOffset) * scale
+		}
+
+		g := G.NewGraph()
+		mean := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("mean"),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(meanBacking))))
+		stddev := G.NewTensor(g, tensor.Float64, len(shape),
+			G.WithName("stddev"), G.WithValue(tensor.NewDense(
+				tensor.Float64, shape, tensor.WithBacking(stddevBacking))))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		logProb, err := n.LogProbAtMean()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var logProbVal G.Value
+		G.Read(logProb, &logProbVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		expectedShape := append([]int{1}, shape...)
+		if !logProbVal.Shape().Eq(tensor.Shape(expectedShape)) {
+			t.Errorf("expected shape %v but got %v", expectedShape,
+				logProbVal.Shape())
+		}
+
+		for j, v := range logProbVal.Data().([]float64) {
+			target := -math.Log(stddevBacking[j] * math.Sqrt(2*math.Pi))
+			if math.Abs(v-target) > threshold {
+				t.Errorf("expected: %v received: %v for stddev %v", target, v,
+					stddevBacking[j])
+			}
+		}
+	}
+}
+
+// TestNormalExpand tests that Expand broadcasts the parameters of a
+// Normal to a larger shape, and that samples of the expanded Normal
+// have the expanded shape
+func TestNormalExpand(t *testing.T) {
+	tests := []struct {
+		shape    []int        // Shape of the mean and stddev
+		expanded tensor.Shape // Shape to expand to
+		valid    bool         // Whether the expansion is valid
+	}{
+		{[]int{}, tensor.Shape{8}, true},
+		{[]int{}, tensor.Shape{3, 4}, true},
+		{[]int{4}, tensor.Shape{3, 4}, true},
+		{[]int{3, 1}, tensor.Shape{3, 4}, true},
+		{[]int{1, 4}, tensor.Shape{2, 3, 4}, true},
+		{[]int{4}, tensor.Shape{4}, true},
+		{[]int{3}, tensor.Shape{4}, false},
+		{[]int{3, 4}, tensor.Shape{4}, false},
+		{[]int{2, 4}, tensor.Shape{3, 4}, false},
+	}
+
+	for _, test := range tests {
+		size := tensor.ProdInts(test.shape)
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		for i := range meanBacking {
+			meanBacking[i] = float64(i)
+			stddevBacking[i] = float64(i+1) * 1e-9
+		}
+
+		g := G.NewGraph()
+		var mean, stddev *G.Node
+		if len(test.shape) == 0 {
+			mean = G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+				G.WithValue(meanBacking[0]))
+			stddev = G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+				G.WithValue(stddevBacking[0]))
+		} else {
+			mean = G.NewTensor(g, tensor.Float64, len(test.shape),
+				G.WithName("mean"), G.WithValue(tensor.NewDense(
+					tensor.Float64, test.shape,
+					tensor.WithBacking(meanBacking))))
+			stddev = G.NewTensor(g, tensor.Float64, len(test.shape),
+				G.WithName("stddev"), G.WithValue(tensor.NewDense(
+					tensor.Float64, test.shape,
+					tensor.WithBacking(stddevBacking))))
+		}
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expanded, err := n.Expand(test.expanded)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("expected error expanding %v to %v", test.shape,
+					test.expanded)
+			}
+			continue
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if !expanded.Shape().Eq(test.expanded) {
+			t.Errorf("expected expanded shape %v but got %v", test.expanded,
+				expanded.Shape())
+		}
+
+		samples, err := expanded.Sample(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var meanVal, samplesVal G.Value
+		G.Read(expanded.Mean(), &meanVal)
+		G.Read(samples, &samplesVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		expectedShape := append(tensor.Shape{4}, test.expanded...)
+		if !samplesVal.Shape().Eq(expectedShape) {
+			t.Errorf("expected sample shape %v but got %v", expectedShape,
+				samplesVal.Shape())
+		}
+
+		// The mean should be broadcast following NumPy's rules, and
+		// samples should be near the mean due to the tiny stddev
+		target := broadcastTo(meanBacking, test.shape, test.expanded)
+		for i, v := range meanVal.Data().([]float64) {
+			if v != target[i] {
+				t.Errorf("expand %v to %v: expected mean %v but got %v",
+					test.shape, test.expanded, target, meanVal.Data())
+				break
+			}
+		}
+		for i, v := range samplesVal.Data().([]float64) {
+			if math.Abs(v-target[i%len(target)]) > 1e-6 {
+				t.Errorf("expand %v to %v: sample %v not near mean %v",
+					test.shape, test.expanded, v, target[i%len(target)])
+				break
+			}
+		}
+	}
+}
+
+// broadcastTo returns the row-major data of x with shape shape
+// broadcast to shape to following NumPy's broadcasting rules
+func broadcastTo(x []float64, shape []int, to tensor.Shape) []float64 {
+	aligned := make([]int, len(to))
+	offset := len(to) - len(shape)
+	for i := range aligned {
+		aligned[i] = 1
+		if i >= offset {
+			aligned[i] = shape[i-offset]
+		}
+	}
+
+	out := make([]float64, to.TotalSize())
+	for i := range out {
+		// Convert the flat index into the expanded shape into a flat
+		// index into x, using index 0 along broadcast dimensions
+		rem, src, stride := i, 0, 1
+		for axis := len(to) - 1; axis >= 0; axis-- {
+			coord := rem % to[axis]
+			rem /= to[axis]
+			if aligned[axis] != 1 {
+				src += coord * stride
+			}
+			stride *= aligned[axis]
+		}
+		out[i] = x[src]
+	}
+
+	return out
+}
+
+// TestNormalRsampleWithNoise tests that RsampleWithNoise returns
+// exactly mean + stddev * noise for both batches of noise and single
+// noise samples, and that gradients flow to the mean and stddev
+func TestNormalRsampleWithNoise(t *testing.T) {
+	const tests int = 15    // Number of tests to run
+	const maxSize int = 10  // Maximum number of distributions
+	const maxBatch int = 10 // Maximum batch size
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		// A Normal with a single distribution treats a vector of noise
+		// as a batch, so use at least two distributions
+		size := 2 + rand.Intn(maxSize-1)
+		batch := 1 + rand.Intn(maxBatch)
+		batched := i%2 == 0
+
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		for j := range meanBacking {
+			meanBacking[j] = rand.NormFloat64()
+			stddevBacking[j] = math.Exp(rand.NormFloat64())
+		}
+		noiseShape := []int{size}
+		if batched {
+			noiseShape = []int{batch, size}
+		}
+		noiseBacking := make([]float64, tensor.ProdInts(noiseShape))
+		for j := range noiseBacking {
+			noiseBacking[j] = rand.NormFloat64()
+		}
+
+		g := G.NewGraph()
+		mean := G.NewVector(g, tensor.Float64, G.WithName("mean"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(meanBacking))))
+		stddev := G.NewVector(g, tensor.Float64, G.WithName("stddev"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(stddevBacking))))
+		noise := G.NewTensor(g, tensor.Float64, len(noiseShape),
+			G.WithName("noise"), G.WithValue(tensor.NewDense(
+				tensor.Float64, noiseShape, tensor.WithBacking(noiseBacking))))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := n.RsampleWithNoise(noise)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		grads, err := G.Grad(G.Must(G.Sum(out)), mean, stddev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var meanGrad, stddevGrad G.Value
+		G.Read(grads[0], &meanGrad)
+		G.Read(grads[1], &stddevGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !outVal.Shape().Eq(tensor.Shape(noiseShape)) {
+			t.Errorf("expected shape %v but got %v", noiseShape,
+				outVal.Shape())
+		}
+
+		stddevGradTarget := make([]float64, size)
+		for j, v := range outVal.Data().([]float64) {
+			k := j % size
+			target := meanBacking[k] + stddevBacking[k]*noiseBacking[j]
+			if v != target {
+				t.Errorf("expected: %v received: %v", target, v)
+			}
+			stddevGradTarget[j%size] += noiseBacking[j]
+		}
+
+		for j := 0; j < size; j++ {
+			meanGradTarget := float64(len(noiseBacking) / size)
+			if g := meanGrad.Data().([]float64)[j]; g != meanGradTarget {
+				t.Errorf("mean grad: expected %v but got %v", meanGradTarget,
+					g)
+			}
+			g := stddevGrad.Data().([]float64)[j]
+			if math.Abs(g-stddevGradTarget[j]) > 1e-9 {
+				t.Errorf("stddev grad: expected %v but got %v",
+					stddevGradTarget[j], g)
+			}
+		}
+	}
+}
+
+// TestNormalRsampleAntithetic tests that RsampleAntithetic pairs each
+// sample with its reflection about the mean, so that the empirical mean
+// of the samples is the true mean, which is closer to the true mean
+// than that of the same number of samples from Rsample
+func TestNormalRsampleAntithetic(t *testing.T) {
+	const threshold float64 = 1e-9 // Threshold to consider floats equal
+	const tests int = 15           // Number of tests to run
+	const maxSize int = 10         // Maximum number of distributions
+	const maxPairs int = 25        // Maximum number of antithetic pairs
+	rand.Seed(time.Now().UnixNano())
+
+	var plainErr, antitheticErr float64
+	for i := 0; i < tests; i++ {
+		size := 1 + rand.Intn(maxSize)
+		m := 2 * (1 + rand.Intn(maxPairs))
+
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		for j := range meanBacking {
+			meanBacking[j] = rand.NormFloat64()
+			stddevBacking[j] = math.Exp(rand.NormFloat64())
+		}
+
+		g := G.NewGraph()
+		mean := G.NewVector(g, tensor.Float64, G.WithName("mean"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(meanBacking))))
+		stddev := G.NewVector(g, tensor.Float64, G.WithName("stddev"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(stddevBacking))))
+
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := n.RsampleAntithetic(m + 1); err == nil {
+			t.Errorf("expected error with odd number of samples %v", m+1)
+		}
+
+		antithetic, err := n.RsampleAntithetic(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plain, err := n.Rsample(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var antitheticVal, plainVal G.Value
+		G.Read(antithetic, &antitheticVal)
+		G.Read(plain, &plainVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		expectedShape := tensor.Shape{m, size}
+		if !antitheticVal.Shape().Eq(expectedShape) {
+			t.Errorf("expected shape %v but got %v", expectedShape,
+				antitheticVal.Shape())
+		}
+
+		antitheticData := antitheticVal.Data().([]float64)
+		plainData := plainVal.Data().([]float64)
+		for k := 0; k < size; k++ {
+			var antitheticMean, plainMean float64
+			for j := 0; j < m; j++ {
+				antitheticMean += antitheticData[j*size+k]
+				plainMean += plainData[j*size+k]
+			}
+			antitheticMean /= float64(m)
+			plainMean /= float64(m)
+
+			// Each of the first m/2 samples should be paired with its
+			// reflection about the mean in the last m/2 samples
+			for j := 0; j < m/2; j++ {
+				sum := antitheticData[j*size+k] +
+					antitheticData[(j+m/2)*size+k]
+				if math.Abs(sum-2*meanBacking[k]) > threshold {
+					t.Errorf("expected samples %v and %v to be reflected "+
+						"about mean %v", antitheticData[j*size+k],
+						antitheticData[(j+m/2)*size+k], meanBacking[k])
+				}
+			}
+
+			antitheticErr += math.Abs(antitheticMean - meanBacking[k])
+			plainErr += math.Abs(plainMean - meanBacking[k])
+		}
+	}
+
+	if antitheticErr >= plainErr {
+		t.Errorf("expected antithetic samples to have empirical mean "+
+			"closer to the true mean: antithetic error %v, plain error %v",
+			antitheticErr, plainErr)
+	}
+}
+
+// TestNormalQuantileRoundTrip tests that the Quantile of the Normal is
+// the inverse of its Cdf on random parameters, for both float64 and
+// float32 Normals
+func TestNormalQuantileRoundTrip(t *testing.T) {
+	const tests int = 10   // Number of tests to run
+	const samples int = 50 // Number of samples per test
+	const maxSize int = 10 // Maximum number of distributions
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		tolerance := 1e-6
+		if dt == tensor.Float32 {
+			tolerance = 1e-2
+		}
+
+		for i := 0; i < tests; i++ {
+			size := 1 + rand.Intn(maxSize)
+			meanBacking := make([]float64, size)
+			stddevBacking := make([]float64, size)
+			for j := range meanBacking {
+				meanBacking[j] = (rand.Float64() - 0.5) * scale
+				stddevBacking[j] = (math.Exp(rand.Float64()) + stdOffset) *
+					scale
+			}
+
+			// dense returns backing as a vector of data type dt
+			dense := func(backing []float64) *tensor.Dense {
+				if dt == tensor.Float64 {
+					return tensor.NewDense(dt, []int{size},
+						tensor.WithBacking(backing))
+				}
+				backing32 := make([]float32, len(backing))
+				for j := range backing {
+					backing32[j] = float32(backing[j])
+				}
+				return tensor.NewDense(dt, []int{size},
+					tensor.WithBacking(backing32))
+			}
+
+			g := G.NewGraph()
+			mean := G.NewVector(g, dt, G.WithName("mean"),
+				G.WithValue(dense(meanBacking)))
+			stddev := G.NewVector(g, dt, G.WithName("stddev"),
+				G.WithValue(dense(stddevBacking)))
+
+			n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = CheckQuantileRoundTrip(n, samples, tolerance)
+			if err != nil {
+				t.Errorf("%v: %v", dt, err)
+			}
+		}
+	}
+}
+
+// TestNormalSampleValues tests that SampleValues returns a concrete
+// tensor of shape (m, n.Shape()...) whose samples have the mean and
+// standard deviation of the Normal
+func TestNormalSampleValues(t *testing.T) {
+	const samples int = 20000 // Number of samples to draw
+	const tolerance float64 = 0.05
+	meanBacking := []float64{-2, 0, 0.5, 3, 10, -7}
+	stddevBacking := []float64{0.1, 1, 2, 0.5, 3, 1.5}
+	shape := []int{2, 3}
+
+	g := G.NewGraph()
+	mean := G.NewMatrix(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(meanBacking))))
+	stddev := G.NewMatrix(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(tensor.NewDense(tensor.Float64, shape,
+			tensor.WithBacking(stddevBacking))))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range []int{1, 3} {
+		values, err := n.SampleValues(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := tensor.Shape(append([]int{m}, shape...))
+		if !values.Shape().Eq(expected) || values.Dims() != len(expected) {
+			t.Errorf("expected shape %v but got %v", expected,
+				values.Shape())
+		}
+	}
+
+	values, err := n.SampleValues(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := values.Data().([]float64)
+	for i := range meanBacking {
+		column := make([]float64, samples)
+		for j := range column {
+			column[j] = data[j*len(meanBacking)+i]
+		}
+		sampleMean, sampleStd := stat.MeanStdDev(column, nil)
+
+		if math.Abs(sampleMean-meanBacking[i]) > tolerance*
+			math.Max(1, stddevBacking[i]) {
+			t.Errorf("distribution %v: expected mean %v but got %v", i,
+				meanBacking[i], sampleMean)
+		}
+		if math.Abs(sampleStd-stddevBacking[i]) > tolerance*
+			stddevBacking[i] {
+			t.Errorf("distribution %v: expected stddev %v but got %v", i,
+				stddevBacking[i], sampleStd)
+		}
+	}
+
+	// Parameters without values cannot be sampled from
+	x := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("x"))
+	n, err = NewNormal(x, G.Must(G.Exp(x)), uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.SampleValues(1); err == nil {
+		t.Error("expected error sampling from parameters without values")
+	}
+}
+
+// TestNormalSampleValuesScalar tests that SampleValues can sample
+// from a Normal constructed from scalar input nodes with values, which
+// the Normal reshapes to shape (1), without running the graph
+func TestNormalSampleValuesScalar(t *testing.T) {
+	const samples int = 20000 // Number of samples to draw
+	const tolerance float64 = 0.05
+	const meanTarget, stddevTarget float64 = 3.0, 0.5
+
+	g := G.NewGraph()
+	mean := G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(meanTarget))
+	stddev := G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(stddevTarget))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := n.SampleValues(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (tensor.Shape{samples, 1}); !values.Shape().Eq(expected) {
+		t.Fatalf("expected shape %v but got %v", expected, values.Shape())
+	}
+
+	sampleMean, sampleStd := stat.MeanStdDev(values.Data().([]float64), nil)
+	if math.Abs(sampleMean-meanTarget) > tolerance {
+		t.Errorf("expected mean %v but got %v", meanTarget, sampleMean)
+	}
+	if math.Abs(sampleStd-stddevTarget) > tolerance*stddevTarget {
+		t.Errorf("expected stddev %v but got %v", stddevTarget, sampleStd)
+	}
+}
+
+// TestNormalEntropyGrad tests that the gradient of the entropy of the
+// Normal with respect to its standard deviation is 1/σ, for both
+// float64 and float32 Normals, including scalar Normals
+func TestNormalEntropyGrad(t *testing.T) {
+	const tests int = 10     // Number of tests to run
+	const maxSize int = 16   // Maximum number of distributions
+	const scale float64 = 2. // Scale at which to sample
+	const stdOffset float64 = 0.001
+	rand.Seed(time.Now().UnixNano())
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		tolerance := 1e-6
+		if dt == tensor.Float32 {
+			tolerance = 1e-4
+		}
+
+		for i := 0; i < tests; i++ {
+			size := 1 + rand.Intn(maxSize)
+			stdBacking := make([]float64, size)
+			for j := range stdBacking {
+				stdBacking[j] = (math.Exp(rand.Float64()) + stdOffset) * scale
+			}
+
+			// The first test uses scalar parameters
+			g := G.NewGraph()
+			var mean, stddev *G.Node
+			if i == 0 {
+				stdBacking = stdBacking[:1]
+				mean = G.NewScalar(g, dt, G.WithName("mean"),
+					G.WithInit(G.Zeroes()))
+				stddev = G.NewScalar(g, dt, G.WithName("stddev"),
+					G.WithValue(castTo(stdBacking[0], dt)))
+			} else {
+				mean = G.NewVector(g, dt, G.WithName("mean"),
+					G.WithShape(size), G.WithInit(G.Zeroes()))
+				stddev = G.NewVector(g, dt, G.WithName("stddev"),
+					G.WithValue(denseOf(stdBacking, dt)))
+			}
+
+			n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			entropy, err := n.Entropy()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			grads, err := G.Grad(G.Must(G.Sum(entropy)), stddev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gradVal G.Value
+			G.Read(grads[0], &gradVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			var grad []float64
+			switch data := gradVal.Data().(type) {
+			case float64:
+				grad = []float64{data}
+			case float32:
+				grad = []float64{float64(data)}
+			case []float64:
+				grad = data
+			case []float32:
+				for _, v := range data {
+					grad = append(grad, float64(v))
+				}
+			}
+
+			if len(grad) != len(stdBacking) {
+				t.Fatalf("%v: expected %v gradients but got %v", dt,
+					len(stdBacking), len(grad))
+			}
+			for j := range grad {
+				target := 1 / stdBacking[j]
+				if math.Abs(grad[j]-target) > tolerance*target {
+					t.Errorf("%v: incorrect gradient at σ = %v \nexpected: "+
+						"%v \nreceived: %v", dt, stdBacking[j], target,
+						grad[j])
+				}
+			}
+		}
+	}
+}
+
+// castTo converts x to a float of data type dt
+func castTo(x float64, dt tensor.Dtype) interface{} {
+	if dt == tensor.Float32 {
+		return float32(x)
+	}
+	return x
+}
+
+// denseOf returns backing as a vector of data type dt
+func denseOf(backing []float64, dt tensor.Dtype) *tensor.Dense {
+	if dt == tensor.Float32 {
+		backing32 := make([]float32, len(backing))
+		for i := range backing {
+			backing32[i] = float32(backing[i])
+		}
+		return tensor.NewDense(dt, []int{len(backing)},
+			tensor.WithBacking(backing32))
+	}
+	return tensor.NewDense(dt, []int{len(backing)},
+		tensor.WithBacking(backing))
+}
+
+// TestNormalSampleMode tests that SampleMode returns the mean of the
+// Normal and is deterministic across runs of the graph, unlike Sample
+func TestNormalSampleMode(t *testing.T) {
+	const runs int = 3 // Number of times to run the graph
+	meanBacking := []float64{-1, 0, 2.5, 7}
+	stddevBacking := []float64{0.5, 1, 2, 3}
+
+	g := G.NewGraph()
+	mean := G.NewVector(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{4},
+			tensor.WithBacking(meanBacking))))
+	stddev := G.NewVector(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{4},
+			tensor.WithBacking(stddevBacking))))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mode, err := n.SampleMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var modeVal, meanVal G.Value
+	G.Read(mode, &modeVal)
+	G.Read(n.Mean(), &meanVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	for i := 0; i < runs; i++ {
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !modeVal.Shape().Eq(n.Shape()) {
+			t.Errorf("expected shape %v but got %v", n.Shape(),
+				modeVal.Shape())
+		}
+		computed := modeVal.Data().([]float64)
+		for j := range meanBacking {
+			if computed[j] != meanBacking[j] ||
+				computed[j] != meanVal.Data().([]float64)[j] {
+				t.Errorf("run %v: expected mode %v but got %v", i,
+					meanBacking, computed)
+				break
+			}
+		}
+		vm.Reset()
+	}
+}
+
+// TestNormalFromTensors tests constructing a Normal from tensors with
+// NormalFromTensors and computing its LogProb, for both vector and
+// scalar parameters
+func TestNormalFromTensors(t *testing.T) {
+	const threshold float64 = 0.0000001 // Threshold to consider floats equal
+	const tests int = 10                // Number of tests to run
+	const maxSize int = 10              // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		// Use scalar parameters for half the tests
+		size := 1 + rand.Intn(maxSize)
+		scalar := i%2 == 1
+		if scalar {
+			size = 1
+		}
+
+		meanBacking := make([]float64, size)
+		stddevBacking := make([]float64, size)
+		xBacking := make([]float64, size)
+		for j := range meanBacking {
+			meanBacking[j] = rand.NormFloat64()
+			stddevBacking[j] = math.Exp(rand.NormFloat64())
+			xBacking[j] = rand.NormFloat64()
+		}
+
+		var mean, stddev tensor.Tensor
+		if scalar {
+			mean = tensor.New(tensor.FromScalar(meanBacking[0]))
+			stddev = tensor.New(tensor.FromScalar(stddevBacking[0]))
+		} else {
+			mean = tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(meanBacking))
+			stddev = tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(stddevBacking))
+		}
+
+		g := G.NewGraph()
+		n, err := NormalFromTensors(g, mean, stddev,
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !n.Shape().Eq(tensor.Shape{size}) {
+			t.Errorf("expected shape (%v) but got %v", size, n.Shape())
+		}
+
+		x := G.NewVector(g, tensor.Float64, G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(xBacking))))
+		logProb, err := n.LogProb(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var logProbVal G.Value
+		G.Read(logProb, &logProbVal)
+
+		// Compute the targets before running the graph, since the
+		// tensors are used as the values of the nodes
+		targets := make([]float64, size)
+		for j := range targets {
+			targets[j] = distuv.Normal{
+				Mu:    meanBacking[j],
+				Sigma: stddevBacking[j],
+			}.LogProb(xBacking[j])
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		var computed []float64
+		switch data := logProbVal.Data().(type) {
+		case float64:
+			computed = []float64{data}
+		case []float64:
+			computed = data
+		}
+		for j := range targets {
+			if math.Abs(computed[j]-targets[j]) > threshold {
+				t.Errorf("expected: %v received: %v", targets[j], computed[j])
+			}
+		}
+	}
+
+	g := G.NewGraph()
+	stddev := tensor.New(tensor.FromScalar(1.0))
+	if _, err := NormalFromTensors(g, nil, stddev, 0); err == nil {
+		t.Error("expected error with nil mean but got nil")
+	}
+	mean := tensor.New(tensor.FromScalar(float32(1.0)))
+	if _, err := NormalFromTensors(g, mean, stddev, 0); err == nil {
+		t.Error("expected error with mismatched data types but got nil")
+	}
+}
+
+// TestNormalParameterValues tests reading the values of the mean and
+// standard deviation of a Normal with MeanValue and StdDevValue, both
+// before and after running the graph
+func TestNormalParameterValues(t *testing.T) {
+	const threshold float64 = 0.0000001 // Threshold to consider floats equal
+
+	meanBacking := []float64{-1.0, 0.5, 2.0}
+	logStdBacking := []float64{0.0, math.Log(2.0), math.Log(0.5)}
+	stddevTarget := []float64{1.0, 2.0, 0.5}
+
+	g := G.NewGraph()
+	mean := G.NewVector(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking(append([]float64{}, meanBacking...)))))
+	logStd := G.NewVector(g, tensor.Float64, G.WithName("logStd"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking(logStdBacking))))
+	stddev := G.Must(G.Exp(logStd))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mean is an input node, and so has a value before the graph is
+	// run, but the standard deviation does not
+	if _, err := n.MeanValue(); err != nil {
+		t.Errorf("expected mean to have a value before running the "+
+			"graph: %v", err)
+	}
+	if _, err := n.StdDevValue(); err == nil {
+		t.Error("expected error reading standard deviation before " +
+			"running the graph")
+	}
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	meanVal, err := n.MeanValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stddevVal, err := n.StdDevValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		val    tensor.Tensor
+		target []float64
+	}{
+		{"mean", meanVal, meanBacking},
+		{"stddev", stddevVal, stddevTarget},
+	} {
+		if !test.val.Shape().Eq(n.Shape()) {
+			t.Errorf("%v: expected shape %v but got %v", test.name,
+				n.Shape(), test.val.Shape())
+		}
+		data := test.val.Data().([]float64)
+		for i := range test.target {
+			if math.Abs(data[i]-test.target[i]) > threshold {
+				t.Errorf("%v: expected: %v received: %v", test.name,
+					test.target[i], data[i])
+			}
+		}
+	}
+
+	// The returned values should be copies
+	meanVal.Data().([]float64)[0] = 100.0
+	meanVal, err = n.MeanValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := meanVal.Data().([]float64)[0]; v != meanBacking[0] {
+		t.Errorf("expected modifying the returned mean not to modify the "+
+			"Normal, expected: %v received: %v", meanBacking[0], v)
+	}
+}
+
+// TestNormalParameterValuesScalar tests reading the values of the
+// mean and standard deviation of a Normal constructed from scalar
+// input nodes, which the Normal reshapes to shape (1), both before and
+// after running the graph
+func TestNormalParameterValuesScalar(t *testing.T) {
+	g := G.NewGraph()
+	mean := G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(-1.5))
+	stddev := G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(2.0))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(when string) {
+		meanVal, err := n.MeanValue()
+		if err != nil {
+			t.Fatalf("%v: %v", when, err)
+		}
+		stddevVal, err := n.StdDevValue()
+		if err != nil {
+			t.Fatalf("%v: %v", when, err)
+		}
+
+		for _, test := range []struct {
+			name   string
+			val    tensor.Tensor
+			target float64
+		}{
+			{"mean", meanVal, -1.5},
+			{"stddev", stddevVal, 2.0},
+		} {
+			if !test.val.Shape().Eq(n.Shape()) ||
+				test.val.Dims() != n.Shape().Dims() {
+				t.Errorf("%v: %v: expected shape %v but got %v", when,
+					test.name, n.Shape(), test.val.Shape())
+			}
+			if v := test.val.Data().([]float64)[0]; v != test.target {
+				t.Errorf("%v: %v: expected: %v received: %v", when,
+					test.name, test.target, v)
+			}
+		}
+	}
+
+	check("before running")
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	check("after running")
+}
+
+// TestNormalCanEvaluate tests that CanEvaluate accepts inputs with the
+// legal shapes documented for the Normal, either the shape of the
+// Normal or a batch of inputs of that shape, and rejects all other
+// shapes, in agreement with Prob
+func TestNormalCanEvaluate(t *testing.T) {
+	tests := []struct {
+		shape   []int   // Shape of the Normal
+		legal   [][]int // Legal input shapes, where nil is a scalar
+		illegal [][]int
+	}{
+		{
+			// A single distribution accepts inputs of any shape
+			shape: []int{1},
+			legal: [][]int{nil, {1}, {5}, {5, 1}, {5, 2}, {5, 1, 1}},
+		},
+		{
+			shape:   []int{3},
+			legal:   [][]int{{3}, {1, 3}, {5, 3}},
+			illegal: [][]int{nil, {4}, {5, 4}, {3, 1}, {5, 3, 1}},
+		},
+		{
+			shape:   []int{2, 3},
+			legal:   [][]int{{2, 3}, {1, 2, 3}, {5, 2, 3}},
+			illegal: [][]int{nil, {3}, {3, 2}, {5, 3, 2}, {5, 2, 4}},
+		},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		mean := full(g, tensor.Float64, test.shape, 0.0, "mean")
+		stddev := full(g, tensor.Float64, test.shape, 1.0, "stddev")
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		newInput := func(shape []int) *G.Node {
+			if shape == nil {
+				return G.NewScalar(g, tensor.Float64,
+					G.WithName(gop.Unique("x")))
+			}
+			return G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithShape(shape...), G.WithName(gop.Unique("x")))
+		}
+
+		for _, shape := range test.legal {
+			x := newInput(shape)
+			if err := n.CanEvaluate(x); err != nil {
+				t.Errorf("normal shape %v: expected input shape %v to be "+
+					"legal but got error: %v", test.shape, shape, err)
+			}
+			if _, err := n.Prob(x); err != nil {
+				t.Errorf("normal shape %v: expected Prob to accept input "+
+					"shape %v but got error: %v", test.shape, shape, err)
+			}
+		}
+
+		for _, shape := range test.illegal {
+			x := newInput(shape)
+			if err := n.CanEvaluate(x); err == nil {
+				t.Errorf("normal shape %v: expected input shape %v to be "+
+					"illegal", test.shape, shape)
+			}
+			if _, err := n.Prob(x); err == nil {
+				t.Errorf("normal shape %v: expected Prob to reject input "+
+					"shape %v", test.shape, shape)
+			}
+		}
+	}
+}
+
+// TestNormalScalarMatrixBatch tests that a Normal with scalar
+// parameters treats each element of a matrix input as an i.i.d. sample
+// from its single distribution
+func TestNormalScalarMatrixBatch(t *testing.T) {
+	const threshold float64 = 0.0000001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	mean := rand.NormFloat64()
+	stddev := math.Exp(rand.NormFloat64())
+	xBacking := make([]float64, 12)
+	targets := make([]float64, len(xBacking))
+	for i := range xBacking {
+		xBacking[i] = rand.NormFloat64()
+		targets[i] = distuv.Normal{Mu: mean, Sigma: stddev}.Prob(xBacking[i])
+	}
+
+	g := G.NewGraph()
+	meanNode := G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(mean))
+	stddevNode := G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(stddev))
+	n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := G.NewMatrix(g, tensor.Float64, G.WithName("x"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3, 4},
+			tensor.WithBacking(xBacking))))
+	if err := n.CanEvaluate(x); err != nil {
+		t.Fatal(err)
+	}
+	prob, err := n.Prob(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var probVal G.Value
+	G.Read(prob, &probVal)
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	expectedShape := tensor.Shape{len(xBacking), 1}
+	if !probVal.Shape().Eq(expectedShape) {
+		t.Errorf("expected shape %v but got %v", expectedShape,
+			probVal.Shape())
+	}
+	for i, v := range probVal.Data().([]float64) {
+		if math.Abs(v-targets[i]) > threshold {
+			t.Errorf("expected: %v received: %v", targets[i], v)
+		}
+	}
+}
+
+// TestNewNormalDifferentGraphs tests that NewNormal and
+// NewNormalWithSource reject a mean and standard deviation which are
+// on different graphs
+func TestNewNormalDifferentGraphs(t *testing.T) {
+	g1 := G.NewGraph()
+	g2 := G.NewGraph()
+	mean := full(g1, tensor.Float64, []int{3}, 0.0, "mean")
+	stddev := full(g2, tensor.Float64, []int{3}, 1.0, "stddev")
+
+	if _, err := NewNormal(mean, stddev, 0); err == nil {
+		t.Error("newNormal: expected error with mean and stddev on " +
+			"different graphs")
+	}
+	_, err := NewNormalWithSource(mean, stddev, expRand.NewSource(0))
+	if err == nil {
+		t.Error("newNormalWithSource: expected error with mean and " +
+			"stddev on different graphs")
+	}
+
+	// Nodes on the same graph should be accepted
+	stddev = full(g1, tensor.Float64, []int{3}, 1.0, "stddev")
+	if _, err := NewNormal(mean, stddev, 0); err != nil {
+		t.Errorf("newNormal: unexpected error with mean and stddev on "+
+			"the same graph: %v", err)
+	}
+}
+
+// TestNewNormalNonPositiveStdDev tests that NewNormal rejects a stddev
+// with a value which is not positive, and accepts a stddev without a
+// value, which cannot be validated at construction
+func TestNewNormalNonPositiveStdDev(t *testing.T) {
+	tests := []struct {
+		name   string
+		dt     tensor.Dtype
+		stddev []float64
+	}{
+		{"negative", tensor.Float64, []float64{1.0, -1.0, 2.0}},
+		{"zero", tensor.Float64, []float64{1.0, 0.0, 2.0}},
+		{"NaN", tensor.Float64, []float64{math.NaN(), 1.0, 2.0}},
+		{"negative float32", tensor.Float32, []float64{1.0, 2.0, -0.5}},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		mean := full(g, test.dt, []int{3}, 0.0, "mean")
+		stddev := G.NewVector(g, test.dt, G.WithName("stddev"),
+			G.WithValue(denseOf(test.stddev, test.dt)))
+
+		if _, err := NewNormal(mean, stddev, 0); err == nil {
+			t.Errorf("%v: expected error with stddev %v", test.name,
+				test.stddev)
+		}
+	}
+
+	// Scalar stddev
+	g := G.NewGraph()
+	mean := G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(0.0))
+	stddev := G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(-1.0))
+	if _, err := NewNormal(mean, stddev, 0); err == nil {
+		t.Error("scalar: expected error with stddev -1")
+	}
+
+	// A stddev computed from other nodes has no value and so cannot be
+	// validated at construction
+	g = G.NewGraph()
+	mean = full(g, tensor.Float64, []int{3}, 0.0, "mean")
+	logStd := full(g, tensor.Float64, []int{3}, -1.0, "logStd")
+	stddev = G.Must(G.Exp(logStd))
+	if _, err := NewNormal(mean, stddev, 0); err != nil {
+		t.Errorf("symbolic: unexpected error: %v", err)
+	}
+}
+
+// TestNormalSharedConstants tests that Prob, LogProb, Cdf, and Quantile
+// compute correct values when called on the same Normal, and so share
+// the same constant nodes in the graph
+func TestNormalSharedConstants(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	const batch, size = 3, 4
+	meanBacking := make([]float64, size)
+	stddevBacking := make([]float64, size)
+	for i := range meanBacking {
+		meanBacking[i] = rand.NormFloat64()
+		stddevBacking[i] = math.Exp(rand.NormFloat64())
+	}
+	xBacking := make([]float64, batch*size)
+	pBacking := make([]float64, batch*size)
+	for i := range xBacking {
+		xBacking[i] = rand.NormFloat64() * 2
+		pBacking[i] = 0.01 + 0.98*rand.Float64()
+	}
+
+	// Compute the targets for each method
+	targets := make([][]float64, 4)
+	for i := range targets {
+		targets[i] = make([]float64, len(xBacking))
+	}
+	for i := range xBacking {
+		j := i % size
+		dist := distuv.Normal{Mu: meanBacking[j], Sigma: stddevBacking[j]}
+		targets[0][i] = dist.Prob(xBacking[i])
+		targets[1][i] = dist.LogProb(xBacking[i])
+		targets[2][i] = dist.CDF(xBacking[i])
+		targets[3][i] = dist.Quantile(pBacking[i])
+	}
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		g := G.NewGraph()
+		mean := G.NewVector(g, dt, G.WithName("mean"),
+			G.WithValue(denseOf(meanBacking, dt)))
+		stddev := G.NewVector(g, dt, G.WithName("stddev"),
+			G.WithValue(denseOf(stddevBacking, dt)))
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		methods := []func(*G.Node) (*G.Node, error){
+			n.Prob, n.LogProb, n.Cdf, n.Quantile,
+		}
+		inputs := [][]float64{xBacking, xBacking, xBacking, pBacking}
+		outVals := make([]G.Value, len(methods))
+		for i, method := range methods {
+			inVal := denseOf(inputs[i], dt).Clone().(*tensor.Dense)
+			if err := inVal.Reshape(batch, size); err != nil {
+				t.Fatal(err)
+			}
+			in := G.NewMatrix(g, dt, G.WithName(fmt.Sprintf("in%d", i)),
+				G.WithValue(inVal))
+			out, err := method(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			G.Read(out, &outVals[i])
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		for i, outVal := range outVals {
+			var data []float64
+			switch d := outVal.Data().(type) {
+			case []float64:
+				data = d
+			case []float32:
+				for _, v := range d {
+					data = append(data, float64(v))
+				}
+			}
+
+			for j, v := range data {
+				tol := threshold * math.Max(1, math.Abs(targets[i][j]))
+				if dt == tensor.Float32 {
+					tol *= 100
+				}
+				if math.Abs(v-targets[i][j]) > tol {
+					t.Errorf("%v method %d: expected: %v received: %v", dt,
+						i, targets[i][j], v)
+				}
+			}
+		}
+	}
+}
+
+// TestNormalFixShapeShared tests that calling multiple methods of the
+// Normal on the same input adds a single reshape of the input to the
+// graph, since Gorgonia deduplicates identical nodes, and that each
+// method still computes correct values
+func TestNormalFixShapeShared(t *testing.T) {
+	const threshold float64 = 0.0000001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	mean := rand.NormFloat64()
+	stddev := math.Exp(rand.NormFloat64())
+	xBacking := make([]float64, 12)
+	probTargets := make([]float64, len(xBacking))
+	logProbTargets := make([]float64, len(xBacking))
+	for i := range xBacking {
+		xBacking[i] = rand.NormFloat64()
+		dist := distuv.Normal{Mu: mean, Sigma: stddev}
+		probTargets[i] = dist.Prob(xBacking[i])
+		logProbTargets[i] = dist.LogProb(xBacking[i])
+	}
+
+	g := G.NewGraph()
+	meanNode := G.NewScalar(g, tensor.Float64, G.WithName("mean"),
+		G.WithValue(mean))
+	stddevNode := G.NewScalar(g, tensor.Float64, G.WithName("stddev"),
+		G.WithValue(stddev))
+	n, err := NewNormal(meanNode, stddevNode, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The (3, 4) input is flattened to a batch of shape (12, 1)
+	x := G.NewMatrix(g, tensor.Float64, G.WithName("x"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3, 4},
+			tensor.WithBacking(xBacking))))
+	prob, err := n.Prob(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logProb, err := n.LogProb(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reshapes := 0
+	for _, node := range g.AllNodes() {
+		if node.Op() != nil &&
+			strings.HasPrefix(node.Op().String(), "Reshape") &&
+			node.Dims() == 2 && node.Shape()[0] == len(xBacking) {
+			reshapes++
+		}
+	}
+	if reshapes != 1 {
+		t.Errorf("expected 1 reshape of x but got %v", reshapes)
+	}
+
+	var probVal, logProbVal G.Value
+	G.Read(prob, &probVal)
+	G.Read(logProb, &logProbVal)
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	for i, v := range probVal.Data().([]float64) {
+		if math.Abs(v-probTargets[i]) > threshold {
+			t.Errorf("prob: expected: %v received: %v", probTargets[i], v)
+		}
+	}
+	for i, v := range logProbVal.Data().([]float64) {
+		if math.Abs(v-logProbTargets[i]) > threshold {
+			t.Errorf("logProb: expected: %v received: %v", logProbTargets[i],
+				v)
+		}
+	}
+}
+
+// TestNormalScore tests that Score and StdDevScore compute the
+// gradients of the log probability with respect to the mean and
+// standard deviation for each sample in a batch, by comparing their
+// sum over the batch against the gradient of the summed LogProb
+func TestNormalScore(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	const batch, size = 4, 3
+	meanBacking := make([]float64, size)
+	stddevBacking := make([]float64, size)
+	for i := range meanBacking {
+		meanBacking[i] = rand.NormFloat64()
+		stddevBacking[i] = math.Exp(rand.NormFloat64())
+	}
+	xBacking := make([]float64, batch*size)
+	for i := range xBacking {
+		xBacking[i] = rand.NormFloat64() * 2
+	}
+
+	// score returns the values of score applied to x and the gradient
+	// of the summed log probability of x with respect to the parameter
+	// returned by param
+	score := func(method func(*Normal) func(*G.Node) (*G.Node, error),
+		param func(*Normal) *G.Node) ([]float64, []float64) {
+		g := G.NewGraph()
+		mean := G.NewVector(g, tensor.Float64, G.WithName("mean"),
+			G.WithValue(denseOf(append([]float64{}, meanBacking...),
+				tensor.Float64)))
+		stddev := G.NewVector(g, tensor.Float64, G.WithName("stddev"),
+			G.WithValue(denseOf(append([]float64{}, stddevBacking...),
+				tensor.Float64)))
+		n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		x := G.NewMatrix(g, tensor.Float64, G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{batch, size},
+				tensor.WithBacking(append([]float64{}, xBacking...)))))
+		s, err := method(n)(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logProb, err := n.LogProb(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		grad, err := G.Grad(G.Must(G.Sum(logProb)), param(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var scoreVal, gradVal G.Value
+		G.Read(s, &scoreVal)
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		defer vm.Close()
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		return append([]float64{}, scoreVal.Data().([]float64)...),
+			append([]float64{}, gradVal.Data().([]float64)...)
+	}
+
+	meanScore, meanGrad := score(
+		func(n *Normal) func(*G.Node) (*G.Node, error) { return n.Score },
+		(*Normal).Mean,
+	)
+	stddevScore, stddevGrad := score(
+		func(n *Normal) func(*G.Node) (*G.Node, error) {
+			return n.StdDevScore
+		},
+		(*Normal).StdDev,
+	)
+
+	for i := range xBacking {
+		j := i % size
+		z := (xBacking[i] - meanBacking[j]) / stddevBacking[j]
+		target := z / stddevBacking[j]
+		if math.Abs(meanScore[i]-target) > threshold {
+			t.Errorf("score: expected: %v received: %v", target,
+				meanScore[i])
+		}
+		target = (z*z - 1) / stddevBacking[j]
+		if math.Abs(stddevScore[i]-target) > threshold {
+			t.Errorf("stdDevScore: expected: %v received: %v", target,
+				stddevScore[i])
+		}
+	}
+
+	for j := 0; j < size; j++ {
+		var meanSum, stddevSum float64
+		for i := 0; i < batch; i++ {
+			meanSum += meanScore[i*size+j]
+			stddevSum += stddevScore[i*size+j]
+		}
+		if math.Abs(meanSum-meanGrad[j]) > threshold {
+			t.Errorf("score: expected summed score to equal gradient %v "+
+				"but got %v", meanGrad[j], meanSum)
+		}
+		if math.Abs(stddevSum-stddevGrad[j]) > threshold {
+			t.Errorf("stdDevScore: expected summed score to equal "+
+				"gradient %v but got %v", stddevGrad[j], stddevSum)
+		}
+	}
+}
+
+// TestNewNormalErrorTypes tests that the errors returned by NewNormal
+// for mismatched shapes and data types can be distinguished with
+// errors.As
+func TestNewNormalErrorTypes(t *testing.T) {
+	g := G.NewGraph()
+	mean := full(g, tensor.Float64, []int{3}, 0.0, "mean")
+
+	stddev := full(g, tensor.Float64, []int{2}, 1.0, "stddev")
+	_, err := NewNormal(mean, stddev, 0)
+	var shapeErr *gop.ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("expected ShapeError but got %v", err)
+	}
+
+	stddev = full(g, tensor.Float32, []int{3}, 1.0, "stddev32")
+	_, err = NewNormal(mean, stddev, 0)
+	var dtypeErr *gop.DtypeError
+	if !errors.As(err, &dtypeErr) {
+		t.Errorf("expected DtypeError but got %v", err)
+	} else if errors.As(err, &shapeErr) {
+		t.Errorf("expected data type error not to be a ShapeError")
+	}
+
+	// Wrapping distribution constructors preserve the error type
+	_, err = NewTruncatedNormal(mean, full(g, tensor.Float64, []int{2}, 1.0,
+		"stddev2"), mean, mean, 0)
+	if !errors.Is(err, &gop.ShapeError{}) {
+		t.Errorf("expected ShapeError but got %v", err)
+	}
+}
+
+// TestNormalMethodErrorTypes tests that the methods of a Normal wrap
+// the ShapeError returned for an input of invalid shape, so that it can
+// be recovered with errors.As
+func TestNormalMethodErrorTypes(t *testing.T) {
+	g := G.NewGraph()
+	mean := full(g, tensor.Float64, []int{3}, 0.0, "mean")
+	stddev := full(g, tensor.Float64, []int{3}, 1.0, "stddev")
+	n, err := NewNormal(mean, stddev, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := full(g, tensor.Float64, []int{4, 2}, 0.5, "x")
+
+	methods := []func(*G.Node) (*G.Node, error){
+		n.Prob, n.LogProb, n.LogProbFused, n.Score, n.StdDevScore, n.Cdf,
+		n.Sf, n.LogCdf, n.Quantile,
+	}
+	names := []string{
+		"Prob", "LogProb", "LogProbFused", "Score", "StdDevScore", "Cdf",
+		"Sf", "LogCdf", "Quantile",
+	}
+	for i, method := range methods {
+		_, err := method(x)
+		var shapeErr *gop.ShapeError
+		if !errors.As(err, &shapeErr) {
+			t.Errorf("%v: expected ShapeError but got %v", names[i], err)
+		}
+	}
+
+	if err := n.CanEvaluate(x); !errors.Is(err, &gop.ShapeError{}) {
+		t.Errorf("CanEvaluate: expected ShapeError but got %v", err)
+	}
+}
+
+// TestNormalSampleHistogram tests that the histogram of samples drawn
+// from a Normal has its modal bin near the mean of the Normal
+func TestNormalSampleHistogram(t *testing.T) {
+	const samples int = 20000 // Number of samples per distribution
+	const bins int = 40       // Number of histogram bins
+	const low, high float64 = -10, 10
+	const width float64 = (high - low) / float64(bins)
+
+	means := []float64{-3, 0, 1.3, 4.6}
+	stddevs := []float64{1, 0.5, 1.5, 1}
+
+	g := G.NewGraph()
+	n, err := NormalFromTensors(g,
+		tensor.NewDense(tensor.Float64, []int{len(means)},
+			tensor.WithBacking(means)),
+		tensor.NewDense(tensor.Float64, []int{len(stddevs)},
+			tensor.WithBacking(stddevs)),
+		uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sample has shape (samples, len(means)), so transpose to histogram
+	// the samples of each distribution along the last axis
+	sample, err := n.Sample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample = G.Must(G.Transpose(sample, 1, 0))
+	hist, err := gop.Histogram(sample, bins, low, high)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	counts := hist.Value().Data().([]int)
+	for i, mean := range means {
+		row := counts[i*bins : (i+1)*bins]
+
+		total, mode := 0, 0
+		for j, count := range row {
+			total += count
+			if count > row[mode] {
+				mode = j
+			}
+		}
+		if total != samples {
+			t.Errorf("expected %v samples in histogram but got %v", samples,
+				total)
+		}
+
+		// The centre of the modal bin should be within a bin of the mean
+		centre := low + (float64(mode)+0.5)*width
+		if math.Abs(centre-mean) > 1.5*width {
+			t.Errorf("expected modal bin near mean %v but got bin centred "+
+				"at %v with counts %v", mean, centre, row)
+		}
+	}
+}
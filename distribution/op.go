@@ -3,32 +3,197 @@ package distribution
 import (
 	"fmt"
 
+	"golang.org/x/exp/rand"
+
+	"github.com/samuelfneumann/gop"
 	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
 )
 
 // NormalSample returns numSamples samples from a normal distribution
 // with mean mean and standard deviation stddev. The batch dimension
-// is dimension 0 always.
+// is dimension 0 always. NormalSample, along with
+// NormalSampleWithSource, is the single exported entry point for
+// sampling from a normal distribution, and is used by the Sample and
+// Rsample methods of the Normal. An error is returned if numSamples
+// is less than 1.
 //
 // NormalSample is not a differentiable operation. For a differentiable
 // sampling operation, see Normal.
 func NormalSample(mean, stddev *G.Node, seed uint64,
 	numSamples int) (*G.Node, error) {
+	if numSamples < 1 {
+		return nil, fmt.Errorf("normalSample: expected numSamples >= 1 "+
+			"but got %v", numSamples)
+	}
+
 	if mean.Dtype() != stddev.Dtype() {
-		return nil, fmt.Errorf("normalRand: mean and stddev should have "+
+		return nil, fmt.Errorf("normalSample: mean and stddev should have "+
 			"same dtype but got %v and %v", mean.Dtype(), stddev.Dtype())
 	}
 
 	if !mean.Shape().Eq(stddev.Shape()) {
-		return nil, fmt.Errorf("normalRand: mean and stddev should have "+
+		return nil, fmt.Errorf("normalSample: mean and stddev should have "+
 			"same shape but got %v and %v", mean.Shape(), stddev.Shape())
 	}
 
-	n, err := newNormalSampleOp(mean.Dtype(), seed, numSamples,
+	out, err := NormalSampleWithSource(mean, stddev, rand.NewSource(seed),
+		numSamples)
+	if err != nil {
+		return nil, fmt.Errorf("normalSample: %w", err)
+	}
+
+	return out, nil
+}
+
+// NormalSampleWithSource is like NormalSample, but draws samples using
+// source rather than a source seeded with some seed. Nodes which share
+// a source draw from the same random sequence, so that all sampling in
+// a graph can be driven by a single deterministic source.
+func NormalSampleWithSource(mean, stddev *G.Node, source rand.Source,
+	numSamples int) (*G.Node, error) {
+	if numSamples < 1 {
+		return nil, fmt.Errorf("normalSampleWithSource: expected "+
+			"numSamples >= 1 but got %v", numSamples)
+	}
+
+	if mean.Dtype() != stddev.Dtype() {
+		return nil, fmt.Errorf("normalSampleWithSource: mean and stddev "+
+			"should have same dtype but got %v and %v", mean.Dtype(),
+			stddev.Dtype())
+	}
+
+	if !mean.Shape().Eq(stddev.Shape()) {
+		return nil, fmt.Errorf("normalSampleWithSource: mean and stddev "+
+			"should have same shape but got %v and %v", mean.Shape(),
+			stddev.Shape())
+	}
+
+	n, err := newNormalSampleOp(mean.Dtype(), source, numSamples,
 		mean.Shape()...)
 	if err != nil {
-		return nil, fmt.Errorf("normalRand: %v", err)
+		return nil, fmt.Errorf("normalSampleWithSource: %w", err)
 	}
 
 	return G.ApplyOp(n, mean, stddev)
 }
+
+// UniformSample returns numSamples samples from a uniform distribution
+// on the interval [low, high). The batch dimension is dimension 0
+// always.
+//
+// UniformSample is not a differentiable operation.
+func UniformSample(low, high *G.Node, seed uint64,
+	numSamples int) (*G.Node, error) {
+	if low.Dtype() != high.Dtype() {
+		return nil, fmt.Errorf("uniformSample: low and high should have "+
+			"same dtype but got %v and %v", low.Dtype(), high.Dtype())
+	}
+
+	if !low.Shape().Eq(high.Shape()) {
+		return nil, fmt.Errorf("uniformSample: low and high should have "+
+			"same shape but got %v and %v", low.Shape(), high.Shape())
+	}
+
+	u, err := newUniformSampleOp(low.Dtype(), seed, numSamples,
+		low.Shape()...)
+	if err != nil {
+		return nil, fmt.Errorf("uniformSample: %w", err)
+	}
+
+	return G.ApplyOp(u, low, high)
+}
+
+// GammaSample returns numSamples samples from a gamma distribution
+// with shape parameter alpha and rate parameter rate. The batch
+// dimension is dimension 0 always.
+//
+// GammaSample is not a differentiable operation.
+func GammaSample(alpha, rate *G.Node, seed uint64,
+	numSamples int) (*G.Node, error) {
+	if alpha.Dtype() != rate.Dtype() {
+		return nil, fmt.Errorf("gammaSample: alpha and rate should have "+
+			"same dtype but got %v and %v", alpha.Dtype(), rate.Dtype())
+	}
+
+	if !alpha.Shape().Eq(rate.Shape()) {
+		return nil, fmt.Errorf("gammaSample: alpha and rate should have "+
+			"same shape but got %v and %v", alpha.Shape(), rate.Shape())
+	}
+
+	op, err := newGammaSampleOp(alpha.Dtype(), seed, numSamples,
+		alpha.Shape()...)
+	if err != nil {
+		return nil, fmt.Errorf("gammaSample: %w", err)
+	}
+
+	return G.ApplyOp(op, alpha, rate)
+}
+
+// GumbelSoftmaxSample returns a sample from the Gumbel-softmax (also
+// called the concrete) distribution with logits logits and temperature
+// tau. The last axis of logits holds the categories, and all other axes
+// are batch dimensions, as for the Categorical. The returned node has
+// the same shape as logits.
+//
+// Standard Gumbel noise is added to the logits, which are then divided
+// by tau and passed through a softmax along the last axis. The result
+// is a relaxed one-hot encoding of a sample from the Categorical with
+// the same logits, which approaches a one-hot encoding as tau → 0.
+// Unlike the Categorical's sampling, GumbelSoftmaxSample is
+// differentiable with respect to logits.
+func GumbelSoftmaxSample(logits *G.Node, tau float64,
+	seed uint64) (*G.Node, error) {
+	if logits.Dims() < 1 {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: expected logits to "+
+			"have at least 1 dimension but got %v", logits.Dims())
+	}
+	if logits.Dtype() != tensor.Float64 && logits.Dtype() != tensor.Float32 {
+		return nil, gop.DtypeErrorf("gumbelSoftmaxSample: data type %v "+
+			"unsupported", logits.Dtype())
+	}
+	if tau <= 0 {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: expected tau > 0 but "+
+			"got %v", tau)
+	}
+
+	graph := logits.Graph()
+	shape := logits.Shape().Clone()
+	low := full(graph, logits.Dtype(), shape, 0.0, "low")
+	high := full(graph, logits.Dtype(), shape, 1.0, "high")
+
+	u, err := UniformSample(low, high, seed, 1)
+	if err != nil {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: could not sample "+
+			"from standard uniform: %w", err)
+	}
+	u, err = G.Reshape(u, shape)
+	if err != nil {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: could not remove "+
+			"batch dimension: %w", err)
+	}
+
+	// Standard Gumbel noise: -log(-log(u))
+	w := G.Must(G.Log(u))
+	w = G.Must(G.Log(G.Must(G.Neg(w))))
+	w = G.Must(G.Neg(w))
+
+	perturbed, err := G.Add(logits, w)
+	if err != nil {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: could not add noise "+
+			"to logits: %w", err)
+	}
+	perturbed, err = G.HadamardDiv(perturbed,
+		constant(graph, logits.Dtype(), tau))
+	if err != nil {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: could not divide by "+
+			"temperature: %w", err)
+	}
+
+	out, err := gop.Softmax(perturbed, -1)
+	if err != nil {
+		return nil, fmt.Errorf("gumbelSoftmaxSample: %w", err)
+	}
+
+	return out, nil
+}
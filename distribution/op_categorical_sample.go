@@ -0,0 +1,232 @@
+package distribution
+
+import (
+	"fmt"
+	"hash"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/chewxy/hm"
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// categoricalSampleOp is an operation that samples from a categorical
+// distribution whenever the node is passed through. The input to the
+// op is a tensor of probabilities whose last axis holds the
+// categories. If oneHot is false, the op outputs the integer index of
+// the sampled categories. Otherwise, the op outputs a one-hot encoding
+// of the sampled categories, with the same data type as the input.
+// The categoricalSampleOp is not differentiable.
+type categoricalSampleOp struct {
+	dt         tensor.Dtype
+	shape      tensor.Shape
+	rng        *rand.Rand
+	source     rand.Source
+	numSamples int
+	oneHot     bool
+}
+
+// newCategoricalSampleOp returns a new categoricalSampleOp
+func newCategoricalSampleOp(dt tensor.Dtype, seed uint64, numSamples int,
+	oneHot bool, shape ...int) (*categoricalSampleOp, error) {
+	if dt != tensor.Float64 && dt != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newCategoricalSampleOp: dtype %v "+
+			"not supported", dt)
+	}
+
+	if numSamples < 1 {
+		return nil, fmt.Errorf("cannot samples %v < 1 samples", numSamples)
+	}
+
+	if len(shape) < 1 {
+		return nil, gop.ShapeErrorf("expected probabilities to have at " +
+			"least 1 dimension")
+	}
+
+	source := rand.NewSource(seed)
+
+	return &categoricalSampleOp{
+		dt:         dt,
+		shape:      tensor.Shape(shape),
+		rng:        rand.New(source),
+		source:     source,
+		numSamples: numSamples,
+		oneHot:     oneHot,
+	}, nil
+}
+
+// Arity implements the gorgonia.Op interface
+func (c *categoricalSampleOp) Arity() int { return 1 }
+
+// DiffWRT implements the gorgonia.SDOp interface. The
+// categoricalSampleOp is not differentiable with respect to any of its
+// inputs, but must report so in order to be used in graphs which are
+// differentiated.
+func (c *categoricalSampleOp) DiffWRT(inputs int) []bool {
+	return make([]bool, inputs)
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (c *categoricalSampleOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	return nil, fmt.Errorf("symDiff: %v is not differentiable", c)
+}
+
+// Type implements the gorgonia.Op interface
+func (c *categoricalSampleOp) Type() hm.Type {
+	in := G.TensorType{
+		Dims: c.shape.Dims(),
+		Of:   c.dt,
+	}
+
+	var out G.TensorType
+	if c.oneHot {
+		out = G.TensorType{Dims: c.shape.Dims() + 1, Of: c.dt}
+	} else {
+		out = G.TensorType{Dims: c.shape.Dims(), Of: tensor.Int}
+	}
+
+	return hm.NewFnType(in, out)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (c *categoricalSampleOp) InferShape(...G.DimSizer) (tensor.Shape,
+	error) {
+	return c.outShape(), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (c *categoricalSampleOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (c *categoricalSampleOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (c *categoricalSampleOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (c *categoricalSampleOp) String() string {
+	return fmt.Sprintf("CategoricalSample{shape=%v, oneHot=%v}()",
+		c.outShape(), c.oneHot)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (c *categoricalSampleOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, c.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (c *categoricalSampleOp) Hashcode() uint32 {
+	return gop.SimpleHash(c)
+}
+
+// Do implements the gorgonia.Op interface
+func (c *categoricalSampleOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := c.checkInputs(inputs...); err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	probs := inputs[0].(tensor.Tensor)
+	if v, ok := probs.(tensor.View); ok && v.IsMaterializable() {
+		probs = v.Materialize()
+	}
+
+	var p []float64
+	switch data := probs.Data().(type) {
+	case []float64:
+		p = data
+	case []float32:
+		p = make([]float64, len(data))
+		for i := range data {
+			p[i] = float64(data[i])
+		}
+	}
+
+	k := c.shape[len(c.shape)-1]
+	dists := len(p) / k
+
+	out := tensor.NewDense(c.outDtype(), c.outShape())
+	for i := 0; i < c.numSamples; i++ {
+		for j := 0; j < dists; j++ {
+			category := c.sample(p[j*k : (j+1)*k])
+
+			index := i*dists + j
+			if !c.oneHot {
+				out.Set(index, category)
+			} else if c.dt == tensor.Float64 {
+				out.Set(index*k+category, 1.0)
+			} else {
+				out.Set(index*k+category, float32(1.0))
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// sample samples a category from the probabilities p using the
+// inverse CDF
+func (c *categoricalSampleOp) sample(p []float64) int {
+	total := 0.0
+	for _, prob := range p {
+		total += prob
+	}
+
+	u := c.rng.Float64() * total
+	cumulative := 0.0
+	for i, prob := range p {
+		cumulative += prob
+		if u < cumulative {
+			return i
+		}
+	}
+
+	// Guard against floating point error in the cumulative sum
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] > 0 {
+			return i
+		}
+	}
+	return len(p) - 1
+}
+
+// outShape returns the shape of the output of the receiver
+func (c *categoricalSampleOp) outShape() tensor.Shape {
+	if c.oneHot {
+		return append([]int{c.numSamples}, c.shape...)
+	}
+	return append([]int{c.numSamples}, c.shape[:len(c.shape)-1]...)
+}
+
+// outDtype returns the data type of the output of the receiver
+func (c *categoricalSampleOp) outDtype() tensor.Dtype {
+	if c.oneHot {
+		return c.dt
+	}
+	return tensor.Int
+}
+
+// checkInputs returns an error if inputs is an illegal input for the
+// receiver
+func (c *categoricalSampleOp) checkInputs(inputs ...G.Value) error {
+	if err := gop.CheckArity(c, len(inputs)); err != nil {
+		return err
+	}
+
+	probs, ok := inputs[0].(tensor.Tensor)
+	if !ok || probs == nil {
+		return fmt.Errorf("cannot sample from nil probabilities")
+	} else if probs.Size() == 0 {
+		return fmt.Errorf("cannot sample from empty probabilities tensor")
+	} else if !probs.Shape().Eq(c.shape) {
+		return fmt.Errorf("expected probabilities to have shape %v but "+
+			"got %v", c.shape, probs.Shape())
+	} else if !probs.Dtype().Eq(c.dt) {
+		return fmt.Errorf("expected probabilities to have dtype %v but "+
+			"got %v", c.dt, probs.Dtype())
+	}
+
+	return nil
+}
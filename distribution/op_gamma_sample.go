@@ -0,0 +1,206 @@
+package distribution
+
+import (
+	"fmt"
+	"hash"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/chewxy/hm"
+	"github.com/samuelfneumann/gop"
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// gammaSampleOp is an operation that samples from a gamma
+// distribution whenever the node is passed through. The gammaSampleOp
+// is not differentiable.
+type gammaSampleOp struct {
+	dt         tensor.Dtype
+	shape      tensor.Shape
+	dist       distuv.Gamma
+	source     rand.Source
+	numSamples int
+}
+
+// newGammaSampleOp returns a new gammaSampleOp
+func newGammaSampleOp(dt tensor.Dtype, seed uint64, numSamples int,
+	shape ...int) (*gammaSampleOp, error) {
+	if dt != tensor.Float64 && dt != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newGammaSampleOp: dtype %v not "+
+			"supported", dt)
+	}
+
+	if numSamples < 1 {
+		return nil, fmt.Errorf("cannot samples %v < 1 samples", numSamples)
+	}
+
+	source := rand.NewSource(seed)
+
+	return &gammaSampleOp{
+		dt:     dt,
+		shape:  tensor.Shape(shape),
+		source: source,
+		dist: distuv.Gamma{
+			Alpha: 1.0,
+			Beta:  1.0,
+			Src:   source,
+		},
+		numSamples: numSamples,
+	}, nil
+}
+
+// Arity implements the gorgonia.Op interface
+func (g *gammaSampleOp) Arity() int { return 2 }
+
+// DiffWRT implements the gorgonia.SDOp interface. The gammaSampleOp
+// is not differentiable with respect to any of its inputs, but must
+// report so in order to be used in graphs which are differentiated.
+func (g *gammaSampleOp) DiffWRT(inputs int) []bool {
+	return make([]bool, inputs)
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (g *gammaSampleOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	return nil, fmt.Errorf("symDiff: %v is not differentiable", g)
+}
+
+// Type implements the gorgonia.Op interface
+func (g *gammaSampleOp) Type() hm.Type {
+	in := G.TensorType{
+		Dims: g.shape.Dims(),
+		Of:   g.dt,
+	}
+	out := G.TensorType{
+		Dims: g.shape.Dims() + 1,
+		Of:   g.dt,
+	}
+
+	return hm.NewFnType(in, in, out)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (g *gammaSampleOp) InferShape(...G.DimSizer) (tensor.Shape, error) {
+	return append([]int{g.numSamples}, g.shape...), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (g *gammaSampleOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (g *gammaSampleOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (g *gammaSampleOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (g *gammaSampleOp) String() string {
+	return fmt.Sprintf("GammaSample{shape=%v}()",
+		append([]int{g.numSamples}, g.shape...))
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (g *gammaSampleOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, g.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (g *gammaSampleOp) Hashcode() uint32 {
+	return gop.SimpleHash(g)
+}
+
+// Do implements the gorgonia.Op interface
+func (g *gammaSampleOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := g.checkInputs(inputs...); err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	out := tensor.NewDense(
+		g.dt,
+		append([]int{g.numSamples}, g.shape...),
+	)
+
+	alpha := inputs[0].(tensor.Tensor)
+	rate := inputs[1].(tensor.Tensor)
+
+	// Create the distributions and sample
+	for i := 0; i < alpha.Size(); i++ {
+		coords, err := tensor.Itol(i, alpha.Shape(), alpha.Strides())
+		if err != nil {
+			return nil, fmt.Errorf("do: could not get coords at index %v", i)
+		}
+
+		currentAlpha, err := alpha.At(coords...)
+		if err != nil {
+			return nil, fmt.Errorf("do: could not get alpha at index %v", i)
+		}
+		currentRate, err := rate.At(coords...)
+		if err != nil {
+			return nil, fmt.Errorf("do: could not get rate at index %v", i)
+		}
+
+		if g.dt == tensor.Float64 {
+			g.dist.Alpha = currentAlpha.(float64)
+			g.dist.Beta = currentRate.(float64)
+		} else {
+			g.dist.Alpha = float64(currentAlpha.(float32))
+			g.dist.Beta = float64(currentRate.(float32))
+		}
+
+		if g.dist.Alpha <= 0 || g.dist.Beta <= 0 {
+			return nil, fmt.Errorf("do: expected positive alpha and rate "+
+				"but got %v and %v", g.dist.Alpha, g.dist.Beta)
+		}
+
+		outCoords := append([]int{0}, coords...)
+		for j := 0; j < g.numSamples; j++ {
+			outCoords[0] = j
+
+			if g.dt == tensor.Float64 {
+				out.SetAt(g.dist.Rand(), outCoords...)
+			} else {
+				out.SetAt(float32(g.dist.Rand()), outCoords...)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// checkInputs returns an error if inputs is an illegal input for the
+// receiver
+func (g *gammaSampleOp) checkInputs(inputs ...G.Value) error {
+	if err := gop.CheckArity(g, len(inputs)); err != nil {
+		return err
+	}
+
+	alpha := inputs[0].(tensor.Tensor)
+	if alpha == nil {
+		return fmt.Errorf("cannot sample from nil alpha")
+	} else if alpha.Size() == 0 {
+		return fmt.Errorf("cannot sample from empty alpha tensor")
+	} else if !alpha.Shape().Eq(g.shape) {
+		return fmt.Errorf("expected alpha to have shape %v but got %v",
+			g.shape, alpha.Shape())
+	} else if !alpha.Dtype().Eq(g.dt) {
+		return fmt.Errorf("expected alpha to have dtype %v but got %v",
+			g.dt, alpha.Dtype())
+	}
+
+	rate := inputs[1].(tensor.Tensor)
+	if rate == nil {
+		return fmt.Errorf("cannot sample from nil rate")
+	} else if rate.Size() == 0 {
+		return fmt.Errorf("cannot sample from empty rate tensor")
+	} else if !rate.Shape().Eq(g.shape) {
+		return fmt.Errorf("expected rate to have shape %v but got %v",
+			g.shape, rate.Shape())
+	} else if !rate.Dtype().Eq(g.dt) {
+		return fmt.Errorf("expected rate to have dtype %v but got %v",
+			g.dt, rate.Dtype())
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+package distribution
+
+import (
+	"fmt"
+	"hash"
+	"math"
+
+	"github.com/chewxy/hm"
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// logRootTwoPi is log(√(2π))
+var logRootTwoPi = math.Log(math.Sqrt(2.0 * math.Pi))
+
+// normalLogProbOp is an operation that computes the log density of a
+// normal distribution in a single pass. The inputs to the op are the
+// mean, standard deviation, and the input x, in that order. The mean
+// and standard deviation have the shape of the distribution, and x
+// has either the same shape or an additional leading batch dimension.
+// The output of the op has the same shape as x.
+type normalLogProbOp struct {
+	dt     tensor.Dtype
+	shape  tensor.Shape
+	xShape tensor.Shape
+}
+
+// newNormalLogProbOp returns a new normalLogProbOp for a distribution
+// of shape shape and input of shape xShape
+func newNormalLogProbOp(dt tensor.Dtype, shape,
+	xShape tensor.Shape) (*normalLogProbOp, error) {
+	if dt != tensor.Float64 && dt != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newNormalLogProbOp: dtype %v not "+
+			"supported", dt)
+	}
+
+	if !xShape.Eq(shape) && !isBatchShape(xShape, shape) {
+		return nil, gop.ShapeErrorf("newNormalLogProbOp: expected x to "+
+			"have shape %v with an optional batch dimension but got %v",
+			shape, xShape)
+	}
+
+	return &normalLogProbOp{
+		dt:     dt,
+		shape:  shape.Clone(),
+		xShape: xShape.Clone(),
+	}, nil
+}
+
+// Arity implements the gorgonia.Op interface
+func (n *normalLogProbOp) Arity() int { return 3 }
+
+// DiffWRT implements the gorgonia.SDOp interface
+func (n *normalLogProbOp) DiffWRT(inputs int) []bool {
+	return []bool{true, true, true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (n *normalLogProbOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	if err := gop.CheckArity(n, len(inputs)); err != nil {
+		return nil, fmt.Errorf("symDiff: %w", err)
+	}
+
+	nodes := make(G.Nodes, len(inputs))
+	for i := range inputs {
+		diffOp := &normalLogProbDiffOp{n, i}
+
+		var err error
+		nodes[i], err = G.ApplyOp(diffOp, inputs[0], inputs[1], inputs[2],
+			grad)
+		if err != nil {
+			return nil, fmt.Errorf("symDiff: %w", err)
+		}
+	}
+
+	return nodes, nil
+}
+
+// Type implements the gorgonia.Op interface
+func (n *normalLogProbOp) Type() hm.Type {
+	param := G.TensorType{Dims: n.shape.Dims(), Of: n.dt}
+	x := G.TensorType{Dims: n.xShape.Dims(), Of: n.dt}
+
+	return hm.NewFnType(param, param, x, x)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (n *normalLogProbOp) InferShape(...G.DimSizer) (tensor.Shape, error) {
+	return n.xShape.Clone(), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (n *normalLogProbOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (n *normalLogProbOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (n *normalLogProbOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (n *normalLogProbOp) String() string {
+	return fmt.Sprintf("NormalLogProb{shape=%v, x=%v}()", n.shape, n.xShape)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (n *normalLogProbOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, n.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (n *normalLogProbOp) Hashcode() uint32 {
+	return gop.SimpleHash(n)
+}
+
+// Do implements the gorgonia.Op interface
+func (n *normalLogProbOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := n.checkInputs(inputs...); err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	mean := float64Data(inputs[0])
+	stddev := float64Data(inputs[1])
+	x := float64Data(inputs[2])
+
+	out := make([]float64, len(x))
+	for i := range x {
+		j := i % len(mean)
+		z := (x[i] - mean[j]) / stddev[j]
+		out[i] = -0.5*z*z - math.Log(stddev[j]) - logRootTwoPi
+	}
+
+	return newDense(n.dt, n.xShape, out), nil
+}
+
+// checkInputs returns an error if inputs is an illegal input for the
+// receiver
+func (n *normalLogProbOp) checkInputs(inputs ...G.Value) error {
+	if err := gop.CheckArity(n, len(inputs)); err != nil {
+		return err
+	}
+
+	names := []string{"mean", "stddev", "x"}
+	shapes := []tensor.Shape{n.shape, n.shape, n.xShape}
+	for i, input := range inputs[:3] {
+		t, ok := input.(tensor.Tensor)
+		if !ok || t == nil {
+			return fmt.Errorf("expected %v to be a tensor but got %T",
+				names[i], input)
+		} else if !t.Shape().Eq(shapes[i]) {
+			return fmt.Errorf("expected %v to have shape %v but got %v",
+				names[i], shapes[i], t.Shape())
+		} else if !t.Dtype().Eq(n.dt) {
+			return fmt.Errorf("expected %v to have dtype %v but got %v",
+				names[i], n.dt, t.Dtype())
+		}
+	}
+
+	return nil
+}
+
+// normalLogProbDiffOp is the derivative of the normalLogProbOp with
+// respect to its input at index wrt. The inputs to the op are the
+// inputs of the normalLogProbOp followed by the gradient of its
+// output. If x holds a batch, then the derivatives with respect to the
+// mean and standard deviation are summed over the batch.
+type normalLogProbDiffOp struct {
+	op  *normalLogProbOp
+	wrt int
+}
+
+// Arity implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) Arity() int { return 4 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) OverwritesInput() int { return -1 }
+
+// Type implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) Type() hm.Type {
+	param := G.TensorType{Dims: n.op.shape.Dims(), Of: n.op.dt}
+	x := G.TensorType{Dims: n.op.xShape.Dims(), Of: n.op.dt}
+
+	if n.wrt == 2 {
+		return hm.NewFnType(param, param, x, x, x)
+	}
+	return hm.NewFnType(param, param, x, x, param)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) InferShape(...G.DimSizer) (tensor.Shape,
+	error) {
+	return n.outShape(), nil
+}
+
+// String implements the fmt.Stringer interface
+func (n *normalLogProbDiffOp) String() string {
+	return fmt.Sprintf("NormalLogProbDiff{shape=%v, x=%v, wrt=%v}()",
+		n.op.shape, n.op.xShape, n.wrt)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, n.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) Hashcode() uint32 {
+	return gop.SimpleHash(n)
+}
+
+// Do implements the gorgonia.Op interface
+func (n *normalLogProbDiffOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := gop.CheckArity(n, len(inputs)); err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	if err := n.op.checkInputs(inputs[:3]...); err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	mean := float64Data(inputs[0])
+	stddev := float64Data(inputs[1])
+	x := float64Data(inputs[2])
+	grad := float64Data(inputs[3])
+	if len(grad) != len(x) {
+		return nil, fmt.Errorf("do: expected gradient to have %v elements "+
+			"but got %v", len(x), len(grad))
+	}
+
+	out := make([]float64, n.outShape().TotalSize())
+	for i := range x {
+		j := i % len(mean)
+		z := (x[i] - mean[j]) / stddev[j]
+
+		switch n.wrt {
+		case 0:
+			// ∂/∂μ = (x - μ) / σ²
+			out[j] += grad[i] * z / stddev[j]
+		case 1:
+			// ∂/∂σ = ((x - μ)² - σ²) / σ³
+			out[j] += grad[i] * (z*z - 1) / stddev[j]
+		case 2:
+			// ∂/∂x = -(x - μ) / σ²
+			out[i] = -grad[i] * z / stddev[j]
+		}
+	}
+
+	return newDense(n.op.dt, n.outShape(), out), nil
+}
+
+// outShape returns the shape of the output of the receiver
+func (n *normalLogProbDiffOp) outShape() tensor.Shape {
+	if n.wrt == 2 {
+		return n.op.xShape.Clone()
+	}
+	return n.op.shape.Clone()
+}
+
+// isBatchShape returns whether xShape is a batch of inputs to a
+// distribution of shape shape
+func isBatchShape(xShape, shape tensor.Shape) bool {
+	return len(xShape) == len(shape)+1 && tensor.Shape(xShape[1:]).Eq(shape)
+}
+
+// float64Data returns the data of v as a []float64, converting from
+// float32 if needed
+func float64Data(v G.Value) []float64 {
+	t := v.(tensor.Tensor)
+	if view, ok := t.(tensor.View); ok && view.IsMaterializable() {
+		t = view.Materialize()
+	}
+
+	switch data := t.Data().(type) {
+	case []float64:
+		return data
+	case []float32:
+		out := make([]float64, len(data))
+		for i := range data {
+			out[i] = float64(data[i])
+		}
+		return out
+	}
+
+	return nil
+}
+
+// newDense returns a new tensor of data type dt and shape shape with
+// the values in data
+func newDense(dt tensor.Dtype, shape tensor.Shape,
+	data []float64) *tensor.Dense {
+	if dt == tensor.Float32 {
+		backing := make([]float32, len(data))
+		for i := range data {
+			backing[i] = float32(data[i])
+		}
+		return tensor.NewDense(dt, shape.Clone(), tensor.WithBacking(backing))
+	}
+
+	return tensor.NewDense(dt, shape.Clone(), tensor.WithBacking(data))
+}
@@ -24,19 +24,23 @@ type normalSampleOp struct {
 	numSamples int
 }
 
-// newNormalSampleOp returns a new normalSampleOp
-func newNormalSampleOp(dt tensor.Dtype, seed uint64, numSamples int,
+// newNormalSampleOp returns a new normalSampleOp which draws samples
+// using source
+func newNormalSampleOp(dt tensor.Dtype, source rand.Source, numSamples int,
 	shape ...int) (*normalSampleOp, error) {
 	if dt != tensor.Float64 && dt != tensor.Float32 {
-		return nil, fmt.Errorf("newGaussianSampleOp: dtype %v not supported",
-			dt)
+		return nil, gop.DtypeErrorf("newNormalSampleOp: dtype %v not "+
+			"supported", dt)
 	}
 
 	if numSamples < 1 {
-		return nil, fmt.Errorf("cannot samples %v < 1 samples", numSamples)
+		return nil, fmt.Errorf("newNormalSampleOp: cannot sample %v < 1 "+
+			"samples", numSamples)
 	}
 
-	source := rand.NewSource(seed)
+	if source == nil {
+		return nil, fmt.Errorf("newNormalSampleOp: nil source")
+	}
 
 	return &normalSampleOp{
 		dt:     dt,
@@ -54,6 +58,19 @@ func newNormalSampleOp(dt tensor.Dtype, seed uint64, numSamples int,
 // Arity implements the gorgonia.Op interface
 func (n *normalSampleOp) Arity() int { return 2 }
 
+// DiffWRT implements the gorgonia.SDOp interface. The normalSampleOp
+// is not differentiable with respect to any of its inputs, but must
+// report so in order to be used in graphs which are differentiated.
+func (n *normalSampleOp) DiffWRT(inputs int) []bool {
+	return make([]bool, inputs)
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (n *normalSampleOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	return nil, fmt.Errorf("symDiff: %v is not differentiable", n)
+}
+
 // Type implements the gorgonia.Op interface
 func (n *normalSampleOp) Type() hm.Type {
 	in := G.TensorType{
@@ -84,13 +101,16 @@ func (n *normalSampleOp) OverwritesInput() int { return -1 }
 
 // String implements the fmt.Stringer interface
 func (n *normalSampleOp) String() string {
-	return fmt.Sprintf("NormalRand{shape=%v}()", append([]int{n.numSamples},
+	return fmt.Sprintf("NormalSample{shape=%v}()", append([]int{n.numSamples},
 		n.shape...))
 }
 
-// WriteHash implements the gorgonia.Op interface
+// WriteHash implements the gorgonia.Op interface. The address of the
+// receiver is included in the hash so that Gorgonia never merges two
+// sampling nodes, which may share a source and so must draw different
+// samples.
 func (n *normalSampleOp) WriteHash(h hash.Hash) {
-	fmt.Fprint(h, n.String())
+	fmt.Fprintf(h, "%v-%p", n.String(), n)
 }
 
 // Hashcode implements the gorgonia.Op interface
@@ -101,7 +121,7 @@ func (n *normalSampleOp) Hashcode() uint32 {
 // Do implements the gorgonia.Op interface
 func (n *normalSampleOp) Do(inputs ...G.Value) (G.Value, error) {
 	if err := n.checkInputs(inputs...); err != nil {
-		return nil, fmt.Errorf("do: %v", err)
+		return nil, fmt.Errorf("do: %w", err)
 	}
 
 	out := tensor.NewDense(
@@ -128,8 +148,13 @@ func (n *normalSampleOp) Do(inputs ...G.Value) (G.Value, error) {
 			return nil, fmt.Errorf("do: could not get std at index %v", i)
 		}
 
-		n.dist.Mu = currentMean.(float64)
-		n.dist.Sigma = currentStd.(float64)
+		if n.dt == tensor.Float64 {
+			n.dist.Mu = currentMean.(float64)
+			n.dist.Sigma = currentStd.(float64)
+		} else {
+			n.dist.Mu = float64(currentMean.(float32))
+			n.dist.Sigma = float64(currentStd.(float32))
+		}
 
 		outCoords := append([]int{0}, coords...)
 		for j := 0; j < n.numSamples; j++ {
@@ -6,15 +6,18 @@ import (
 	"testing"
 	"time"
 
+	exprand "golang.org/x/exp/rand"
+
 	"github.com/samuelfneumann/gop"
+	"gonum.org/v1/gonum/stat/distuv"
 	G "gorgonia.org/gorgonia"
 	"gorgonia.org/tensor"
 )
 
-// TestNormalRand tests to ensure that the node returned by NormalRand
-// returns different sampled data on consecutive runs of the
-// computational graph
-func TestNormalRand(t *testing.T) {
+// TestNormalSample tests to ensure that the node returned by
+// NormalSample returns different sampled data on consecutive runs of
+// the computational graph
+func TestNormalSample(t *testing.T) {
 	const threshold float64 = 0.00000001 // Threshold to consider floats equal
 	const tests int = 50                 // Number of tests to run
 	const scale float64 = 2.0
@@ -97,3 +100,177 @@ func TestNormalRand(t *testing.T) {
 		vm.Close()
 	}
 }
+
+// TestNormalSampleWithSource tests that two sampling nodes sharing a
+// source draw consecutive blocks of the source's sequence. Each node
+// draws its samples in order of the elements of the mean and stddev,
+// and a single sample is drawn per element here, so that the samples
+// of one node are the first size draws of the source, and the samples
+// of the other node are the next size draws, depending on the order in
+// which the nodes are executed.
+func TestNormalSampleWithSource(t *testing.T) {
+	const threshold float64 = 0.00000001 // Threshold to consider floats equal
+	const size int = 10                  // Number of distributions
+
+	seed := uint64(time.Now().UnixNano())
+
+	// Draw the target sequence
+	target := make([]float64, 2*size)
+	dist := distuv.Normal{Mu: 0, Sigma: 1, Src: exprand.NewSource(seed)}
+	for i := range target {
+		target[i] = dist.Rand()
+	}
+
+	g := G.NewGraph()
+	meanT := tensor.NewDense(tensor.Float64, []int{size})
+	mean := G.NewVector(g, tensor.Float64, G.WithValue(meanT),
+		G.WithName("mean"))
+	stddevT := tensor.NewDense(tensor.Float64, []int{size},
+		tensor.WithBacking(ones(size)))
+	stddev := G.NewVector(g, tensor.Float64, G.WithValue(stddevT),
+		G.WithName("stddev"))
+
+	source := exprand.NewSource(seed)
+	a, err := NormalSampleWithSource(mean, stddev, source, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NormalSampleWithSource(mean, stddev, source, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aVal, bVal G.Value
+	G.Read(a, &aVal)
+	G.Read(b, &bVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	equal := func(x, y []float64) bool {
+		for i := range x {
+			if math.Abs(x[i]-y[i]) > threshold {
+				return false
+			}
+		}
+		return true
+	}
+
+	aData := aVal.Data().([]float64)
+	bData := bVal.Data().([]float64)
+	inOrder := equal(aData, target[:size]) && equal(bData, target[size:])
+	swapped := equal(bData, target[:size]) && equal(aData, target[size:])
+	if !inOrder && !swapped {
+		t.Errorf("expected nodes sharing a source to draw consecutive "+
+			"blocks of %v \nreceived: %v and %v", target, aData, bData)
+	}
+}
+
+// ones returns a slice of n ones
+func ones(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = 1.0
+	}
+	return out
+}
+
+// TestNormalSampleSeed tests that sampling nodes in two separate
+// graphs constructed with the same seed draw identical samples
+func TestNormalSampleSeed(t *testing.T) {
+	const tests int = 10        // Number of tests to run
+	const maxDims int = 3       // Maximum number of dims in mean/stddev
+	const maxDimSize int = 5    // Maximum size of each dim in mean/stddev
+	const maxBatchSize int = 10 // Maxium size of batch
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := randInt(1+rand.Intn(maxDims), 1, maxDimSize)
+		numDists := tensor.ProdInts(shape)
+		batchSize := 1 + rand.Intn(maxBatchSize)
+		seed := rand.Uint64()
+
+		meanBacking := make([]float64, numDists)
+		stddevBacking := make([]float64, numDists)
+		for r := range meanBacking {
+			meanBacking[r] = rand.Float64() - 0.5
+			stddevBacking[r] = math.Exp(rand.Float64())
+		}
+
+		// Sample from a freshly constructed graph and op
+		sample := func() []float64 {
+			g := G.NewGraph()
+			meanT := tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(meanBacking))
+			mean := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithValue(meanT), G.WithName("mean"))
+			stddevT := tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(stddevBacking))
+			stddev := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithValue(stddevT), G.WithName("stddev"))
+
+			s, err := NormalSample(mean, stddev, seed, batchSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sampled G.Value
+			G.Read(s, &sampled)
+
+			vm := G.NewTapeMachine(g)
+			defer vm.Close()
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+
+			return sampled.Data().([]float64)
+		}
+
+		first := sample()
+		second := sample()
+		for j := range first {
+			if first[j] != second[j] {
+				t.Errorf("expected identical samples with seed %v at "+
+					"index %v but got %v and %v", seed, j, first[j],
+					second[j])
+			}
+		}
+	}
+}
+
+// TestNormalSampleNumSamples tests that requesting fewer than one
+// sample returns an error rather than panicking
+func TestNormalSampleNumSamples(t *testing.T) {
+	g := G.NewGraph()
+	mean := G.NewVector(g, tensor.Float64, G.WithShape(3),
+		G.WithName("mean"), G.WithInit(G.Zeroes()))
+	stddev := G.NewVector(g, tensor.Float64, G.WithShape(3),
+		G.WithName("stddev"), G.WithInit(G.Ones()))
+
+	n, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, numSamples := range []int{0, -1} {
+		if _, err := NormalSample(mean, stddev, 0, numSamples); err == nil {
+			t.Errorf("NormalSample: expected error for %v samples",
+				numSamples)
+		}
+
+		source := exprand.NewSource(0)
+		_, err := NormalSampleWithSource(mean, stddev, source, numSamples)
+		if err == nil {
+			t.Errorf("NormalSampleWithSource: expected error for %v samples",
+				numSamples)
+		}
+
+		if _, err := n.Sample(numSamples); err == nil {
+			t.Errorf("Sample: expected error for %v samples", numSamples)
+		}
+		if _, err := n.Rsample(numSamples); err == nil {
+			t.Errorf("Rsample: expected error for %v samples", numSamples)
+		}
+	}
+}
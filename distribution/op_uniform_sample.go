@@ -0,0 +1,201 @@
+package distribution
+
+import (
+	"fmt"
+	"hash"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/chewxy/hm"
+	"github.com/samuelfneumann/gop"
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// uniformSampleOp is an operation that samples from a uniform
+// distribution whenever the node is passed through. The
+// uniformSampleOp is not differentiable.
+type uniformSampleOp struct {
+	dt         tensor.Dtype
+	shape      tensor.Shape
+	dist       distuv.Uniform
+	source     rand.Source
+	numSamples int
+}
+
+// newUniformSampleOp returns a new uniformSampleOp
+func newUniformSampleOp(dt tensor.Dtype, seed uint64, numSamples int,
+	shape ...int) (*uniformSampleOp, error) {
+	if dt != tensor.Float64 && dt != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newUniformSampleOp: dtype %v not "+
+			"supported", dt)
+	}
+
+	if numSamples < 1 {
+		return nil, fmt.Errorf("cannot samples %v < 1 samples", numSamples)
+	}
+
+	source := rand.NewSource(seed)
+
+	return &uniformSampleOp{
+		dt:     dt,
+		shape:  tensor.Shape(shape),
+		source: source,
+		dist: distuv.Uniform{
+			Min: 0.0,
+			Max: 1.0,
+			Src: source,
+		},
+		numSamples: numSamples,
+	}, nil
+}
+
+// Arity implements the gorgonia.Op interface
+func (u *uniformSampleOp) Arity() int { return 2 }
+
+// DiffWRT implements the gorgonia.SDOp interface. The uniformSampleOp
+// is not differentiable with respect to any of its inputs, but must
+// report so in order to be used in graphs which are differentiated.
+func (u *uniformSampleOp) DiffWRT(inputs int) []bool {
+	return make([]bool, inputs)
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (u *uniformSampleOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	return nil, fmt.Errorf("symDiff: %v is not differentiable", u)
+}
+
+// Type implements the gorgonia.Op interface
+func (u *uniformSampleOp) Type() hm.Type {
+	in := G.TensorType{
+		Dims: u.shape.Dims(),
+		Of:   u.dt,
+	}
+	out := G.TensorType{
+		Dims: u.shape.Dims() + 1,
+		Of:   u.dt,
+	}
+
+	return hm.NewFnType(in, in, out)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (u *uniformSampleOp) InferShape(...G.DimSizer) (tensor.Shape, error) {
+	return append([]int{u.numSamples}, u.shape...), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (u *uniformSampleOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (u *uniformSampleOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (u *uniformSampleOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (u *uniformSampleOp) String() string {
+	return fmt.Sprintf("UniformSample{shape=%v}()",
+		append([]int{u.numSamples}, u.shape...))
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (u *uniformSampleOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, u.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (u *uniformSampleOp) Hashcode() uint32 {
+	return gop.SimpleHash(u)
+}
+
+// Do implements the gorgonia.Op interface
+func (u *uniformSampleOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := u.checkInputs(inputs...); err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	out := tensor.NewDense(
+		u.dt,
+		append([]int{u.numSamples}, u.shape...),
+	)
+
+	low := inputs[0].(tensor.Tensor)
+	high := inputs[1].(tensor.Tensor)
+
+	// Create the distributions and sample
+	for i := 0; i < low.Size(); i++ {
+		coords, err := tensor.Itol(i, low.Shape(), low.Strides())
+		if err != nil {
+			return nil, fmt.Errorf("do: could not get coords at index %v", i)
+		}
+
+		currentLow, err := low.At(coords...)
+		if err != nil {
+			return nil, fmt.Errorf("do: could not get low at index %v", i)
+		}
+		currentHigh, err := high.At(coords...)
+		if err != nil {
+			return nil, fmt.Errorf("do: could not get high at index %v", i)
+		}
+
+		if u.dt == tensor.Float64 {
+			u.dist.Min = currentLow.(float64)
+			u.dist.Max = currentHigh.(float64)
+		} else {
+			u.dist.Min = float64(currentLow.(float32))
+			u.dist.Max = float64(currentHigh.(float32))
+		}
+
+		outCoords := append([]int{0}, coords...)
+		for j := 0; j < u.numSamples; j++ {
+			outCoords[0] = j
+
+			if u.dt == tensor.Float64 {
+				out.SetAt(u.dist.Rand(), outCoords...)
+			} else {
+				out.SetAt(float32(u.dist.Rand()), outCoords...)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// checkInputs returns an error if inputs is an illegal input for the
+// receiver
+func (u *uniformSampleOp) checkInputs(inputs ...G.Value) error {
+	if err := gop.CheckArity(u, len(inputs)); err != nil {
+		return err
+	}
+
+	low := inputs[0].(tensor.Tensor)
+	if low == nil {
+		return fmt.Errorf("cannot sample from nil low")
+	} else if low.Size() == 0 {
+		return fmt.Errorf("cannot sample from empty low tensor")
+	} else if !low.Shape().Eq(u.shape) {
+		return fmt.Errorf("expected low to have shape %v but got %v",
+			u.shape, low.Shape())
+	} else if !low.Dtype().Eq(u.dt) {
+		return fmt.Errorf("expected low to have dtype %v but got %v",
+			u.dt, low.Dtype())
+	}
+
+	high := inputs[1].(tensor.Tensor)
+	if high == nil {
+		return fmt.Errorf("cannot sample from nil high")
+	} else if high.Size() == 0 {
+		return fmt.Errorf("cannot sample from empty high tensor")
+	} else if !high.Shape().Eq(u.shape) {
+		return fmt.Errorf("expected high to have shape %v but got %v",
+			u.shape, high.Shape())
+	} else if !high.Dtype().Eq(u.dt) {
+		return fmt.Errorf("expected high to have dtype %v but got %v",
+			u.dt, high.Dtype())
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+)
+
+// Transform is an invertible, differentiable element-wise mapping
+// y = f(x) which may be used to transform the samples of a base
+// distribution. The density of the transformed samples is then
+//
+//		log(p_Y(y)) = log(p_X(x)) - log|dy/dx|
+//
+// where x = f⁻¹(y).
+type Transform interface {
+	// Forward computes y = f(x)
+	Forward(x *G.Node) (*G.Node, error)
+
+	// Inverse computes x = f⁻¹(y)
+	Inverse(y *G.Node) (*G.Node, error)
+
+	// LogAbsDetJacobian computes the element-wise log|dy/dx| at x
+	LogAbsDetJacobian(x *G.Node) (*G.Node, error)
+}
+
+// SigmoidTransform is a Transform which maps the real line onto (0, 1)
+// using the sigmoid function, y = 1 / (1 + exp(-x)).
+type SigmoidTransform struct{}
+
+// NewSigmoidTransform returns a new SigmoidTransform
+func NewSigmoidTransform() *SigmoidTransform {
+	return &SigmoidTransform{}
+}
+
+// Forward computes the sigmoid of x
+func (s *SigmoidTransform) Forward(x *G.Node) (*G.Node, error) {
+	y, err := G.Sigmoid(x)
+	if err != nil {
+		return nil, fmt.Errorf("forward: %w", err)
+	}
+	return y, nil
+}
+
+// Inverse computes the logit of y, log(y) - log(1 - y)
+func (s *SigmoidTransform) Inverse(y *G.Node) (*G.Node, error) {
+	logY, err := G.Log(y)
+	if err != nil {
+		return nil, fmt.Errorf("inverse: %w", err)
+	}
+
+	log1mY, err := gop.Log1p(G.Must(G.Neg(y)))
+	if err != nil {
+		return nil, fmt.Errorf("inverse: %w", err)
+	}
+
+	return G.Sub(logY, log1mY)
+}
+
+// LogAbsDetJacobian computes the log of the derivative of the sigmoid
+// at x, -softplus(-x) - softplus(x)
+func (s *SigmoidTransform) LogAbsDetJacobian(x *G.Node) (*G.Node, error) {
+	softplusNegX, err := gop.Softplus(G.Must(G.Neg(x)))
+	if err != nil {
+		return nil, fmt.Errorf("logAbsDetJacobian: %w", err)
+	}
+
+	softplusX, err := gop.Softplus(x)
+	if err != nil {
+		return nil, fmt.Errorf("logAbsDetJacobian: %w", err)
+	}
+
+	return G.Neg(G.Must(G.Add(softplusNegX, softplusX)))
+}
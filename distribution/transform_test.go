@@ -0,0 +1,96 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestSigmoidTransform tests that the SigmoidTransform is invertible,
+// that its log-determinant of the Jacobian is correct, and that a
+// standard Normal pushed through the transform has a density which
+// integrates to one on (0, 1), using a Monte-Carlo estimate.
+func TestSigmoidTransform(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const mcThreshold float64 = 0.02   // Threshold for Monte-Carlo estimate
+	const samples int = 100000         // Number of Monte-Carlo samples
+	rand.Seed(time.Now().UnixNano())
+
+	// Uniform samples on (0, 1) in which to evaluate the density
+	backing := make([]float64, samples)
+	for i := range backing {
+		backing[i] = (float64(i) + rand.Float64()) / float64(samples)
+	}
+
+	g := G.NewGraph()
+	yT := tensor.NewDense(tensor.Float64, []int{samples},
+		tensor.WithBacking(backing))
+	y := G.NewVector(g, tensor.Float64, G.WithValue(yT), G.WithName("y"))
+
+	mean := G.NewScalar(g, tensor.Float64, G.WithValue(0.0),
+		G.WithName("mean"))
+	stddev := G.NewScalar(g, tensor.Float64, G.WithValue(1.0),
+		G.WithName("stddev"))
+	normal, err := NewNormal(mean, stddev, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transform := NewSigmoidTransform()
+	x, err := transform.Inverse(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := transform.Forward(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logDet, err := transform.LogAbsDetJacobian(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logProb, err := normal.LogProb(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logProb = G.Must(G.Sub(logProb, logDet))
+	integral := G.Must(G.Mean(G.Must(G.Exp(logProb))))
+
+	var roundTripVal, logDetVal, integralVal G.Value
+	G.Read(roundTrip, &roundTripVal)
+	G.Read(logDet, &logDetVal)
+	G.Read(integral, &integralVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	roundTripData := roundTripVal.Data().([]float64)
+	logDetData := logDetVal.Data().([]float64)
+	for i := range backing {
+		if math.Abs(roundTripData[i]-backing[i]) > threshold {
+			t.Errorf("forward(inverse(y)): expected: %v received: %v",
+				backing[i], roundTripData[i])
+		}
+
+		logit := math.Log(backing[i] / (1 - backing[i]))
+		sigmoid := 1 / (1 + math.Exp(-logit))
+		target := math.Log(sigmoid * (1 - sigmoid))
+		if math.Abs(logDetData[i]-target) > threshold {
+			t.Errorf("logAbsDetJacobian: expected: %v received: %v",
+				target, logDetData[i])
+		}
+	}
+
+	if math.Abs(integralVal.Data().(float64)-1.0) > mcThreshold {
+		t.Errorf("expected density to integrate to 1 but got %v",
+			integralVal.Data())
+	}
+}
@@ -0,0 +1,488 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TruncatedNormal is a univariate normal distribution truncated to the
+// interval [low, high], which may hold a batch of truncated normal
+// distributions simultaneously. The mean, standard deviation, low, and
+// high tensors are treated element-wise in the same way as the mean
+// and standard deviation tensors of the Normal, and so any input to
+// any method of the TruncatedNormal must have a shape consistent with
+// that of the TruncatedNormal, as described in the documentation for
+// Normal. Note that the mean and standard deviation are those of the
+// underlying Normal, rather than those of the TruncatedNormal.
+//
+// Samples are drawn through the inverse CDF of the underlying Normal
+// between Cdf(low) and Cdf(high), so that no rejection sampling is
+// needed. The bounds low and high must be finite. Since the
+// TruncatedNormal is computed from the Cdf of the underlying Normal,
+// it loses precision when [low, high] lies many standard deviations
+// from the mean, where the Cdf saturates at 0 or 1.
+type TruncatedNormal struct {
+	normal *Normal
+	low    *G.Node
+	high   *G.Node
+
+	seed uint64
+}
+
+var _ Quantiler = (*TruncatedNormal)(nil)
+
+// NewTruncatedNormal returns a new TruncatedNormal, which is a Normal
+// with mean mean and standard deviation stddev truncated to the
+// interval [low, high]. If low and high have values when the
+// TruncatedNormal is constructed, then an error is returned if any
+// element of low is not less than the corresponding element of high.
+func NewTruncatedNormal(mean, stddev, low, high *G.Node,
+	seed uint64) (*TruncatedNormal, error) {
+	if !low.Shape().Eq(high.Shape()) || !low.Shape().Eq(mean.Shape()) {
+		return nil, gop.ShapeErrorf("newTruncatedNormal: expected low and "+
+			"high to have the same shape as mean %v but got %v and %v",
+			mean.Shape(), low.Shape(), high.Shape())
+	}
+	if low.Dtype() != high.Dtype() || low.Dtype() != mean.Dtype() {
+		return nil, gop.DtypeErrorf("newTruncatedNormal: expected low and "+
+			"high to have the same data type as mean %v but got %v and %v",
+			mean.Dtype(), low.Dtype(), high.Dtype())
+	}
+
+	normal, err := NewNormal(mean, stddev, seed)
+	if err != nil {
+		return nil, fmt.Errorf("newTruncatedNormal: %w", err)
+	}
+
+	if err := checkLessThan(low.Value(), high.Value()); err != nil {
+		return nil, fmt.Errorf("newTruncatedNormal: %w", err)
+	}
+
+	if low.IsScalar() {
+		low, err = G.Reshape(low, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newTruncatedNormal: could not expand "+
+				"low to shape (1): %w", err)
+		}
+		high, err = G.Reshape(high, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newTruncatedNormal: could not expand "+
+				"high to shape (1): %w", err)
+		}
+	}
+
+	return &TruncatedNormal{
+		normal: normal,
+		low:    low,
+		high:   high,
+		seed:   seed,
+	}, nil
+}
+
+// Prob calculates the probability density of x, which is 0 outside of
+// [low, high]. The shape of x is treated in the same way as the
+// Normal's Prob() method.
+func (t *TruncatedNormal) Prob(x *G.Node) (*G.Node, error) {
+	logProb, err := t.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("prob: %w", err)
+	}
+
+	return G.Exp(logProb)
+}
+
+// LogProb calculates the log probability density of x, which is -Inf
+// outside of [low, high]. The log density is that of the underlying
+// Normal less log(Cdf(high) - Cdf(low)). The shape of x is treated in
+// the same way as the Normal's Prob() method.
+func (t *TruncatedNormal) LogProb(x *G.Node) (*G.Node, error) {
+	x, err := fixShape(x, t.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	logProb, err := t.normal.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	z, err := t.normalizer()
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+	lnZ := G.Must(G.Log(z))
+
+	if isBatch(x, t.Shape()) {
+		logProb = G.Must(G.BroadcastSub(logProb, lnZ, nil, []byte{0}))
+	} else {
+		logProb = G.Must(G.Sub(logProb, lnZ))
+	}
+
+	inSupport, err := unitIntervalMask(G.Must(t.standardize(x)))
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	logProb, err = maskSupport(logProb, inSupport)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, which always lies within [low, high]. The returned node
+// has shape (1, t.Shape()...).
+func (t *TruncatedNormal) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(t)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// Cdf computes the cumulative distribution function of x, which is 0
+// below low and 1 above high. The shape of x is treated in the same
+// way as the Normal's Prob() method.
+func (t *TruncatedNormal) Cdf(x *G.Node) (*G.Node, error) {
+	x, err := fixShape(x, t.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	cdf, err := t.normal.Cdf(x)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	cdfLow, err := t.boundCdf(t.low)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+	z, err := t.normalizer()
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	// cdf(x) = (Φ(x) - Φ(low)) / (Φ(high) - Φ(low)), clamped to [0, 1]
+	if isBatch(x, t.Shape()) {
+		batchDim := []byte{0}
+		cdf = G.Must(G.BroadcastSub(cdf, cdfLow, nil, batchDim))
+		cdf = G.Must(G.BroadcastHadamardDiv(cdf, z, nil, batchDim))
+	} else {
+		cdf = G.Must(G.Sub(cdf, cdfLow))
+		cdf = G.Must(G.HadamardDiv(cdf, z))
+	}
+
+	var min, max interface{} = 0.0, 1.0
+	if t.Dtype() == tensor.Float32 {
+		min, max = float32(0.0), float32(1.0)
+	}
+
+	return gop.Clamp(cdf, min, max, false)
+}
+
+// Sf computes the survival function, 1 - Cdf(x), of x. The shape of x
+// is treated in the same way as the Normal's Prob() method.
+func (t *TruncatedNormal) Sf(x *G.Node) (*G.Node, error) {
+	cdf, err := t.Cdf(x)
+	if err != nil {
+		return nil, fmt.Errorf("sf: %w", err)
+	}
+
+	one := constant(cdf.Graph(), t.Dtype(), 1.0)
+	return G.Sub(one, cdf)
+}
+
+// Quantile computes the inverse cumulative distribution function at
+// probability p, which is the quantile of the underlying Normal at
+// Cdf(low) + p(Cdf(high) - Cdf(low)). The shape of p is treated in the
+// same way as the Normal's Prob() method.
+func (t *TruncatedNormal) Quantile(p *G.Node) (*G.Node, error) {
+	p, err := fixShape(p, t.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	p, err = validateProbs(p)
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	q, err := t.quantile(p)
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	return q, nil
+}
+
+// Shape returns the number of distributions stored by the receiver
+func (t *TruncatedNormal) Shape() tensor.Shape {
+	return t.normal.Shape()
+}
+
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (t *TruncatedNormal) NumDistributions() int {
+	return tensor.ProdInts(t.Shape())
+}
+
+// Low returns the lower bound of the support of the receiver
+func (t *TruncatedNormal) Low() *G.Node { return t.low }
+
+// High returns the upper bound of the support of the receiver
+func (t *TruncatedNormal) High() *G.Node { return t.high }
+
+// Mean returns the mean of the distribution(s) stored by the receiver,
+// μ + σ(φ(α) - φ(β)) / Z, where α and β are the standardized bounds,
+// φ is the standard normal density, and Z = Φ(β) - Φ(α)
+func (t *TruncatedNormal) Mean() *G.Node {
+	_, _, phiAlpha, phiBeta, z := t.standardBounds()
+	mean := G.Must(G.Sub(phiAlpha, phiBeta))
+	mean = G.Must(G.HadamardDiv(mean, z))
+	mean = G.Must(G.HadamardProd(mean, t.normal.StdDev()))
+
+	return G.Must(G.Add(t.normal.Mean(), mean))
+}
+
+// Variance returns the variance of the distribution(s) stored by the
+// receiver, σ²[1 + (αφ(α) - βφ(β)) / Z - ((φ(α) - φ(β)) / Z)²]
+func (t *TruncatedNormal) Variance() *G.Node {
+	alpha, beta, phiAlpha, phiBeta, z := t.standardBounds()
+	one := constant(z.Graph(), t.Dtype(), 1.0)
+
+	moment := G.Must(G.Sub(
+		G.Must(G.HadamardProd(alpha, phiAlpha)),
+		G.Must(G.HadamardProd(beta, phiBeta)),
+	))
+	moment = G.Must(G.HadamardDiv(moment, z))
+
+	shift := G.Must(G.Sub(phiAlpha, phiBeta))
+	shift = G.Must(G.Square(G.Must(G.HadamardDiv(shift, z))))
+
+	variance := G.Must(G.Sub(G.Must(G.Add(one, moment)), shift))
+	return G.Must(G.HadamardProd(variance, t.normal.Variance()))
+}
+
+// StdDev returns the standard deviation of the distribution(s) stored
+// by the receiver
+func (t *TruncatedNormal) StdDev() *G.Node {
+	return G.Must(G.Sqrt(t.Variance()))
+}
+
+// Entropy returns the entropy of the distribution(s) stored by the
+// receiver, log(√(2πe)σZ) + (αφ(α) - βφ(β)) / 2Z
+func (t *TruncatedNormal) Entropy() (*G.Node, error) {
+	alpha, beta, phiAlpha, phiBeta, z := t.standardBounds()
+
+	c := constant(z.Graph(), t.Dtype(), 0.5*math.Log(2*math.Pi*math.E))
+	two := constant(z.Graph(), t.Dtype(), 2.0)
+
+	entropy := G.Must(G.HadamardProd(t.normal.StdDev(), z))
+	entropy = G.Must(G.Add(G.Must(G.Log(entropy)), c))
+
+	moment := G.Must(G.Sub(
+		G.Must(G.HadamardProd(alpha, phiAlpha)),
+		G.Must(G.HadamardProd(beta, phiBeta)),
+	))
+	moment = G.Must(G.HadamardDiv(moment, G.Must(G.HadamardProd(two, z))))
+
+	return G.Add(entropy, moment)
+}
+
+// HasRsample returns whether the receiver supports reparameterized
+// sample -- true for the TruncatedNormal.
+func (t *TruncatedNormal) HasRsample() bool { return true }
+
+// Dtype returns the type that the receiver operates on
+func (t *TruncatedNormal) Dtype() tensor.Dtype { return t.normal.Dtype() }
+
+// Rsample samples m samples from the receiver using reparameterized
+// sampling through the inverse CDF, without rejection. The returned
+// node has shape (m, t.Shape()...), even when m == 1, which is
+// consistent with the Normal. This is a differentiable operation.
+func (t *TruncatedNormal) Rsample(m int) (*G.Node, error) {
+	graph := t.low.Graph()
+	zeroLow := full(graph, t.Dtype(), t.Shape(), 0.0, "zeroLow")
+	unitHigh := full(graph, t.Dtype(), t.Shape(), 1.0, "unitHigh")
+
+	u, err := UniformSample(zeroLow, unitHigh, t.seed, m)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: could not sample from "+
+			"standard uniform: %w", err)
+	}
+
+	out, err := t.quantile(u)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	return out, nil
+}
+
+// Sample samples m samples from the receiver. The samples are drawn
+// through the inverse CDF as in Rsample, but the gradient is stopped
+// at the samples, so that the parameters of the receiver receive no
+// gradient through them. This operation is not differentiable.
+func (t *TruncatedNormal) Sample(m int) (*G.Node, error) {
+	samples, err := t.Rsample(m)
+	if err != nil {
+		return nil, fmt.Errorf("sample: %w", err)
+	}
+
+	return gop.StopGradient(samples)
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., t.Shape()...). This operation is not
+// differentiable.
+func (t *TruncatedNormal) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(t.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// normalizer returns Cdf(high) - Cdf(low) of the underlying Normal,
+// the probability mass of the underlying Normal within [low, high]
+func (t *TruncatedNormal) normalizer() (*G.Node, error) {
+	cdfLow, err := t.boundCdf(t.low)
+	if err != nil {
+		return nil, err
+	}
+	cdfHigh, err := t.boundCdf(t.high)
+	if err != nil {
+		return nil, err
+	}
+
+	return G.Sub(cdfHigh, cdfLow)
+}
+
+// boundCdf returns the Cdf of the underlying Normal at bound, which
+// has the same shape as the receiver. The Cdf is computed directly
+// rather than with the Normal's Cdf() method, since a bound of shape
+// (1) would otherwise be treated as a batch of scalars.
+func (t *TruncatedNormal) boundCdf(bound *G.Node) (*G.Node, error) {
+	rootTwo := constant(bound.Graph(), t.Dtype(), math.Sqrt(2.0))
+	one := constant(bound.Graph(), t.Dtype(), 1.0)
+	half := constant(bound.Graph(), t.Dtype(), 0.5)
+
+	// Φ(x) = 0.5(1 + erf((x - μ) / σ√2))
+	x := G.Must(G.Sub(bound, t.normal.Mean()))
+	x = G.Must(G.HadamardDiv(x, rootTwo))
+	x = G.Must(G.HadamardDiv(x, t.normal.StdDev()))
+	x = G.Must(gop.Erf(x))
+	x = G.Must(G.Add(one, x))
+
+	return G.HadamardProd(half, x)
+}
+
+// quantile returns the quantile of the underlying Normal at
+// Cdf(low) + p(Cdf(high) - Cdf(low)), without validating p. The shape
+// of p must already have been fixed.
+func (t *TruncatedNormal) quantile(p *G.Node) (*G.Node, error) {
+	cdfLow, err := t.boundCdf(t.low)
+	if err != nil {
+		return nil, err
+	}
+	z, err := t.normalizer()
+	if err != nil {
+		return nil, err
+	}
+
+	if isBatch(p, t.Shape()) {
+		batchDim := []byte{0}
+		p = G.Must(G.BroadcastHadamardProd(p, z, nil, batchDim))
+		p = G.Must(G.BroadcastAdd(p, cdfLow, nil, batchDim))
+	} else {
+		p = G.Must(G.HadamardProd(p, z))
+		p = G.Must(G.Add(p, cdfLow))
+	}
+
+	return t.normal.Quantile(p)
+}
+
+// standardize returns (x - low) / (high - low), which lies in [0, 1]
+// on the support of the receiver. The shape of x must already have
+// been fixed.
+func (t *TruncatedNormal) standardize(x *G.Node) (*G.Node, error) {
+	width := G.Must(G.Sub(t.high, t.low))
+
+	if isBatch(x, t.Shape()) {
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, t.low, nil, batchDim))
+		return G.BroadcastHadamardDiv(x, width, nil, batchDim)
+	}
+
+	x = G.Must(G.Sub(x, t.low))
+	return G.HadamardDiv(x, width)
+}
+
+// standardBounds returns the standardized bounds α = (low - μ) / σ and
+// β = (high - μ) / σ, the standard normal density at each, φ(α) and
+// φ(β), and the normalizer Z = Φ(β) - Φ(α)
+func (t *TruncatedNormal) standardBounds() (alpha, beta, phiAlpha,
+	phiBeta, z *G.Node) {
+	mean, stddev := t.normal.Mean(), t.normal.StdDev()
+	alpha = G.Must(G.HadamardDiv(G.Must(G.Sub(t.low, mean)), stddev))
+	beta = G.Must(G.HadamardDiv(G.Must(G.Sub(t.high, mean)), stddev))
+
+	z = G.Must(t.normalizer())
+
+	// φ(x) = exp(-x²/2) / √(2π)
+	negHalf := constant(z.Graph(), t.Dtype(), -0.5)
+	invRootTwoPi := constant(z.Graph(), t.Dtype(), 1/math.Sqrt(2*math.Pi))
+	phi := func(x *G.Node) *G.Node {
+		x = G.Must(G.HadamardProd(G.Must(G.Square(x)), negHalf))
+		return G.Must(G.HadamardProd(G.Must(G.Exp(x)), invRootTwoPi))
+	}
+
+	return alpha, beta, phi(alpha), phi(beta), z
+}
+
+// checkLessThan returns an error if any element of low is not less
+// than the corresponding element of high. If either of low or high is
+// nil, as is the case for nodes which have not yet been given a value,
+// then no error is returned.
+func checkLessThan(low, high G.Value) error {
+	if low == nil || high == nil {
+		return nil
+	}
+
+	var lowData, highData []float64
+	switch l := low.Data().(type) {
+	case float64:
+		lowData, highData = []float64{l}, []float64{high.Data().(float64)}
+	case float32:
+		lowData = []float64{float64(l)}
+		highData = []float64{float64(high.Data().(float32))}
+	case []float64:
+		lowData, highData = l, high.Data().([]float64)
+	case []float32:
+		h := high.Data().([]float32)
+		lowData, highData = make([]float64, len(l)), make([]float64, len(h))
+		for i := range l {
+			lowData[i], highData[i] = float64(l[i]), float64(h[i])
+		}
+	default:
+		return fmt.Errorf("data type %v unsupported", low.Dtype())
+	}
+
+	for i := range lowData {
+		if !(lowData[i] < highData[i]) {
+			return fmt.Errorf("expected low < high but got low = %v and "+
+				"high = %v at index %v", lowData[i], highData[i], i)
+		}
+	}
+
+	return nil
+}
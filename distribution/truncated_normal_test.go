@@ -0,0 +1,324 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// drawTruncatedNormalParams draws the mean, stddev, low, and high of a
+// random TruncatedNormal
+func drawTruncatedNormalParams(x []float64) {
+	const scale float64 = 2.0
+	const stdOffset float64 = 0.1
+	const widthOffset float64 = 0.01
+
+	x[0] = (rand.Float64() - 0.5) * scale
+	x[1] = rand.Float64()*scale + stdOffset
+
+	// Keep the bounds within a few standard deviations of the mean,
+	// where the Cdf of the underlying Normal is not saturated
+	x[2] = x[0] + (rand.Float64()-0.5)*4*x[1]
+	x[3] = x[2] + (rand.Float64()+widthOffset)*2*x[1]
+}
+
+// truncatedNormalLogProb returns the log density of x under a Normal
+// with mean mean and standard deviation stddev truncated to
+// [low, high]
+func truncatedNormalLogProb(x, mean, stddev, low, high float64) float64 {
+	if x < low || x > high {
+		return math.Inf(-1)
+	}
+	normal := distuv.Normal{Mu: mean, Sigma: stddev}
+	return normal.LogProb(x) - math.Log(normal.CDF(high)-normal.CDF(low))
+}
+
+// TestTruncatedNormalLogProb tests the LogProb of the TruncatedNormal
+// on random parameters and batches of inputs, both within and outside
+// of the support, against the log density of the underlying Normal
+// renormalized on [low, high]
+func TestTruncatedNormalLogProb(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const maxSize int = 10             // Maximum number of distributions
+	const maxBatch int = 10            // Maximum batch size
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := 1 + rand.Intn(maxSize)
+		batch := 1 + rand.Intn(maxBatch)
+
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"mean", "stddev", "low", "high"}, drawTruncatedNormalParams)
+		tn, err := NewTruncatedNormal(params[0], params[1], params[2], params[3],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mean, stddev, low, high := backing[0], backing[1], backing[2], backing[3]
+
+		inBacking := make([]float64, batch*size)
+		for j := range inBacking {
+			k := j % size
+			width := high[k] - low[k]
+			inBacking[j] = low[k] + (rand.Float64()*1.5-0.25)*width
+		}
+		in := G.NewMatrix(g, tensor.Float64, G.WithName("input"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{batch, size},
+				tensor.WithBacking(inBacking))))
+
+		logProb, err := tn.LogProb(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var logProbVal G.Value
+		G.Read(logProb, &logProbVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		for j, v := range logProbVal.Data().([]float64) {
+			k := j % size
+			target := truncatedNormalLogProb(inBacking[j], mean[k], stddev[k],
+				low[k], high[k])
+			if math.IsInf(target, -1) && math.IsInf(v, -1) {
+				continue
+			}
+			if math.Abs(v-target) > threshold {
+				t.Errorf("expected: %v received: %v for input %v in [%v, %v]",
+					target, v, inBacking[j], low[k], high[k])
+			}
+		}
+	}
+}
+
+// TestTruncatedNormalRsample tests that samples from the
+// TruncatedNormal lie within [low, high]
+func TestTruncatedNormalRsample(t *testing.T) {
+	const tests int = 10    // Number of tests to run
+	const samples int = 500 // Number of samples per test
+	const maxSize int = 10  // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := 1 + rand.Intn(maxSize)
+
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"mean", "stddev", "low", "high"}, drawTruncatedNormalParams)
+		tn, err := NewTruncatedNormal(params[0], params[1], params[2], params[3],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		low, high := backing[2], backing[3]
+
+		sample, err := tn.Rsample(samples)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sampleVal G.Value
+		G.Read(sample, &sampleVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !sampleVal.Shape().Eq(tensor.Shape{samples, size}) {
+			t.Fatalf("expected shape %v but got %v",
+				tensor.Shape{samples, size}, sampleVal.Shape())
+		}
+		for j, s := range sampleVal.Data().([]float64) {
+			k := j % size
+			if s < low[k] || s > high[k] || math.IsNaN(s) {
+				t.Errorf("sample %v outside support [%v, %v]", s, low[k],
+					high[k])
+			}
+		}
+	}
+}
+
+// TestTruncatedNormalSample tests that Sample on a TruncatedNormal
+// produces samples of the correct shape, and that no gradient flows
+// through the samples to the parameters
+func TestTruncatedNormalSample(t *testing.T) {
+	const tests int = 10  // Number of tests to run
+	const maxSize int = 5 // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, _ := newRandomVectors(g, 1+rand.Intn(maxSize),
+			[]string{"mean", "stddev", "low", "high"}, drawTruncatedNormalParams)
+		tn, err := NewTruncatedNormal(params[0], params[1], params[2], params[3],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		CheckSample(t, tn, tn.normal.mean, tn.normal.stddev, tn.low, tn.high)
+	}
+}
+
+// TestTruncatedNormalNormalized tests that the density of the
+// TruncatedNormal integrates to one on [low, high] using Monte-Carlo
+// integration with uniformly distributed points
+func TestTruncatedNormalNormalized(t *testing.T) {
+	const tests int = 5      // Number of tests to run
+	const points int = 20000 // Number of Monte-Carlo points
+	const size int = 4       // Number of distributions
+	const tolerance float64 = 0.05
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"mean", "stddev", "low", "high"}, drawTruncatedNormalParams)
+		tn, err := NewTruncatedNormal(params[0], params[1], params[2], params[3],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		low, high := backing[2], backing[3]
+
+		inBacking := make([]float64, points*size)
+		for j := range inBacking {
+			k := j % size
+			inBacking[j] = low[k] + rand.Float64()*(high[k]-low[k])
+		}
+		in := G.NewMatrix(g, tensor.Float64, G.WithName("input"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{points, size},
+				tensor.WithBacking(inBacking))))
+
+		prob, err := tn.Prob(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var probVal G.Value
+		G.Read(prob, &probVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		integrals := make([]float64, size)
+		for j, p := range probVal.Data().([]float64) {
+			integrals[j%size] += p
+		}
+		for k := range integrals {
+			integrals[k] *= (high[k] - low[k]) / float64(points)
+			if math.Abs(integrals[k]-1) > tolerance {
+				t.Errorf("expected density to integrate to 1 on [%v, %v] "+
+					"but got %v", low[k], high[k], integrals[k])
+			}
+		}
+	}
+}
+
+// TestTruncatedNormalValidate tests that constructing a
+// TruncatedNormal with low >= high returns an error
+func TestTruncatedNormalValidate(t *testing.T) {
+	g := G.NewGraph()
+	vector := func(backing []float64, name string) *G.Node {
+		return G.NewVector(g, tensor.Float64, G.WithName(name),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{2},
+				tensor.WithBacking(backing))))
+	}
+	mean := vector([]float64{0, 0}, "mean")
+	stddev := vector([]float64{1, 1}, "stddev")
+
+	bounds := [][2][]float64{
+		{{-1, 1}, {1, 1}},  // low == high
+		{{-1, 3}, {1, 2}},  // low > high
+		{{-1, 1}, {-2, 2}}, // low > high
+	}
+	for i, b := range bounds {
+		low := vector(b[0], fmt.Sprintf("low%v", i))
+		high := vector(b[1], fmt.Sprintf("high%v", i))
+		if _, err := NewTruncatedNormal(mean, stddev, low, high, 0); err == nil {
+			t.Errorf("bounds %v: expected error for low = %v and high = %v",
+				i, b[0], b[1])
+		}
+	}
+}
+
+// TestTruncatedNormalMoments tests the Mean, Variance, and Entropy of
+// the TruncatedNormal against numerical integration of the truncated
+// density on [low, high]
+func TestTruncatedNormalMoments(t *testing.T) {
+	const threshold float64 = 0.0001 // Threshold to consider floats equal
+	const tests int = 10             // Number of tests to run
+	const steps int = 20000          // Number of integration steps
+	const size int = 5               // Number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"mean", "stddev", "low", "high"}, drawTruncatedNormalParams)
+		tn, err := NewTruncatedNormal(params[0], params[1], params[2], params[3],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mean, stddev, low, high := backing[0], backing[1], backing[2], backing[3]
+
+		entropy, err := tn.Entropy()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var meanVal, varianceVal, entropyVal G.Value
+		G.Read(tn.Mean(), &meanVal)
+		G.Read(tn.Variance(), &varianceVal)
+		G.Read(entropy, &entropyVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		for k := 0; k < size; k++ {
+			// Midpoint rule integration of the moments of the density
+			h := (high[k] - low[k]) / float64(steps)
+			var m1, m2, ent float64
+			for j := 0; j < steps; j++ {
+				x := low[k] + (float64(j)+0.5)*h
+				logP := truncatedNormalLogProb(x, mean[k], stddev[k], low[k],
+					high[k])
+				p := math.Exp(logP)
+				m1 += x * p * h
+				m2 += x * x * p * h
+				ent -= logP * p * h
+			}
+
+			names := []string{"Mean", "Variance", "Entropy"}
+			targets := []float64{m1, m2 - m1*m1, ent}
+			computed := []float64{
+				meanVal.Data().([]float64)[k],
+				varianceVal.Data().([]float64)[k],
+				entropyVal.Data().([]float64)[k],
+			}
+			for j := range targets {
+				if math.Abs(computed[j]-targets[j]) > threshold {
+					t.Errorf("%v: expected: %v received: %v", names[j],
+						targets[j], computed[j])
+				}
+			}
+		}
+	}
+}
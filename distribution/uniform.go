@@ -0,0 +1,324 @@
+package distribution
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// Uniform is a univariate continuous uniform distribution on the
+// interval [low, high], which may hold a batch of uniform distributions
+// simultaneously. The low and high tensors are treated element-wise in
+// the same way as the mean and standard deviation tensors of the
+// Normal, and so any input to any method of the Uniform must have a
+// shape consistent with that of the Uniform, as described in the
+// documentation for Normal.
+//
+// The Uniform has bounded support, and so its Prob is exactly 0 and
+// its LogProb exactly -Inf for inputs outside [low, high].
+type Uniform struct {
+	low  *G.Node
+	high *G.Node
+
+	seed uint64
+}
+
+var _ Quantiler = (*Uniform)(nil)
+
+// NewUniform returns a new Uniform on the interval [low, high]
+func NewUniform(low, high *G.Node, seed uint64) (*Uniform, error) {
+	if !low.Shape().Eq(high.Shape()) {
+		return nil, gop.ShapeErrorf("newUniform: expected low and high "+
+			"to have the same shape but got %v and %v", low.Shape(),
+			high.Shape())
+	}
+	if low.Dtype() != high.Dtype() {
+		return nil, gop.DtypeErrorf("newUniform: expected low and high "+
+			"to have the same data type but got %v and %v", low.Dtype(),
+			high.Dtype())
+	} else if low.Dtype() != tensor.Float64 &&
+		low.Dtype() != tensor.Float32 {
+		return nil, gop.DtypeErrorf("newUniform: data type %v unsupported",
+			low.Dtype())
+	}
+
+	var err error
+	if low.IsScalar() {
+		low, err = G.Reshape(low, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newUniform: could not expand low to "+
+				"shape (1): %w", err)
+		}
+		high, err = G.Reshape(high, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("newUniform: could not expand high to "+
+				"shape (1): %w", err)
+		}
+	}
+
+	return &Uniform{
+		low:  low,
+		high: high,
+		seed: seed,
+	}, nil
+}
+
+// UniformFromTensors returns a new Uniform in graph g on the interval
+// [low, high], whose bounds are input nodes with values low and high,
+// in the same way as NormalFromTensors()
+func UniformFromTensors(g *G.ExprGraph, low, high tensor.Tensor,
+	seed uint64) (*Uniform, error) {
+	lowNode, err := nodeFromTensor(g, low, "low")
+	if err != nil {
+		return nil, fmt.Errorf("uniformFromTensors: %w", err)
+	}
+	highNode, err := nodeFromTensor(g, high, "high")
+	if err != nil {
+		return nil, fmt.Errorf("uniformFromTensors: %w", err)
+	}
+
+	uniform, err := NewUniform(lowNode, highNode, seed)
+	if err != nil {
+		return nil, fmt.Errorf("uniformFromTensors: %w", err)
+	}
+	return uniform, nil
+}
+
+// Prob calculates the probability density of x, which is 0 outside of
+// [low, high]. The shape of x is treated in the same way as the
+// Normal's Prob() method.
+func (u *Uniform) Prob(x *G.Node) (*G.Node, error) {
+	logProb, err := u.LogProb(x)
+	if err != nil {
+		return nil, fmt.Errorf("prob: %w", err)
+	}
+
+	return G.Exp(logProb)
+}
+
+// LogProb calculates the log probability density of x, which is -Inf
+// outside of [low, high]. The shape of x is treated in the same way as
+// the Normal's Prob() method.
+func (u *Uniform) LogProb(x *G.Node) (*G.Node, error) {
+	z, err := u.standardize(x)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	// log(p(x)) = -log(high - low) on the support
+	lnWidth := G.Must(G.Log(u.width()))
+	zeros := full(x.Graph(), u.Dtype(), z.Shape(), 0.0, "zeros")
+
+	var logProb *G.Node
+	if isBatch(z, u.Shape()) {
+		logProb = G.Must(G.BroadcastSub(zeros, lnWidth, nil, []byte{0}))
+	} else {
+		logProb = G.Must(G.Sub(zeros, lnWidth))
+	}
+
+	inSupport, err := unitIntervalMask(z)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	logProb, err = maskSupport(logProb, inSupport)
+	if err != nil {
+		return nil, fmt.Errorf("logProb: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// LogProbAtMean returns the log probability density of the receiver
+// at its mean, which is -log(high - low) since the density is constant
+// on the support. The returned node has shape (1, u.Shape()...).
+func (u *Uniform) LogProbAtMean() (*G.Node, error) {
+	logProb, err := logProbAtMean(u)
+	if err != nil {
+		return nil, fmt.Errorf("logProbAtMean: %w", err)
+	}
+
+	return logProb, nil
+}
+
+// Cdf computes the cumulative distribution function of x, which is 0
+// below low and 1 above high. The shape of x is treated in the same
+// way as the Normal's Prob() method.
+func (u *Uniform) Cdf(x *G.Node) (*G.Node, error) {
+	z, err := u.standardize(x)
+	if err != nil {
+		return nil, fmt.Errorf("cdf: %w", err)
+	}
+
+	// cdf(x) = clamp((x - low) / (high - low), 0, 1)
+	var min, max interface{} = 0.0, 1.0
+	if u.Dtype() == tensor.Float32 {
+		min, max = float32(0.0), float32(1.0)
+	}
+
+	return gop.Clamp(z, min, max, false)
+}
+
+// Sf computes the survival function, 1 - Cdf(x), of x. The shape of x
+// is treated in the same way as the Normal's Prob() method.
+func (u *Uniform) Sf(x *G.Node) (*G.Node, error) {
+	cdf, err := u.Cdf(x)
+	if err != nil {
+		return nil, fmt.Errorf("sf: %w", err)
+	}
+
+	one := constant(x.Graph(), u.Dtype(), 1.0)
+	return G.Sub(one, cdf)
+}
+
+// Quantile computes the inverse cumulative distribution function at
+// probability p, low + p(high - low). The shape of p is treated in the
+// same way as the Normal's Prob() method.
+func (u *Uniform) Quantile(p *G.Node) (*G.Node, error) {
+	p, err := fixShape(p, u.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	p, err = validateProbs(p)
+	if err != nil {
+		return nil, fmt.Errorf("quantile: %w", err)
+	}
+
+	if isBatch(p, u.Shape()) {
+		batchDim := []byte{0}
+		p = G.Must(G.BroadcastHadamardProd(p, u.width(), nil, batchDim))
+		return G.BroadcastAdd(p, u.low, nil, batchDim)
+	}
+
+	p = G.Must(G.HadamardProd(p, u.width()))
+	return G.Add(p, u.low)
+}
+
+// Shape returns the number of distributions stored by the receiver
+func (u *Uniform) Shape() tensor.Shape {
+	return u.low.Shape()
+}
+
+// NumDistributions returns the number of distributions stored by the
+// receiver, which is the product of the dimensions of its shape
+func (u *Uniform) NumDistributions() int {
+	return tensor.ProdInts(u.Shape())
+}
+
+// Low returns the lower bound of the support of the receiver
+func (u *Uniform) Low() *G.Node { return u.low }
+
+// High returns the upper bound of the support of the receiver
+func (u *Uniform) High() *G.Node { return u.high }
+
+// Mean returns the mean of the distribution(s) stored by the
+// receiver, (low + high) / 2
+func (u *Uniform) Mean() *G.Node {
+	half := constant(u.low.Graph(), u.Dtype(), 0.5)
+	mean := G.Must(G.Add(u.low, u.high))
+
+	return G.Must(G.HadamardProd(mean, half))
+}
+
+// Variance returns the variance of the distribution(s) stored by the
+// receiver, (high - low)² / 12
+func (u *Uniform) Variance() *G.Node {
+	c := constant(u.low.Graph(), u.Dtype(), 1.0/12.0)
+	variance := G.Must(G.Square(u.width()))
+
+	return G.Must(G.HadamardProd(variance, c))
+}
+
+// StdDev returns the standard deviation of the distribution(s) stored
+// by the receiver
+func (u *Uniform) StdDev() *G.Node {
+	c := constant(u.low.Graph(), u.Dtype(), 1.0/math.Sqrt(12.0))
+
+	return G.Must(G.HadamardProd(u.width(), c))
+}
+
+// Entropy returns the entropy of the distribution(s) stored by the
+// receiver, log(high - low)
+func (u *Uniform) Entropy() (*G.Node, error) {
+	return G.Log(u.width())
+}
+
+// HasRsample returns whether the receiver supports reparameterized
+// sample -- true for the Uniform.
+func (u *Uniform) HasRsample() bool { return true }
+
+// Dtype returns the type that the receiver operates on
+func (u *Uniform) Dtype() tensor.Dtype { return u.low.Dtype() }
+
+// Rsample samples m samples from the receiver using reparameterized
+// sampling, low + (high - low)u, where u is standard uniform noise.
+// The returned node has shape (m, u.Shape()...), even when m == 1,
+// which is consistent with the Normal. This is a differentiable
+// operation.
+func (u *Uniform) Rsample(m int) (*G.Node, error) {
+	graph := u.low.Graph()
+	zeroLow := full(graph, u.Dtype(), u.Shape(), 0.0, "zeroLow")
+	unitHigh := full(graph, u.Dtype(), u.Shape(), 1.0, "unitHigh")
+
+	noise, err := UniformSample(zeroLow, unitHigh, u.seed, m)
+	if err != nil {
+		return nil, fmt.Errorf("rsample: could not sample from "+
+			"standard uniform: %w", err)
+	}
+
+	out, err := reparameterize(noise, u.low, u.width())
+	if err != nil {
+		return nil, fmt.Errorf("rsample: %w", err)
+	}
+
+	return out, nil
+}
+
+// Sample samples m samples from the receiver. The returned node has
+// the same shape as that of Rsample. This operation is not
+// differentiable.
+func (u *Uniform) Sample(m int) (*G.Node, error) {
+	return UniformSample(u.low, u.high, u.seed, m)
+}
+
+// SampleShape samples prod(shape) samples from the receiver, returned
+// with shape (shape..., u.Shape()...). This operation is not
+// differentiable.
+func (u *Uniform) SampleShape(shape ...int) (*G.Node, error) {
+	samples, err := sampleShape(u.Sample, shape)
+	if err != nil {
+		return nil, fmt.Errorf("sampleShape: %w", err)
+	}
+
+	return samples, nil
+}
+
+// width returns high - low
+func (u *Uniform) width() *G.Node {
+	return G.Must(G.Sub(u.high, u.low))
+}
+
+// standardize returns (x - low) / (high - low), which lies in [0, 1]
+// on the support of the receiver. The shape of x is treated in the
+// same way as the Normal's Prob() method.
+func (u *Uniform) standardize(x *G.Node) (*G.Node, error) {
+	x, err := fixShape(x, u.Shape())
+	if err != nil {
+		return nil, err
+	}
+
+	if isBatch(x, u.Shape()) {
+		batchDim := []byte{0}
+		x = G.Must(G.BroadcastSub(x, u.low, nil, batchDim))
+		x = G.Must(G.BroadcastHadamardDiv(x, u.width(), nil, batchDim))
+	} else {
+		x = G.Must(G.Sub(x, u.low))
+		x = G.Must(G.HadamardDiv(x, u.width()))
+	}
+
+	return x, nil
+}
@@ -0,0 +1,458 @@
+package distribution
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/stat/distuv"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// drawUniformParams draws the low and high of a random Uniform
+func drawUniformParams(x []float64) {
+	const scale float64 = 2.0
+	const widthOffset float64 = 0.001
+
+	x[0] = (rand.Float64() - 0.5) * scale
+	x[1] = x[0] + (rand.Float64()+widthOffset)*scale
+}
+
+// TestUniform tests the Prob, LogProb, and Quantile methods of the
+// Uniform on random vector parameters and batches of inputs, both
+// within and outside of the support, against gonum's Uniform
+// distribution. The Cdf and Sf are checked with CompareCdfToGonum and
+// CompareSfToGonum.
+func TestUniform(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const minSize int = 1              // Minimum number of distributions
+	const maxSize int = 10             // Maximum number of distributions
+	const minBatch int = 1             // Minimum batch size
+	const maxBatch int = 10            // Maximum batch size
+	rand.Seed(time.Now().UnixNano())
+
+	type method struct {
+		name   string
+		f      func(*Uniform, *G.Node) (*G.Node, error)
+		target func(distuv.Uniform, float64) float64
+		input  func() float64
+	}
+	methods := []method{
+		{
+			name:   "Prob",
+			f:      (*Uniform).Prob,
+			target: distuv.Uniform.Prob,
+			input:  func() float64 { return (rand.Float64() - 0.5) * 6.0 },
+		},
+		{
+			name:   "LogProb",
+			f:      (*Uniform).LogProb,
+			target: distuv.Uniform.LogProb,
+			input:  func() float64 { return (rand.Float64() - 0.5) * 6.0 },
+		},
+		{
+			name:   "Quantile",
+			f:      (*Uniform).Quantile,
+			target: distuv.Uniform.Quantile,
+			input:  func() float64 { return 0.001 + rand.Float64()*0.998 },
+		},
+	}
+
+	for _, m := range methods {
+		for i := 0; i < tests; i++ {
+			size := minSize + rand.Intn(maxSize-minSize)
+			batch := minBatch + rand.Intn(maxBatch-minBatch)
+
+			g := G.NewGraph()
+			params, backing := newRandomVectors(g, size,
+				[]string{"low", "high"}, drawUniformParams)
+			uniform, err := NewUniform(params[0], params[1],
+				uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			low, high := backing[0], backing[1]
+
+			// Construct the input and target
+			inBacking := make([]float64, batch*size)
+			target := make([]float64, batch*size)
+			for j := range inBacking {
+				inBacking[j] = m.input()
+				dist := distuv.Uniform{Min: low[j%size], Max: high[j%size]}
+				target[j] = m.target(dist, inBacking[j])
+			}
+			inT := tensor.NewDense(
+				tensor.Float64,
+				[]int{batch, size},
+				tensor.WithBacking(inBacking),
+			)
+			in := G.NewMatrix(g, tensor.Float64, G.WithValue(inT),
+				G.WithName("input"))
+
+			out, err := m.f(uniform, in)
+			if err != nil {
+				t.Error(err)
+			}
+			var outVal G.Value
+			G.Read(out, &outVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Error(err)
+			}
+
+			outData := outVal.Data().([]float64)
+			for j := range target {
+				if math.IsInf(target[j], -1) && math.IsInf(outData[j], -1) {
+					continue
+				}
+				if math.Abs(outData[j]-target[j]) > threshold {
+					t.Errorf("%v: expected: %v received: %v for input: %v",
+						m.name, target[j], outData[j], inBacking[j])
+				}
+			}
+
+			vm.Close()
+		}
+	}
+
+	// CompareCdfToGonum and CompareSfToGonum broadcast each point to
+	// every distribution, so all distributions share the same bounds
+	compare := []func(*testing.T, Distribution, func(float64) float64,
+		[]float64){CompareCdfToGonum, CompareSfToGonum}
+	for i := 0; i < tests; i++ {
+		size := minSize + rand.Intn(maxSize-minSize)
+		lowVal := (rand.Float64() - 0.5) * 2.0
+		highVal := lowVal + (rand.Float64()+0.001)*2.0
+
+		lowBacking := make([]float64, size)
+		highBacking := make([]float64, size)
+		for j := range lowBacking {
+			lowBacking[j] = lowVal
+			highBacking[j] = highVal
+		}
+
+		points := make([]float64, maxBatch)
+		for j := range points {
+			points[j] = (rand.Float64() - 0.5) * 6.0
+		}
+		target := distuv.Uniform{Min: lowVal, Max: highVal}
+		references := []func(float64) float64{target.CDF, target.Survival}
+
+		for j := range compare {
+			g := G.NewGraph()
+			low := G.NewVector(g, tensor.Float64, G.WithName("low"),
+				G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+					tensor.WithBacking(lowBacking))))
+			high := G.NewVector(g, tensor.Float64, G.WithName("high"),
+				G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+					tensor.WithBacking(highBacking))))
+
+			uniform, err := NewUniform(low, high,
+				uint64(time.Now().UnixNano()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			compare[j](t, uniform, references[j], points)
+		}
+	}
+}
+
+// TestUniformSupport tests that the Prob of the Uniform is exactly 0,
+// and the LogProb exactly -Inf, below low and above high, and that
+// the gradient of the LogProb within the support is unaffected
+func TestUniformSupport(t *testing.T) {
+	lowBacking := []float64{-1.0, 0.0, 2.0}
+	highBacking := []float64{1.0, 0.5, 5.0}
+	inBacking := []float64{
+		-1.5, 0.25, 1.0, // Below, within, and below the support
+		2.0, -0.1, 5.5, // Above, below, and above the support
+		0.0, 0.5, 3.0, // Within the support, including at the bound
+	}
+	inSupport := []bool{false, true, false, false, false, false, true,
+		true, true}
+
+	g := G.NewGraph()
+	low := G.NewVector(g, tensor.Float64, G.WithName("low"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking(lowBacking))))
+	high := G.NewVector(g, tensor.Float64, G.WithName("high"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking(highBacking))))
+	in := G.NewMatrix(g, tensor.Float64, G.WithName("input"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3, 3},
+			tensor.WithBacking(inBacking))))
+
+	uniform, err := NewUniform(low, high, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prob, err := uniform.Prob(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logProb, err := uniform.LogProb(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var probVal, logProbVal G.Value
+	G.Read(prob, &probVal)
+	G.Read(logProb, &logProbVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	probs := probVal.Data().([]float64)
+	logProbs := logProbVal.Data().([]float64)
+	for j := range inBacking {
+		width := highBacking[j%3] - lowBacking[j%3]
+		if inSupport[j] {
+			if math.Abs(probs[j]-1/width) > 1e-9 {
+				t.Errorf("prob: expected %v but got %v for input %v",
+					1/width, probs[j], inBacking[j])
+			}
+			if math.Abs(logProbs[j]+math.Log(width)) > 1e-9 {
+				t.Errorf("logProb: expected %v but got %v for input %v",
+					-math.Log(width), logProbs[j], inBacking[j])
+			}
+			continue
+		}
+
+		if probs[j] != 0 {
+			t.Errorf("prob: expected 0 but got %v for input %v outside "+
+				"[%v, %v]", probs[j], inBacking[j], lowBacking[j%3],
+				highBacking[j%3])
+		}
+		if !math.IsInf(logProbs[j], -1) {
+			t.Errorf("logProb: expected -Inf but got %v for input %v "+
+				"outside [%v, %v]", logProbs[j], inBacking[j],
+				lowBacking[j%3], highBacking[j%3])
+		}
+	}
+}
+
+// TestUniformMoments tests the Mean, Variance, StdDev, and Entropy
+// methods of the Uniform on random vector parameters against gonum's
+// Uniform distribution.
+func TestUniformMoments(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	const tests int = 15               // Number of tests to run
+	const minSize int = 1              // Minimum number of distributions
+	const maxSize int = 10             // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		size := minSize + rand.Intn(maxSize-minSize)
+
+		g := G.NewGraph()
+		params, backing := newRandomVectors(g, size,
+			[]string{"low", "high"}, drawUniformParams)
+		uniform, err := NewUniform(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		low, high := backing[0], backing[1]
+
+		entropy, err := uniform.Entropy()
+		if err != nil {
+			t.Error(err)
+		}
+		nodes := []*G.Node{uniform.Mean(), uniform.Variance(),
+			uniform.StdDev(), entropy}
+		names := []string{"Mean", "Variance", "StdDev", "Entropy"}
+		values := make([]G.Value, len(nodes))
+		for j := range nodes {
+			G.Read(nodes[j], &values[j])
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Error(err)
+		}
+
+		for j := 0; j < size; j++ {
+			dist := distuv.Uniform{Min: low[j], Max: high[j]}
+			targets := []float64{dist.Mean(), dist.Variance(), dist.StdDev(),
+				dist.Entropy()}
+
+			for k := range targets {
+				computed := values[k].Data().([]float64)[j]
+				if math.Abs(computed-targets[k]) > threshold {
+					t.Errorf("%v: expected: %v received: %v", names[k],
+						targets[k], computed)
+				}
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestUniformRsample tests that Rsample produces samples of the
+// correct shape which lie within the support of the Uniform
+func TestUniformRsample(t *testing.T) {
+	const samples int = 100 // Number of samples to draw
+	const size int = 5      // Number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	g := G.NewGraph()
+	params, backing := newRandomVectors(g, size,
+		[]string{"low", "high"}, drawUniformParams)
+	uniform, err := NewUniform(params[0], params[1],
+		uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	low, high := backing[0], backing[1]
+
+	sample, err := uniform.Rsample(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sampleVal G.Value
+	G.Read(sample, &sampleVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sampleVal.Shape().Eq(tensor.Shape{samples, size}) {
+		t.Fatalf("expected shape %v but got %v",
+			tensor.Shape{samples, size}, sampleVal.Shape())
+	}
+	for j, s := range sampleVal.Data().([]float64) {
+		if s < low[j%size] || s > high[j%size] {
+			t.Errorf("sample %v outside support [%v, %v]", s, low[j%size],
+				high[j%size])
+		}
+	}
+}
+
+// TestUniformRsampleShape tests that Rsample on a Uniform keeps the
+// batch dimension of a single sample, as the Normal does, and that
+// gradients can be computed through the samples
+func TestUniformRsampleShape(t *testing.T) {
+	const tests int = 10  // Number of tests to run
+	const maxSize int = 5 // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, _ := newRandomVectors(g, 1+rand.Intn(maxSize),
+			[]string{"low", "high"}, drawUniformParams)
+		uniform, err := NewUniform(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		CheckRsample(t, uniform, uniform.Low(), uniform.High())
+	}
+}
+
+// TestUniformSample tests that Sample on a Uniform produces samples of
+// the correct shape, and that no gradient flows through the samples
+// to the bounds
+func TestUniformSample(t *testing.T) {
+	const tests int = 10  // Number of tests to run
+	const maxSize int = 5 // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, _ := newRandomVectors(g, 1+rand.Intn(maxSize),
+			[]string{"low", "high"}, drawUniformParams)
+		uniform, err := NewUniform(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		CheckSample(t, uniform, uniform.Low(), uniform.High())
+	}
+}
+
+// TestUniformQuantileRoundTrip tests that the Quantile of the Uniform
+// is the inverse of its Cdf on random parameters
+func TestUniformQuantileRoundTrip(t *testing.T) {
+	const tolerance float64 = 0.000001 // Tolerance of the round trip
+	const tests int = 10               // Number of tests to run
+	const samples int = 50             // Number of samples per test
+	const maxSize int = 10             // Maximum number of distributions
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		g := G.NewGraph()
+		params, _ := newRandomVectors(g, 1+rand.Intn(maxSize),
+			[]string{"low", "high"}, drawUniformParams)
+		uniform, err := NewUniform(params[0], params[1],
+			uint64(time.Now().UnixNano()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = CheckQuantileRoundTrip(uniform, samples, tolerance)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// TestUniformFromTensors tests constructing a Uniform from tensors with
+// UniformFromTensors and computing its LogProb
+func TestUniformFromTensors(t *testing.T) {
+	const threshold float64 = 0.0000001 // Threshold to consider floats equal
+
+	low := tensor.NewDense(tensor.Float64, []int{3},
+		tensor.WithBacking([]float64{-1, 0, 2}))
+	high := tensor.NewDense(tensor.Float64, []int{3},
+		tensor.WithBacking([]float64{1, 4, 2.5}))
+
+	g := G.NewGraph()
+	u, err := UniformFromTensors(g, low, high, uint64(time.Now().UnixNano()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := G.NewVector(g, tensor.Float64, G.WithName("x"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking([]float64{0, 5, 2.25}))))
+	logProb, err := u.LogProb(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logProbVal G.Value
+	G.Read(logProb, &logProbVal)
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	targets := []float64{-math.Log(2), math.Inf(-1), -math.Log(0.5)}
+	for i, v := range logProbVal.Data().([]float64) {
+		if math.IsInf(targets[i], -1) {
+			if !math.IsInf(v, -1) {
+				t.Errorf("expected -Inf but got %v", v)
+			}
+		} else if math.Abs(v-targets[i]) > threshold {
+			t.Errorf("expected: %v received: %v", targets[i], v)
+		}
+	}
+
+	if _, err := UniformFromTensors(g, low, nil, 0); err == nil {
+		t.Error("expected error with nil high but got nil")
+	}
+}
@@ -1,31 +1,370 @@
 package distribution
 
-import "math/rand"
+import (
+	"fmt"
+	"math"
+	"math/rand"
 
-func ones64(size int) []float64 {
-	slice := make([]float64, size)
+	"github.com/samuelfneumann/gop"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// randInt returns a random int slice of length size
+func randInt(size int, min, max int) []int {
+	slice := make([]int, size)
 	for i := range slice {
-		slice[i] = 1.0
+		slice[i] = min + rand.Intn(max-min)
 	}
 
 	return slice
 }
 
-func ones32(size int) []float32 {
-	slice := make([]float32, size)
-	for i := range slice {
-		slice[i] = 1.0
+// constant returns a constant node on graph g of data type dt with
+// value v. Gorgonia shares constant nodes of equal value on a graph, so
+// that repeated calls with the same arguments return the same node
+// rather than allocating a new one.
+func constant(g *G.ExprGraph, dt tensor.Dtype, v float64) *G.Node {
+	if dt == tensor.Float32 {
+		return g.Constant(G.NewF32(float32(v)))
 	}
+	return g.Constant(G.NewF64(v))
+}
 
-	return slice
+// isBatch returns whether x is a batch of samples to calculate some
+// method on for a distribution with shape shape. Since Gorgonia
+// considers shapes such as (1, n) and (n) equal, the number of
+// dimensions is also compared, so that a batch of a single sample is
+// still treated as a batch.
+func isBatch(x *G.Node, shape tensor.Shape) bool {
+	return x.Dims() != len(shape) || !x.Shape().Eq(shape)
 }
 
-// randInt returns a random int slice of length size
-func randInt(size int, min, max int) []int {
-	slice := make([]int, size)
-	for i := range slice {
-		slice[i] = min + rand.Intn(max-min)
+// checkShape returns an error if x is of an invalid shape for some
+// method of a distribution with shape shape, which fixShape could not
+// adjust, and nil otherwise
+func checkShape(x *G.Node, shape tensor.Shape) error {
+	if x.IsScalar() {
+		if shape[0] != 1 {
+			return gop.ShapeErrorf("expected a tensor for distribution shape "+
+				"%v but got a scalar", shape)
+		}
+		return nil
+
+	} else if len(x.Shape()) == 1 && shape[0] == 1 {
+		// A vector input x indicates a batch of samples
+		return nil
+
+	} else if isSingle(shape) {
+		// All elements of x are treated as a batch of samples from the
+		// single distribution
+		return nil
+
+	} else if isBatch(x, shape) && (x.Dims() != len(shape)+1 ||
+		!tensor.Shape(x.Shape()[1:]).Eq(shape)) {
+		// The number of dimensions is compared since tensor.Shape.Eq
+		// considers shapes such as (n, 1) and (n) equal
+		msg := "expected shape to match distribution shape %v at all " +
+			"dimensions except batch (dim 0) but got x shape %v"
+		return gop.ShapeErrorf(msg, shape, x.Shape())
+
+	} else if !isBatch(x, shape) && !shape.Eq(x.Shape()) {
+		msg := "expected shape to match distribution shape %v but got %v"
+		return gop.ShapeErrorf(msg, shape, x.Shape())
 	}
 
-	return slice
+	return nil
+}
+
+// fixShape adjusts the shape of x so that it can be used in some
+// method of a distribution with shape shape. It returns an error
+// indicating if x is of an invalid shape which could not be adjusted,
+// as determined by checkShape.
+func fixShape(x *G.Node, shape tensor.Shape) (*G.Node, error) {
+	if err := checkShape(x, shape); err != nil {
+		return nil, err
+	}
+
+	if x.IsScalar() {
+		return G.Reshape(x, []int{1})
+
+	} else if len(x.Shape()) == 1 && shape[0] == 1 {
+		// When distribution shape was inputted as a scalar, then a
+		// vector input x indicates a batch of samples -> reshape
+		// so batch dims = 0 and shape of samples = dim 1
+		return G.Reshape(x, []int{x.Shape()[0], 1})
+
+	} else if isSingle(shape) && (x.Dims() != 2 || x.Shape()[1] != 1) {
+		// When the distribution holds a single distribution, then an
+		// input x of any shape is flattened into a batch of samples of
+		// shape (x.Shape().TotalSize(), 1)
+		return G.Reshape(x, []int{x.Shape().TotalSize(), 1})
+	}
+
+	return x, nil
+}
+
+// isSingle returns whether a distribution with shape shape holds a
+// single distribution, which is the case when it was constructed with
+// scalar parameters or parameters with a single element
+func isSingle(shape tensor.Shape) bool {
+	return len(shape) == 1 && shape[0] == 1
+}
+
+// full returns a node on graph g of data type dt and shape shape with
+// all elements set to v
+func full(g *G.ExprGraph, dt tensor.Dtype, shape tensor.Shape, v float64,
+	name string) *G.Node {
+	size := tensor.ProdInts(shape)
+
+	var backing interface{}
+	if dt == tensor.Float32 {
+		b := make([]float32, size)
+		for i := range b {
+			b[i] = float32(v)
+		}
+		backing = b
+	} else {
+		b := make([]float64, size)
+		for i := range b {
+			b[i] = v
+		}
+		backing = b
+	}
+
+	t := tensor.NewDense(dt, shape, tensor.WithBacking(backing))
+	return G.NewTensor(
+		g,
+		t.Dtype(),
+		t.Dims(),
+		G.WithValue(t),
+		G.WithName(gop.Unique(name)),
+	)
+}
+
+// nodeFromTensor returns a new node in g with value t, named with a
+// unique name starting with name
+func nodeFromTensor(g *G.ExprGraph, t tensor.Tensor, name string) (*G.Node,
+	error) {
+	if t == nil {
+		return nil, fmt.Errorf("nodeFromTensor: nil %v tensor", name)
+	}
+
+	return G.NodeFromAny(g, t, G.WithName(gop.Unique(name))), nil
+}
+
+// valueOf returns a copy of the value of node with the shape of node,
+// where read is the value read from node with G.Read and input is the
+// node from which node was reshaped, or node itself. The value read is
+// used if the graph of node has been run, otherwise the value of node
+// itself is used, which is non-nil for input nodes with values. If
+// node has no value, for example if it is the reshape of a scalar
+// input node whose graph has not been run, then the value of input is
+// used.
+func valueOf(node, input *G.Node, read G.Value) (tensor.Tensor, error) {
+	val := read
+	if val == nil {
+		val = node.Value()
+	}
+	if val == nil {
+		val = input.Value()
+	}
+	if val == nil {
+		return nil, fmt.Errorf("node %v has no value, its graph must be "+
+			"run first", node.Name())
+	}
+
+	var t tensor.Tensor
+	if v, ok := val.(tensor.Tensor); ok {
+		t = v.Clone().(tensor.Tensor)
+	} else {
+		t = tensor.New(tensor.FromScalar(val.Data()))
+	}
+
+	if err := t.Reshape(node.Shape().Clone()...); err != nil {
+		return nil, fmt.Errorf("could not reshape value of %v to %v: %w",
+			node.Name(), node.Shape(), err)
+	}
+	return t, nil
+}
+
+// checkPositive returns an error if node has a value with any element
+// which is not strictly positive. If node does not yet have a value,
+// for example if it is computed from other nodes, then nil is returned
+// and validation is left to the caller.
+func checkPositive(node *G.Node) error {
+	val := node.Value()
+	if val == nil {
+		return nil
+	}
+
+	var data []float64
+	switch d := val.Data().(type) {
+	case float64:
+		data = []float64{d}
+	case float32:
+		data = []float64{float64(d)}
+	case []float64:
+		data = d
+	case []float32:
+		data = make([]float64, len(d))
+		for i := range d {
+			data[i] = float64(d[i])
+		}
+	default:
+		return fmt.Errorf("cannot check positivity of data type %T", d)
+	}
+
+	for i, v := range data {
+		// Also rejects NaN
+		if !(v > 0) {
+			return fmt.Errorf("expected %v to be positive but got %v at "+
+				"index %v", node.Name(), v, i)
+		}
+	}
+	return nil
+}
+
+// reparameterize returns loc + scale * noise, where noise is a batch of
+// samples of standard (zero location, unit scale) noise with shape
+// (m, loc.Shape()...). The returned node has the same shape as noise,
+// including when m == 1. This is the reparameterization trick used by
+// the Rsample() method of location-scale distributions.
+func reparameterize(noise, loc, scale *G.Node) (*G.Node, error) {
+	if !loc.Shape().Eq(scale.Shape()) {
+		return nil, fmt.Errorf("reparameterize: expected loc and scale "+
+			"to have the same shape but got %v and %v", loc.Shape(),
+			scale.Shape())
+	}
+
+	if noise.Dims() != loc.Dims()+1 || !noise.Shape()[1:].Eq(loc.Shape()) {
+		return nil, fmt.Errorf("reparameterize: expected noise to have "+
+			"shape (m, %v) but got %v", loc.Shape(), noise.Shape())
+	}
+
+	batchDim := []byte{0}
+	out := G.Must(G.BroadcastHadamardProd(noise, scale, nil, batchDim))
+	return G.BroadcastAdd(out, loc, nil, batchDim)
+}
+
+// maskSupport returns logProb where inSupport is 1 and -Inf where
+// inSupport is 0, so that distributions with bounded support have a
+// log probability of -Inf, and a probability of exactly 0, outside of
+// their support. The mask inSupport must have the same shape and data
+// type as logProb.
+func maskSupport(logProb, inSupport *G.Node) (*G.Node, error) {
+	negInf := full(logProb.Graph(), logProb.Dtype(), logProb.Shape(),
+		math.Inf(-1), "negInf")
+
+	out, err := gop.Where(inSupport, logProb, negInf)
+	if err != nil {
+		return nil, fmt.Errorf("could not mask support: %w", err)
+	}
+	return out, nil
+}
+
+// unitIntervalMask returns a mask which is 1 where z lies within
+// [0, 1] and 0 elsewhere
+func unitIntervalMask(z *G.Node) (*G.Node, error) {
+	zero := constant(z.Graph(), z.Dtype(), 0.0)
+	one := constant(z.Graph(), z.Dtype(), 1.0)
+
+	aboveLow, err := G.Gte(z, zero, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not compare to lower bound: %w", err)
+	}
+	belowHigh, err := G.Lte(z, one, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not compare to upper bound: %w", err)
+	}
+
+	return G.HadamardProd(aboveLow, belowHigh)
+}
+
+// sampleShape draws prod(shape) samples using sample and reshapes them
+// to (shape..., drawShape...), where drawShape is the shape of a
+// single draw, without the batch dimension
+func sampleShape(sample func(int) (*G.Node, error),
+	shape []int) (*G.Node, error) {
+	for _, dim := range shape {
+		if dim <= 0 {
+			return nil, gop.ShapeErrorf("expected sample shape dimensions to "+
+				"be > 0 but got %v", shape)
+		}
+	}
+
+	samples, err := sample(tensor.ProdInts(shape))
+	if err != nil {
+		return nil, err
+	}
+	drawShape := samples.Shape()[1:].Clone()
+
+	return G.Reshape(samples, append(append([]int{}, shape...),
+		drawShape...))
+}
+
+// logProbAtMean returns the log probability density or mass of d at
+// its own mean. The mean is given a batch dimension of size 1 before
+// being passed to LogProb, and so the returned node has the shape of
+// LogProb for a batch of a single sample.
+func logProbAtMean(d Distribution) (*G.Node, error) {
+	mean := d.Mean()
+	mean, err := G.Reshape(mean, append([]int{1}, mean.Shape()...))
+	if err != nil {
+		return nil, fmt.Errorf("could not add batch dimension to mean: %w",
+			err)
+	}
+
+	return d.LogProb(mean)
+}
+
+// expand broadcasts x to shape by prepending dimensions of size 1 and
+// then repeating each dimension of size 1 to match shape, following
+// NumPy's broadcasting rules. An error is returned if x cannot be
+// broadcast to shape.
+func expand(x *G.Node, shape tensor.Shape) (*G.Node, error) {
+	src := x.Shape()
+	if len(src) > len(shape) {
+		return nil, gop.ShapeErrorf("cannot expand shape %v to shape %v with "+
+			"fewer dimensions", src, shape)
+	}
+
+	// Align the dimensions of x with the trailing dimensions of shape
+	aligned := make([]int, len(shape))
+	offset := len(shape) - len(src)
+	for i := range aligned {
+		if i < offset {
+			aligned[i] = 1
+			continue
+		}
+		aligned[i] = src[i-offset]
+		if aligned[i] != 1 && aligned[i] != shape[i] {
+			return nil, gop.ShapeErrorf("cannot expand shape %v to shape %v: "+
+				"dimension %v has size %v but expected 1 or %v", src, shape,
+				i-offset, aligned[i], shape[i])
+		}
+	}
+
+	// Shape.Eq treats vector-like shapes such as (4) and (1, 4) as
+	// equal, so the number of dimensions must be compared separately
+	var err error
+	if len(src) != len(aligned) || !src.Eq(tensor.Shape(aligned)) {
+		x, err = G.Reshape(x, aligned)
+		if err != nil {
+			return nil, fmt.Errorf("could not reshape %v to %v: %w", src,
+				aligned, err)
+		}
+	}
+
+	for axis, dim := range shape {
+		if aligned[axis] == 1 && dim != 1 {
+			x, err = gop.Repeat(x, axis, dim)
+			if err != nil {
+				return nil, fmt.Errorf("could not repeat axis %v: %w", axis,
+					err)
+			}
+		}
+	}
+
+	return x, nil
 }
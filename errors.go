@@ -0,0 +1,80 @@
+package gop
+
+import "fmt"
+
+// ShapeError is returned when a node or value has a shape which is
+// invalid for some operation or distribution, or when an axis is out
+// of range for a shape. It supports errors.Is and errors.As, so that
+// callers can distinguish shape errors from other errors after the
+// error has been wrapped:
+//
+//		var shapeErr *gop.ShapeError
+//		if errors.As(err, &shapeErr) { ... }
+type ShapeError struct {
+	Msg string
+}
+
+// ShapeErrorf returns a new ShapeError with a message formatted
+// according to format
+func ShapeErrorf(format string, args ...interface{}) error {
+	return &ShapeError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// Error implements the error interface
+func (e *ShapeError) Error() string { return e.Msg }
+
+// Is returns whether target is a ShapeError, so that
+// errors.Is(err, &ShapeError{}) reports whether err wraps a ShapeError
+func (e *ShapeError) Is(target error) bool {
+	_, ok := target.(*ShapeError)
+	return ok
+}
+
+// DtypeError is returned when a node or value has a data type which is
+// unsupported by some operation or distribution, or when the data
+// types of multiple nodes or values do not match. Like ShapeError, it
+// supports errors.Is and errors.As.
+type DtypeError struct {
+	Msg string
+}
+
+// DtypeErrorf returns a new DtypeError with a message formatted
+// according to format
+func DtypeErrorf(format string, args ...interface{}) error {
+	return &DtypeError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// Error implements the error interface
+func (e *DtypeError) Error() string { return e.Msg }
+
+// Is returns whether target is a DtypeError, so that
+// errors.Is(err, &DtypeError{}) reports whether err wraps a DtypeError
+func (e *DtypeError) Is(target error) bool {
+	_, ok := target.(*DtypeError)
+	return ok
+}
+
+// UnsupportedOpError is returned when an operation is not supported
+// by the receiver, such as reparameterized sampling from a
+// distribution without a reparameterization. Like ShapeError, it
+// supports errors.Is and errors.As.
+type UnsupportedOpError struct {
+	Msg string
+}
+
+// UnsupportedOpErrorf returns a new UnsupportedOpError with a message
+// formatted according to format
+func UnsupportedOpErrorf(format string, args ...interface{}) error {
+	return &UnsupportedOpError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// Error implements the error interface
+func (e *UnsupportedOpError) Error() string { return e.Msg }
+
+// Is returns whether target is an UnsupportedOpError, so that
+// errors.Is(err, &UnsupportedOpError{}) reports whether err wraps an
+// UnsupportedOpError
+func (e *UnsupportedOpError) Is(target error) bool {
+	_, ok := target.(*UnsupportedOpError)
+	return ok
+}
@@ -0,0 +1,153 @@
+package gop
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestErrorTypes tests that each error type can be recovered with
+// errors.As and errors.Is after being wrapped, and that wrapping keeps
+// its message
+func TestErrorTypes(t *testing.T) {
+	tests := []struct {
+		err    error
+		target error
+	}{
+		{ShapeErrorf("bad shape %v", []int{2, 3}), &ShapeError{}},
+		{DtypeErrorf("bad dtype %v", tensor.Int), &DtypeError{}},
+		{UnsupportedOpErrorf("bad op"), &UnsupportedOpError{}},
+	}
+
+	for i, test := range tests {
+		wrapped := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", test.err))
+		if wrapped.Error() != "outer: inner: "+test.err.Error() {
+			t.Errorf("unexpected message %q", wrapped.Error())
+		}
+
+		for j, other := range tests {
+			if is := errors.Is(wrapped, other.target); is != (i == j) {
+				t.Errorf("errors.Is(%T, %T) = %v", test.err, other.target, is)
+			}
+		}
+
+		var shapeErr *ShapeError
+		var dtypeErr *DtypeError
+		var opErr *UnsupportedOpError
+		as := []bool{
+			errors.As(wrapped, &shapeErr),
+			errors.As(wrapped, &dtypeErr),
+			errors.As(wrapped, &opErr),
+		}
+		for j := range as {
+			if as[j] != (i == j) {
+				t.Errorf("errors.As(%T, %T) = %v", test.err, tests[j].target,
+					as[j])
+			}
+		}
+	}
+}
+
+// TestAxisShapeError tests that an out of range axis passed to an
+// operation results in a ShapeError
+func TestAxisShapeError(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewMatrix(g, tensor.Float64, G.WithShape(2, 3), G.WithName("x"))
+
+	_, err := Flip(x, 2)
+	var shapeErr *ShapeError
+	if !errors.As(err, &shapeErr) {
+		t.Errorf("expected ShapeError but got %v", err)
+	}
+
+	_, err = ReduceAdd(x, -3, false)
+	if !errors.Is(err, &ShapeError{}) {
+		t.Errorf("expected ShapeError but got %v", err)
+	}
+
+	// Operations which normalize the axis before building other nodes
+	// pass the ShapeError through
+	w := G.NewMatrix(g, tensor.Float64, G.WithShape(2, 3), G.WithName("w"))
+	ops := map[string]func() (*G.Node, error){
+		"Argmax":     func() (*G.Node, error) { return Argmax(x, 2) },
+		"LogSoftmax": func() (*G.Node, error) { return LogSoftmax(x, 2) },
+		"ReduceLogSumExp": func() (*G.Node, error) {
+			return ReduceLogSumExp(x, 2, false)
+		},
+		"ReduceVar": func() (*G.Node, error) {
+			return ReduceVar(x, 2, false, false)
+		},
+		"Standardize": func() (*G.Node, error) { return Standardize(x, 2, 0) },
+		"ReduceWeightedMean": func() (*G.Node, error) {
+			return ReduceWeightedMean(x, w, 2, false)
+		},
+	}
+	for name, op := range ops {
+		if _, err := op(); !errors.As(err, &shapeErr) {
+			t.Errorf("%v: expected ShapeError but got %v", name, err)
+		}
+	}
+}
+
+// TestConstructorErrorTypes tests that the shape and data type checks
+// of operations return a ShapeError or DtypeError respectively
+func TestConstructorErrorTypes(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewMatrix(g, tensor.Float64, G.WithShape(2, 3), G.WithName("x"))
+	y := G.NewMatrix(g, tensor.Float64, G.WithShape(3, 2), G.WithName("y"))
+	x32 := G.NewMatrix(g, tensor.Float32, G.WithShape(2, 3),
+		G.WithName("x32"))
+	xInt := G.NewMatrix(g, tensor.Int, G.WithShape(2, 3), G.WithName("xInt"))
+	indices := G.NewVector(g, tensor.Int, G.WithShape(2),
+		G.WithName("indices"))
+	indicesF := G.NewVector(g, tensor.Float64, G.WithShape(2),
+		G.WithName("indicesF"))
+
+	shapeOps := map[string]func() (*G.Node, error){
+		"Where":       func() (*G.Node, error) { return Where(x, x, y) },
+		"IndexSelect": func() (*G.Node, error) { return IndexSelect(x, 0, x) },
+		"ScatterAdd": func() (*G.Node, error) {
+			return ScatterAdd(x, indices, x, 0)
+		},
+		"Repeat": func() (*G.Node, error) { return Repeat(x, 2, 2) },
+		"ReduceWeightedMean": func() (*G.Node, error) {
+			return ReduceWeightedMean(x, y, 0, false)
+		},
+	}
+	for name, op := range shapeOps {
+		var shapeErr *ShapeError
+		if _, err := op(); !errors.As(err, &shapeErr) {
+			t.Errorf("%v: expected ShapeError but got %v", name, err)
+		}
+	}
+
+	dtypeOps := map[string]func() (*G.Node, error){
+		"Where": func() (*G.Node, error) { return Where(x, x, x32) },
+		"IndexSelect": func() (*G.Node, error) {
+			return IndexSelect(x, 0, indicesF)
+		},
+		"ScatterAdd": func() (*G.Node, error) {
+			return ScatterAdd(x, indicesF, indicesF, 0)
+		},
+		"ReduceWeightedMean": func() (*G.Node, error) {
+			return ReduceWeightedMean(xInt, xInt, 0, false)
+		},
+		"ReduceAll": func() (*G.Node, error) { return ReduceAll(xInt, 0, false) },
+		"ReduceAny": func() (*G.Node, error) { return ReduceAny(xInt, 0, false) },
+	}
+	for name, op := range dtypeOps {
+		var dtypeErr *DtypeError
+		if _, err := op(); !errors.As(err, &dtypeErr) {
+			t.Errorf("%v: expected DtypeError but got %v", name, err)
+		}
+	}
+
+	var dtypeErr *DtypeError
+	if _, err := ClipByGlobalNorm([]*G.Node{x, x32}, 1); !errors.As(err,
+		&dtypeErr) {
+		t.Errorf("ClipByGlobalNorm: expected DtypeError but got %v", err)
+	}
+}
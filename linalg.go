@@ -0,0 +1,196 @@
+package gop
+
+import (
+	"fmt"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// Trace returns the sum of the diagonal of the square matrix x. The
+// gradient of the trace is the identity matrix scaled by the upstream
+// gradient.
+func Trace(x *G.Node) (*G.Node, error) {
+	if x.Dims() != 2 {
+		return nil, fmt.Errorf("trace: expected a matrix but got shape %v",
+			x.Shape())
+	}
+	if x.Shape()[0] != x.Shape()[1] {
+		return nil, fmt.Errorf("trace: expected a square matrix but got "+
+			"shape %v", x.Shape())
+	}
+
+	eye, err := identity(x.Dtype(), x.Shape()[0])
+	if err != nil {
+		return nil, fmt.Errorf("trace: %v", err)
+	}
+
+	diag, err := G.HadamardProd(x, G.NewConstant(eye))
+	if err != nil {
+		return nil, fmt.Errorf("trace: %v", err)
+	}
+
+	return G.Sum(diag)
+}
+
+// Outer computes the outer product of the vectors a and b. If a has
+// length n and b has length m, then the output has shape (n, m). The
+// gradients with respect to a and b are grad·b and aᵀ·grad respectively.
+func Outer(a, b *G.Node) (*G.Node, error) {
+	if !a.IsVector() || !b.IsVector() {
+		return nil, fmt.Errorf("outer: expected vector inputs but got "+
+			"shapes %v and %v", a.Shape(), b.Shape())
+	}
+
+	col, err := G.Reshape(a, []int{a.Shape().TotalSize(), 1})
+	if err != nil {
+		return nil, fmt.Errorf("outer: %v", err)
+	}
+	row, err := G.Reshape(b, []int{1, b.Shape().TotalSize()})
+	if err != nil {
+		return nil, fmt.Errorf("outer: %v", err)
+	}
+
+	outer, err := G.BroadcastHadamardProd(col, row, []byte{1}, []byte{0})
+	if err != nil {
+		return nil, fmt.Errorf("outer: %v", err)
+	}
+
+	return outer, nil
+}
+
+// Kron computes the Kronecker product of the matrices a and b. If a
+// has shape (p, q) and b has shape (r, s), then the output has shape
+// (p*r, q*s) and block (i, j) of the output is a[i][j] * b.
+func Kron(a, b *G.Node) (*G.Node, error) {
+	if a.Dims() != 2 || b.Dims() != 2 {
+		return nil, fmt.Errorf("kron: expected matrix inputs but got "+
+			"shapes %v and %v", a.Shape(), b.Shape())
+	}
+	if a.Dtype() != b.Dtype() {
+		return nil, fmt.Errorf("kron: expected inputs to have the same "+
+			"type but got %v and %v", a.Dtype(), b.Dtype())
+	}
+
+	return G.ApplyOp(newKronOp(), a, b)
+}
+
+// identity returns an n x n identity matrix of data type dt
+func identity(dt tensor.Dtype, n int) (*tensor.Dense, error) {
+	switch dt {
+	case tensor.Float64:
+		backing := make([]float64, n*n)
+		for i := 0; i < n; i++ {
+			backing[i*n+i] = 1
+		}
+		return tensor.NewDense(dt, []int{n, n}, tensor.WithBacking(backing)),
+			nil
+	case tensor.Float32:
+		backing := make([]float32, n*n)
+		for i := 0; i < n; i++ {
+			backing[i*n+i] = 1
+		}
+		return tensor.NewDense(dt, []int{n, n}, tensor.WithBacking(backing)),
+			nil
+	}
+
+	return nil, fmt.Errorf("cannot construct identity matrix of type %v", dt)
+}
+
+// BatchMatMul performs a batched matrix multiplication of a and b. The
+// leading dimension of each input is treated as the batch dimension, and
+// the trailing matrices are multiplied. If a has shape (B, n, m), then b
+// must either have shape (B, m, p), in which case the output has shape
+// (B, n, p), or b must have shape (B, m), in which case each matrix is
+// multiplied with a vector and the output has shape (B, n).
+func BatchMatMul(a, b *G.Node) (*G.Node, error) {
+	if a.Dims() != 3 {
+		return nil, fmt.Errorf("batchMatMul: expected a to be a batch of "+
+			"matrices with 3 dimensions but got shape %v", a.Shape())
+	}
+
+	vector := b.Dims() == 2
+	if !vector && b.Dims() != 3 {
+		return nil, fmt.Errorf("batchMatMul: expected b to be a batch of "+
+			"matrices or vectors with 3 or 2 dimensions but got shape %v",
+			b.Shape())
+	}
+
+	if a.Shape()[0] != b.Shape()[0] {
+		return nil, fmt.Errorf("batchMatMul: batch sizes do not match, "+
+			"a has batch size %d but b has batch size %d", a.Shape()[0],
+			b.Shape()[0])
+	}
+	if a.Shape()[2] != b.Shape()[1] {
+		return nil, fmt.Errorf("batchMatMul: inner dimensions do not "+
+			"match for shapes %v and %v (%d != %d)", a.Shape(), b.Shape(),
+			a.Shape()[2], b.Shape()[1])
+	}
+
+	var err error
+	if vector {
+		b, err = G.Reshape(b, []int{b.Shape()[0], b.Shape()[1], 1})
+		if err != nil {
+			return nil, fmt.Errorf("batchMatMul: %v", err)
+		}
+	}
+
+	var prod *G.Node
+	n, m, p := a.Shape()[1], a.Shape()[2], b.Shape()[2]
+	if (n == 1 && m == 1) || (m == 1 && p == 1) || (n == 1 && p == 1) {
+		// Gorgonia's batched matrix multiplication fails when any of the
+		// matrices multiplied in the forward or backward pass is 1 x 1,
+		// so fall back to a broadcasted product and sum
+		prod, err = broadcastBatchMatMul(a, b)
+	} else {
+		prod, err = G.BatchedMatMul(a, b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("batchMatMul: %v", err)
+	}
+
+	if vector {
+		prod, err = G.Reshape(prod, []int{a.Shape()[0], a.Shape()[1]})
+		if err != nil {
+			return nil, fmt.Errorf("batchMatMul: %v", err)
+		}
+	}
+
+	return prod, nil
+}
+
+// broadcastBatchMatMul performs a batched matrix multiplication of a
+// with shape (B, n, m) and b with shape (B, m, p) using elementwise
+// operations, for the cases where m == 1 or n == p == 1
+func broadcastBatchMatMul(a, b *G.Node) (*G.Node, error) {
+	batch, n, m := a.Shape()[0], a.Shape()[1], a.Shape()[2]
+	p := b.Shape()[2]
+
+	if m == 1 {
+		// Each product is an outer product of a column of a and a row
+		// of b
+		return G.BroadcastHadamardProd(a, b, []byte{2}, []byte{1})
+	}
+
+	// Each product is a dot product of a row of a and a column of b
+	a, err := G.Reshape(a, []int{batch, m})
+	if err != nil {
+		return nil, err
+	}
+	b, err = G.Reshape(b, []int{batch, m})
+	if err != nil {
+		return nil, err
+	}
+
+	prod, err := G.HadamardProd(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	prod, err = G.Sum(prod, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return G.Reshape(prod, []int{batch, n, p})
+}
@@ -0,0 +1,344 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestTrace tests the forward and backward pass of Trace on random
+// square matrices
+func TestTrace(t *testing.T) {
+	const threshold float64 = 1e-10 // Threshold to consider floats equal
+	const tests int = 10            // Number of random tests to run
+	const maxSize int = 6           // Maximum number of rows
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		n := 1 + rand.Intn(maxSize)
+		backing := randF64(n*n, -5, 5)
+
+		target := 0.0
+		for j := 0; j < n; j++ {
+			target += backing[j*n+j]
+		}
+
+		g := G.NewGraph()
+		x := G.NewMatrix(g, tensor.Float64, G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{n, n},
+				tensor.WithBacking(append([]float64{}, backing...)))))
+
+		trace, err := Trace(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var traceVal G.Value
+		G.Read(trace, &traceVal)
+
+		// Scale the upstream gradient so that the gradient is the
+		// identity scaled by the upstream gradient
+		upstream := rand.Float64() * 5
+		scale := G.NewConstant(upstream)
+		grad, err := G.Grad(G.Must(G.HadamardProd(trace, scale)), x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if computed := traceVal.Data().(float64); math.Abs(computed-target) > threshold {
+			t.Errorf("incorrect trace \nexpected: %v \nreceived: %v", target,
+				computed)
+		}
+
+		for j, v := range gradVal.Data().([]float64) {
+			gradTarget := 0.0
+			if j/n == j%n {
+				gradTarget = upstream
+			}
+			if math.Abs(v-gradTarget) > threshold {
+				t.Errorf("incorrect gradient at index %d \nexpected: %v "+
+					"\nreceived: %v", j, gradTarget, v)
+			}
+		}
+	}
+}
+
+// TestTraceShape tests that Trace returns an error for inputs which are
+// not square matrices
+func TestTraceShape(t *testing.T) {
+	g := G.NewGraph()
+	shapes := [][]int{{3}, {2, 3}, {2, 2, 2}}
+	for _, shape := range shapes {
+		x := G.NewTensor(g, tensor.Float64, len(shape), G.WithShape(shape...),
+			G.WithName(Unique("x")), G.WithInit(G.Zeroes()))
+
+		if _, err := Trace(x); err == nil {
+			t.Errorf("expected an error computing the trace of shape %v",
+				shape)
+		}
+	}
+}
+
+// batchMatMul computes the batched matrix product of a with shape
+// (batch, n, m) and b with shape (batch, m, p)
+func batchMatMul(a, b []float64, batch, n, m, p int) []float64 {
+	out := make([]float64, batch*n*p)
+	for k := 0; k < batch; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < p; j++ {
+				for l := 0; l < m; l++ {
+					out[k*n*p+i*p+j] += a[k*n*m+i*m+l] * b[k*m*p+l*p+j]
+				}
+			}
+		}
+	}
+	return out
+}
+
+// weightedSum returns the sum of the elementwise product of x and w
+func weightedSum(x, w []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * w[i]
+	}
+	return sum
+}
+
+// TestBatchMatMul tests the forward and backward pass of BatchMatMul
+// on batches of matrix-matrix and matrix-vector products. Gradients are
+// checked against finite differences of a weighted sum of the output.
+func TestBatchMatMul(t *testing.T) {
+	const threshold float64 = 1e-6 // Threshold to consider floats equal
+	const h float64 = 1e-5         // Step size for finite differences
+	const tests int = 5            // Number of random tests to run
+	const batch int = 3            // Batch size
+	const maxSize int = 3          // Maximum size of each matrix dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < 2*tests; i++ {
+		n, m := 1+rand.Intn(maxSize), 1+rand.Intn(maxSize)
+		p := 1 + rand.Intn(maxSize)
+		if i < 2 {
+			// Always test products of 1 x 1 matrices, which are computed
+			// separately
+			n, m = 1, 1
+		}
+		bShape := []int{batch, m, p}
+
+		// Test matrix-vector products on every second iteration
+		vector := i%2 == 1
+		if vector {
+			p = 1
+			bShape = []int{batch, m}
+		}
+
+		aBacking := randF64(batch*n*m, -2, 2)
+		bBacking := randF64(batch*m*p, -2, 2)
+		weights := randF64(batch*n*p, -2, 2)
+		target := batchMatMul(aBacking, bBacking, batch, n, m, p)
+
+		g := G.NewGraph()
+		a := G.NewTensor(g, tensor.Float64, 3, G.WithName("a"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{batch, n, m},
+				tensor.WithBacking(append([]float64{}, aBacking...)))))
+		b := G.NewTensor(g, tensor.Float64, len(bShape), G.WithName("b"),
+			G.WithValue(tensor.NewDense(tensor.Float64, bShape,
+				tensor.WithBacking(append([]float64{}, bBacking...)))))
+
+		prod, err := BatchMatMul(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var prodVal G.Value
+		G.Read(prod, &prodVal)
+
+		outShape := []int{batch, n, p}
+		if vector {
+			outShape = []int{batch, n}
+		}
+		w := G.NewTensor(g, tensor.Float64, len(outShape), G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, outShape,
+				tensor.WithBacking(append([]float64{}, weights...)))))
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(prod, w))))
+
+		grads, err := G.Grad(loss, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var aGradVal, bGradVal G.Value
+		G.Read(grads[0], &aGradVal)
+		G.Read(grads[1], &bGradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !prodVal.Shape().Eq(tensor.Shape(outShape)) {
+			t.Fatalf("incorrect shape \nexpected: %v \nreceived: %v",
+				outShape, prodVal.Shape())
+		}
+		for j, v := range prodVal.Data().([]float64) {
+			if math.Abs(v-target[j]) > threshold {
+				t.Errorf("incorrect product at index %d \nexpected: %v "+
+					"\nreceived: %v", j, target[j], v)
+			}
+		}
+
+		// Check gradients with respect to both inputs
+		inputs := [][]float64{aBacking, bBacking}
+		gradVals := []G.Value{aGradVal, bGradVal}
+		for k, input := range inputs {
+			for j, computed := range gradVals[k].Data().([]float64) {
+				orig := input[j]
+				input[j] = orig + h
+				upper := weightedSum(batchMatMul(aBacking, bBacking, batch,
+					n, m, p), weights)
+				input[j] = orig - h
+				lower := weightedSum(batchMatMul(aBacking, bBacking, batch,
+					n, m, p), weights)
+				input[j] = orig
+
+				gradTarget := (upper - lower) / (2 * h)
+				if math.Abs(computed-gradTarget) > threshold {
+					t.Errorf("incorrect gradient for input %d at index %d "+
+						"\nexpected: %v \nreceived: %v", k, j, gradTarget,
+						computed)
+				}
+			}
+		}
+	}
+}
+
+// TestBatchMatMulShape tests that BatchMatMul returns an error for
+// inputs with incompatible shapes
+func TestBatchMatMulShape(t *testing.T) {
+	shapes := [][2][]int{
+		{{3, 2, 4}, {3, 3, 2}}, // Inner dimensions mismatch
+		{{3, 2, 4}, {3, 3}},    // Inner dimensions mismatch for vectors
+		{{3, 2, 4}, {2, 4, 2}}, // Batch size mismatch
+		{{2, 4}, {4, 2}},       // Not batched
+		{{3, 2, 4}, {3}},       // b is not batched
+	}
+
+	g := G.NewGraph()
+	for _, shape := range shapes {
+		a := G.NewTensor(g, tensor.Float64, len(shape[0]),
+			G.WithShape(shape[0]...), G.WithName(Unique("a")),
+			G.WithInit(G.Zeroes()))
+		b := G.NewTensor(g, tensor.Float64, len(shape[1]),
+			G.WithShape(shape[1]...), G.WithName(Unique("b")),
+			G.WithInit(G.Zeroes()))
+
+		if _, err := BatchMatMul(a, b); err == nil {
+			t.Errorf("expected an error multiplying shapes %v and %v",
+				shape[0], shape[1])
+		}
+	}
+}
+
+// TestOuter tests the forward and backward pass of Outer. Gradients are
+// checked against finite differences of a weighted sum of the output.
+func TestOuter(t *testing.T) {
+	const threshold float64 = 1e-6 // Threshold to consider floats equal
+	const h float64 = 1e-5         // Step size for finite differences
+	const tests int = 10           // Number of random tests to run
+	const maxSize int = 5          // Maximum length of each vector
+	rand.Seed(time.Now().UnixNano())
+
+	// outer computes the outer product of a and b using a double loop
+	outer := func(a, b []float64) []float64 {
+		out := make([]float64, len(a)*len(b))
+		for i := range a {
+			for j := range b {
+				out[i*len(b)+j] = a[i] * b[j]
+			}
+		}
+		return out
+	}
+
+	for i := 0; i < tests; i++ {
+		n, m := 1+rand.Intn(maxSize), 1+rand.Intn(maxSize)
+		aBacking := randF64(n, -5, 5)
+		bBacking := randF64(m, -5, 5)
+		weights := randF64(n*m, -2, 2)
+		target := outer(aBacking, bBacking)
+
+		g := G.NewGraph()
+		a := G.NewVector(g, tensor.Float64, G.WithName("a"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{n},
+				tensor.WithBacking(append([]float64{}, aBacking...)))))
+		b := G.NewVector(g, tensor.Float64, G.WithName("b"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{m},
+				tensor.WithBacking(append([]float64{}, bBacking...)))))
+		w := G.NewMatrix(g, tensor.Float64, G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{n, m},
+				tensor.WithBacking(append([]float64{}, weights...)))))
+
+		prod, err := Outer(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var prodVal G.Value
+		G.Read(prod, &prodVal)
+
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(prod, w))))
+		grads, err := G.Grad(loss, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var aGradVal, bGradVal G.Value
+		G.Read(grads[0], &aGradVal)
+		G.Read(grads[1], &bGradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !prodVal.Shape().Eq(tensor.Shape{n, m}) {
+			t.Fatalf("incorrect shape \nexpected: %v \nreceived: %v",
+				tensor.Shape{n, m}, prodVal.Shape())
+		}
+		for j, v := range prodVal.Data().([]float64) {
+			if math.Abs(v-target[j]) > threshold {
+				t.Errorf("incorrect outer product at index %d \nexpected: %v "+
+					"\nreceived: %v", j, target[j], v)
+			}
+		}
+
+		// Check gradients with respect to both inputs
+		inputs := [][]float64{aBacking, bBacking}
+		gradVals := []G.Value{aGradVal, bGradVal}
+		for k, input := range inputs {
+			for j, computed := range gradVals[k].Data().([]float64) {
+				orig := input[j]
+				input[j] = orig + h
+				upper := weightedSum(outer(aBacking, bBacking), weights)
+				input[j] = orig - h
+				lower := weightedSum(outer(aBacking, bBacking), weights)
+				input[j] = orig
+
+				gradTarget := (upper - lower) / (2 * h)
+				if math.Abs(computed-gradTarget) > threshold {
+					t.Errorf("incorrect gradient for input %d at index %d "+
+						"\nexpected: %v \nreceived: %v", k, j, gradTarget,
+						computed)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestMinMaxTieGradient tests that the gradients of Min and Max with
+// respect to their two inputs sum to the upstream gradient exactly
+// when the inputs are equal, with the full gradient sent to the first
+// input
+func TestMinMaxTieGradient(t *testing.T) {
+	const size int = 10 // Number of elements to test
+	rand.Seed(time.Now().UnixNano())
+
+	ops := []struct {
+		name string
+		op   func(a, b *G.Node) (*G.Node, error)
+	}{
+		{"Min", Min},
+		{"Max", Max},
+		{"BroadcastMin", func(a, b *G.Node) (*G.Node, error) {
+			return BroadcastMin(a, b, nil, nil)
+		}},
+		{"BroadcastMax", func(a, b *G.Node) (*G.Node, error) {
+			return BroadcastMax(a, b, nil, nil)
+		}},
+	}
+
+	for _, test := range ops {
+		backing := randF64(size, -5, 5)
+		upstream := randF64(size, -5, 5)
+
+		g := G.NewGraph()
+		a := G.NewVector(g, tensor.Float64, G.WithName("a"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(append([]float64{}, backing...)))))
+		b := G.NewVector(g, tensor.Float64, G.WithName("b"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(append([]float64{}, backing...)))))
+		w := G.NewVector(g, tensor.Float64, G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{size},
+				tensor.WithBacking(append([]float64{}, upstream...)))))
+
+		out, err := test.op(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The upstream gradient of out is w
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(out, w))))
+		grads, err := G.Grad(loss, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var aGrad, bGrad G.Value
+		G.Read(grads[0], &aGrad)
+		G.Read(grads[1], &bGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		aData := aGrad.Data().([]float64)
+		bData := bGrad.Data().([]float64)
+		for i := range upstream {
+			if aData[i]+bData[i] != upstream[i] {
+				t.Errorf("%v: expected gradients to sum to %v but got "+
+					"%v + %v", test.name, upstream[i], aData[i], bData[i])
+			}
+			if bData[i] != 0 {
+				t.Errorf("%v: expected no gradient for the second input "+
+					"on ties but got %v", test.name, bData[i])
+			}
+		}
+	}
+}
+
+// TestClipByGlobalNorm tests that ClipByGlobalNorm scales a set of
+// nodes to have global norm maxNorm when their global norm exceeds
+// maxNorm, and leaves them unchanged otherwise
+func TestClipByGlobalNorm(t *testing.T) {
+	const threshold float64 = 1e-9 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	tests := []struct {
+		name    string
+		maxNorm float64
+		scale   float64 // Scale applied to the random inputs
+	}{
+		{"exceeds", 1.5, 10.0},
+		{"within", 1.5, 0.01},
+		{"zero", 1.5, 0.0},
+		{"exceeds small maxNorm", 0.1, 10.0},
+		{"within small maxNorm", 0.1, 0.001},
+
+		// 7.7 * rsqrt(7.7²) rounds to 1 - 2⁻⁵³, so nodes within the norm
+		// are only unchanged if they are not scaled
+		{"within inexact maxNorm", 7.7, 1.0},
+	}
+
+	for _, test := range tests {
+		shapes := [][]int{{3, 4}, {5}}
+		inputs := make([][]float64, len(shapes))
+		var sumSquares float64
+		for i, shape := range shapes {
+			inputs[i] = make([]float64, tensor.ProdInts(shape))
+			for j := range inputs[i] {
+				inputs[i][j] = test.scale * (rand.Float64() + 0.5)
+				sumSquares += inputs[i][j] * inputs[i][j]
+			}
+		}
+		norm := math.Sqrt(sumSquares)
+
+		g := G.NewGraph()
+		nodes := make([]*G.Node, len(shapes))
+		for i, shape := range shapes {
+			nodes[i] = G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithValue(tensor.NewDense(tensor.Float64, shape,
+					tensor.WithBacking(inputs[i]))))
+		}
+
+		clipped, err := ClipByGlobalNorm(nodes, test.maxNorm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := make([]G.Value, len(clipped))
+		for i := range clipped {
+			G.Read(clipped[i], &values[i])
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		var clippedSumSquares float64
+		for i := range values {
+			data := values[i].Data().([]float64)
+			for j := range data {
+				if math.IsNaN(data[j]) {
+					t.Fatalf("%v: unexpected NaN in clipped node %v",
+						test.name, i)
+				}
+				clippedSumSquares += data[j] * data[j]
+
+				if norm <= test.maxNorm && data[j] != inputs[i][j] {
+					t.Errorf("%v: expected node %v to be unchanged at "+
+						"index %v: expected %v but got %v", test.name, i, j,
+						inputs[i][j], data[j])
+				}
+			}
+		}
+
+		clippedNorm := math.Sqrt(clippedSumSquares)
+		expected := math.Min(norm, test.maxNorm)
+		if math.Abs(clippedNorm-expected) > threshold {
+			t.Errorf("%v: expected global norm %v but got %v", test.name,
+				expected, clippedNorm)
+		}
+	}
+}
+
+// TestClipByGlobalNormError tests that ClipByGlobalNorm returns an
+// error on invalid inputs
+func TestClipByGlobalNormError(t *testing.T) {
+	g := G.NewGraph()
+	f64 := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("f64"))
+	f32 := G.NewVector(g, tensor.Float32, G.WithShape(3), G.WithName("f32"))
+	i := G.NewVector(g, tensor.Int, G.WithShape(3), G.WithName("i"))
+
+	tests := []struct {
+		name    string
+		nodes   []*G.Node
+		maxNorm float64
+	}{
+		{"no nodes", nil, 1.0},
+		{"zero maxNorm", []*G.Node{f64}, 0.0},
+		{"negative maxNorm", []*G.Node{f64}, -1.0},
+		{"mixed dtypes", []*G.Node{f64, f32}, 1.0},
+		{"int dtype", []*G.Node{i}, 1.0},
+	}
+
+	for _, test := range tests {
+		if _, err := ClipByGlobalNorm(test.nodes, test.maxNorm); err == nil {
+			t.Errorf("%v: expected error but got nil", test.name)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package gop
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"runtime"
+	"sort"
 
 	colour "github.com/samuelfneumann/gocolour"
 
@@ -38,14 +40,143 @@ func init() {
 func Gather(x *G.Node, axis int, indices *G.Node) (*G.Node, error) {
 	op, err := newGatherOp(axis, indices.Shape().Dims())
 	if err != nil {
-		return nil, fmt.Errorf("gather: %v", err)
+		return nil, fmt.Errorf("gather: %w", err)
 	}
 
 	return G.ApplyOp(op, x, indices)
 }
 
-// Unsqueeze adds a dimension of length 1 at dimension axis
+// IndexSelect selects whole rows of x along axis using the 1-D integer
+// indices, stacking the selected rows along axis in the order of the
+// indices. The output has the shape of x, with the length of axis
+// replaced by the number of indices. Indices may be repeated, in which
+// case the gradients of the repeated rows are accumulated. IndexSelect
+// is a simpler alternative to Gather for the common case of selecting
+// rows of a tensor. A negative axis counts from the last dimension.
+func IndexSelect(x *G.Node, axis int, indices *G.Node) (*G.Node, error) {
+	if x.Shape().Dims() == 0 {
+		return nil, ShapeErrorf("indexSelect: cannot select from non-tensor " +
+			"node")
+	}
+	if indices.Dims() != 1 {
+		return nil, ShapeErrorf("indexSelect: expected indices to be 1-D "+
+			"but got shape %v", indices.Shape())
+	}
+	if indices.Dtype() != tensor.Int {
+		return nil, DtypeErrorf("indexSelect: expected indices to have type "+
+			"%v but got %v", tensor.Int, indices.Dtype())
+	}
+
+	op, err := newIndexSelectOp(axis, x.Shape().Dims())
+	if err != nil {
+		return nil, fmt.Errorf("indexSelect: %w", err)
+	}
+
+	return G.ApplyOp(op, x, indices)
+}
+
+// ScatterAdd adds each element of updates into x at the position
+// given by the corresponding element of indices along axis, and is the
+// inverse of Gather. For a 2-D x and axis 0:
+//
+//		out[indices[i][j]][j] = x[indices[i][j]][j] + updates[i][j]
+//
+// The integer indices and updates must have the same shape, which must
+// match the shape of x at all dimensions except axis. Repeated indices
+// accumulate their updates. The gradient with respect to x is the
+// incoming gradient, and with respect to updates is the incoming
+// gradient gathered at indices. A negative axis counts from the last
+// dimension.
+func ScatterAdd(x, indices, updates *G.Node, axis int) (*G.Node, error) {
+	if x.Shape().Dims() == 0 {
+		return nil, ShapeErrorf("scatterAdd: cannot scatter into non-tensor " +
+			"node")
+	}
+	if indices.Dtype() != tensor.Int {
+		return nil, DtypeErrorf("scatterAdd: expected indices to have type "+
+			"%v but got %v", tensor.Int, indices.Dtype())
+	}
+	if !indices.Shape().Eq(updates.Shape()) {
+		return nil, ShapeErrorf("scatterAdd: expected indices and updates "+
+			"to have the same shape but got %v and %v", indices.Shape(),
+			updates.Shape())
+	}
+
+	op, err := newScatterAddOp(axis, x.Shape().Dims())
+	if err != nil {
+		return nil, fmt.Errorf("scatterAdd: %w", err)
+	}
+
+	return G.ApplyOp(op, x, indices, updates)
+}
+
+// Where selects each element of its output from a where cond is
+// non-zero, and from b elsewhere. The condition must be a mask of 0s
+// and 1s with the same data type as a and b, such as those returned by
+// Gorgonia's comparison operations with retSame set to true. All of
+// cond, a, and b must have the same shape.
+//
+// Elements of the unselected tensor never contribute to the output, so
+// that, unlike masking with products, infinite or NaN elements of the
+// unselected tensor do not propagate to the output. The gradient with
+// respect to a is the incoming gradient masked by cond, and with
+// respect to b is the incoming gradient masked by 1 - cond.
+func Where(cond, a, b *G.Node) (*G.Node, error) {
+	if a.Dims() == 0 {
+		return nil, ShapeErrorf("where: cannot select from non-tensor node")
+	}
+	if !cond.Shape().Eq(a.Shape()) || !b.Shape().Eq(a.Shape()) {
+		return nil, ShapeErrorf("where: expected cond, a, and b to have the "+
+			"same shape but got %v, %v, and %v", cond.Shape(), a.Shape(),
+			b.Shape())
+	}
+	if cond.Dtype() != a.Dtype() || b.Dtype() != a.Dtype() {
+		return nil, DtypeErrorf("where: expected cond, a, and b to have the "+
+			"same type but got %v, %v, and %v", cond.Dtype(), a.Dtype(),
+			b.Dtype())
+	}
+
+	return G.ApplyOp(newWhereOp(a.Dims()), cond, a, b)
+}
+
+// Piecewise builds the two branches of a piecewise function by calling
+// ifTrue and ifFalse, and selects each element of its output from the
+// first branch where cond is non-zero and from the second elsewhere,
+// as in Where. The branches are only built when Piecewise is called,
+// and each must have the same shape and data type as cond. Since
+// selection uses Where, infinite or NaN elements of the unselected
+// branch, such as the log density outside of a distribution's support,
+// do not propagate to the output or its gradient.
+func Piecewise(cond *G.Node, ifTrue, ifFalse func() (*G.Node, error)) (
+	*G.Node, error) {
+	a, err := ifTrue()
+	if err != nil {
+		return nil, fmt.Errorf("piecewise: could not build true branch: %w",
+			err)
+	}
+	b, err := ifFalse()
+	if err != nil {
+		return nil, fmt.Errorf("piecewise: could not build false branch: "+
+			"%w", err)
+	}
+
+	out, err := Where(cond, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("piecewise: %w", err)
+	}
+
+	return out, nil
+}
+
+// Unsqueeze adds a dimension of length 1 at dimension axis. A
+// negative axis counts from the end of the output shape, so that an
+// axis of -1 appends a dimension of length 1.
 func Unsqueeze(x *G.Node, axis int) (*G.Node, error) {
+	axis, err := normalizeAxis(axis, x.Dims()+1)
+	if err != nil {
+		return nil, fmt.Errorf("unsqueeze: %w", err)
+	}
+
 	shape := make(tensor.Shape, 0, x.Dims()+1)
 	shape = append(shape, x.Shape()[:axis]...)
 	shape = append(shape, 1)
@@ -53,14 +184,20 @@ func Unsqueeze(x *G.Node, axis int) (*G.Node, error) {
 
 	out, err := G.Reshape(x, shape)
 	if err != nil {
-		return nil, fmt.Errorf("unsqueeze: could not reshape: %v", err)
+		return nil, fmt.Errorf("unsqueeze: could not reshape: %w", err)
 	}
 	return out, nil
 }
 
 // Squeeze removes an axis if it has a length of 1, otherwise it is
-// a no-op
+// a no-op. A negative axis counts from the last dimension, and an
+// error is returned if the axis is out of range.
 func Squeeze(x *G.Node, axis int) (*G.Node, error) {
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("squeeze: %w", err)
+	}
+
 	if x.Shape()[axis] != 1 {
 		return x, nil
 	}
@@ -73,27 +210,30 @@ func Squeeze(x *G.Node, axis int) (*G.Node, error) {
 
 	out, err := G.Reshape(x, shape)
 	if err != nil {
-		return nil, fmt.Errorf("squeeze: %v", err)
+		return nil, fmt.Errorf("squeeze: %w", err)
 	}
 
 	return out, err
 }
 
-// SqueezeAll squeezes all dimensions
+// SqueezeAll squeezes all dimensions. A tensor whose dimensions are
+// all of length 1 is squeezed to a scalar.
 func SqueezeAll(x *G.Node) (*G.Node, error) {
 	return SqueezeAllBut(x, -1)
 }
 
-// SqueezeAllBut squeezes all dimensions but axis
+// SqueezeAllBut squeezes all dimensions but axis. An axis of -1
+// indicates that all dimensions should be squeezed, and so negative
+// axes are not normalized as they are in the other functions.
 func SqueezeAllBut(x *G.Node, axis int) (*G.Node, error) {
 	var err error
 	dimToSqueeze := 0
-	for {
+	for x.Dims() > 0 {
 		if x.Shape()[dimToSqueeze] == 1 && dimToSqueeze != axis {
 			x, err = Squeeze(x, dimToSqueeze)
 			if err != nil {
-				return nil, fmt.Errorf("reduceAdd: could not squeeze dim %v",
-					dimToSqueeze)
+				return nil, fmt.Errorf("squeezeAllBut: could not squeeze "+
+					"dim %v: %w", dimToSqueeze, err)
 			}
 			if dimToSqueeze < axis {
 				axis--
@@ -111,13 +251,23 @@ func SqueezeAllBut(x *G.Node, axis int) (*G.Node, error) {
 }
 
 // ReduceMean calculates the mean along axis and squeezes all axes.
-// If keepdims is true, then only axis is squeezed.
+// If keepdims is true, then only axis is squeezed. A negative axis
+// counts from the last dimension.
 func ReduceMean(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
+	// If input is a scalar, just return it
+	if x.Dims() == 0 {
+		return x, nil
+	}
+
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("reduceMean: %w", err)
+	}
 	length := x.Shape()[axis]
 
 	sum, err := ReduceAdd(x, axis, keepdims)
 	if err != nil {
-		return nil, fmt.Errorf("reduceMean: could not sum: %v", err)
+		return nil, fmt.Errorf("reduceMean: could not sum: %w", err)
 	}
 
 	var n *G.Node
@@ -126,7 +276,7 @@ func ReduceMean(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 	} else if x.Dtype() == tensor.Float32 {
 		n = G.NewConstant(float32(length))
 	} else {
-		return nil, fmt.Errorf("reduceMean: cannot compute mean of tensor "+
+		return nil, DtypeErrorf("reduceMean: cannot compute mean of tensor "+
 			"with type %v", x.Dtype())
 	}
 
@@ -137,19 +287,19 @@ func ReduceMean(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 		sum, err = G.Reshape(sum, []int{1})
 		if err != nil {
 			return nil, fmt.Errorf("reduceMean: could not reshape scalar to "+
-				"1-vector: %v", err)
+				"1-vector: %w", err)
 		}
 
 		out, err := G.HadamardDiv(sum, n)
 		if err != nil {
 			return nil, fmt.Errorf("reduceMean: could not divide by number "+
-				"of rows: %v", err)
+				"of rows: %w", err)
 		}
 
 		out, err = Squeeze(out, 0)
 		if err != nil {
 			return nil, fmt.Errorf("reduceMean: could not reshape back to "+
-				"scalar: %v", err)
+				"scalar: %w", err)
 		}
 
 		return out, nil
@@ -158,7 +308,7 @@ func ReduceMean(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 	out, err := G.HadamardDiv(sum, n)
 	if err != nil {
 		return nil, fmt.Errorf("reduceMean: could not divide by number of "+
-			"elements: %v", err)
+			"elements: %w", err)
 	}
 
 	return out, err
@@ -174,19 +324,40 @@ func ReduceMean(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 // result and the third row. The process continues until no more
 // rows are left along axis, and dimension axis is then removed.
 //
-// ReduceAlong is like Python's reduce.
+// ReduceAlong is like Python's reduce. A negative axis counts from
+// the last dimension. Any data type supported by f may be reduced, so
+// that ReduceAdd, ReduceSub, and ReduceProd also support integer
+// tensors.
+//
+// The output shape of ReduceAlong, and of all Reduce functions, is the
+// shape of x without axis when keepdims is true. When keepdims is
+// false, all other dimensions of length 1 are removed as well. If no
+// dimensions remain, the output is a 0-dim scalar rather than a vector
+// of shape (1). A 0-dim scalar x is returned as is.
 func ReduceAlong(x *G.Node, axis int, keepdims bool,
 	f func(*G.Node, *G.Node) (*G.Node, error)) (*G.Node, error) {
-	if axis >= x.Dims() {
-		return nil, fmt.Errorf("reduceAlong: axis out of range [%v] with "+
-			"length %v", axis, x.Dims())
-	}
+	return reduceAlong(x, axis, keepdims, f, false)
+}
 
+// reduceAlong is like ReduceAlong, but if associative is true, then f
+// is assumed to be associative and commutative, and the rows along
+// axis are reduced pairwise in a tree rather than sequentially. At
+// each level of the tree, the first half of the rows is combined with
+// the second half using a single application of f, so that the graph
+// holds O(log n) rather than O(n) nodes for an axis of length n.
+func reduceAlong(x *G.Node, axis int, keepdims bool,
+	f func(*G.Node, *G.Node) (*G.Node, error), associative bool) (*G.Node,
+	error) {
 	// If input is a scalar, just return it
 	if x.Dims() == 0 {
 		return x, nil
 	}
 
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("reduceAlong: %w", err)
+	}
+
 	// Get the original shape less axis
 	var origShape tensor.Shape
 	if keepdims {
@@ -199,7 +370,7 @@ func ReduceAlong(x *G.Node, axis int, keepdims bool,
 			out, err := Squeeze(x, axis)
 			if err != nil {
 				return nil, fmt.Errorf("reduceAlong: could not squeeze "+
-					"axis %v: %v", axis, err)
+					"axis %v: %w", axis, err)
 			}
 			return out, nil
 		}
@@ -209,10 +380,9 @@ func ReduceAlong(x *G.Node, axis int, keepdims bool,
 	newAxis := axis - countOnesBefore(x.Shape(), axis)
 
 	// Squeeze out all dimensions of length 1 besides axis
-	var err error
 	x, err = SqueezeAllBut(x, axis)
 	if err != nil {
-		return nil, fmt.Errorf("reduceAlong: could not squeeze dimensions: %v",
+		return nil, fmt.Errorf("reduceAlong: could not squeeze dimensions: %w",
 			err)
 	}
 	axis = newAxis // Update axis to reflect squeezing of dims
@@ -223,17 +393,43 @@ func ReduceAlong(x *G.Node, axis int, keepdims bool,
 		out, err := Squeeze(x, axis)
 		if err != nil {
 			return nil, fmt.Errorf("reduceAlong: could not squeeze "+
-				"axis %v: %v", axis, err)
+				"axis %v: %w", axis, err)
 		}
 		return out, nil
 	}
 
+	var row *G.Node
+	if associative {
+		row, err = reduceTree(x, axis, length, f)
+	} else {
+		row, err = reduceSequential(x, axis, length, f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reduceAlong: %w", err)
+	}
+
+	if keepdims {
+		// Reshape back to original shape less axis
+		row, err = G.Reshape(row, origShape)
+		if err != nil {
+			return nil, fmt.Errorf("reduceAlong: could not reshape back to "+
+				"original dims: %w", err)
+		}
+	}
+
+	return row, nil
+}
+
+// reduceSequential applies f to each row of x along axis of length
+// length in turn, so that the result is f(...f(f(r_0, r_1), r_2)...)
+func reduceSequential(x *G.Node, axis, length int,
+	f func(*G.Node, *G.Node) (*G.Node, error)) (*G.Node, error) {
 	// Get the first row along the axis
 	ind := make([]tensor.Slice, x.Dims())
 	ind[axis] = G.S(0, 1, 1)
 	row, err := G.Slice(x, ind...)
 	if err != nil {
-		return nil, fmt.Errorf("reduceAlong: axis does not have any elements")
+		return nil, fmt.Errorf("axis does not have any elements")
 	}
 
 	// Calculate f(row, next row) for each next row
@@ -241,27 +437,71 @@ func ReduceAlong(x *G.Node, axis int, keepdims bool,
 		ind[axis] = G.S(i, i+1, 1)
 		nextRow, err := G.Slice(x, ind...)
 		if err != nil {
-			return nil, fmt.Errorf("reduceAlong: could not get row %v: %v",
-				i, err)
+			return nil, fmt.Errorf("could not get row %v: %w", i, err)
 		}
 
 		row, err = f(row, nextRow)
 		if err != nil {
-			return nil, fmt.Errorf("reduceAlong: could not compute f "+
-				"along rows: %v", err)
+			return nil, fmt.Errorf("could not compute f along rows: %w", err)
 		}
 	}
 
-	if keepdims {
-		// Reshape back to original shape less axis
-		row, err = G.Reshape(row, origShape)
+	return row, nil
+}
+
+// reduceTree reduces x along axis of length length by repeatedly
+// applying f to the first and second halves of the rows along axis.
+// If the number of rows is odd, the last row is set aside and combined
+// with the result at the end. The function f must be associative and
+// commutative.
+func reduceTree(x *G.Node, axis, length int,
+	f func(*G.Node, *G.Node) (*G.Node, error)) (*G.Node, error) {
+	var err error
+	var leftover []*G.Node
+	ind := make([]tensor.Slice, x.Dims())
+
+	for length > 1 {
+		half := length / 2
+		if length%2 == 1 {
+			ind[axis] = G.S(length-1, length, 1)
+			last, err := G.Slice(x, ind...)
+			if err != nil {
+				return nil, fmt.Errorf("could not get row %v: %w", length-1,
+					err)
+			}
+			leftover = append(leftover, last)
+		}
+
+		ind[axis] = G.S(0, half, 1)
+		first, err := G.Slice(x, ind...)
 		if err != nil {
-			return nil, fmt.Errorf("reduceAlong: could not reshape back to "+
-				"original dims: %v", err)
+			return nil, fmt.Errorf("could not get rows [0, %v): %w", half,
+				err)
 		}
+		ind[axis] = G.S(half, 2*half, 1)
+		second, err := G.Slice(x, ind...)
+		if err != nil {
+			return nil, fmt.Errorf("could not get rows [%v, %v): %w", half,
+				2*half, err)
+		}
+
+		x, err = f(first, second)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute f along rows: %w", err)
+		}
+		length = half
 	}
 
-	return row, nil
+	// Slicing a single row removes axis, so x and each leftover row
+	// have the same shape
+	for _, last := range leftover {
+		x, err = f(x, last)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute f along rows: %w", err)
+		}
+	}
+
+	return x, nil
 }
 
 // ReduceSub calculates the difference along axis and squeezes all
@@ -271,9 +511,34 @@ func ReduceSub(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 }
 
 // ReduceAdd calculates the sum along axis and squeezes all axes.
-// If keepdims is true, then only axis is squeezed.
+// If keepdims is true, then only axis is squeezed. Unlike the other
+// Reduce functions, the sum is computed by a single operation rather
+// than by slicing out the rows along axis.
 func ReduceAdd(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
-	return ReduceAlong(x, axis, keepdims, G.Add)
+	// If input is a scalar, just return it
+	if x.Dims() == 0 {
+		return x, nil
+	}
+
+	op, err := newReduceAddOp(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("reduceAdd: %w", err)
+	}
+
+	out, err := G.ApplyOp(op, x)
+	if err != nil {
+		return nil, fmt.Errorf("reduceAdd: %w", err)
+	}
+
+	if !keepdims && out.Dims() > 0 {
+		out, err = SqueezeAll(out)
+		if err != nil {
+			return nil, fmt.Errorf("reduceAdd: could not squeeze dims: %w",
+				err)
+		}
+	}
+
+	return out, nil
 }
 
 // ReduceDiv calculates the quotient along axis and squeezes all axes.
@@ -285,7 +550,170 @@ func ReduceDiv(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 // ReduceProd calculates the product along axis and squeezes all axes.
 // If keepdims is true, then only axis is squeezed.
 func ReduceProd(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
-	return ReduceAlong(x, axis, keepdims, G.HadamardProd)
+	return reduceAlong(x, axis, keepdims, G.HadamardProd, true)
+}
+
+// ReduceAddAxes calculates the sum over all axes in axes. If keepdims
+// is true, then only the axes in axes are removed. Otherwise, all axes
+// of length 1 are squeezed, as in ReduceAdd.
+func ReduceAddAxes(x *G.Node, axes []int, keepdims bool) (*G.Node, error) {
+	out, err := reduceAxes(x, axes, keepdims, ReduceAdd)
+	if err != nil {
+		return nil, fmt.Errorf("reduceAddAxes: %w", err)
+	}
+	return out, nil
+}
+
+// ReduceMeanAxes calculates the mean over all axes in axes. If
+// keepdims is true, then only the axes in axes are removed. Otherwise,
+// all axes of length 1 are squeezed, as in ReduceMean.
+func ReduceMeanAxes(x *G.Node, axes []int, keepdims bool) (*G.Node,
+	error) {
+	out, err := reduceAxes(x, axes, keepdims, ReduceMean)
+	if err != nil {
+		return nil, fmt.Errorf("reduceMeanAxes: %w", err)
+	}
+	return out, nil
+}
+
+// ReduceProdAxes calculates the product over all axes in axes. If
+// keepdims is true, then only the axes in axes are removed. Otherwise,
+// all axes of length 1 are squeezed, as in ReduceProd.
+func ReduceProdAxes(x *G.Node, axes []int, keepdims bool) (*G.Node,
+	error) {
+	out, err := reduceAxes(x, axes, keepdims, ReduceProd)
+	if err != nil {
+		return nil, fmt.Errorf("reduceProdAxes: %w", err)
+	}
+	return out, nil
+}
+
+// ReduceAll calculates the logical and along axis and squeezes all
+// axes, treating nonzero elements of x as true. The returned node holds
+// 1 where all elements along axis are nonzero and 0 elsewhere. If
+// keepdims is true, then only axis is squeezed. A negative axis counts
+// from the last dimension. x must have data type Float64 or Float32.
+func ReduceAll(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
+	if err := checkFloatDtype("reduceAll", x.Dtype()); err != nil {
+		return nil, err
+	}
+
+	mask, err := nonzero(x)
+	if err != nil {
+		return nil, fmt.Errorf("reduceAll: %w", err)
+	}
+
+	out, err := ReduceProd(mask, axis, keepdims)
+	if err != nil {
+		return nil, fmt.Errorf("reduceAll: %w", err)
+	}
+
+	return out, nil
+}
+
+// ReduceAny calculates the logical or along axis and squeezes all
+// axes, treating nonzero elements of x as true. The returned node holds
+// 1 where any element along axis is nonzero and 0 elsewhere. If
+// keepdims is true, then only axis is squeezed. A negative axis counts
+// from the last dimension. x must have data type Float64 or Float32.
+func ReduceAny(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
+	if err := checkFloatDtype("reduceAny", x.Dtype()); err != nil {
+		return nil, err
+	}
+
+	mask, err := nonzero(x)
+	if err != nil {
+		return nil, fmt.Errorf("reduceAny: %w", err)
+	}
+
+	out, err := reduceAlong(mask, axis, keepdims, logicalOr, true)
+	if err != nil {
+		return nil, fmt.Errorf("reduceAny: %w", err)
+	}
+
+	return out, nil
+}
+
+// logicalOr returns the element-wise logical or of the binary nodes a
+// and b, computed as a + b - a⊙b so that the result remains binary
+func logicalOr(a, b *G.Node) (*G.Node, error) {
+	sum, err := G.Add(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("logicalOr: %w", err)
+	}
+
+	prod, err := G.HadamardProd(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("logicalOr: %w", err)
+	}
+
+	return G.Sub(sum, prod)
+}
+
+// nonzero returns a node of the same shape and data type as x that is
+// 1 where x is nonzero and 0 elsewhere. x must have data type Float64
+// or Float32.
+func nonzero(x *G.Node) (*G.Node, error) {
+	var zero *G.Node
+	if x.Dtype() == tensor.Float32 {
+		zero = G.NewConstant(float32(0.0))
+	} else {
+		zero = G.NewConstant(0.0)
+	}
+
+	mask, err := G.Ne(x, zero, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute nonzero mask: %w", err)
+	}
+
+	return mask, nil
+}
+
+// oneLike returns a scalar constant 1 of the same data type as x,
+// which must be Float64 or Float32
+func oneLike(x *G.Node) *G.Node {
+	if x.Dtype() == tensor.Float32 {
+		return G.NewConstant(float32(1.0))
+	}
+	return G.NewConstant(1.0)
+}
+
+// reduceAxes applies the single-axis reduction reduce over each axis
+// in axes. Axes may be negative and are reduced from last to first,
+// so that removing an axis does not shift the axes yet to be reduced.
+func reduceAxes(x *G.Node, axes []int, keepdims bool,
+	reduce func(*G.Node, int, bool) (*G.Node, error)) (*G.Node, error) {
+	normalized := make([]int, 0, len(axes))
+	seen := make(map[int]bool, len(axes))
+	for _, axis := range axes {
+		axis, err := normalizeAxis(axis, x.Dims())
+		if err != nil {
+			return nil, err
+		}
+		if seen[axis] {
+			return nil, ShapeErrorf("axis %v repeated", axis)
+		}
+		seen[axis] = true
+		normalized = append(normalized, axis)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(normalized)))
+
+	var err error
+	for _, axis := range normalized {
+		x, err = reduce(x, axis, true)
+		if err != nil {
+			return nil, fmt.Errorf("could not reduce axis %v: %w", axis, err)
+		}
+	}
+
+	if !keepdims && x.Dims() > 0 {
+		x, err = SqueezeAll(x)
+		if err != nil {
+			return nil, fmt.Errorf("could not squeeze dims: %w", err)
+		}
+	}
+
+	return x, nil
 }
 
 // Repeat repeats the elements of x along axis repeats times. This
@@ -293,16 +721,62 @@ func ReduceProd(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
 // PyTorch's repeat_interleave function.
 func Repeat(x *G.Node, axis, repeats int) (*G.Node, error) {
 	if x.Shape().Dims() == 0 {
-		return nil, fmt.Errorf("repeat: cannot repeat non-tensor node")
+		return nil, ShapeErrorf("repeat: cannot repeat non-tensor node")
 	}
 	if axis >= x.Shape().Dims() {
-		return nil, fmt.Errorf("repeat: cannot have axis (%v) > dims (%v)",
+		return nil, ShapeErrorf("repeat: cannot have axis (%v) > dims (%v)",
 			axis, x.Shape().Dims())
 	}
 
 	op, err := newRepeatOp(axis, x.Shape().Dims(), repeats)
 	if err != nil {
-		return nil, fmt.Errorf("repeat: %v", err)
+		return nil, fmt.Errorf("repeat: %w", err)
+	}
+
+	return G.ApplyOp(op, x)
+}
+
+// repeatAlong repeats x, which has length 1 along axis, so that it has
+// length elements along axis. This is used in place of broadcasting,
+// since Gorgonia computes incorrect gradients when broadcasting along
+// some axes.
+func repeatAlong(x *G.Node, axis, length int) (*G.Node, error) {
+	if length == 1 {
+		return x, nil
+	}
+	return Repeat(x, axis, length)
+}
+
+// Roll circularly shifts the elements of x along axis by shift
+// positions, so that elements shifted beyond the last position along
+// axis are re-introduced at the first. A negative shift rolls elements
+// towards the first position, and a negative axis counts from the last
+// dimension. This function is conceptually similar to Numpy's roll
+// function.
+func Roll(x *G.Node, axis, shift int) (*G.Node, error) {
+	if x.Shape().Dims() == 0 {
+		return nil, ShapeErrorf("roll: cannot roll non-tensor node")
+	}
+
+	op, err := newRollOp(axis, x.Shape().Dims(), shift)
+	if err != nil {
+		return nil, fmt.Errorf("roll: %w", err)
+	}
+
+	return G.ApplyOp(op, x)
+}
+
+// Flip reverses the order of the elements of x along axis. A negative
+// axis counts from the last dimension. Flip can be used with Argsort
+// to obtain descending orderings.
+func Flip(x *G.Node, axis int) (*G.Node, error) {
+	if x.Shape().Dims() == 0 {
+		return nil, ShapeErrorf("flip: cannot flip non-tensor node")
+	}
+
+	op, err := newFlipOp(axis, x.Shape().Dims())
+	if err != nil {
+		return nil, fmt.Errorf("flip: %w", err)
 	}
 
 	return G.ApplyOp(op, x)
@@ -311,10 +785,11 @@ func Repeat(x *G.Node, axis, repeats int) (*G.Node, error) {
 // Clamp clamps a node's values to be between min and max. This function
 // can clamp a tensor storing float64's, float32's, or any integer
 // type, but is only differentiable if the tensor stores floating point
-// types. If clamping a tensor of an integer type, the returned tensor
-// will have type tensor.Int, regardless of the input tensor integer
-// type. If passGradient is true, then the gradient is passed through
-// the clamping operation:
+// types. The returned tensor has the same data type as x, including
+// for integer tensors of any width, and min and max must have the same
+// type as the elements of x. To request a different output data type,
+// use ClampAs(). If passGradient is true, then the gradient is passed
+// through the clamping operation:
 //
 //				⎧ 1 if min <= x <= max
 //		grad =  ⎨
@@ -326,144 +801,570 @@ func Repeat(x *G.Node, axis, repeats int) (*G.Node, error) {
 //		grad =  ⎨
 //				⎩ 0 otherwise
 //
+// Either min or max may be nil, in which case x is not clamped on that
+// side, and the gradient is always passed through on that side. NaNs
+// are passed through unchanged, along with their gradient. To replace
+// NaNs with a bound instead, use ClampNaN().
 func Clamp(x *G.Node, min, max interface{}, passGradient bool) (*G.Node,
 	error) {
+	if min == nil && max == nil {
+		return nil, fmt.Errorf("clamp: at least one of min or max must " +
+			"be non-nil")
+	}
+
 	op, err := newClampOp(min, max, passGradient)
 	if err != nil {
-		return nil, fmt.Errorf("clamp: %v", err)
+		return nil, fmt.Errorf("clamp: %w", err)
 	}
 
 	return G.ApplyOp(op, x)
 }
 
-// Argsort returns the indices that would sort x along axis
-func Argsort(x *G.Node, axis int) (*G.Node, error) {
-	op := newArgsortOp(axis, x.Shape().Dims())
-
-	return G.ApplyOp(op, x)
-}
+// ClampNaN clamps a node's values to be between min and max in the
+// same way as Clamp(), treating NaNs according to the policy nan. With
+// NaNPreserve, NaNs are passed through unchanged as in Clamp(). With
+// NaNToMin or NaNToMax, NaNs are replaced with min or max respectively,
+// which must then be non-nil, and are treated as clamped values, so
+// that their gradient is 0 unless passGradient is true. This is useful
+// when clamping the outputs of a network which may become NaN during
+// unstable training.
+func ClampNaN(x *G.Node, min, max interface{}, passGradient bool,
+	nan NaNPolicy) (*G.Node, error) {
+	if min == nil && max == nil {
+		return nil, fmt.Errorf("clampNaN: at least one of min or max " +
+			"must be non-nil")
+	}
 
-// Erfinv computes the element-wise inverse error function
-func Erfinv(x *G.Node) (*G.Node, error) {
-	op := newErfinvOp()
+	op, err := newClampNaNOp(min, max, passGradient, nan)
+	if err != nil {
+		return nil, fmt.Errorf("clampNaN: %w", err)
+	}
 
 	return G.ApplyOp(op, x)
 }
 
-// Erf computes the element-wise error function
-func Erf(x *G.Node) (*G.Node, error) {
-	op := newErfOp()
+// ClampAs clamps a node's values to be between min and max in the same
+// way as Clamp(), and then converts the clamped values to data type
+// dt. For example, an integer tensor of any width may be clamped and
+// converted to tensor.Float64 for use in a differentiable graph, or
+// to tensor.Int for use with other operations in this package. The
+// gradient with respect to x is converted back to the data type of x.
+func ClampAs(x *G.Node, min, max interface{}, passGradient bool,
+	dt tensor.Dtype) (*G.Node, error) {
+	if min == nil && max == nil {
+		return nil, fmt.Errorf("clampAs: at least one of min or max must " +
+			"be non-nil")
+	}
+
+	op, err := newClampAsOp(min, max, passGradient, dt, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("clampAs: %w", err)
+	}
 
 	return G.ApplyOp(op, x)
 }
 
-// Erfc computes the element-wise complementary error function
-func Erfc(x *G.Node) (*G.Node, error) {
-	op := newErfOp()
+// ClampMin clamps a node's values to be at least min, leaving values
+// above min unchanged. For example, ClampMin(x, 0.0, false) computes
+// the ReLU of a float64 node x. The gradient is treated in the same
+// way as in Clamp().
+func ClampMin(x *G.Node, min interface{}, passGradient bool) (*G.Node,
+	error) {
+	if min == nil {
+		return nil, fmt.Errorf("clampMin: min must be non-nil")
+	}
 
-	retVal, err := G.ApplyOp(op, x)
+	out, err := Clamp(x, min, nil, passGradient)
 	if err != nil {
-		return nil, fmt.Errorf("erfc: %v", err)
+		return nil, fmt.Errorf("clampMin: %w", err)
 	}
+	return out, nil
+}
 
-	var one *G.Node
-	switch x.Dtype() {
-	case G.Float64:
-		one = G.NewScalar(
-			x.Graph(),
-			G.Float64,
-			G.WithValue(1.0),
-		)
-
-	case G.Float32:
-		one = G.NewScalar(
-			x.Graph(),
-			G.Float32,
-			G.WithValue(float32(1.0)),
-		)
+// ClampMax clamps a node's values to be at most max, leaving values
+// below max unchanged. The gradient is treated in the same way as in
+// Clamp().
+func ClampMax(x *G.Node, max interface{}, passGradient bool) (*G.Node,
+	error) {
+	if max == nil {
+		return nil, fmt.Errorf("clampMax: max must be non-nil")
 	}
 
-	return G.Sub(one, retVal)
+	out, err := Clamp(x, nil, max, passGradient)
+	if err != nil {
+		return nil, fmt.Errorf("clampMax: %w", err)
+	}
+	return out, nil
 }
 
-// Clip performs an element-wise clipping of all values in a node
-// to be within [max, min]. This is similar to the Clamp operation,
-// but is implemented differently. The Clamp operation should be
-// used in place of this one whenever possible.
-func Clip(value *G.Node, min, max float64) (retVal *G.Node, err error) {
-	// Construct clipping nodes
-	var minNode, maxNode *G.Node
-	switch value.Dtype() {
-	case G.Float32:
-		minNode = G.NewScalar(
-			value.Graph(),
-			G.Float32,
-			G.WithValue(float32(min)),
-			G.WithName("clip_min"),
-		)
-		maxNode = G.NewScalar(
-			value.Graph(),
-			G.Float32,
-			G.WithValue(float32(max)),
-			G.WithName("clip_max"),
-		)
-	case G.Float64:
-		minNode = G.NewScalar(
-			value.Graph(),
-			G.Float64,
-			G.WithValue(min),
-			G.WithName("clip_min"),
-		)
-		maxNode = G.NewScalar(
-			value.Graph(),
-			G.Float64,
-			G.WithValue(max),
-			G.WithName("clip_max"),
-		)
-	}
-
-	// Check if its the min value
-	minMask, err := G.Lt(value, minNode, true)
-	if err != nil {
+// StableTanh computes the element-wise hyperbolic tangent of x,
+// clamped to be within [-1+eps, 1-eps] so that the output can safely
+// be passed to an inverse hyperbolic tangent. The gradient is
+// 1 - y², where y is the clamped output, so that it never saturates to
+// exactly 0: for inputs of large magnitude, the gradient is
+// 2eps - eps² rather than the 0 of Gorgonia's tanh. Only float64 and
+// float32 nodes are supported.
+func StableTanh(x *G.Node, eps float64) (*G.Node, error) {
+	if eps <= 0 || eps >= 1 {
+		return nil, fmt.Errorf("stableTanh: expected 0 < eps < 1 but got %v",
+			eps)
+	}
+	if err := checkFloatDtype("stableTanh", x.Dtype()); err != nil {
 		return nil, err
 	}
-	minVal, err := G.HadamardProd(minNode, minMask)
-	if err != nil {
+	op := newStableTanhOp(eps)
+
+	return G.ApplyOp(op, x)
+}
+
+// Atanh computes the element-wise inverse hyperbolic tangent. Running
+// the operation results in an error if any element of x lies outside
+// (-1, 1), and so inputs which may saturate, such as the outputs of a
+// tanh, should first be clamped, for example using StableTanh.
+func Atanh(x *G.Node) (*G.Node, error) {
+	op := newAtanhOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// CheckProbs returns x unchanged, but running the operation results in
+// an error if any element of x lies outside (0, 1). CheckProbs can be
+// used to validate inputs to functions such as quantile functions,
+// which would otherwise silently produce NaN or ±Inf for inputs that
+// are not probabilities. The gradient is passed through unchanged.
+func CheckProbs(x *G.Node) (*G.Node, error) {
+	op := newCheckProbsOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Sign computes the element-wise sign of x, which is -1 for negative
+// elements, 1 for positive elements, and 0 for elements equal to 0.
+// The gradient of Sign is defined to be 0 everywhere, so that Sign can
+// be used in differentiated graphs.
+func Sign(x *G.Node) (*G.Node, error) {
+	op := newSignOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// StopGradient returns the values of x unchanged, but with a gradient
+// of 0 everywhere, so that x is treated as a constant by any gradient
+// computed through the returned node. Like Sign, StopGradient can be
+// used in differentiated graphs.
+func StopGradient(x *G.Node) (*G.Node, error) {
+	op := newStopGradientOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Reciprocal computes the element-wise reciprocal, 1 / x. Division by
+// zero follows IEEE 754 semantics, so that the reciprocal of ±0 is ±Inf
+// and its gradient is -Inf.
+func Reciprocal(x *G.Node) (*G.Node, error) {
+	op := newReciprocalOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Rsqrt computes the element-wise reciprocal square root, 1 / √x, such
+// as to convert a variance to the reciprocal of a standard deviation.
+// The reciprocal square root of 0 is +Inf and of a negative number is
+// NaN, and these values propagate to the gradient.
+func Rsqrt(x *G.Node) (*G.Node, error) {
+	op := newRsqrtOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Square computes the element-wise square, x². The square is computed
+// as x * x rather than through a general power operation, which is
+// faster and more accurate, and has the exact gradient 2x.
+func Square(x *G.Node) (*G.Node, error) {
+	op := newSquareOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Pow2 computes the element-wise square, x², and is equivalent to
+// Square. It can be used in place of Pow(x, 2).
+func Pow2(x *G.Node) (*G.Node, error) {
+	return Square(x)
+}
+
+// Round rounds each element of x to the nearest integer, rounding half
+// away from zero. Since rounding is piecewise constant, its gradient
+// is 0 almost everywhere, which would stop any gradient from flowing
+// through it. Instead, Round uses the straight-through estimator,
+// passing the gradient through unchanged as if Round were the
+// identity. This allows Round to be used in differentiable pipelines,
+// such as quantization-aware training.
+func Round(x *G.Node) (*G.Node, error) {
+	op := newRoundOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Floor computes the element-wise floor of x. Like Round(), Floor uses
+// the straight-through estimator, passing the gradient through
+// unchanged.
+func Floor(x *G.Node) (*G.Node, error) {
+	op := newFloorOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Ceil computes the element-wise ceiling of x. Like Round(), Ceil uses
+// the straight-through estimator, passing the gradient through
+// unchanged.
+func Ceil(x *G.Node) (*G.Node, error) {
+	op := newCeilOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Log1p computes the element-wise log(1 + x), which is more accurate
+// than computing the log of 1 + x when x is near 0
+func Log1p(x *G.Node) (*G.Node, error) {
+	op := newLog1pOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Expm1 computes the element-wise exp(x) - 1, which is more accurate
+// than subtracting 1 from the exponential of x when x is near 0
+func Expm1(x *G.Node) (*G.Node, error) {
+	op := newExpm1Op()
+
+	return G.ApplyOp(op, x)
+}
+
+// Softplus computes the element-wise softplus function,
+// log(1 + exp(x)), in a numerically stable way. Softplus is commonly
+// used to constrain parameters, such as the standard deviation of a
+// Normal, to be positive.
+func Softplus(x *G.Node) (*G.Node, error) {
+	op := newSoftplusOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Argsort returns the indices that would sort x along axis
+func Argsort(x *G.Node, axis int) (*G.Node, error) {
+	op := newArgsortOp(axis, x.Shape().Dims())
+
+	return G.ApplyOp(op, x)
+}
+
+// SoftSort computes a differentiable relaxation of the permutation
+// which sorts x along axis in ascending order, as Argsort does, using
+// the NeuralSort relaxation of Grover et al. (2019). For each slice
+// s of length n along axis, the soft permutation matrix has rows
+//
+//	P[i, :] = softmax(((2i + 1 - n) s - A 1) / tau)
+//
+// for i = 0, 1, ..., n-1, where A is the matrix of pairwise absolute
+// differences |s_j - s_k|. Each row of P is a distribution over the
+// elements of s, with row i giving the weight of each element in
+// position i of the sorted slice, so that P s is a relaxation of the
+// sorted slice. As tau -> 0, P approaches the hard permutation matrix,
+// whose row i is one-hot at the i-th index returned by Argsort.
+//
+// The returned node has the shape of x with axis removed and two
+// dimensions of length n appended, so that the soft permutation of a
+// vector is an (n, n) matrix. A negative axis counts from the last
+// dimension. Only float64 and float32 nodes are supported, and tau
+// must be positive.
+func SoftSort(x *G.Node, axis int, tau float64) (*G.Node, error) {
+	if x.Dims() == 0 {
+		return nil, ShapeErrorf("softSort: cannot sort non-tensor node")
+	}
+	if err := checkFloatDtype("softSort", x.Dtype()); err != nil {
 		return nil, err
 	}
+	if tau <= 0 {
+		return nil, fmt.Errorf("softSort: expected tau > 0 but got %v", tau)
+	}
 
-	// Check if its the given value
-	isMaskGt, err := G.Gt(value, minNode, true)
+	axis, err := normalizeAxis(axis, x.Dims())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("softSort: %w", err)
 	}
-	isMaskLt, err := G.Lt(value, maxNode, true)
+
+	// As in LogSoftmax, move axis to the end and flatten all other axes
+	// so that each row is sorted independently
+	shape := x.Shape().Clone()
+	length := shape[axis]
+	batch := shape.TotalSize() / length
+	outShape := append(shape[:axis:axis], shape[axis+1:]...)
+	outShape = append(outShape, length, length)
+
+	if axis != x.Dims()-1 {
+		pattern := make([]int, 0, x.Dims())
+		for i := 0; i < x.Dims(); i++ {
+			if i != axis {
+				pattern = append(pattern, i)
+			}
+		}
+		pattern = append(pattern, axis)
+
+		x, err = G.Transpose(x, pattern...)
+		if err != nil {
+			return nil, fmt.Errorf("softSort: could not move axis %v to "+
+				"the end: %w", axis, err)
+		}
+	}
+
+	// Compute the pairwise differences (B, n, n) where rows[b, j, k] is
+	// s_j and cols[b, j, k] is s_k
+	rows, err := G.Reshape(x, []int{batch, length, 1})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("softSort: could not flatten: %w", err)
 	}
-	isMask, err := G.HadamardProd(isMaskGt, isMaskLt)
+	cols, err := G.Reshape(x, []int{batch, 1, length})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("softSort: could not flatten: %w", err)
 	}
-	isVal, err := G.HadamardProd(value, isMask)
+	rows, err = repeatAlong(rows, 2, length)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("softSort: could not repeat rows: %w", err)
+	}
+	cols, err = repeatAlong(cols, 1, length)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not repeat columns: %w",
+			err)
 	}
 
-	// Check if its the max value
-	maxMask, err := G.Gt(value, maxNode, true)
+	diffs, err := G.Sub(rows, cols)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("softSort: could not compute pairwise "+
+			"differences: %w", err)
+	}
+	diffs, err = G.Abs(diffs)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not compute pairwise "+
+			"differences: %w", err)
+	}
+
+	// (A 1)_k = Σ_j |s_j - s_k|, repeated along each row of P
+	absSum, err := ReduceAdd(diffs, 1, true)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not sum pairwise "+
+			"differences: %w", err)
+	}
+	absSum, err = G.Reshape(absSum, []int{batch, 1, length})
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not reshape sum of "+
+			"pairwise differences: %w", err)
+	}
+	absSum, err = repeatAlong(absSum, 1, length)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not repeat sum of "+
+			"pairwise differences: %w", err)
+	}
+
+	// The scaling (2i + 1 - n) / tau of row i, which is constant
+	scale := make([]float64, batch*length*length)
+	for b := 0; b < batch; b++ {
+		for i := 0; i < length; i++ {
+			for j := 0; j < length; j++ {
+				scale[(b*length+i)*length+j] = float64(2*i+1-length) / tau
+			}
+		}
 	}
-	maxVal, err := G.HadamardProd(maxNode, maxMask)
+	scaleTensor, err := convertDtype(tensor.NewDense(tensor.Float64,
+		[]int{batch, length, length}, tensor.WithBacking(scale)), x.Dtype())
 	if err != nil {
+		return nil, fmt.Errorf("softSort: could not create scaling: %w", err)
+	}
+	scaleNode := G.NewConstant(scaleTensor)
+
+	var invTau *G.Node
+	if x.Dtype() == tensor.Float64 {
+		invTau = G.NewConstant(1.0 / tau)
+	} else {
+		invTau = G.NewConstant(float32(1.0 / tau))
+	}
+
+	logits, err := G.HadamardProd(scaleNode, cols)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not scale inputs: %w", err)
+	}
+	absSum, err = G.Mul(absSum, invTau)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not scale sum of pairwise "+
+			"differences: %w", err)
+	}
+	logits, err = G.Sub(logits, absSum)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not compute logits: %w", err)
+	}
+
+	out, err := Softmax(logits, 2)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: %w", err)
+	}
+
+	out, err = G.Reshape(out, outShape)
+	if err != nil {
+		return nil, fmt.Errorf("softSort: could not reshape to %v: %w",
+			outShape, err)
+	}
+
+	return out, nil
+}
+
+// Argmax returns the index of the maximum element of x along axis,
+// with ties broken in favour of the lowest index. The returned node is
+// of type tensor.Int and has the shape of x with axis removed, so that
+// the argmax of a vector is a scalar. A negative axis counts from the
+// last dimension. Argmax is not differentiable.
+func Argmax(x *G.Node, axis int) (*G.Node, error) {
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("argmax: %w", err)
+	}
+	op := newArgmaxOp(axis, x.Dims())
+
+	return G.ApplyOp(op, x)
+}
+
+// Histogram counts the number of elements of x falling in each of bins
+// equal-width bins spanning [low, high] along the last axis of x. Bin
+// i counts the elements in [low + i*w, low + (i+1)*w), where
+// w = (high - low) / bins, except that the last bin also includes
+// high. Values outside of [low, high] are clamped into the edge bins,
+// and NaN values are not counted.
+//
+// The returned node is of type tensor.Int and has the shape of x with
+// the last axis replaced by bins, so that the histogram of a vector is
+// a vector of length bins. Histogram is not differentiable, and is
+// useful for inspecting samples, such as those returned by the Sample
+// method of a distribution.
+func Histogram(x *G.Node, bins int, low, high float64) (*G.Node, error) {
+	if x.Dims() == 0 {
+		return nil, ShapeErrorf("histogram: cannot compute histogram of " +
+			"non-tensor node")
+	}
+	if err := checkFloatDtype("histogram", x.Dtype()); err != nil {
+		return nil, err
+	}
+	if bins <= 0 {
+		return nil, fmt.Errorf("histogram: expected bins > 0 but got %v",
+			bins)
+	}
+	if !(high > low) || math.IsInf(low, 0) || math.IsInf(high, 0) {
+		return nil, fmt.Errorf("histogram: expected finite low < high but "+
+			"got low = %v, high = %v", low, high)
+	}
+	op := newHistogramOp(bins, low, high, x.Dims())
+
+	return G.ApplyOp(op, x)
+}
+
+// Erfinv computes the element-wise inverse error function. Only
+// float64 and float32 nodes are supported, and a DtypeError is
+// returned for any other data type.
+func Erfinv(x *G.Node) (*G.Node, error) {
+	if err := checkFloatDtype("erfinv", x.Dtype()); err != nil {
+		return nil, err
+	}
+	op := newErfinvOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Erf computes the element-wise error function. Only float64 and
+// float32 nodes are supported, and a DtypeError is returned for any
+// other data type.
+func Erf(x *G.Node) (*G.Node, error) {
+	if err := checkFloatDtype("erf", x.Dtype()); err != nil {
 		return nil, err
 	}
-	return G.ReduceAdd(G.Nodes{minVal, isVal, maxVal})
+	op := newErfOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Erfc computes the element-wise complementary error function. The
+// complementary error function is computed directly rather than as
+// 1 - erf(x), and so remains accurate for large x. Only float64 and
+// float32 nodes are supported, and a DtypeError is returned for any
+// other data type.
+func Erfc(x *G.Node) (*G.Node, error) {
+	if err := checkFloatDtype("erfc", x.Dtype()); err != nil {
+		return nil, err
+	}
+	op := newErfcOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Erfcx computes the element-wise scaled complementary error function,
+// exp(x²)·erfc(x), without the overflow of computing exp(x²) and
+// erfc(x) separately for large x
+func Erfcx(x *G.Node) (*G.Node, error) {
+	op := newErfcxOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// LogNdtr computes the element-wise log of the standard normal
+// cumulative distribution function, log(Φ(x)). Unlike taking the log
+// of Φ(x), LogNdtr remains finite for very negative x, where Φ(x)
+// underflows.
+func LogNdtr(x *G.Node) (*G.Node, error) {
+	op := newLogNdtrOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Lgamma computes the element-wise natural logarithm of the absolute
+// value of the gamma function
+func Lgamma(x *G.Node) (*G.Node, error) {
+	op := newLgammaOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Digamma computes the element-wise digamma function, the derivative
+// of Lgamma
+func Digamma(x *G.Node) (*G.Node, error) {
+	op := newDigammaOp()
+
+	return G.ApplyOp(op, x)
+}
+
+// Clip performs an element-wise clipping of all values in a node
+// to be within [min, max]. Clip is equivalent to Clamp() with
+// passGradient false, with bounds given as float64's and converted to
+// the data type of value, which must be float64 or float32. Values
+// equal to min or max are left unchanged, along with their gradient.
+func Clip(value *G.Node, min, max float64) (retVal *G.Node, err error) {
+	var minVal, maxVal interface{}
+	switch value.Dtype() {
+	case G.Float32:
+		minVal, maxVal = float32(min), float32(max)
+	case G.Float64:
+		minVal, maxVal = min, max
+	default:
+		return nil, DtypeErrorf("clip: data type %v unsupported",
+			value.Dtype())
+	}
+
+	retVal, err = Clamp(value, minVal, maxVal, false)
+	if err != nil {
+		return nil, fmt.Errorf("clip: %w", err)
+	}
+
+	return retVal, nil
 }
 
 // Min returns the min value between the nodes. If values are equal
-// the first value is returned
+// the first value is returned. The masks selecting a (a <= b) and b
+// (b < a) are mutually exclusive, so that on ties the full gradient is
+// sent to a and none to b, and the gradients sum to the upstream
+// gradient.
 func Min(a *G.Node, b *G.Node) (retVal *G.Node, err error) {
 	aMask, err := G.Lte(a, b, true)
 	if err != nil {
@@ -486,7 +1387,9 @@ func Min(a *G.Node, b *G.Node) (retVal *G.Node, err error) {
 }
 
 // Max value between the nodes. If values are equal the first value
-// is returned.
+// is returned. As with Min(), the masks selecting a (a >= b) and b
+// (b > a) are mutually exclusive, so that on ties the full gradient is
+// sent to a.
 func Max(a *G.Node, b *G.Node) (retVal *G.Node, err error) {
 	aMask, err := G.Gte(a, b, true)
 	if err != nil {
@@ -508,6 +1411,160 @@ func Max(a *G.Node, b *G.Node) (retVal *G.Node, err error) {
 	return G.Add(aVal, bVal)
 }
 
+// ClipByGlobalNorm scales the nodes, such as the gradients of a set of
+// parameters, so that their global L2 norm, √(Σᵢ ||nodesᵢ||²), does
+// not exceed maxNorm. If the global norm is at most maxNorm, then the
+// nodes are returned unscaled. Otherwise, each node is multiplied by
+// maxNorm / norm, so that the returned nodes have global norm maxNorm.
+// All nodes must have the same floating point data type.
+//
+// The scale maxNorm / norm is computed as maxNorm / √max(norm², maxNorm²),
+// which never divides by zero when all nodes are zero, and is replaced
+// by exactly 1 when the global norm is at most maxNorm, since the
+// computed ratio may differ from 1 by rounding.
+func ClipByGlobalNorm(nodes []*G.Node, maxNorm float64) ([]*G.Node,
+	error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("clipByGlobalNorm: no nodes to clip")
+	}
+	if maxNorm <= 0 {
+		return nil, fmt.Errorf("clipByGlobalNorm: expected maxNorm > 0 "+
+			"but got %v", maxNorm)
+	}
+
+	dt := nodes[0].Dtype()
+	if err := checkFloatDtype("clipByGlobalNorm", dt); err != nil {
+		return nil, err
+	}
+
+	squares := make(G.Nodes, len(nodes))
+	for i, node := range nodes {
+		if node.Dtype() != dt {
+			return nil, DtypeErrorf("clipByGlobalNorm: expected node %v to "+
+				"have data type %v but got %v", i, dt, node.Dtype())
+		}
+
+		sq, err := Square(node)
+		if err != nil {
+			return nil, fmt.Errorf("clipByGlobalNorm: %w", err)
+		}
+		if !sq.IsScalar() {
+			sq, err = G.Sum(sq)
+			if err != nil {
+				return nil, fmt.Errorf("clipByGlobalNorm: could not sum "+
+					"node %v: %w", i, err)
+			}
+		}
+		squares[i] = sq
+	}
+
+	sumSquares, err := G.ReduceAdd(squares)
+	if err != nil {
+		return nil, fmt.Errorf("clipByGlobalNorm: %w", err)
+	}
+
+	var max, maxSquared *G.Node
+	if dt == tensor.Float64 {
+		max = G.NewConstant(maxNorm)
+		maxSquared = G.NewConstant(maxNorm * maxNorm)
+	} else {
+		max = G.NewConstant(float32(maxNorm))
+		maxSquared = G.NewConstant(float32(maxNorm * maxNorm))
+	}
+
+	within, err := G.Lte(sumSquares, maxSquared, true)
+	if err != nil {
+		return nil, fmt.Errorf("clipByGlobalNorm: %w", err)
+	}
+
+	sumSquares, err = Max(sumSquares, maxSquared)
+	if err != nil {
+		return nil, fmt.Errorf("clipByGlobalNorm: %w", err)
+	}
+	scale, err := Rsqrt(sumSquares)
+	if err != nil {
+		return nil, fmt.Errorf("clipByGlobalNorm: %w", err)
+	}
+	scale = G.Must(G.HadamardProd(max, scale))
+
+	// Select a scale of 1 when within the norm. The scale is finite, so
+	// masking with products selects it exactly.
+	outside := G.Must(G.Sub(oneLike(within), within))
+	scale = G.Must(G.Add(within, G.Must(G.HadamardProd(outside, scale))))
+
+	clipped := make([]*G.Node, len(nodes))
+	for i, node := range nodes {
+		clipped[i], err = G.HadamardProd(node, scale)
+		if err != nil {
+			return nil, fmt.Errorf("clipByGlobalNorm: could not scale "+
+				"node %v: %w", i, err)
+		}
+	}
+
+	return clipped, nil
+}
+
+// BroadcastMin returns the element-wise min value between the nodes,
+// broadcasting a and b along the axes in leftPattern and rightPattern
+// respectively, in the same way as Gorgonia's BroadcastAdd. For
+// example, a matrix of shape (n, m) may be compared to a vector of
+// shape (m) using a rightPattern of []byte{0}. If values are equal the
+// first value is returned.
+func BroadcastMin(a, b *G.Node, leftPattern, rightPattern []byte) (*G.Node,
+	error) {
+	out, err := broadcastSelect(a, b, leftPattern, rightPattern,
+		G.BroadcastLte, G.BroadcastLt)
+	if err != nil {
+		return nil, fmt.Errorf("broadcastMin: %w", err)
+	}
+	return out, nil
+}
+
+// BroadcastMax returns the element-wise max value between the nodes,
+// broadcasting a and b in the same way as BroadcastMin(). If values are
+// equal the first value is returned.
+func BroadcastMax(a, b *G.Node, leftPattern, rightPattern []byte) (*G.Node,
+	error) {
+	out, err := broadcastSelect(a, b, leftPattern, rightPattern,
+		G.BroadcastGte, G.BroadcastGt)
+	if err != nil {
+		return nil, fmt.Errorf("broadcastMax: %w", err)
+	}
+	return out, nil
+}
+
+// broadcastCmpFn is a Gorgonia broadcast comparison operation, such as
+// G.BroadcastGte
+type broadcastCmpFn func(a, b *G.Node, retSame bool, leftPattern,
+	rightPattern []byte) (*G.Node, error)
+
+// broadcastSelect returns the elements of a where aCmp(a, b) holds and
+// the elements of b where bCmp(b, a) holds, broadcasting a and b along
+// the axes in leftPattern and rightPattern respectively. The
+// comparisons must be mutually exclusive and cover all elements.
+func broadcastSelect(a, b *G.Node, leftPattern, rightPattern []byte,
+	aCmp, bCmp broadcastCmpFn) (*G.Node, error) {
+	aMask, err := aCmp(a, b, true, leftPattern, rightPattern)
+	if err != nil {
+		return nil, err
+	}
+	aVal, err := G.BroadcastHadamardProd(a, aMask, leftPattern, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bMask, err := bCmp(b, a, true, rightPattern, leftPattern)
+	if err != nil {
+		return nil, err
+	}
+	bVal, err := G.BroadcastHadamardProd(b, bMask, rightPattern, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return G.Add(aVal, bVal)
+}
+
 // AddFauxF32 adds a the faux zero value 1e-6.
 func AddFauxF32(n *G.Node) (retVal *G.Node, err error) {
 	faux := G.NewScalar(n.Graph(), G.Float32, G.WithValue(float32(1e-6)))
@@ -522,7 +1579,7 @@ func AddFauxF32(n *G.Node) (retVal *G.Node, err error) {
 func LogSumExp(logits *G.Node, along int) *G.Node {
 	max := G.Must(G.Max(logits, along))
 
-	exponent := G.Must(G.BroadcastSub(logits, max, nil, []byte{1}))
+	exponent := G.Must(G.BroadcastSub(logits, max, nil, []byte{byte(along)}))
 	exponent = G.Must(G.Exp(exponent))
 
 	sum := G.Must(G.Sum(exponent, along))
@@ -531,6 +1588,499 @@ func LogSumExp(logits *G.Node, along int) *G.Node {
 	return G.Must(G.Add(max, log))
 }
 
+// ReduceLogSumExp calculates the log of the summation of exponentials
+// along axis and squeezes all axes. If keepdims is true, then only
+// axis is squeezed. A negative axis counts from the last dimension.
+func ReduceLogSumExp(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
+	// If input is a scalar, just return it
+	if x.Dims() == 0 {
+		return x, nil
+	}
+
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("reduceLogSumExp: %w", err)
+	}
+
+	// Gorgonia's Max reduction may index out of range along inner axes
+	// of higher-dimensional tensors, so move axis to the end and flatten
+	// all other axes, then compute the log-sum-exp along rows
+	shape := x.Shape().Clone()
+	outShape := append(shape[:axis:axis], shape[axis+1:]...)
+	length := shape[axis]
+
+	if axis != x.Dims()-1 {
+		pattern := make([]int, 0, x.Dims())
+		for i := 0; i < x.Dims(); i++ {
+			if i != axis {
+				pattern = append(pattern, i)
+			}
+		}
+		pattern = append(pattern, axis)
+
+		x, err = G.Transpose(x, pattern...)
+		if err != nil {
+			return nil, fmt.Errorf("reduceLogSumExp: could not move axis "+
+				"%v to the end: %w", axis, err)
+		}
+	}
+
+	x, err = G.Reshape(x, []int{shape.TotalSize() / length, length})
+	if err != nil {
+		return nil, fmt.Errorf("reduceLogSumExp: could not flatten: %w", err)
+	}
+
+	out := LogSumExp(x, 1)
+	out, err = G.Reshape(out, outShape)
+	if err != nil {
+		return nil, fmt.Errorf("reduceLogSumExp: could not reshape to %v: %w",
+			outShape, err)
+	}
+
+	if !keepdims && out.Dims() > 0 {
+		out, err = SqueezeAll(out)
+		if err != nil {
+			return nil, fmt.Errorf("reduceLogSumExp: could not squeeze "+
+				"dims: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+// LogSoftmax calculates the log of the softmax of x along axis,
+// x - log(Σ exp(x)), using a numerically stable log-sum-exp. The output
+// has the same shape as x. A negative axis counts from the last
+// dimension.
+func LogSoftmax(x *G.Node, axis int) (*G.Node, error) {
+	if x.Dims() == 0 {
+		return nil, ShapeErrorf("logSoftmax: cannot compute log-softmax of " +
+			"non-tensor node")
+	}
+
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("logSoftmax: %w", err)
+	}
+
+	// As in ReduceLogSumExp, move axis to the end and flatten all other
+	// axes, then normalize along rows
+	shape := x.Shape().Clone()
+	length := shape[axis]
+	var pattern, inverse []int
+	if axis != x.Dims()-1 {
+		pattern = make([]int, 0, x.Dims())
+		for i := 0; i < x.Dims(); i++ {
+			if i != axis {
+				pattern = append(pattern, i)
+			}
+		}
+		pattern = append(pattern, axis)
+
+		// Compute the inverse permutation to move axis back
+		inverse = make([]int, len(pattern))
+		for i, p := range pattern {
+			inverse[p] = i
+		}
+
+		x, err = G.Transpose(x, pattern...)
+		if err != nil {
+			return nil, fmt.Errorf("logSoftmax: could not move axis %v to "+
+				"the end: %w", axis, err)
+		}
+	}
+	transposed := x.Shape().Clone()
+
+	x, err = G.Reshape(x, []int{shape.TotalSize() / length, length})
+	if err != nil {
+		return nil, fmt.Errorf("logSoftmax: could not flatten: %w", err)
+	}
+
+	lse := LogSumExp(x, 1)
+	out, err := G.BroadcastSub(x, lse, nil, []byte{1})
+	if err != nil {
+		return nil, fmt.Errorf("logSoftmax: could not normalize: %w", err)
+	}
+
+	out, err = G.Reshape(out, transposed)
+	if err != nil {
+		return nil, fmt.Errorf("logSoftmax: could not reshape to %v: %w",
+			transposed, err)
+	}
+
+	if inverse != nil {
+		out, err = G.Transpose(out, inverse...)
+		if err != nil {
+			return nil, fmt.Errorf("logSoftmax: could not move axis %v "+
+				"back: %w", axis, err)
+		}
+	}
+
+	return out, nil
+}
+
+// Softmax calculates the softmax of x along axis, exp(x) / Σ exp(x),
+// by exponentiating LogSoftmax. The output has the same shape as x. A
+// negative axis counts from the last dimension.
+func Softmax(x *G.Node, axis int) (*G.Node, error) {
+	logSoftmax, err := LogSoftmax(x, axis)
+	if err != nil {
+		return nil, fmt.Errorf("softmax: %w", err)
+	}
+
+	return G.Exp(logSoftmax)
+}
+
+// ReduceVar calculates the variance along axis and squeezes all axes.
+// If keepdims is true, then only axis is squeezed. If unbiased is true,
+// then Bessel's correction is applied and the sum of squared deviations
+// from the mean is divided by n-1 rather than n, where n is the length
+// of axis. The unbiased variance along an axis of length 1 is NaN. A
+// negative axis counts from the last dimension.
+func ReduceVar(x *G.Node, axis int, keepdims, unbiased bool) (*G.Node,
+	error) {
+	if x.Dims() == 0 {
+		return nil, ShapeErrorf("reduceVar: cannot compute variance of " +
+			"non-tensor node")
+	}
+
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: %w", err)
+	}
+	length := x.Shape()[axis]
+
+	mean, err := ReduceMean(x, axis, true)
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: could not compute mean: %w", err)
+	}
+
+	// Reshape the mean so that it can be repeated along axis
+	shape := x.Shape().Clone()
+	shape[axis] = 1
+	mean, err = G.Reshape(mean, shape)
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: could not reshape mean: %w", err)
+	}
+
+	mean, err = repeatAlong(mean, axis, length)
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: could not broadcast mean: %w",
+			err)
+	}
+	deviation, err := G.Sub(x, mean)
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: could not compute deviations: %w",
+			err)
+	}
+	sq, err := G.Square(deviation)
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: could not square deviations: %w",
+			err)
+	}
+
+	// Scale the squared deviations before averaging so that the
+	// correction is never applied to a 0-dim scalar
+	if unbiased {
+		n := float64(length)
+		var correction *G.Node
+		if x.Dtype() == tensor.Float64 {
+			correction = G.NewConstant(n / (n - 1))
+		} else {
+			correction = G.NewConstant(float32(n / (n - 1)))
+		}
+
+		sq, err = G.HadamardProd(sq, correction)
+		if err != nil {
+			return nil, fmt.Errorf("reduceVar: could not apply Bessel's "+
+				"correction: %w", err)
+		}
+	}
+
+	out, err := ReduceMean(sq, axis, keepdims)
+	if err != nil {
+		return nil, fmt.Errorf("reduceVar: could not average squared "+
+			"deviations: %w", err)
+	}
+
+	return out, nil
+}
+
+// ReduceStd calculates the standard deviation along axis and squeezes
+// all axes. If keepdims is true, then only axis is squeezed. If
+// unbiased is true, then the standard deviation is the square root of
+// the variance with Bessel's correction applied, as in ReduceVar. A
+// negative axis counts from the last dimension.
+func ReduceStd(x *G.Node, axis int, keepdims, unbiased bool) (*G.Node,
+	error) {
+	variance, err := ReduceVar(x, axis, keepdims, unbiased)
+	if err != nil {
+		return nil, fmt.Errorf("reduceStd: %w", err)
+	}
+
+	// Gorgonia cannot differentiate the square root of the 0-dim
+	// tensor returned when reducing a vector, so the variance is
+	// reshaped to a 1-vector. The result is then summed back to a
+	// scalar rather than reshaped, since Gorgonia computes the gradient
+	// of the square root in-place, which would otherwise overwrite the
+	// constant gradient that Gorgonia shares between all graphs.
+	scalar := variance.Dims() == 0
+	if scalar {
+		variance, err = G.Reshape(variance, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("reduceStd: could not reshape scalar "+
+				"to 1-vector: %w", err)
+		}
+	}
+
+	out, err := G.Sqrt(variance)
+	if err != nil {
+		return nil, fmt.Errorf("reduceStd: %w", err)
+	}
+
+	if scalar {
+		out, err = G.Sum(out)
+		if err != nil {
+			return nil, fmt.Errorf("reduceStd: could not reduce back to "+
+				"scalar: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+// Standardize computes the z-score (x - mean) / (std + eps) along
+// axis, where mean and std are the mean and (biased) standard deviation
+// of x along axis. The returned node has the same shape as x, and each
+// slice along axis has approximately zero mean and unit standard
+// deviation. The gradient flows through both the mean and standard
+// deviation. A negative axis counts from the last dimension.
+//
+// The constant eps >= 0 guards against division by zero when all
+// values along axis are equal. This is commonly used to normalize
+// advantages in policy gradient methods.
+func Standardize(x *G.Node, axis int, eps float64) (*G.Node, error) {
+	if x.Dims() == 0 {
+		return nil, ShapeErrorf("standardize: cannot standardize " +
+			"non-tensor node")
+	}
+	if eps < 0 {
+		return nil, fmt.Errorf("standardize: expected eps >= 0 but got %v",
+			eps)
+	}
+
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("standardize: %w", err)
+	}
+	length := x.Shape()[axis]
+
+	// Treat a vector as a matrix with a single row so that the mean and
+	// standard deviation are never 0-dim scalars, which Gorgonia cannot
+	// always differentiate through reshapes
+	vector := x.Dims() == 1
+	if vector {
+		x, err = G.Reshape(x, []int{1, length})
+		if err != nil {
+			return nil, fmt.Errorf("standardize: could not reshape vector "+
+				"to matrix: %w", err)
+		}
+		axis = 1
+	}
+
+	mean, err := ReduceMean(x, axis, true)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not compute mean: %w",
+			err)
+	}
+	std, err := ReduceStd(x, axis, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not compute standard "+
+			"deviation: %w", err)
+	}
+
+	// Reshape the statistics so that they can be repeated along axis,
+	// as in ReduceVar
+	shape := x.Shape().Clone()
+	shape[axis] = 1
+	mean, err = G.Reshape(mean, shape)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not reshape mean: %w",
+			err)
+	}
+	std, err = G.Reshape(std, shape)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not reshape standard "+
+			"deviation: %w", err)
+	}
+
+	var epsNode *G.Node
+	if x.Dtype() == tensor.Float64 {
+		epsNode = G.NewConstant(eps)
+	} else {
+		epsNode = G.NewConstant(float32(eps))
+	}
+	std, err = G.Add(std, epsNode)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not add eps: %w", err)
+	}
+
+	mean, err = repeatAlong(mean, axis, length)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not broadcast mean: %w",
+			err)
+	}
+	std, err = repeatAlong(std, axis, length)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not broadcast standard "+
+			"deviation: %w", err)
+	}
+
+	out, err := G.Sub(x, mean)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not compute "+
+			"deviations: %w", err)
+	}
+	out, err = G.HadamardDiv(out, std)
+	if err != nil {
+		return nil, fmt.Errorf("standardize: could not scale deviations: "+
+			"%w", err)
+	}
+
+	if vector {
+		out, err = G.Reshape(out, []int{length})
+		if err != nil {
+			return nil, fmt.Errorf("standardize: could not reshape back to "+
+				"vector: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+// ReduceWeightedMean calculates the weighted mean Σ(w*x)/Σw along axis
+// and squeezes all axes. If keepdims is true, then only axis is
+// squeezed. A negative axis counts from the last dimension.
+//
+// The weights must either be a vector with one weight per element
+// along axis, or have the same number of dimensions as x, with each
+// dimension equal to that of x or 1. In the latter case, weights are
+// broadcast along all dimensions of length 1.
+func ReduceWeightedMean(x, weights *G.Node, axis int, keepdims bool) (
+	*G.Node, error) {
+	if x.Dims() == 0 {
+		return nil, ShapeErrorf("reduceWeightedMean: cannot compute " +
+			"weighted mean of non-tensor node")
+	}
+	if err := checkFloatDtype("reduceWeightedMean", x.Dtype()); err != nil {
+		return nil, err
+	}
+	if weights.Dtype() != x.Dtype() {
+		return nil, DtypeErrorf("reduceWeightedMean: weights must have the "+
+			"same type as x (%v) but got %v", x.Dtype(), weights.Dtype())
+	}
+
+	axis, err := normalizeAxis(axis, x.Dims())
+	if err != nil {
+		return nil, fmt.Errorf("reduceWeightedMean: %w", err)
+	}
+	shape := x.Shape()
+
+	// Reshape vector weights so that they broadcast along all axes
+	// other than axis
+	if weights.Dims() == 1 && x.Dims() != 1 {
+		if weights.Shape()[0] != shape[axis] {
+			return nil, ShapeErrorf("reduceWeightedMean: expected %v "+
+				"weights along axis %v but got %v", shape[axis], axis,
+				weights.Shape()[0])
+		}
+
+		weightShape := make([]int, x.Dims())
+		for i := range weightShape {
+			weightShape[i] = 1
+		}
+		weightShape[axis] = shape[axis]
+		weights, err = G.Reshape(weights, weightShape)
+		if err != nil {
+			return nil, fmt.Errorf("reduceWeightedMean: could not reshape "+
+				"weights: %w", err)
+		}
+	}
+
+	// Broadcast the weights to the shape of x so that Σw is computed
+	// over the same elements as Σ(w*x)
+	if weights.Dims() != x.Dims() {
+		return nil, ShapeErrorf("reduceWeightedMean: cannot broadcast "+
+			"weights of shape %v against x of shape %v", weights.Shape(),
+			shape)
+	}
+	weightShape := weights.Shape().Clone()
+	for i, dim := range weightShape {
+		if dim == shape[i] {
+			continue
+		} else if dim != 1 {
+			return nil, ShapeErrorf("reduceWeightedMean: cannot broadcast "+
+				"weights of shape %v against x of shape %v", weightShape,
+				shape)
+		}
+
+		weights, err = repeatAlong(weights, i, shape[i])
+		if err != nil {
+			return nil, fmt.Errorf("reduceWeightedMean: could not "+
+				"broadcast weights along axis %v: %w", i, err)
+		}
+	}
+
+	weighted, err := G.HadamardProd(x, weights)
+	if err != nil {
+		return nil, fmt.Errorf("reduceWeightedMean: could not weight x: %w",
+			err)
+	}
+
+	num, err := ReduceAdd(weighted, axis, keepdims)
+	if err != nil {
+		return nil, fmt.Errorf("reduceWeightedMean: could not sum weighted "+
+			"values: %w", err)
+	}
+	denom, err := ReduceAdd(weights, axis, keepdims)
+	if err != nil {
+		return nil, fmt.Errorf("reduceWeightedMean: could not sum "+
+			"weights: %w", err)
+	}
+
+	// Deal with the edge case when the sums are scalars, which Gorgonia
+	// cannot divide, as in ReduceMean
+	scalar := num.Dims() == 0
+	if scalar {
+		num, err = G.Reshape(num, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("reduceWeightedMean: could not reshape "+
+				"scalar to 1-vector: %w", err)
+		}
+		denom, err = G.Reshape(denom, []int{1})
+		if err != nil {
+			return nil, fmt.Errorf("reduceWeightedMean: could not reshape "+
+				"scalar to 1-vector: %w", err)
+		}
+	}
+
+	out, err := G.HadamardDiv(num, denom)
+	if err != nil {
+		return nil, fmt.Errorf("reduceWeightedMean: could not divide by "+
+			"sum of weights: %w", err)
+	}
+
+	if scalar {
+		out, err = Squeeze(out, 0)
+		if err != nil {
+			return nil, fmt.Errorf("reduceWeightedMean: could not reshape "+
+				"back to scalar: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
 // Prod calculates the product of a Node along an axis
 func Prod(input *G.Node, along int) *G.Node {
 	shape := input.Shape()
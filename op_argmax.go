@@ -0,0 +1,166 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// argmaxOp is the argmax operation, which returns the index of the
+// maximum element along an axis
+type argmaxOp struct {
+	axis int
+	dims int // The number of dimensions in the input tensor
+}
+
+// newArgmaxOp returns a new argmaxOp
+func newArgmaxOp(axis int, dims int) *argmaxOp {
+	return &argmaxOp{
+		axis: axis,
+		dims: dims,
+	}
+}
+
+// Arity implements the gorgonia.Op interface
+func (a *argmaxOp) Arity() int { return 1 }
+
+// Type implements the gorgonia.Op interface
+func (a *argmaxOp) Type() hm.Type {
+	in := G.TensorType{
+		Dims: a.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+
+	if a.dims == 1 {
+		return hm.NewFnType(in, tensor.Int)
+	}
+	out := G.TensorType{
+		Dims: a.dims - 1,
+		Of:   tensor.Int,
+	}
+	return hm.NewFnType(in, out)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (a *argmaxOp) InferShape(inputs ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(a, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	if inputs[0] == nil {
+		return nil, fmt.Errorf("inferShape: nil input")
+	}
+
+	shapes, err := G.DimSizersToShapes(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	return a.outShape(shapes[0]), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (a *argmaxOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (a *argmaxOp) CallsExtern() bool { return false }
+
+// OverwriteInput implements the gorgonia.Op interface
+func (a *argmaxOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (a *argmaxOp) String() string {
+	return fmt.Sprintf("Argmax{axis=%v}()", a.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (a *argmaxOp) WriteHash(h hash.Hash) { fmt.Fprint(h, a.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (a *argmaxOp) Hashcode() uint32 { return SimpleHash(a) }
+
+// Do implements the gorgonia.Op interface
+func (a *argmaxOp) Do(values ...G.Value) (G.Value, error) {
+	err := a.checkInputs(values...)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := values[0].(tensor.Tensor)
+	if v, ok := input.(tensor.View); ok && v.IsMaterializable() {
+		input = v.Materialize()
+	}
+
+	var x []float64
+	switch data := input.Data().(type) {
+	case []float64:
+		x = data
+	case []float32:
+		x = make([]float64, len(data))
+		for i := range data {
+			x[i] = float64(data[i])
+		}
+	default:
+		return nil, fmt.Errorf("do: data type %v unsupported",
+			input.Dtype())
+	}
+
+	shape := input.Shape()
+	outer := tensor.ProdInts(shape[:a.axis])
+	inner := tensor.ProdInts(shape[a.axis+1:])
+	length := shape[a.axis]
+
+	indices := make([]int, outer*inner)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			index := 0
+			max := x[o*length*inner+i]
+			for j := 1; j < length; j++ {
+				if v := x[(o*length+j)*inner+i]; v > max {
+					index, max = j, v
+				}
+			}
+			indices[o*inner+i] = index
+		}
+	}
+
+	if a.dims == 1 {
+		return G.NewI(indices[0]), nil
+	}
+	return tensor.NewDense(tensor.Int, a.outShape(shape),
+		tensor.WithBacking(indices)), nil
+}
+
+// outShape returns the shape of the output of the receiver for an
+// input of shape shape
+func (a *argmaxOp) outShape(shape tensor.Shape) tensor.Shape {
+	out := make(tensor.Shape, 0, len(shape)-1)
+	out = append(out, shape[:a.axis]...)
+	return append(out, shape[a.axis+1:]...)
+}
+
+// checkInputs returns an error if the input to the receiver is invalid
+func (a *argmaxOp) checkInputs(inputs ...G.Value) error {
+	if err := CheckArity(a, len(inputs)); err != nil {
+		return err
+	}
+
+	t, ok := inputs[0].(tensor.Tensor)
+
+	if !ok {
+		return fmt.Errorf("expected input to be a tensor, got %T", inputs[0])
+	}
+
+	if len(t.Shape()) <= 0 || t.Size() == 0 {
+		return fmt.Errorf("tensor does not have any elements")
+	}
+
+	if len(t.Shape()) <= a.axis {
+		return fmt.Errorf("axis out of range [%v] with tensor shape %v",
+			a.axis, t.Shape())
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package gop
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestArgmax tests Argmax along random axes of random tensors, which
+// may be negative, against a naive argmax
+func TestArgmax(t *testing.T) {
+	const tests int = 20     // Number of tests to run
+	const maxDims int = 4    // Maximum number of tensor dimensions
+	const maxDimSize int = 5 // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := randInt(1+rand.Intn(maxDims), 1, maxDimSize+1)
+		axis := rand.Intn(len(shape))
+		argmaxAxis := axis
+		if rand.Intn(2) == 0 {
+			argmaxAxis -= len(shape)
+		}
+
+		// Use a small set of values so that ties are common
+		backing := make([]float64, tensor.ProdInts(shape))
+		for j := range backing {
+			backing[j] = float64(rand.Intn(4))
+		}
+
+		// Compute the target with the first maximal index along axis
+		outer := tensor.ProdInts(shape[:axis])
+		inner := tensor.ProdInts(shape[axis+1:])
+		target := make([]int, outer*inner)
+		for o := 0; o < outer; o++ {
+			for in := 0; in < inner; in++ {
+				for a := 1; a < shape[axis]; a++ {
+					v := backing[(o*shape[axis]+a)*inner+in]
+					max := backing[(o*shape[axis]+target[o*inner+in])*inner+in]
+					if v > max {
+						target[o*inner+in] = a
+					}
+				}
+			}
+		}
+
+		for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+			var inTensor *tensor.Dense
+			if dt == tensor.Float64 {
+				inTensor = tensor.NewDense(dt, shape,
+					tensor.WithBacking(backing))
+			} else {
+				backing32 := make([]float32, len(backing))
+				for j := range backing {
+					backing32[j] = float32(backing[j])
+				}
+				inTensor = tensor.NewDense(dt, shape,
+					tensor.WithBacking(backing32))
+			}
+
+			g := G.NewGraph()
+			in := G.NewTensor(g, dt, len(shape), G.WithValue(inTensor))
+			out, err := Argmax(in, argmaxAxis)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var outVal G.Value
+			G.Read(out, &outVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			expectedShape := append(append([]int{}, shape[:axis]...),
+				shape[axis+1:]...)
+			if !outVal.Shape().Eq(tensor.Shape(expectedShape)) ||
+				len(outVal.Shape()) != len(expectedShape) {
+				t.Errorf("expected shape %v but got %v", expectedShape,
+					outVal.Shape())
+				continue
+			}
+
+			var computed []int
+			switch data := outVal.Data().(type) {
+			case int:
+				computed = []int{data}
+			case []int:
+				computed = data
+			}
+			for j := range target {
+				if computed[j] != target[j] {
+					t.Errorf("argmax of %v along axis %v of %v \nexpected: "+
+						"%v \nreceived: %v", shape, argmaxAxis, backing,
+						target, computed)
+					break
+				}
+			}
+		}
+	}
+}
+
+// TestArgmaxAxis tests that Argmax returns an error for an axis out of
+// range
+func TestArgmaxAxis(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewMatrix(g, tensor.Float64, G.WithShape(2, 3),
+		G.WithInit(G.Zeroes()))
+
+	for _, axis := range []int{2, -3} {
+		if _, err := Argmax(x, axis); err == nil {
+			t.Errorf("expected error for axis %v with shape %v", axis,
+				x.Shape())
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package gop
 
 import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
 	"testing"
+	"time"
 
 	G "gorgonia.org/gorgonia"
 	"gorgonia.org/tensor"
@@ -194,3 +199,153 @@ func TestArgsort(t *testing.T) {
 		}
 	}
 }
+
+// TestSoftSort tests that the rows of the soft permutation computed by
+// SoftSort sum to one, that it approaches the hard permutation of
+// Argsort for small temperatures, and that gradients flow back to the
+// input
+func TestSoftSort(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 10              // Number of tests to run
+	const maxDims int = 3             // Maximum number of dimensions
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+
+		// Use distinct values spaced by 1 so that the hard permutation
+		// is unique and recovered at small temperatures
+		data := make([]float64, size)
+		for j, k := range rand.Perm(size) {
+			data[j] = float64(k) - float64(size)/2
+		}
+
+		for axis := -len(shape); axis < len(shape); axis++ {
+			ax := (axis + len(shape)) % len(shape)
+			outer := tensor.ProdInts(shape[:ax])
+			inner := tensor.ProdInts(shape[ax+1:])
+			length := shape[ax]
+
+			for _, tau := range []float64{1.0, 0.01} {
+				g := G.NewGraph()
+				x := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("x"),
+					G.WithValue(tensor.NewDense(tensor.Float64, shape,
+						tensor.WithBacking(append([]float64{}, data...)))))
+
+				p, err := SoftSort(x, axis, tau)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var pVal G.Value
+				G.Read(p, &pVal)
+
+				w := G.NewTensor(g, tensor.Float64, p.Dims(), G.WithName("w"),
+					G.WithValue(tensor.NewDense(tensor.Float64, p.Shape(),
+						tensor.WithBacking(randF64(p.Shape().TotalSize(),
+							-1, 1)))))
+				loss := G.Must(G.Sum(G.Must(G.HadamardProd(p, w))))
+				grad, err := G.Grad(loss, x)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var gradVal G.Value
+				G.Read(grad[0], &gradVal)
+
+				vm := G.NewTapeMachine(g)
+				if err := vm.RunAll(); err != nil {
+					t.Fatal(err)
+				}
+				vm.Close()
+
+				outShape := append(append([]int{}, shape[:ax]...),
+					shape[ax+1:]...)
+				outShape = append(outShape, length, length)
+				if !pVal.Shape().Eq(tensor.Shape(outShape)) {
+					t.Errorf("shape %v axis %v: expected shape %v but got %v",
+						shape, axis, outShape, pVal.Shape())
+					continue
+				}
+
+				perm := pVal.Data().([]float64)
+				for o := 0; o < outer; o++ {
+					for in := 0; in < inner; in++ {
+						b := o*inner + in
+
+						// Compute the hard permutation of the slice
+						order := make([]int, length)
+						for k := range order {
+							order[k] = k
+						}
+						sort.Slice(order, func(m, n int) bool {
+							return data[(o*length+order[m])*inner+in] <
+								data[(o*length+order[n])*inner+in]
+						})
+
+						for r := 0; r < length; r++ {
+							row := perm[(b*length+r)*length:][:length]
+							sum := 0.0
+							for _, v := range row {
+								sum += v
+							}
+							if math.Abs(sum-1) > threshold {
+								t.Errorf("shape %v axis %v tau %v: expected "+
+									"row to sum to 1 but got %v", shape, axis,
+									tau, sum)
+							}
+
+							if tau < 1 && math.Abs(row[order[r]]-1) > 0.001 {
+								t.Errorf("shape %v axis %v tau %v: expected "+
+									"row %v to be one-hot at %v but got %v",
+									shape, axis, tau, r, order[r], row)
+							}
+						}
+					}
+				}
+
+				// Gradients should flow to x whenever the soft
+				// permutation is not trivially constant
+				gradData := gradVal.Data().([]float64)
+				nonzero := false
+				for _, v := range gradData {
+					if math.IsNaN(v) || math.IsInf(v, 0) {
+						t.Errorf("shape %v axis %v tau %v: non-finite "+
+							"gradient %v", shape, axis, tau, gradData)
+						break
+					}
+					nonzero = nonzero || v != 0
+				}
+				if tau == 1 && length > 1 && !nonzero {
+					t.Errorf("shape %v axis %v: expected non-zero gradient",
+						shape, axis)
+				}
+			}
+		}
+	}
+}
+
+// TestSoftSortError tests that SoftSort returns an error for invalid
+// inputs rather than panicking
+func TestSoftSortError(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("x"))
+	scalar := G.NewScalar(g, tensor.Float64, G.WithName("scalar"))
+	ints := G.NewVector(g, tensor.Int, G.WithShape(3), G.WithName("ints"))
+
+	if _, err := SoftSort(x, 0, 0); err == nil {
+		t.Error("expected error for tau = 0")
+	}
+	if _, err := SoftSort(x, 1, 1); !errors.Is(err, &ShapeError{}) {
+		t.Errorf("expected ShapeError for axis out of range but got %v", err)
+	}
+	if _, err := SoftSort(scalar, 0, 1); !errors.Is(err, &ShapeError{}) {
+		t.Errorf("expected ShapeError for scalar input but got %v", err)
+	}
+	if _, err := SoftSort(ints, 0, 1); !errors.Is(err, &DtypeError{}) {
+		t.Errorf("expected DtypeError for int input but got %v", err)
+	}
+}
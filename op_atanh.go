@@ -0,0 +1,36 @@
+package gop
+
+import (
+	"fmt"
+	"math"
+)
+
+// newAtanhOp returns a new pointwise operation which computes the
+// inverse hyperbolic tangent, 0.5 log((1 + x) / (1 - x)). The
+// derivative of the atanh function is 1 / (1 - x²).
+func newAtanhOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Atanh",
+		f64:  math.Atanh,
+		f32:  func(x float32) float32 { return float32(math.Atanh(float64(x))) },
+		df64: atanhDiff,
+		df32: func(x float32) float32 {
+			return float32(atanhDiff(float64(x)))
+		},
+		domain: atanhDomain,
+	}
+}
+
+// atanhDiff returns the derivative of the atanh function at x
+func atanhDiff(x float64) float64 {
+	return 1 / (1 - x*x)
+}
+
+// atanhDomain returns an error if x lies outside the open interval
+// (-1, 1), at whose bounds the atanh function is infinite
+func atanhDomain(x float64) error {
+	if !(x > -1 && x < 1) {
+		return fmt.Errorf("input %v outside domain (-1, 1)", x)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestAtanh(t *testing.T) {
+	df := func(x float64) float64 { return finiteDifference(math.Atanh, x) }
+	input := func() float64 { return (rand.Float64() - 0.5) * 1.8 }
+
+	testPointwise(t, "Atanh", Atanh, math.Atanh, df, input)
+}
+
+// TestAtanhDomain tests that running Atanh on inputs at or beyond ±1
+// results in an error
+func TestAtanhDomain(t *testing.T) {
+	inputs := []G.Value{
+		tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking([]float64{0.5, 1.0, -0.5})),
+		tensor.NewDense(tensor.Float32, []int{2},
+			tensor.WithBacking([]float32{-1.5, 0.0})),
+		G.NewF64(-1.0),
+		G.NewF32(2.0),
+	}
+
+	for _, in := range inputs {
+		g := G.NewGraph()
+
+		var x *G.Node
+		if tensorIn, ok := in.(tensor.Tensor); ok {
+			x = G.NewVector(g, tensorIn.Dtype(), G.WithValue(in))
+		} else {
+			x = G.NewScalar(g, in.Dtype(), G.WithValue(in))
+		}
+
+		if _, err := Atanh(x); err != nil {
+			t.Fatal(err)
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err == nil {
+			t.Errorf("expected error computing atanh of %v", in)
+		}
+		vm.Close()
+	}
+}
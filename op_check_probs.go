@@ -0,0 +1,27 @@
+package gop
+
+import "fmt"
+
+// newCheckProbsOp returns a new pointwise operation which returns its
+// input unchanged, but results in an error if any element of the input
+// is not a probability strictly within (0, 1). The derivative of the
+// operation is 1 everywhere.
+func newCheckProbsOp() *pointwiseOp {
+	return &pointwiseOp{
+		name:   "CheckProbs",
+		f64:    func(x float64) float64 { return x },
+		f32:    func(x float32) float32 { return x },
+		df64:   func(float64) float64 { return 1 },
+		df32:   func(float32) float32 { return 1 },
+		domain: checkProbsDomain,
+	}
+}
+
+// checkProbsDomain returns an error if x lies outside the open
+// interval (0, 1)
+func checkProbsDomain(x float64) error {
+	if !(x > 0 && x < 1) {
+		return fmt.Errorf("probability %v outside domain (0, 1)", x)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package gop
+
+import (
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestCheckProbs(t *testing.T) {
+	f := func(x float64) float64 { return x }
+	df := func(float64) float64 { return 1 }
+	input := func() float64 { return 0.01 + rand.Float64()*0.98 }
+
+	testPointwise(t, "CheckProbs", CheckProbs, f, df, input)
+}
+
+// TestCheckProbsDomain tests that running CheckProbs on inputs at or
+// beyond 0 and 1 results in an error
+func TestCheckProbsDomain(t *testing.T) {
+	inputs := []G.Value{
+		tensor.NewDense(tensor.Float64, []int{3},
+			tensor.WithBacking([]float64{0.5, 1.5, 0.2})),
+		tensor.NewDense(tensor.Float32, []int{2},
+			tensor.WithBacking([]float32{0.0, 0.5})),
+		G.NewF64(1.0),
+		G.NewF32(-0.5),
+	}
+
+	for _, in := range inputs {
+		g := G.NewGraph()
+
+		var x *G.Node
+		if tensorIn, ok := in.(tensor.Tensor); ok {
+			x = G.NewVector(g, tensorIn.Dtype(), G.WithValue(in))
+		} else {
+			x = G.NewScalar(g, in.Dtype(), G.WithValue(in))
+		}
+
+		if _, err := CheckProbs(x); err != nil {
+			t.Fatal(err)
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err == nil {
+			t.Errorf("expected error checking probabilities %v", in)
+		}
+		vm.Close()
+	}
+}
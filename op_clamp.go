@@ -3,6 +3,7 @@ package gop
 import (
 	"fmt"
 	"hash"
+	"math"
 
 	"github.com/chewxy/hm"
 	G "gorgonia.org/gorgonia"
@@ -11,11 +12,55 @@ import (
 	"github.com/samuelfneumann/top"
 )
 
+// NaNPolicy determines how a clamp operation treats NaN elements of
+// a floating point tensor, which are neither below nor above any
+// bound.
+type NaNPolicy int
+
+const (
+	// NaNPreserve passes NaNs through the clamp unchanged, along with
+	// their incoming gradient
+	NaNPreserve NaNPolicy = iota
+
+	// NaNToMin replaces NaNs with the lower bound of the clamp. Like
+	// other clamped elements, the gradient at NaNs is 0 unless the
+	// gradient is passed through the clamp.
+	NaNToMin
+
+	// NaNToMax replaces NaNs with the upper bound of the clamp. Like
+	// other clamped elements, the gradient at NaNs is 0 unless the
+	// gradient is passed through the clamp.
+	NaNToMax
+)
+
+// String implements the fmt.Stringer interface
+func (n NaNPolicy) String() string {
+	switch n {
+	case NaNPreserve:
+		return "NaNPreserve"
+	case NaNToMin:
+		return "NaNToMin"
+	case NaNToMax:
+		return "NaNToMax"
+	default:
+		return fmt.Sprintf("NaNPolicy(%d)", int(n))
+	}
+}
+
 // clampOp implements the clamp operation, clamping all values in a
-// tensor to be within some range.
+// tensor to be within some range. If either min or max is nil, then
+// the range is unbounded on that side. If dt is set, then the clamped
+// tensor is converted to data type dt, which is a tensor with dims
+// dimensions. Otherwise, the clamped tensor has the same data type as
+// the input. NaNs in floating point tensors are treated according to
+// nan.
 type clampOp struct {
 	min, max     interface{}
 	passGradient bool
+	nan          NaNPolicy
+
+	dt   tensor.Dtype
+	dims int
 }
 
 // newClamp returns a new clampOp
@@ -29,6 +74,62 @@ func newClampOp(min, max interface{}, passGradient bool) (*clampOp, error) {
 	return op, nil
 }
 
+// newClampNaNOp returns a new clampOp which treats NaNs according to
+// nan. The bound which NaNs are replaced with must be non-nil.
+func newClampNaNOp(min, max interface{}, passGradient bool,
+	nan NaNPolicy) (*clampOp, error) {
+	switch nan {
+	case NaNPreserve:
+	case NaNToMin:
+		if min == nil {
+			return nil, fmt.Errorf("cannot replace NaNs with nil min")
+		}
+	case NaNToMax:
+		if max == nil {
+			return nil, fmt.Errorf("cannot replace NaNs with nil max")
+		}
+	default:
+		return nil, fmt.Errorf("unknown NaN policy %v", nan)
+	}
+
+	op, err := newClampOp(min, max, passGradient)
+	if err != nil {
+		return nil, err
+	}
+
+	op.nan = nan
+	return op, nil
+}
+
+// newClampAsOp returns a new clampOp which converts its output to data
+// type dt. The input to the op must have dims dimensions.
+func newClampAsOp(min, max interface{}, passGradient bool, dt tensor.Dtype,
+	dims int) (*clampOp, error) {
+	op, err := newClampOp(min, max, passGradient)
+	if err != nil {
+		return nil, err
+	}
+
+	op.dt = dt
+	op.dims = dims
+	return op, nil
+}
+
+// converts returns whether the receiver converts its output to a
+// different data type
+func (c *clampOp) converts() bool {
+	return c.dt != tensor.Dtype{}
+}
+
+// outType returns the type of the output of the receiver given the
+// type of its input
+func (c *clampOp) outType(in hm.Type) hm.Type {
+	if !c.converts() {
+		return in
+	}
+	return G.TensorType{Dims: c.dims, Of: c.dt}
+}
+
 // DiffWRT implements the gorgonia.SDOp interface
 func (c *clampOp) DiffWRT(inputs int) []bool {
 	return []bool{true}
@@ -57,7 +158,7 @@ func (c *clampOp) Arity() int { return 1 }
 func (c *clampOp) Type() hm.Type {
 	a := hm.TypeVariable('a')
 
-	return hm.NewFnType(a, a)
+	return hm.NewFnType(a, c.outType(a))
 }
 
 // InferShape implements the gorgonia.Op interface
@@ -84,7 +185,17 @@ func (c *clampOp) CallsExtern() bool { return false }
 func (c *clampOp) OverwritesInput() int { return -1 }
 
 // String implements the fmt.Stringer interface
-func (c *clampOp) String() string { return "Clamp() " }
+func (c *clampOp) String() string {
+	if c.converts() {
+		return fmt.Sprintf("Clamp{min=%v, max=%v, passGradient=%v, "+
+			"dtype=%v}()", c.min, c.max, c.passGradient, c.dt)
+	} else if c.nan != NaNPreserve {
+		return fmt.Sprintf("Clamp{min=%v, max=%v, passGradient=%v, "+
+			"nan=%v}()", c.min, c.max, c.passGradient, c.nan)
+	}
+	return fmt.Sprintf("Clamp{min=%v, max=%v, passGradient=%v}()", c.min,
+		c.max, c.passGradient)
+}
 
 // WriteHash implements the gorgonia.Op interface
 func (c *clampOp) WriteHash(h hash.Hash) { fmt.Fprint(h, c.String()) }
@@ -100,9 +211,90 @@ func (c *clampOp) Do(inputs ...G.Value) (G.Value, error) {
 
 	in := inputs[0].(tensor.Tensor)
 
-	cl, err := tensor.Clamp(in, c.min, c.max)
+	min, max, err := c.bounds(in.Dtype())
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
 
-	return cl, err
+	cl, err := tensor.Clamp(in, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.nan {
+	case NaNToMin:
+		replaceNaN(cl, min)
+	case NaNToMax:
+		replaceNaN(cl, max)
+	}
+	if !c.converts() {
+		return cl, nil
+	}
+
+	out, err := convertDtype(cl, c.dt)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+	return out, nil
+}
+
+// bounds returns the bounds of the clamp operation for a tensor of
+// data type dt. If either bound is nil, it is replaced by the most
+// extreme value of dt on that side, so that the tensor is unbounded
+// there.
+func (c *clampOp) bounds(dt tensor.Dtype) (min, max interface{}, err error) {
+	min, max = c.min, c.max
+	if min != nil && max != nil {
+		return min, max, nil
+	}
+
+	var lowest, highest interface{}
+	switch dt {
+	case tensor.Float64:
+		lowest, highest = math.Inf(-1), math.Inf(1)
+	case tensor.Float32:
+		lowest, highest = float32(math.Inf(-1)), float32(math.Inf(1))
+	case tensor.Int:
+		lowest, highest = math.MinInt, math.MaxInt
+	case tensor.Int64:
+		lowest, highest = int64(math.MinInt64), int64(math.MaxInt64)
+	case tensor.Int32:
+		lowest, highest = int32(math.MinInt32), int32(math.MaxInt32)
+	case tensor.Int16:
+		lowest, highest = int16(math.MinInt16), int16(math.MaxInt16)
+	case tensor.Int8:
+		lowest, highest = int8(math.MinInt8), int8(math.MaxInt8)
+	default:
+		return nil, nil, fmt.Errorf("cannot clamp unbounded tensor of "+
+			"type %v", dt)
+	}
+
+	if min == nil {
+		min = lowest
+	}
+	if max == nil {
+		max = highest
+	}
+	return min, max, nil
+}
+
+// replaceNaN replaces each NaN element of the floating point tensor t
+// by v in-place. Tensors of other data types are left unchanged.
+func replaceNaN(t tensor.Tensor, v interface{}) {
+	switch data := t.Data().(type) {
+	case []float64:
+		for i := range data {
+			if math.IsNaN(data[i]) {
+				data[i] = v.(float64)
+			}
+		}
+	case []float32:
+		for i := range data {
+			if math.IsNaN(float64(data[i])) {
+				data[i] = v.(float32)
+			}
+		}
+	}
 }
 
 // checkInputs returns an error if inputs is an invalid input for
@@ -139,7 +331,7 @@ func (c *clampDiffOp) Arity() int { return 2 }
 func (c *clampDiffOp) Type() hm.Type {
 	a := hm.TypeVariable('a')
 
-	return hm.NewFnType(a, a, a)
+	return hm.NewFnType(a, c.op.outType(a), a)
 }
 
 // InferShape implements the gorgonia.Op interface
@@ -173,7 +365,9 @@ func (c *clampDiffOp) WriteHash(h hash.Hash) { fmt.Fprint(h, c.String()) }
 func (c *clampDiffOp) Hashcode() uint32 { return SimpleHash(c) }
 
 // String implements the fmt.Stringer interface
-func (c *clampDiffOp) String() string { return "ClampDiff()" }
+func (c *clampDiffOp) String() string {
+	return fmt.Sprintf("ClampDiff{%v}", c.op)
+}
 
 // Do implements the gorgonia.Op interface
 func (c *clampDiffOp) Do(inputs ...G.Value) (G.Value, error) {
@@ -185,19 +379,64 @@ func (c *clampDiffOp) Do(inputs ...G.Value) (G.Value, error) {
 	x := inputs[0].(tensor.Tensor)
 	dzdy := inputs[1].(tensor.Tensor)
 
+	// The gradient has the data type of the output, so convert it back
+	// to the data type of the input
+	if c.op.converts() {
+		dzdy, err = convertDtype(dzdy, x.Dtype())
+		if err != nil {
+			return nil, fmt.Errorf("do: %v", err)
+		}
+	}
+
 	var dydx G.Value
 	if !c.op.passGradient {
-		dydx, err = top.ClampB(x, c.op.min, c.op.max)
+		min, max, err := c.op.bounds(x.Dtype())
+		if err != nil {
+			return nil, fmt.Errorf("do: %v", err)
+		}
+
+		dydx, err = top.ClampB(x, min, max)
+		if err != nil {
+			return nil, fmt.Errorf("do: could not clampb: %v", err)
+		}
+
+		// NaNs which are replaced by a bound are clamped, and so do not
+		// receive a gradient
+		if c.op.nan != NaNPreserve {
+			zeroNaNGrad(x, dydx.(tensor.Tensor))
+		}
 	} else {
-		dydx, err = tensor.Ones(x.Dtype(), x.Shape()...), nil
-	}
-	if err != nil {
-		return nil, fmt.Errorf("do: could not clampb: %v", err)
+		dydx = tensor.Ones(x.Dtype(), x.Shape()...)
 	}
 
 	return tensor.Mul(dzdy, dydx)
 }
 
+// zeroNaNGrad sets the elements of the gradient dydx to 0 wherever the
+// floating point tensor x is NaN
+func zeroNaNGrad(x, dydx tensor.Tensor) {
+	if v, ok := x.(tensor.View); ok && v.IsMaterializable() {
+		x = v.Materialize()
+	}
+
+	switch data := x.Data().(type) {
+	case []float64:
+		grad := dydx.Data().([]float64)
+		for i := range data {
+			if math.IsNaN(data[i]) {
+				grad[i] = 0
+			}
+		}
+	case []float32:
+		grad := dydx.Data().([]float32)
+		for i := range data {
+			if math.IsNaN(float64(data[i])) {
+				grad[i] = 0
+			}
+		}
+	}
+}
+
 // checkInputs returns an error if inputs in an invalid input to
 // clampDiffOp
 func (c *clampDiffOp) checkInput(inputs ...G.Value) error {
@@ -1,7 +1,9 @@
 package gop
 
 import (
+	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 
@@ -392,3 +394,507 @@ func TestIntClamp(t *testing.T) {
 		vm.Close()
 	}
 }
+
+// TestIntClampDtype tests that clamping an integer tensor returns a
+// tensor of the same integer data type as the input, for integers of
+// any width
+func TestIntClampDtype(t *testing.T) {
+	const min, max int = -3, 3
+	in := []int{-10, -3, -1, 0, 2, 3, 10}
+	target := []int{-3, -3, -1, 0, 2, 3, 3}
+
+	dtypes := []tensor.Dtype{tensor.Int, tensor.Int64, tensor.Int32,
+		tensor.Int16, tensor.Int8}
+	for _, dt := range dtypes {
+		inTensor, err := convertDtype(tensor.NewDense(tensor.Int,
+			[]int{len(in)}, tensor.WithBacking(in)), dt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		minDt := reflect.ValueOf(min).Convert(dt.Type).Interface()
+		maxDt := reflect.ValueOf(max).Convert(dt.Type).Interface()
+
+		g := G.NewGraph()
+		x := G.NewVector(g, dt, G.WithValue(inTensor), G.WithName("x"))
+
+		c, err := Clamp(x, minDt, maxDt, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Dtype() != dt {
+			t.Errorf("expected clamp node to have data type %v but got %v",
+				dt, c.Dtype())
+		}
+		var cVal G.Value
+		G.Read(c, &cVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if cVal.Dtype() != dt {
+			t.Errorf("expected clamped value to have data type %v but got %v",
+				dt, cVal.Dtype())
+		}
+
+		out, err := convertDtype(cVal.(tensor.Tensor), tensor.Int)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, elem := range out.Data().([]int) {
+			if elem != target[i] {
+				t.Errorf("incorrect value at index %d for data type %v "+
+					"\nexpected: %v \nreceived: %v", i, dt, target[i], elem)
+			}
+		}
+	}
+}
+
+// TestClampAs tests that ClampAs converts the clamped tensor to the
+// requested data type and converts the gradient back to the data type
+// of the input
+func TestClampAs(t *testing.T) {
+	// Integer to floating point
+	g := G.NewGraph()
+	ints := tensor.NewDense(tensor.Int32, []int{4},
+		tensor.WithBacking([]int32{-5, -1, 1, 5}))
+	x := G.NewVector(g, tensor.Int32, G.WithValue(ints), G.WithName("x"))
+
+	c, err := ClampAs(x, int32(-2), int32(2), false, tensor.Float64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Dtype() != tensor.Float64 {
+		t.Errorf("expected clamp node to have data type %v but got %v",
+			tensor.Float64, c.Dtype())
+	}
+	var cVal G.Value
+	G.Read(c, &cVal)
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	target := []float64{-2, -1, 1, 2}
+	for i, elem := range cVal.Data().([]float64) {
+		if elem != target[i] {
+			t.Errorf("incorrect value at index %d \nexpected: %v "+
+				"\nreceived: %v", i, target[i], elem)
+		}
+	}
+
+	// Floating point to floating point, with gradients
+	for _, passGradient := range []bool{false, true} {
+		g := G.NewGraph()
+		floats := tensor.NewDense(tensor.Float32, []int{4},
+			tensor.WithBacking([]float32{-5, -1, 1, 5}))
+		x := G.NewVector(g, tensor.Float32, G.WithValue(floats),
+			G.WithName("x"))
+
+		c, err := ClampAs(x, float32(-2), float32(2), passGradient,
+			tensor.Float64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loss := G.Must(G.Sum(c))
+		grad, err := G.Grad(loss, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		gradTarget := []float32{0, 1, 1, 0}
+		if passGradient {
+			gradTarget = []float32{1, 1, 1, 1}
+		}
+		gradData, ok := gradVal.Data().([]float32)
+		if !ok {
+			t.Fatalf("expected gradient to have data type %v but got %v",
+				tensor.Float32, gradVal.Dtype())
+		}
+		for i := range gradTarget {
+			if gradData[i] != gradTarget[i] {
+				t.Errorf("incorrect gradient at index %d \nexpected: %v "+
+					"\nreceived: %v", i, gradTarget[i], gradData[i])
+			}
+		}
+	}
+}
+
+// TestF64ClampMin tests the one-sided ClampMin operation on a float64
+// tensor, with and without passing the gradient
+func TestF64ClampMin(t *testing.T) {
+	testF64OneSidedClamp(t, true, false)
+	testF64OneSidedClamp(t, true, true)
+}
+
+// TestF64ClampMax tests the one-sided ClampMax operation on a float64
+// tensor, with and without passing the gradient
+func TestF64ClampMax(t *testing.T) {
+	testF64OneSidedClamp(t, false, false)
+	testF64OneSidedClamp(t, false, true)
+}
+
+// testF64OneSidedClamp tests ClampMin if lower is true, and ClampMax
+// otherwise. The output should only be clamped on the bounded side,
+// and the gradient should only be masked on the bounded side unless
+// passGradient is true.
+func testF64OneSidedClamp(t *testing.T, lower, passGradient bool) {
+	const numTests int = 15     // The number of random tests to run
+	const clipScale float64 = 2 // Bounds generated based on clipScale
+	const scale float64 = 5     // Values are clamped based on scale
+
+	// Randomly generated input has number of dimensions between dimMin
+	// and dimMax. Each dimension of the randomly generated input has
+	// between sizeMin and sizeMax elements.
+	const sizeMin int = 1
+	const sizeMax int = 10
+	const dimMin int = 1
+	const dimMax int = 4
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < numTests; i++ {
+		bound := clipScale * (rand.Float64() - 0.5) // Centred about 0
+
+		// Construct the size of each dimension randomly, e.g. (3, 1, 2)
+		size := randInt(dimMin+rand.Intn(dimMax-dimMin), sizeMin, sizeMax)
+		numElems := tensor.ProdInts(size)
+
+		// Construct input data, which lies on both sides of the bound
+		inBacking := randF64(numElems, -clipScale*scale, clipScale*scale)
+		inTensor := tensor.NewDense(
+			tensor.Float64,
+			size,
+			tensor.WithBacking(append([]float64{}, inBacking...)),
+		)
+
+		// Construct the target output and gradient
+		target := make([]float64, numElems)
+		gradTarget := make([]float64, numElems)
+		for i, x := range inBacking {
+			target[i] = x
+			gradTarget[i] = 1
+			if lower && x < bound {
+				target[i] = bound
+			} else if !lower && x > bound {
+				target[i] = bound
+			} else {
+				continue
+			}
+
+			if !passGradient {
+				gradTarget[i] = 0
+			}
+		}
+
+		// Construct input node to be clamped
+		g := G.NewGraph()
+		in := G.NewTensor(
+			g,
+			tensor.Float64,
+			len(inTensor.Shape()),
+			G.WithValue(inTensor),
+		)
+
+		// Construct the clamp operation and save the outputted value
+		var c *G.Node
+		var err error
+		if lower {
+			c, err = ClampMin(in, bound, passGradient)
+		} else {
+			c, err = ClampMax(in, bound, passGradient)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cVal G.Value
+		G.Read(c, &cVal)
+
+		// Construct loss + gradient
+		loss := G.Must(G.Sum(c))
+		grad, err := G.Grad(loss, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		// Run the graph
+		vm := G.NewTapeMachine(g)
+		err = vm.RunAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Ensure the output is clamped properly
+		data := cVal.Data().([]float64)
+		for i := range target {
+			if target[i] != data[i] {
+				t.Errorf("incorrect output at index %d \nexpected: %v "+
+					"\nreceived: %v", i, target[i], data[i])
+			}
+		}
+
+		// Ensure the gradient was calculated correctly
+		gradData := gradVal.Data().([]float64)
+		for i := range gradTarget {
+			if gradTarget[i] != gradData[i] {
+				t.Errorf("incorrect gradient at index %d \nexpected: %v "+
+					"\nreceived: %v", i, gradTarget[i], gradData[i])
+			}
+		}
+
+		vm.Close()
+	}
+}
+
+// TestClampUnbounded tests that Clamp returns an error when neither
+// bound is given
+func TestClampUnbounded(t *testing.T) {
+	g := G.NewGraph()
+	in := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("x"),
+		G.WithInit(G.Zeroes()))
+
+	if _, err := Clamp(in, nil, nil, false); err == nil {
+		t.Error("expected an error clamping with no bounds")
+	}
+}
+
+// TestClampNaN tests that each NaN policy of ClampNaN is applied to the
+// forward pass and gradient of the clamp, with and without passing the
+// gradient through the clamp, and that Clamp preserves NaNs
+func TestClampNaN(t *testing.T) {
+	const min, max float64 = -1, 1
+	in := []float64{math.NaN(), -2, 0.5, math.NaN(), 3}
+
+	tests := []struct {
+		nan        NaNPolicy
+		replacedBy float64 // Value that NaNs are replaced by
+	}{
+		{NaNPreserve, math.NaN()},
+		{NaNToMin, min},
+		{NaNToMax, max},
+	}
+
+	for _, test := range tests {
+		for _, passGradient := range []bool{false, true} {
+			for _, dt := range []tensor.Dtype{tensor.Float64,
+				tensor.Float32} {
+				g := G.NewGraph()
+				var x *G.Node
+				var minBound, maxBound interface{} = min, max
+				if dt == tensor.Float64 {
+					x = G.NewVector(g, dt, G.WithName("x"),
+						G.WithValue(tensor.NewDense(dt, []int{len(in)},
+							tensor.WithBacking(append([]float64{}, in...)))))
+				} else {
+					in32 := make([]float32, len(in))
+					for i := range in {
+						in32[i] = float32(in[i])
+					}
+					x = G.NewVector(g, dt, G.WithName("x"),
+						G.WithValue(tensor.NewDense(dt, []int{len(in)},
+							tensor.WithBacking(in32))))
+					minBound, maxBound = float32(min), float32(max)
+				}
+
+				var out *G.Node
+				var err error
+				if test.nan == NaNPreserve && !passGradient {
+					// Clamp itself should preserve NaNs
+					out, err = Clamp(x, minBound, maxBound, passGradient)
+				} else {
+					out, err = ClampNaN(x, minBound, maxBound, passGradient,
+						test.nan)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				var outVal G.Value
+				G.Read(out, &outVal)
+
+				grads, err := G.Grad(G.Must(G.Sum(out)), x)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var gradVal G.Value
+				G.Read(grads[0], &gradVal)
+
+				vm := G.NewTapeMachine(g)
+				if err := vm.RunAll(); err != nil {
+					t.Fatal(err)
+				}
+				vm.Close()
+
+				computed := toF64(outVal.Data())
+				grad := toF64(gradVal.Data())
+				for i, v := range in {
+					target, gradTarget := math.Max(min, math.Min(max, v)), 0.0
+					if v >= min && v <= max || passGradient {
+						gradTarget = 1.0
+					}
+					if math.IsNaN(v) {
+						target = test.replacedBy
+						if test.nan == NaNPreserve {
+							gradTarget = 1.0
+						}
+					}
+
+					if !(computed[i] == target ||
+						math.IsNaN(computed[i]) && math.IsNaN(target)) {
+						t.Errorf("%v, %v, passGradient=%v: expected %v at "+
+							"%v but got %v", test.nan, dt, passGradient,
+							target, v, computed[i])
+					}
+					if grad[i] != gradTarget {
+						t.Errorf("%v, %v, passGradient=%v: expected gradient "+
+							"%v at %v but got %v", test.nan, dt, passGradient,
+							gradTarget, v, grad[i])
+					}
+				}
+
+				// The input should not be modified
+				for i, v := range toF64(x.Value().Data()) {
+					if !(v == in[i] || math.IsNaN(v) && math.IsNaN(in[i])) {
+						t.Errorf("%v: input modified from %v to %v",
+							test.nan, in, x.Value().Data())
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestClampNaNBounds tests that ClampNaN returns an error when NaNs are
+// to be replaced by a nil bound or the policy is unknown
+func TestClampNaNBounds(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("x"),
+		G.WithInit(G.Zeroes()))
+
+	if _, err := ClampNaN(x, nil, 1.0, false, NaNToMin); err == nil {
+		t.Error("expected an error replacing NaNs with a nil min")
+	}
+	if _, err := ClampNaN(x, 0.0, nil, false, NaNToMax); err == nil {
+		t.Error("expected an error replacing NaNs with a nil max")
+	}
+	if _, err := ClampNaN(x, 0.0, 1.0, false, NaNPolicy(-1)); err == nil {
+		t.Error("expected an error for an unknown NaN policy")
+	}
+	if _, err := ClampNaN(x, nil, 1.0, false, NaNToMax); err != nil {
+		t.Errorf("unexpected error replacing NaNs with max: %v", err)
+	}
+}
+
+// TestClipClamp tests that Clip produces the same values and gradients
+// as Clamp with passGradient false, including for values equal to the
+// clipping bounds
+func TestClipClamp(t *testing.T) {
+	const numTests int = 10 // The number of random tests to run
+	const size int = 20     // The number of elements per input
+	const min, max float64 = -1.0, 1.5
+	rand.Seed(time.Now().UnixNano())
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		for i := 0; i < numTests; i++ {
+			backing := make([]float64, size)
+			for j := range backing {
+				backing[j] = 4 * (rand.Float64() - 0.5)
+			}
+			backing[0], backing[1] = min, max
+
+			var minVal, maxVal interface{} = min, max
+			input := func() *tensor.Dense {
+				return tensor.NewDense(dt, []int{size},
+					tensor.WithBacking(append([]float64{}, backing...)))
+			}
+			if dt == tensor.Float32 {
+				minVal, maxVal = float32(min), float32(max)
+				input = func() *tensor.Dense {
+					b := make([]float32, size)
+					for j := range backing {
+						b[j] = float32(backing[j])
+					}
+					return tensor.NewDense(dt, []int{size},
+						tensor.WithBacking(b))
+				}
+			}
+
+			g := G.NewGraph()
+			clipIn := G.NewVector(g, dt, G.WithName("clipIn"),
+				G.WithValue(input()))
+			clampIn := G.NewVector(g, dt, G.WithName("clampIn"),
+				G.WithValue(input()))
+
+			clip, err := Clip(clipIn, min, max)
+			if err != nil {
+				t.Fatal(err)
+			}
+			clamp, err := Clamp(clampIn, minVal, maxVal, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var clipVal, clampVal G.Value
+			G.Read(clip, &clipVal)
+			G.Read(clamp, &clampVal)
+
+			loss := G.Must(G.Add(G.Must(G.Sum(clip)), G.Must(G.Sum(clamp))))
+			grads, err := G.Grad(loss, clipIn, clampIn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var clipGrad, clampGrad G.Value
+			G.Read(grads[0], &clipGrad)
+			G.Read(grads[1], &clampGrad)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			if !reflect.DeepEqual(clipVal.Data(), clampVal.Data()) {
+				t.Errorf("%v: expected Clip values %v to equal Clamp "+
+					"values %v", dt, clipVal.Data(), clampVal.Data())
+			}
+			if !reflect.DeepEqual(clipGrad.Data(), clampGrad.Data()) {
+				t.Errorf("%v: expected Clip gradient %v to equal Clamp "+
+					"gradient %v", dt, clipGrad.Data(), clampGrad.Data())
+			}
+
+			// Values on the bounds should not be dropped
+			out := toF64(clipVal.Data())
+			if out[0] != min || out[1] != max {
+				t.Errorf("%v: expected bounds (%v, %v) to be unchanged "+
+					"but got (%v, %v)", dt, min, max, out[0], out[1])
+			}
+		}
+	}
+}
+
+// toF64 converts float64 or float32 data to a []float64
+func toF64(data interface{}) []float64 {
+	switch data := data.(type) {
+	case []float64:
+		return data
+	case []float32:
+		out := make([]float64, len(data))
+		for i := range data {
+			out[i] = float64(data[i])
+		}
+		return out
+	}
+	return nil
+}
@@ -60,7 +60,8 @@ func (e *erfOp) ReturnsPtr() bool { return true }
 func (e *erfOp) CallsExtern() bool { return false }
 
 // OverwritesInput returns the index of the input that this op
-// will overwrite
+// will overwrite - this op allocates a new output and does not
+// overwrite its input.
 func (e *erfOp) OverwritesInput() int { return -1 }
 
 // String returns the string representation of the struct
@@ -70,19 +71,7 @@ func (e *erfOp) String() string {
 
 // InferShape returns the output shape as a function of the inputs
 func (e *erfOp) InferShape(inputs ...G.DimSizer) (tensor.Shape, error) {
-	err := CheckArity(e, len(inputs))
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	if inputs[0] == nil {
-		return nil, fmt.Errorf("inferShape: nil input")
-	}
-
-	shapes, err := G.DimSizersToShapes(inputs)
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	return shapes[0], nil
+	return pointwiseInferShape(e, inputs...)
 }
 
 // WriteHash writes the hash of the receiver to a hash struct
@@ -163,19 +152,7 @@ func (e *erfDiffOp) String() string { return "ErfDiff()" }
 
 // InferShape returns the output shape as a function of the inputs
 func (e *erfDiffOp) InferShape(inputs ...G.DimSizer) (tensor.Shape, error) {
-	err := CheckArity(e, len(inputs))
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	if inputs[0] == nil {
-		return nil, fmt.Errorf("inferShape: nil input")
-	}
-
-	shapes, err := G.DimSizersToShapes(inputs)
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	return shapes[0], nil
+	return pointwiseInferShape(e, inputs...)
 }
 
 // Type returns the type of the operation
@@ -313,38 +290,78 @@ func computeErf(value G.Value) (G.Value, error) {
 			return nil, fmt.Errorf("do: cannot compute erf on empty tensor")
 		}
 
-		// Create the new output tensor
-		out := tensor.NewDense(
-			v.Dtype(),
-			v.Shape(),
-		)
-
-		iter := v.Iterator()
-		// Go through each element of the tensor and erf it in place
-		for !iter.Done() {
-			// Get the coordinates of the element to erf
-			coords := iter.Coord()
-
-			// Erf the elements of v and store in out
-			err := erfTensorAt(v, out, coords)
-			if err != nil {
-				return nil, fmt.Errorf("do: %v", err)
-			}
+		// Operate directly on the backing slice of contiguous tensors
+		if dense, ok := v.(*tensor.Dense); ok && !dense.RequiresIterator() {
+			switch data := dense.Data().(type) {
+			case []float64:
+				return erfF64Kernel(dense.Shape().Clone(), data), nil
 
-			// Step the iterator
-			_, _, err = iter.NextValid()
-			if err != nil {
-				return nil, fmt.Errorf("do: could not step iterator")
+			case []float32:
+				return erfF32Kernel(dense.Shape().Clone(), data), nil
 			}
 		}
 
-		return out, nil
+		return computeErfIter(v)
 
 	default:
 		return nil, fmt.Errorf("do: unable to compute erf on type %T", v)
 	}
 }
 
+// erfF64Kernel computes the element-wise erf of the backing slice x
+// of a float64 tensor with shape shape
+func erfF64Kernel(shape tensor.Shape, x []float64) *tensor.Dense {
+	backing := make([]float64, len(x))
+	for i, elem := range x {
+		backing[i] = math.Erf(elem)
+	}
+
+	return tensor.NewDense(tensor.Float64, shape, tensor.WithBacking(backing))
+}
+
+// erfF32Kernel computes the element-wise erf of the backing slice x
+// of a float32 tensor with shape shape
+func erfF32Kernel(shape tensor.Shape, x []float32) *tensor.Dense {
+	backing := make([]float32, len(x))
+	for i, elem := range x {
+		backing[i] = math32.Erf(elem)
+	}
+
+	return tensor.NewDense(tensor.Float32, shape, tensor.WithBacking(backing))
+}
+
+// computeErfIter computes the element-wise erf on a tensor using its
+// iterator, which is slower than operating on the backing slice but
+// supports views and tensors which are not *tensor.Dense
+func computeErfIter(v tensor.Tensor) (G.Value, error) {
+	// Create the new output tensor
+	out := tensor.NewDense(
+		v.Dtype(),
+		v.Shape(),
+	)
+
+	iter := v.Iterator()
+	// Go through each element of the tensor and erf it
+	for !iter.Done() {
+		// Get the coordinates of the element to erf
+		coords := iter.Coord()
+
+		// Erf the elements of v and store in out
+		err := erfTensorAt(v, out, coords)
+		if err != nil {
+			return nil, fmt.Errorf("do: %v", err)
+		}
+
+		// Step the iterator
+		_, _, err = iter.NextValid()
+		if err != nil {
+			return nil, fmt.Errorf("do: could not step iterator")
+		}
+	}
+
+	return out, nil
+}
+
 // erfTensorAt computes the erf of tensor v at coords, placing the
 // result in out at coords
 func erfTensorAt(in tensor.Tensor, out tensor.Tensor, coords []int) error {
@@ -1,6 +1,7 @@
 package gop
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"testing"
@@ -86,6 +87,72 @@ func TestErf_graph(t *testing.T) {
 	}
 }
 
+// TestErfInput tests that running Erf in a graph does not modify the
+// value of its input node
+func TestErfInput(t *testing.T) {
+	const maxDims int = 5
+	const maxDimSize int = 10
+
+	shape := make([]int, 1+rand.Intn(maxDims))
+	for i := range shape {
+		shape[i] = 1 + rand.Intn(maxDimSize-1) // Avoid dimension size 0
+	}
+
+	backing := make([]float64, tensor.ProdInts(shape))
+	for i := range backing {
+		backing[i] = (rand.Float64() - 0.5) * 2.0
+	}
+	original := append([]float64{}, backing...)
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(
+		tensor.Float64,
+		shape,
+		tensor.WithBacking(backing),
+	)
+	in := G.NewTensor(
+		g,
+		tensor.Float64,
+		len(shape),
+		G.WithValue(inTensor),
+	)
+
+	// Read the input elsewhere in the graph
+	var inVal G.Value
+	G.Read(in, &inVal)
+
+	erf, err := Erf(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var erfVal G.Value
+	G.Read(erf, &erfVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, val := range []G.Value{in.Value(), inVal} {
+		data := val.Data().([]float64)
+		for i := range original {
+			if data[i] != original[i] {
+				t.Errorf("erf modified input at %v: expected %v but got %v",
+					i, original[i], data[i])
+			}
+		}
+	}
+
+	output := erfVal.Data().([]float64)
+	for i := range original {
+		if math.Abs(output[i]-math.Erf(original[i])) > 0.0001 {
+			t.Errorf("incorrect value\nexpected: %v \nreceived:%v",
+				math.Erf(original[i]), output[i])
+		}
+	}
+}
+
 func TestErfc_graph(t *testing.T) {
 	const tolerance float64 = 0.0001
 	const maxDims int = 5
@@ -251,7 +318,7 @@ func TestErf(t *testing.T) {
 		inCheck := tensor.NewDense(
 			tensor.Float64,
 			shapes[i],
-			tensor.WithBacking(inBacking),
+			tensor.WithBacking(append([]float64{}, inBacking...)),
 		)
 
 		out := tensor.NewDense(
@@ -266,7 +333,7 @@ func TestErf(t *testing.T) {
 			t.Error(err)
 		}
 
-		// Ensure output is expected, input tensor modified, and
+		// Ensure output is expected, input tensor not modified, and
 		// output shape is not changed
 		if !v.(*tensor.Dense).Eq(out) {
 			t.Errorf("expected: \n%v \nreceived: \n%v", out, v)
@@ -327,7 +394,7 @@ func TestErfDiff(t *testing.T) {
 		inCheck := tensor.NewDense(
 			tensor.Float64,
 			shapes[i],
-			tensor.WithBacking(inBacking),
+			tensor.WithBacking(append([]float64{}, inBacking...)),
 		)
 		out := tensor.NewDense(
 			tensor.Float64,
@@ -369,3 +436,92 @@ func TestErfDiff(t *testing.T) {
 	}
 
 }
+
+// TestErfIter tests that the erf computed on the backing slice of a
+// tensor is identical to the erf computed using the tensor's iterator,
+// and that views of tensors are handled correctly
+func TestErfIter(t *testing.T) {
+	shape := []int{3, 4, 5}
+	backing := make([]float64, tensor.ProdInts(shape))
+	for i := range backing {
+		backing[i] = (rand.Float64() - 0.5) * 4.0
+	}
+	in := tensor.NewDense(tensor.Float64, shape, tensor.WithBacking(backing))
+
+	fast, err := computeErf(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slow, err := computeErfIter(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fast.(*tensor.Dense).Eq(slow) {
+		t.Errorf("expected identical results \nfast: \n%v \niterator: \n%v",
+			fast, slow)
+	}
+
+	// A non-contiguous view requires the iterator
+	view, err := in.Slice(nil, G.S(1, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := computeErf(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := view.Materialize().(*tensor.Dense)
+	for i, v := range target.Data().([]float64) {
+		computed := out.(tensor.Tensor).Data().([]float64)[i]
+		if computed != math.Erf(v) {
+			t.Errorf("incorrect erf of view \nexpected: %v \nreceived: %v",
+				math.Erf(v), computed)
+		}
+	}
+}
+
+// benchmarkErf benchmarks computing erf on a large tensor using f
+func benchmarkErf(b *testing.B, f func(G.Value) (G.Value, error)) {
+	shape := []int{1000, 100}
+	backing := make([]float64, tensor.ProdInts(shape))
+	for i := range backing {
+		backing[i] = (rand.Float64() - 0.5) * 4.0
+	}
+	in := tensor.NewDense(tensor.Float64, shape, tensor.WithBacking(backing))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkErf(b *testing.B) {
+	benchmarkErf(b, computeErf)
+}
+
+func BenchmarkErfIter(b *testing.B) {
+	benchmarkErf(b, func(v G.Value) (G.Value, error) {
+		return computeErfIter(v.(tensor.Tensor))
+	})
+}
+
+// TestErfUnsupportedDtype tests that Erf returns a DtypeError when the
+// graph is built for nodes of unsupported data types
+func TestErfUnsupportedDtype(t *testing.T) {
+	for _, dt := range []tensor.Dtype{tensor.Int, tensor.Int64, tensor.Bool} {
+		g := G.NewGraph()
+		x := G.NewVector(g, dt, G.WithShape(3), G.WithName("x"))
+
+		out, err := Erf(x)
+		var dtypeErr *DtypeError
+		if !errors.As(err, &dtypeErr) {
+			t.Errorf("expected DtypeError for data type %v but got %v", dt,
+				err)
+		}
+		if out != nil {
+			t.Errorf("expected nil output for data type %v", dt)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package gop
+
+import (
+	"math"
+
+	"github.com/samuelfneumann/math32"
+)
+
+// newErfcOp returns a new pointwise operation which computes the
+// complementary error function. Unlike 1 - erf(x), the complementary
+// error function is computed directly, so that it does not lose
+// precision for large x. The derivative of the erfc function is
+// -2/√π⋅exp(-x²).
+func newErfcOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Erfc",
+		f64:  math.Erfc,
+		f32:  math32.Erfc,
+		df64: func(x float64) float64 {
+			return -2.0 / math.Sqrt(math.Pi) * math.Exp(-x*x)
+		},
+		df32: func(x float32) float32 {
+			return -2.0 / math32.Sqrt(math32.Pi) * math32.Exp(-x*x)
+		},
+	}
+}
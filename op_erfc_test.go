@@ -0,0 +1,106 @@
+package gop
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestErfc(t *testing.T) {
+	df := func(x float64) float64 { return finiteDifference(math.Erfc, x) }
+	input := func() float64 { return (rand.Float64() - 0.5) * 6.0 }
+
+	testPointwise(t, "Erfc", Erfc, math.Erfc, df, input)
+}
+
+// TestErfcTail tests the Erfc function in the right tail, where
+// 1 - erf(x) rounds to 0, against a continued fraction expansion
+func TestErfcTail(t *testing.T) {
+	const tolerance float64 = 1e-6 // Relative tolerance
+	const size int = 20            // Number of elements to test
+
+	backing := make([]float64, size)
+	for i := range backing {
+		backing[i] = 6.0 + rand.Float64()*20.0
+	}
+
+	if naive := 1 - math.Erf(backing[0]); naive != 0 {
+		t.Fatalf("expected 1 - erf(x) to round to 0 at %v but got %v",
+			backing[0], naive)
+	}
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(tensor.Float64, []int{size},
+		tensor.WithBacking(backing))
+	in := G.NewVector(g, tensor.Float64, G.WithValue(inTensor),
+		G.WithName("x"))
+
+	out, err := Erfc(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal G.Value
+	G.Read(out, &outVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, computed := range outVal.Data().([]float64) {
+		x := backing[i]
+		target := math.Exp(-x*x) * erfcxContinuedFraction(x)
+		if math.Abs(computed-target)/target > tolerance {
+			t.Errorf("incorrect value at %v\nexpected: %v\nreceived: %v", x,
+				target, computed)
+		}
+	}
+}
+
+// TestErfcUnsupportedDtype tests that Erfc returns a DtypeError rather
+// than panicking for nodes and values of unsupported data types
+func TestErfcUnsupportedDtype(t *testing.T) {
+	for _, dt := range []tensor.Dtype{tensor.Int, tensor.Int64, tensor.Bool} {
+		g := G.NewGraph()
+		x := G.NewVector(g, dt, G.WithShape(3), G.WithName("x"))
+
+		var out *G.Node
+		var err error
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("Erfc panicked on data type %v: %v", dt, r)
+				}
+			}()
+			out, err = Erfc(x)
+		}()
+
+		var dtypeErr *DtypeError
+		if !errors.As(err, &dtypeErr) {
+			t.Errorf("expected DtypeError for data type %v but got %v", dt,
+				err)
+		}
+		if out != nil {
+			t.Errorf("expected nil output for data type %v", dt)
+		}
+	}
+
+	// Values of unsupported data types passed to the op directly
+	values := []G.Value{
+		G.NewI(1),
+		tensor.New(tensor.WithShape(3), tensor.WithBacking([]int{1, 2, 3})),
+	}
+	for _, v := range values {
+		_, err := newErfcOp().Do(v)
+		var dtypeErr *DtypeError
+		if !errors.As(err, &dtypeErr) {
+			t.Errorf("expected DtypeError for value of type %T but got %v",
+				v, err)
+		}
+	}
+}
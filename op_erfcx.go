@@ -0,0 +1,53 @@
+package gop
+
+import "math"
+
+// erfcxThreshold is the value above which the scaled complementary
+// error function is computed using its asymptotic series, since
+// exp(x²) overflows and erfc(x) underflows for large x
+const erfcxThreshold float64 = 25.0
+
+// newErfcxOp returns a new pointwise operation which computes the
+// scaled complementary error function, exp(x²)·erfc(x). The
+// derivative of the erfcx function is 2x·erfcx(x) - 2/√π.
+func newErfcxOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Erfcx",
+		f64:  erfcx,
+		f32:  func(x float32) float32 { return float32(erfcx(float64(x))) },
+		df64: erfcxDiff,
+		df32: func(x float32) float32 {
+			return float32(erfcxDiff(float64(x)))
+		},
+	}
+}
+
+// erfcx returns the scaled complementary error function at x. For
+// x > erfcxThreshold, the asymptotic series
+//
+//		erfcx(x) ≈ 1/(x√π) Σ_n (-1)ⁿ (2n-1)!! / (2x²)ⁿ
+//
+// is used, which is accurate to machine precision for such x.
+func erfcx(x float64) float64 {
+	if x <= erfcxThreshold {
+		return math.Exp(x*x) * math.Erfc(x)
+	}
+
+	sum, term := 1.0, 1.0
+	for n := 1; ; n++ {
+		next := -term * float64(2*n-1) / (2 * x * x)
+		if math.Abs(next) >= math.Abs(term) || math.Abs(next) < 1e-17 {
+			break
+		}
+		term = next
+		sum += term
+	}
+
+	return sum / (x * math.Sqrt(math.Pi))
+}
+
+// erfcxDiff returns the derivative of the scaled complementary error
+// function at x
+func erfcxDiff(x float64) float64 {
+	return 2*x*erfcx(x) - 2/math.Sqrt(math.Pi)
+}
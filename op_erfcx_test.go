@@ -0,0 +1,109 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestErfcx(t *testing.T) {
+	f := func(x float64) float64 { return math.Exp(x*x) * math.Erfc(x) }
+	df := func(x float64) float64 { return finiteDifference(f, x) }
+	input := func() float64 { return -1.0 + rand.Float64()*6.0 }
+
+	testPointwise(t, "Erfcx", Erfcx, f, df, input)
+}
+
+// TestErfcxLarge tests the Erfcx function for large x, where the naive
+// exp(x²)·erfc(x) overflows, against a continued fraction expansion
+func TestErfcxLarge(t *testing.T) {
+	const tolerance float64 = 1e-6 // Relative tolerance
+	const size int = 20            // Number of elements to test
+
+	backing64 := make([]float64, size)
+	backing32 := make([]float32, size)
+	for i := range backing64 {
+		backing64[i] = 30.0 + rand.Float64()*1000.0
+		backing32[i] = float32(backing64[i])
+	}
+
+	naive := math.Exp(backing64[0]*backing64[0]) * math.Erfc(backing64[0])
+	if !math.IsNaN(naive) && !math.IsInf(naive, 0) {
+		t.Fatalf("expected naive erfcx to overflow at %v", backing64[0])
+	}
+
+	check := func(dt string, x, computed float64) {
+		target := erfcxContinuedFraction(x)
+		if math.Abs(computed-target)/target > tolerance {
+			t.Errorf("%v: incorrect value at %v\nexpected: %v\nreceived: %v",
+				dt, x, target, computed)
+		}
+	}
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		g := G.NewGraph()
+
+		var inTensor *tensor.Dense
+		var inScalar *G.Node
+		if dt == tensor.Float64 {
+			inTensor = tensor.NewDense(dt, []int{size},
+				tensor.WithBacking(backing64))
+			inScalar = G.NewScalar(g, dt, G.WithValue(backing64[0]),
+				G.WithName("scalar"))
+		} else {
+			inTensor = tensor.NewDense(dt, []int{size},
+				tensor.WithBacking(backing32))
+			inScalar = G.NewScalar(g, dt, G.WithValue(backing32[0]),
+				G.WithName("scalar"))
+		}
+		in := G.NewVector(g, dt, G.WithValue(inTensor), G.WithName("vector"))
+
+		tensorOut, err := Erfcx(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scalarOut, err := Erfcx(inScalar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tensorVal, scalarVal G.Value
+		G.Read(tensorOut, &tensorVal)
+		G.Read(scalarOut, &scalarVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		if dt == tensor.Float64 {
+			for i, v := range tensorVal.Data().([]float64) {
+				check("float64", backing64[i], v)
+			}
+			check("float64", backing64[0], scalarVal.Data().(float64))
+		} else {
+			for i, v := range tensorVal.Data().([]float32) {
+				check("float32", float64(backing32[i]), float64(v))
+			}
+			check("float32", float64(backing32[0]),
+				float64(scalarVal.Data().(float32)))
+		}
+
+		vm.Close()
+	}
+}
+
+// erfcxContinuedFraction returns the scaled complementary error
+// function at x > 0 computed with the continued fraction
+//
+//		erfcx(x) = 1/√π · 1/(x + (1/2)/(x + 1/(x + (3/2)/(x + ...))))
+func erfcxContinuedFraction(x float64) float64 {
+	const terms int = 100
+	cf := x
+	for n := terms; n >= 1; n-- {
+		cf = x + (float64(n)/2)/cf
+	}
+	return 1 / (math.Sqrt(math.Pi) * cf)
+}
@@ -11,9 +11,12 @@ import (
 	"gorgonia.org/tensor"
 )
 
-// ToDo: figure out the type system and fix Type() methos
-
-// erfinvOp is the error function
+// erfinvOp is the inverse error function. The erfinvOp supports
+// float64 and float32 scalars and tensors only. Since the type
+// variable of Type() cannot be restricted to these data types, the
+// data type of the input is checked by Erfinv() when the op is added
+// to a graph and by checkInputs() when the op is run, both of which
+// return a DtypeError for any other data type.
 type erfinvOp struct{}
 
 // newErfOp returns a new erfinvOp
@@ -26,10 +29,9 @@ func (e *erfinvOp) Arity() int {
 	return 1
 }
 
-// Type returns the type of the operation
+// Type returns the type of the operation, a -> a, where a is a float64
+// or float32 scalar or tensor
 func (e *erfinvOp) Type() hm.Type {
-	// All pointwise unary operations have this type:
-	// op :: (Arithable a) => a -> a
 	a := hm.TypeVariable('a')
 	return hm.NewFnType(a, a)
 }
@@ -70,19 +72,7 @@ func (e *erfinvOp) String() string {
 
 // InferShape returns the output shape as a function of the inputs
 func (e *erfinvOp) InferShape(inputs ...G.DimSizer) (tensor.Shape, error) {
-	err := CheckArity(e, len(inputs))
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	if inputs[0] == nil {
-		return nil, fmt.Errorf("inferShape: nil input")
-	}
-
-	shapes, err := G.DimSizersToShapes(inputs)
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	return shapes[0], nil
+	return pointwiseInferShape(e, inputs...)
 }
 
 // WriteHash writes the hash of the receiver to a hash struct
@@ -123,19 +113,20 @@ func (e *erfinvOp) checkInputs(inputs ...G.Value) error {
 		return err
 	}
 
-	_, okF64 := inputs[0].(*G.F64)
-	_, okF32 := inputs[0].(*G.F32)
-	t, okTensor := inputs[0].(tensor.Tensor)
+	switch v := inputs[0].(type) {
+	case *G.F64, *G.F32:
+		return nil
 
-	if okTensor && (len(t.Shape()) <= 0 || t.Size() == 0) {
-		return fmt.Errorf("tensor does not have any elements")
-	}
+	case tensor.Tensor:
+		if len(v.Shape()) <= 0 || v.Size() == 0 {
+			return fmt.Errorf("tensor does not have any elements")
+		}
+		return checkFloatDtype("erfinv", v.Dtype())
 
-	if !(okF64 || okF32 || okTensor) {
-		return fmt.Errorf("expected input to be a tensor, got %T", inputs[0])
+	default:
+		return DtypeErrorf("erfinv: expected a float64 or float32 scalar "+
+			"or tensor but got %T", inputs[0])
 	}
-
-	return nil
 }
 
 // erfinvDiffOp is the derivative of erfinv
@@ -163,19 +154,7 @@ func (e *erfinvDiffOp) String() string { return "ErfinvDiff()" }
 
 // InferShape returns the output shape as a function of the inputs
 func (e *erfinvDiffOp) InferShape(inputs ...G.DimSizer) (tensor.Shape, error) {
-	err := CheckArity(e, len(inputs))
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	if inputs[0] == nil {
-		return nil, fmt.Errorf("inferShape: nil input")
-	}
-
-	shapes, err := G.DimSizersToShapes(inputs)
-	if err != nil {
-		return nil, fmt.Errorf("inferShape: %v", err)
-	}
-	return shapes[0], nil
+	return pointwiseInferShape(e, inputs...)
 }
 
 // Type returns the type of the operation
@@ -212,7 +191,12 @@ func (e *erfinvDiffOp) checkInputs(inputs ...G.Value) error {
 	}
 
 	if !((okF64 || okF32 || okTensor) && okGrad) {
-		return fmt.Errorf("expected input to be a tensor, got %T", inputs[0])
+		return DtypeErrorf("erfinv: expected a float64 or float32 scalar "+
+			"or tensor with a gradient of the same type but got %T and %T",
+			inputs[0], inputs[1])
+	}
+	if okTensor {
+		return checkFloatDtype("erfinv", t.Dtype())
 	}
 
 	return nil
@@ -315,38 +299,78 @@ func computeErfinv(value G.Value) (G.Value, error) {
 			return nil, fmt.Errorf("do: cannot compute erfinv on empty tensor")
 		}
 
-		// Create the new output tensor
-		out := tensor.NewDense(
-			v.Dtype(),
-			v.Shape(),
-		)
-
-		iter := v.Iterator()
-		// Go through each element of the tensor and erfinv it in place
-		for !iter.Done() {
-			// Get the coordinates of the element to erfinv
-			coords := iter.Coord()
-
-			// Erfinv v, storing results in out
-			err := erfinvTensorAt(v, out, coords)
-			if err != nil {
-				return nil, fmt.Errorf("do: %v", err)
-			}
+		// Operate directly on the backing slice of contiguous tensors
+		if dense, ok := v.(*tensor.Dense); ok && !dense.RequiresIterator() {
+			switch data := dense.Data().(type) {
+			case []float64:
+				return erfinvF64Kernel(dense.Shape().Clone(), data), nil
 
-			// Step the iterator
-			_, _, err = iter.NextValid()
-			if err != nil {
-				return nil, fmt.Errorf("do: could not step iterator")
+			case []float32:
+				return erfinvF32Kernel(dense.Shape().Clone(), data), nil
 			}
 		}
 
-		return out, nil
+		return computeErfinvIter(v)
 
 	default:
 		return nil, fmt.Errorf("do: unable to compute erfinv on type %T", v)
 	}
 }
 
+// erfinvF64Kernel computes the element-wise erfinv of the backing
+// slice x of a float64 tensor with shape shape
+func erfinvF64Kernel(shape tensor.Shape, x []float64) *tensor.Dense {
+	backing := make([]float64, len(x))
+	for i, elem := range x {
+		backing[i] = math.Erfinv(elem)
+	}
+
+	return tensor.NewDense(tensor.Float64, shape, tensor.WithBacking(backing))
+}
+
+// erfinvF32Kernel computes the element-wise erfinv of the backing
+// slice x of a float32 tensor with shape shape
+func erfinvF32Kernel(shape tensor.Shape, x []float32) *tensor.Dense {
+	backing := make([]float32, len(x))
+	for i, elem := range x {
+		backing[i] = math32.Erfinv(elem)
+	}
+
+	return tensor.NewDense(tensor.Float32, shape, tensor.WithBacking(backing))
+}
+
+// computeErfinvIter computes the element-wise erfinv on a tensor using
+// its iterator, which is slower than operating on the backing slice
+// but supports views and tensors which are not *tensor.Dense
+func computeErfinvIter(v tensor.Tensor) (G.Value, error) {
+	// Create the new output tensor
+	out := tensor.NewDense(
+		v.Dtype(),
+		v.Shape(),
+	)
+
+	iter := v.Iterator()
+	// Go through each element of the tensor and erfinv it
+	for !iter.Done() {
+		// Get the coordinates of the element to erfinv
+		coords := iter.Coord()
+
+		// Erfinv v, storing results in out
+		err := erfinvTensorAt(v, out, coords)
+		if err != nil {
+			return nil, fmt.Errorf("do: %v", err)
+		}
+
+		// Step the iterator
+		_, _, err = iter.NextValid()
+		if err != nil {
+			return nil, fmt.Errorf("do: could not step iterator")
+		}
+	}
+
+	return out, nil
+}
+
 // erfinvTensorAt computes erfinv of tensor v at coords, storing the
 // result in out at coords
 func erfinvTensorAt(in tensor.Tensor, out tensor.Tensor, coords []int) error {
@@ -1,6 +1,7 @@
 package gop
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"testing"
@@ -294,3 +295,233 @@ func TestErfinvDiff(t *testing.T) {
 	}
 
 }
+
+// TestErfinvIter tests that computing erfinv on the backing slice of a
+// contiguous tensor gives the same results as using the iterator, and
+// that views are still computed correctly.
+func TestErfinvIter(t *testing.T) {
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		shape := []int{3, 4, 5}
+		in := tensor.NewDense(dt, shape)
+		for i := 0; i < in.Size(); i++ {
+			v := (rand.Float64() - 0.5) * 1.98
+			if dt == tensor.Float64 {
+				in.Set(i, v)
+			} else {
+				in.Set(i, float32(v))
+			}
+		}
+
+		fast, err := computeErfinv(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		slow, err := computeErfinvIter(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fast.(*tensor.Dense).Eq(slow) {
+			t.Errorf("expected identical results \nfast: \n%v \niterator: "+
+				"\n%v", fast, slow)
+		}
+	}
+
+	// A non-contiguous view requires the iterator
+	shape := []int{3, 4}
+	backing := make([]float64, tensor.ProdInts(shape))
+	for i := range backing {
+		backing[i] = (rand.Float64() - 0.5) * 1.98
+	}
+	in := tensor.NewDense(tensor.Float64, shape, tensor.WithBacking(backing))
+	view, err := in.Slice(nil, G.S(1, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := computeErfinv(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := view.Materialize().(*tensor.Dense)
+	for i, v := range target.Data().([]float64) {
+		computed := out.(tensor.Tensor).Data().([]float64)[i]
+		if computed != math.Erfinv(v) {
+			t.Errorf("incorrect erfinv of view \nexpected: %v \nreceived: %v",
+				math.Erfinv(v), computed)
+		}
+	}
+}
+
+// benchmarkErfinv benchmarks computing erfinv on a large tensor using f
+func benchmarkErfinv(b *testing.B, f func(G.Value) (G.Value, error)) {
+	shape := []int{1000, 100}
+	backing := make([]float64, tensor.ProdInts(shape))
+	for i := range backing {
+		backing[i] = (rand.Float64() - 0.5) * 1.98
+	}
+	in := tensor.NewDense(tensor.Float64, shape, tensor.WithBacking(backing))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkErfinv(b *testing.B) {
+	benchmarkErfinv(b, computeErfinv)
+}
+
+func BenchmarkErfinvIter(b *testing.B) {
+	benchmarkErfinv(b, func(v G.Value) (G.Value, error) {
+		return computeErfinvIter(v.(tensor.Tensor))
+	})
+}
+
+// TestErfinvDtypes tests that Erfinv computes outputs and gradients of
+// the same data type as its float64 or float32 input, and that any
+// other data type results in a DtypeError
+func TestErfinvDtypes(t *testing.T) {
+	const tolerance float64 = 0.00001
+	in := []float64{-0.9, -0.5, 0, 0.3, 0.7, 0.95}
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		var backing interface{} = append([]float64{}, in...)
+		if dt == tensor.Float32 {
+			backing32 := make([]float32, len(in))
+			for i := range in {
+				backing32[i] = float32(in[i])
+			}
+			backing = backing32
+		}
+
+		g := G.NewGraph()
+		x := G.NewVector(g, dt, G.WithName("x"), G.WithValue(
+			tensor.New(tensor.WithShape(len(in)), tensor.WithBacking(backing))))
+		out, err := Erfinv(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		grad, err := G.Grad(G.Must(G.Sum(out)), x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal, gradVal G.Value
+		G.Read(out, &outVal)
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if outVal.Dtype() != dt || gradVal.Dtype() != dt {
+			t.Errorf("expected output and gradient of type %v but got %v "+
+				"and %v", dt, outVal.Dtype(), gradVal.Dtype())
+			continue
+		}
+		outData, gradData := toF64(outVal.Data()), toF64(gradVal.Data())
+		for i := range in {
+			if math.Abs(outData[i]-math.Erfinv(in[i])) > tolerance {
+				t.Errorf("%v: incorrect erfinv \nexpected: %v \nreceived: %v",
+					dt, math.Erfinv(in[i]), outData[i])
+			}
+			if math.Abs(gradData[i]-erfinvGrad(in[i])) > tolerance {
+				t.Errorf("%v: incorrect gradient \nexpected: %v "+
+					"\nreceived: %v", dt, erfinvGrad(in[i]), gradData[i])
+			}
+		}
+	}
+
+	// Unsupported data types
+	g := G.NewGraph()
+	x := G.NewVector(g, tensor.Int, G.WithShape(3), G.WithName("x"))
+	_, err := Erfinv(x)
+	var dtypeErr *DtypeError
+	if !errors.As(err, &dtypeErr) {
+		t.Errorf("expected DtypeError from Erfinv but got %v", err)
+	}
+
+	intTensor := tensor.New(tensor.WithShape(3),
+		tensor.WithBacking([]int{0, 1, 2}))
+	if err := newErfinvOp().(*erfinvOp).checkInputs(intTensor); !errors.As(
+		err, &dtypeErr) {
+		t.Errorf("expected DtypeError from checkInputs but got %v", err)
+	}
+	if _, err := newErfinvOp().Do(intTensor); err == nil {
+		t.Errorf("expected error computing erfinv of %v tensor", tensor.Int)
+	}
+	if _, err := newErfinvOp().Do(G.NewI(1)); err == nil {
+		t.Errorf("expected error computing erfinv of %T", G.NewI(1))
+	}
+	if _, err := (&erfinvDiffOp{}).Do(intTensor, intTensor); err == nil {
+		t.Errorf("expected error computing erfinv gradient of %v tensor",
+			tensor.Int)
+	}
+}
+
+// TestErfinvScalar tests that Erfinv applied to a scalar node in a
+// graph results in a scalar node, with a scalar value and gradient,
+// and that the erfinvOp infers the same shapes as the erfOp
+func TestErfinvScalar(t *testing.T) {
+	const tolerance float64 = 0.0000001
+	in := rand.Float64()*2 - 1
+
+	g := G.NewGraph()
+	x := G.NewScalar(g, tensor.Float64, G.WithName("x"), G.WithValue(in))
+	out, err := Erfinv(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.IsScalar() {
+		t.Fatalf("expected scalar output but got shape %v", out.Shape())
+	}
+	grad, err := G.Grad(out, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal, gradVal G.Value
+	G.Read(out, &outVal)
+	G.Read(grad[0], &gradVal)
+
+	vm := G.NewTapeMachine(g)
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	v, ok := outVal.(*G.F64)
+	if !ok {
+		t.Fatalf("expected output value of type %T but got %T", v, outVal)
+	}
+	if math.Abs(float64(*v)-math.Erfinv(in)) > tolerance {
+		t.Errorf("incorrect erfinv \nexpected: %v \nreceived: %v",
+			math.Erfinv(in), *v)
+	}
+	dv, ok := gradVal.(*G.F64)
+	if !ok {
+		t.Fatalf("expected gradient value of type %T but got %T", dv,
+			gradVal)
+	}
+	if math.Abs(float64(*dv)-erfinvGrad(in)) > tolerance {
+		t.Errorf("incorrect gradient \nexpected: %v \nreceived: %v",
+			erfinvGrad(in), *dv)
+	}
+
+	for _, shape := range []tensor.Shape{tensor.ScalarShape(), {3}, {2, 3}} {
+		erfShape, err := newErfOp().InferShape(shape)
+		if err != nil {
+			t.Fatal(err)
+		}
+		erfinvShape, err := newErfinvOp().InferShape(shape)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !erfinvShape.Eq(shape) || !erfinvShape.Eq(erfShape) ||
+			len(erfinvShape) != len(shape) {
+			t.Errorf("expected inferred shape %v but got %v (erf: %v)",
+				shape, erfinvShape, erfShape)
+		}
+	}
+}
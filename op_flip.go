@@ -0,0 +1,136 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// flipOp implements the flip operation, which reverses the order of
+// the elements of a tensor along an axis
+type flipOp struct {
+	axis int // Axis along which to flip
+	dims int // Number of dimensions in the input node
+}
+
+// newFlipOp returns a new flipOp
+func newFlipOp(axis, dims int) (*flipOp, error) {
+	axis, err := normalizeAxis(axis, dims)
+	if err != nil {
+		return nil, fmt.Errorf("newFlipOp: %w", err)
+	}
+
+	return &flipOp{
+		axis: axis,
+		dims: dims,
+	}, nil
+}
+
+// DiffWRT implements the gorgonia.SDOp interface
+func (f *flipOp) DiffWRT(inputs int) []bool {
+	return []bool{true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface. Since flipping is
+// its own inverse, the gradient of the flip operation is the incoming
+// gradient flipped back along the same axis.
+func (f *flipOp) SymDiff(inputs G.Nodes, output, grad *G.Node) (G.Nodes,
+	error) {
+	err := CheckArity(f, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	nodes := make(G.Nodes, 1)
+
+	nodes[0], err = G.ApplyOp(f, grad)
+
+	return nodes, err
+}
+
+// Arity implements the gorgonia.Op interface
+func (f *flipOp) Arity() int { return 1 }
+
+// Type implements the gorgonia.Op interface
+func (f *flipOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: f.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+
+	return hm.NewFnType(tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (f *flipOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (f *flipOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (f *flipOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (f *flipOp) String() string {
+	return fmt.Sprintf("Flip{axis=%v}()", f.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (f *flipOp) WriteHash(h hash.Hash) { fmt.Fprint(h, f.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (f *flipOp) Hashcode() uint32 { return SimpleHash(f) }
+
+// InferShape implements the gorgonia.Op interface
+func (f *flipOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(f, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[0].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (f *flipOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := f.checkInputs(inputs...); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := inputs[0].(tensor.Tensor)
+	length := input.Shape()[f.axis]
+
+	return permuteAxis(input, f.axis, func(k int) int {
+		return length - 1 - k
+	}), nil
+}
+
+// checkInputs returns an error if inputs is not a valid input to the
+// receiver
+func (f *flipOp) checkInputs(inputs ...G.Value) error {
+	if err := CheckArity(f, len(inputs)); err != nil {
+		return err
+	}
+
+	t, ok := inputs[0].(tensor.Tensor)
+	if !ok {
+		return fmt.Errorf("expected tensor, received %T", inputs[0])
+	} else if t == nil {
+		return fmt.Errorf("cannot flip nil tensor")
+	} else if t.Size() == 0 {
+		return fmt.Errorf("cannot flip empty tensor")
+	} else if f.axis >= len(t.Shape()) {
+		return fmt.Errorf("axis [%v] out of range for tensor with shape %v",
+			f.axis, t.Shape())
+	}
+
+	return nil
+}
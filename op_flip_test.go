@@ -0,0 +1,102 @@
+package gop
+
+import (
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestFlip tests the forward and backward pass of Flip on 1-D and 2-D
+// inputs, and that flipping twice is the identity
+func TestFlip(t *testing.T) {
+	tests := []struct {
+		shape  []int
+		axis   int
+		in     []float64
+		target []float64
+	}{
+		{[]int{5}, 0, []float64{0, 1, 2, 3, 4}, []float64{4, 3, 2, 1, 0}},
+		{[]int{2, 3}, 0, []float64{0, 1, 2, 3, 4, 5},
+			[]float64{3, 4, 5, 0, 1, 2}},
+		{[]int{2, 3}, 1, []float64{0, 1, 2, 3, 4, 5},
+			[]float64{2, 1, 0, 5, 4, 3}},
+		{[]int{2, 3}, -1, []float64{0, 1, 2, 3, 4, 5},
+			[]float64{2, 1, 0, 5, 4, 3}},
+	}
+
+	for _, test := range tests {
+		// The upstream gradient of the flipped tensor is w, so that the
+		// gradient with respect to x is w flipped
+		upstream := randF64(len(test.in), -1, 1)
+
+		g := G.NewGraph()
+		x := G.NewTensor(g, tensor.Float64, len(test.shape), G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, test.shape,
+				tensor.WithBacking(append([]float64{}, test.in...)))))
+		w := G.NewTensor(g, tensor.Float64, len(test.shape), G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, test.shape,
+				tensor.WithBacking(append([]float64{}, upstream...)))))
+
+		flipped, err := Flip(x, test.axis)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := Flip(flipped, test.axis)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var flippedVal, backVal G.Value
+		G.Read(flipped, &flippedVal)
+		G.Read(back, &backVal)
+
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(flipped, w))))
+		grad, err := G.Grad(loss, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		flippedData := flippedVal.Data().([]float64)
+		backData := backVal.Data().([]float64)
+		gradData := gradVal.Data().([]float64)
+		for i := range test.in {
+			if flippedData[i] != test.target[i] {
+				t.Errorf("incorrect flip of shape %v along axis %v at index "+
+					"%d \nexpected: %v \nreceived: %v", test.shape,
+					test.axis, i, test.target[i], flippedData[i])
+			}
+			if backData[i] != test.in[i] {
+				t.Errorf("flipping twice is not the identity at index %d "+
+					"\nexpected: %v \nreceived: %v", i, test.in[i],
+					backData[i])
+			}
+
+			// Since the input holds its own indices, element i of x is
+			// flipped to the index j where target[j] == i
+			j := indexOf(test.target, float64(i))
+			if gradData[i] != upstream[j] {
+				t.Errorf("incorrect gradient at index %d \nexpected: %v "+
+					"\nreceived: %v", i, upstream[j], gradData[i])
+			}
+		}
+	}
+}
+
+// indexOf returns the index of v in values, or -1 if v is not in
+// values
+func indexOf(values []float64, v float64) int {
+	for i := range values {
+		if values[i] == v {
+			return i
+		}
+	}
+	return -1
+}
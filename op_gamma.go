@@ -0,0 +1,54 @@
+package gop
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mathext"
+)
+
+// newLgammaOp returns a new pointwise operation which computes the
+// natural logarithm of the absolute value of the gamma function. The
+// derivative of the lgamma function is the digamma function.
+func newLgammaOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Lgamma",
+		f64:  lgamma64,
+		f32:  func(x float32) float32 { return float32(lgamma64(float64(x))) },
+		df64: mathext.Digamma,
+		df32: func(x float32) float32 {
+			return float32(mathext.Digamma(float64(x)))
+		},
+	}
+}
+
+// newDigammaOp returns a new pointwise operation which computes the
+// digamma function, the derivative of the lgamma function. The
+// derivative of the digamma function is the trigamma function.
+func newDigammaOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Digamma",
+		f64:  mathext.Digamma,
+		f32: func(x float32) float32 {
+			return float32(mathext.Digamma(float64(x)))
+		},
+		df64: trigamma,
+		df32: func(x float32) float32 { return float32(trigamma(float64(x))) },
+	}
+}
+
+// lgamma64 returns the natural logarithm of the absolute value of the
+// gamma function at x
+func lgamma64(x float64) float64 {
+	lgamma, _ := math.Lgamma(x)
+	return lgamma
+}
+
+// trigamma returns the trigamma function at x, which is the Hurwitz
+// zeta function ζ(2, x). The trigamma function has poles at the
+// non-positive integers, at which +Inf is returned.
+func trigamma(x float64) float64 {
+	if x <= 0 && x == math.Floor(x) {
+		return math.Inf(1)
+	}
+	return mathext.Zeta(2, x)
+}
@@ -0,0 +1,29 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mathext"
+)
+
+func TestLgamma(t *testing.T) {
+	f := func(x float64) float64 {
+		lgamma, _ := math.Lgamma(x)
+		return lgamma
+	}
+	df := func(x float64) float64 { return finiteDifference(f, x) }
+	input := func() float64 { return 0.1 + rand.Float64()*10.0 }
+
+	testPointwise(t, "Lgamma", Lgamma, f, df, input)
+}
+
+func TestDigamma(t *testing.T) {
+	df := func(x float64) float64 {
+		return finiteDifference(mathext.Digamma, x)
+	}
+	input := func() float64 { return 0.1 + rand.Float64()*10.0 }
+
+	testPointwise(t, "Digamma", Digamma, mathext.Digamma, df, input)
+}
@@ -0,0 +1,169 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+	"math"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// histogramOp is the histogram operation, which counts the number of
+// elements falling in each of a number of equal-width bins along the
+// last axis
+type histogramOp struct {
+	bins int
+	low  float64
+	high float64
+	dims int // The number of dimensions in the input tensor
+}
+
+// newHistogramOp returns a new histogramOp
+func newHistogramOp(bins int, low, high float64, dims int) *histogramOp {
+	return &histogramOp{
+		bins: bins,
+		low:  low,
+		high: high,
+		dims: dims,
+	}
+}
+
+// Arity implements the gorgonia.Op interface
+func (h *histogramOp) Arity() int { return 1 }
+
+// Type implements the gorgonia.Op interface
+func (h *histogramOp) Type() hm.Type {
+	in := G.TensorType{
+		Dims: h.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+	out := G.TensorType{
+		Dims: h.dims,
+		Of:   tensor.Int,
+	}
+	return hm.NewFnType(in, out)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (h *histogramOp) InferShape(inputs ...G.DimSizer) (tensor.Shape,
+	error) {
+	err := CheckArity(h, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	if inputs[0] == nil {
+		return nil, fmt.Errorf("inferShape: nil input")
+	}
+
+	shapes, err := G.DimSizersToShapes(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	return h.outShape(shapes[0]), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (h *histogramOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (h *histogramOp) CallsExtern() bool { return false }
+
+// OverwriteInput implements the gorgonia.Op interface
+func (h *histogramOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (h *histogramOp) String() string {
+	return fmt.Sprintf("Histogram{bins=%v, low=%v, high=%v}()", h.bins,
+		h.low, h.high)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (h *histogramOp) WriteHash(w hash.Hash) { fmt.Fprint(w, h.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (h *histogramOp) Hashcode() uint32 { return SimpleHash(h) }
+
+// Do implements the gorgonia.Op interface
+func (h *histogramOp) Do(values ...G.Value) (G.Value, error) {
+	err := h.checkInputs(values...)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := values[0].(tensor.Tensor)
+	if v, ok := input.(tensor.View); ok && v.IsMaterializable() {
+		input = v.Materialize()
+	}
+
+	var x []float64
+	switch data := input.Data().(type) {
+	case []float64:
+		x = data
+	case []float32:
+		x = make([]float64, len(data))
+		for i := range data {
+			x[i] = float64(data[i])
+		}
+	default:
+		return nil, DtypeErrorf("do: data type %v unsupported",
+			input.Dtype())
+	}
+
+	shape := input.Shape()
+	length := shape[len(shape)-1]
+	outer := tensor.ProdInts(shape[:len(shape)-1])
+	width := (h.high - h.low) / float64(h.bins)
+
+	counts := make([]int, outer*h.bins)
+	for o := 0; o < outer; o++ {
+		for _, v := range x[o*length : (o+1)*length] {
+			if math.IsNaN(v) {
+				continue
+			}
+
+			// Values outside [low, high) are clamped into the edge bins
+			bin := 0
+			if v >= h.high {
+				bin = h.bins - 1
+			} else if v > h.low {
+				bin = int((v - h.low) / width)
+				if bin >= h.bins {
+					bin = h.bins - 1
+				}
+			}
+			counts[o*h.bins+bin]++
+		}
+	}
+
+	return tensor.NewDense(tensor.Int, h.outShape(shape),
+		tensor.WithBacking(counts)), nil
+}
+
+// outShape returns the shape of the output of the receiver for an
+// input of shape shape
+func (h *histogramOp) outShape(shape tensor.Shape) tensor.Shape {
+	out := shape.Clone()
+	out[len(out)-1] = h.bins
+	return out
+}
+
+// checkInputs returns an error if the input to the receiver is invalid
+func (h *histogramOp) checkInputs(inputs ...G.Value) error {
+	if err := CheckArity(h, len(inputs)); err != nil {
+		return err
+	}
+
+	t, ok := inputs[0].(tensor.Tensor)
+
+	if !ok {
+		return fmt.Errorf("expected input to be a tensor, got %T", inputs[0])
+	}
+
+	if len(t.Shape()) <= 0 || t.Size() == 0 {
+		return fmt.Errorf("tensor does not have any elements")
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package gop
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestHistogram tests that Histogram counts elements per bin along
+// the last axis and clamps out of range values into the edge bins
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		in     tensor.Tensor
+		bins   int
+		low    float64
+		high   float64
+		target []int
+		shape  []int
+	}{
+		{
+			in: tensor.New(tensor.WithShape(6),
+				tensor.WithBacking([]float64{0, 0.5, 1, 1.5, 2, 4})),
+			bins: 4, low: 0, high: 4,
+			target: []int{2, 2, 1, 1},
+			shape:  []int{4},
+		},
+		{
+			// Out of range values are clamped and NaN is not counted
+			in: tensor.New(tensor.WithShape(6),
+				tensor.WithBacking([]float64{-10, -1, 0.5, 1, 10,
+					math.NaN()})),
+			bins: 2, low: 0, high: 1,
+			target: []int{2, 3},
+			shape:  []int{2},
+		},
+		{
+			in: tensor.New(tensor.WithShape(2, 3),
+				tensor.WithBacking([]float32{0.1, 0.2, 0.9, -1, 0.6, 0.7})),
+			bins: 2, low: 0, high: 1,
+			target: []int{2, 1, 1, 2},
+			shape:  []int{2, 2},
+		},
+		{
+			in: tensor.New(tensor.WithShape(2, 1, 3),
+				tensor.WithBacking([]float64{1, 2, 3, 4, 5, 6})),
+			bins: 3, low: 1, high: 6,
+			target: []int{2, 1, 0, 0, 1, 2},
+			shape:  []int{2, 1, 3},
+		},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		x := G.NewTensor(g, test.in.Dtype(), test.in.Dims(), G.WithName("x"),
+			G.WithValue(test.in))
+
+		hist, err := Histogram(x, test.bins, test.low, test.high)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !hist.Value().Shape().Eq(tensor.Shape(test.shape)) {
+			t.Errorf("expected shape %v but got %v", test.shape,
+				hist.Value().Shape())
+			continue
+		}
+		counts := hist.Value().Data().([]int)
+		for i := range test.target {
+			if counts[i] != test.target[i] {
+				t.Errorf("expected: %v received: %v", test.target, counts)
+				break
+			}
+		}
+	}
+}
+
+// TestHistogramError tests that Histogram returns an error for invalid
+// inputs rather than panicking
+func TestHistogramError(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewVector(g, tensor.Float64, G.WithShape(3), G.WithName("x"))
+	scalar := G.NewScalar(g, tensor.Float64, G.WithName("scalar"))
+	ints := G.NewVector(g, tensor.Int, G.WithShape(3), G.WithName("ints"))
+
+	if _, err := Histogram(x, 0, 0, 1); err == nil {
+		t.Error("expected error for 0 bins")
+	}
+	if _, err := Histogram(x, 2, 1, 1); err == nil {
+		t.Error("expected error for low == high")
+	}
+	if _, err := Histogram(x, 2, 0, math.Inf(1)); err == nil {
+		t.Error("expected error for infinite high")
+	}
+	if _, err := Histogram(scalar, 2, 0, 1); !errors.Is(err, &ShapeError{}) {
+		t.Errorf("expected ShapeError for scalar input but got %v", err)
+	}
+	if _, err := Histogram(ints, 2, 0, 1); !errors.Is(err, &DtypeError{}) {
+		t.Errorf("expected DtypeError for int input but got %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+	"reflect"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// indexSelectOp implements the index select operation, which selects
+// whole rows of a tensor along an axis using a 1-D tensor of integer
+// indices. The selected rows are stacked along the axis in the order
+// of the indices, and an index may be selected more than once.
+type indexSelectOp struct {
+	axis int // Axis along which to select
+	dims int // Number of dimensions in the input node
+}
+
+// newIndexSelectOp returns a new indexSelectOp
+func newIndexSelectOp(axis, dims int) (*indexSelectOp, error) {
+	axis, err := normalizeAxis(axis, dims)
+	if err != nil {
+		return nil, fmt.Errorf("newIndexSelectOp: %w", err)
+	}
+
+	return &indexSelectOp{
+		axis: axis,
+		dims: dims,
+	}, nil
+}
+
+// DiffWRT implements the gorgonia.SDOp interface. The indexSelectOp is
+// differentiable with respect to its input, but not the indices.
+func (i *indexSelectOp) DiffWRT(inputs int) []bool {
+	return []bool{true, false}
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (i *indexSelectOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	err := CheckArity(i, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	diffOp := &indexSelectDiffOp{i}
+	nodes := make(G.Nodes, 2)
+
+	nodes[0], err = G.ApplyOp(diffOp, inputs[0], inputs[1], grad)
+
+	return nodes, err
+}
+
+// Arity implements the gorgonia.Op interface
+func (i *indexSelectOp) Arity() int { return 2 }
+
+// Type implements the gorgonia.Op interface
+func (i *indexSelectOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: i.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+	indices := G.TensorType{
+		Dims: 1,
+		Of:   tensor.Int,
+	}
+
+	return hm.NewFnType(tt, indices, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (i *indexSelectOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (i *indexSelectOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (i *indexSelectOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (i *indexSelectOp) String() string {
+	return fmt.Sprintf("IndexSelect{axis=%v}()", i.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (i *indexSelectOp) WriteHash(h hash.Hash) { fmt.Fprint(h, i.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (i *indexSelectOp) Hashcode() uint32 { return SimpleHash(i) }
+
+// InferShape implements the gorgonia.Op interface
+func (i *indexSelectOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(i, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shape := shapes[0].Clone()
+	shape[i.axis] = shapes[1].TotalSize()
+
+	return shape, nil
+}
+
+// Do implements the gorgonia.Op interface
+func (i *indexSelectOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := checkIndexSelectInputs(i, i.axis, inputs...); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := inputs[0].(tensor.Tensor)
+	if v, ok := input.(tensor.View); ok && v.IsMaterializable() {
+		input = v.Materialize()
+	}
+	indices := inputs[1].Data().([]int)
+
+	shape := input.Shape()
+	length := shape[i.axis]
+	inner := tensor.ProdInts(shape[i.axis+1:])
+	outer := tensor.ProdInts(shape[:i.axis])
+
+	outShape := shape.Clone()
+	outShape[i.axis] = len(indices)
+
+	in := reflect.ValueOf(input.Data())
+	out := reflect.MakeSlice(in.Type(), outShape.TotalSize(),
+		outShape.TotalSize())
+	for o := 0; o < outer; o++ {
+		for j, index := range indices {
+			src := (o*length + index) * inner
+			dst := (o*len(indices) + j) * inner
+			reflect.Copy(out.Slice(dst, dst+inner), in.Slice(src, src+inner))
+		}
+	}
+
+	return tensor.New(tensor.WithShape(outShape...),
+		tensor.WithBacking(out.Interface())), nil
+}
+
+// checkIndexSelectInputs returns an error if inputs is not a valid
+// input to op, the index select operation or its derivative, which
+// selects along axis. The first two inputs must be the tensor to
+// select from and the indices.
+func checkIndexSelectInputs(op ariter, axis int, inputs ...G.Value) error {
+	if err := CheckArity(op, len(inputs)); err != nil {
+		return err
+	}
+
+	t, ok := inputs[0].(tensor.Tensor)
+	if !ok {
+		return fmt.Errorf("expected tensor, received %T", inputs[0])
+	} else if t == nil {
+		return fmt.Errorf("cannot select from nil tensor")
+	} else if t.Size() == 0 {
+		return fmt.Errorf("cannot select from empty tensor")
+	} else if axis >= len(t.Shape()) {
+		return fmt.Errorf("axis [%v] out of range for tensor with shape %v",
+			axis, t.Shape())
+	}
+
+	indices, ok := inputs[1].(tensor.Tensor)
+	if !ok || indices == nil {
+		return fmt.Errorf("expected indices to be a tensor but got %T",
+			inputs[1])
+	} else if indices.Dims() != 1 {
+		return fmt.Errorf("expected indices to be 1-D but got shape %v",
+			indices.Shape())
+	}
+
+	data, ok := indices.Data().([]int)
+	if !ok {
+		return fmt.Errorf("expected indices to have type %v but got %v",
+			tensor.Int, indices.Dtype())
+	}
+	for _, index := range data {
+		if index < 0 || index >= t.Shape()[axis] {
+			return fmt.Errorf("index %v out of range for axis %v with "+
+				"length %v", index, axis, t.Shape()[axis])
+		}
+	}
+
+	return nil
+}
+
+// indexSelectDiffOp is the derivative of the index select operation
+// with respect to its input. The inputs to the op are the inputs of
+// the indexSelectOp followed by the gradient of its output. The
+// gradient is scatter-added back to the selected rows, so that rows
+// selected more than once accumulate their gradients.
+type indexSelectDiffOp struct {
+	op *indexSelectOp
+}
+
+// Arity implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) Arity() int { return 3 }
+
+// Type implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: i.op.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+	indices := G.TensorType{
+		Dims: 1,
+		Of:   tensor.Int,
+	}
+
+	return hm.NewFnType(tt, indices, tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (i *indexSelectDiffOp) String() string {
+	return fmt.Sprintf("IndexSelectDiff{axis=%v}()", i.op.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, i.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) Hashcode() uint32 { return SimpleHash(i) }
+
+// InferShape implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) InferShape(in ...G.DimSizer) (tensor.Shape,
+	error) {
+	err := CheckArity(i, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[0].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (i *indexSelectDiffOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := checkIndexSelectInputs(i, i.op.axis, inputs...); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := inputs[0].(tensor.Tensor)
+	indices := inputs[1].Data().([]int)
+	grad := inputs[2].(tensor.Tensor)
+	if v, ok := grad.(tensor.View); ok && v.IsMaterializable() {
+		grad = v.Materialize()
+	}
+
+	shape := input.Shape()
+	length := shape[i.op.axis]
+	inner := tensor.ProdInts(shape[i.op.axis+1:])
+	outer := tensor.ProdInts(shape[:i.op.axis])
+
+	out := tensor.New(tensor.Of(grad.Dtype()), tensor.WithShape(shape...))
+	for o := 0; o < outer; o++ {
+		for j, index := range indices {
+			src := (o*len(indices) + j) * inner
+			dst := (o*length + index) * inner
+
+			switch gradData := grad.Data().(type) {
+			case []float64:
+				outData := out.Data().([]float64)
+				for e := 0; e < inner; e++ {
+					outData[dst+e] += gradData[src+e]
+				}
+			case []float32:
+				outData := out.Data().([]float32)
+				for e := 0; e < inner; e++ {
+					outData[dst+e] += gradData[src+e]
+				}
+			default:
+				return nil, fmt.Errorf("do: cannot compute gradient of "+
+					"type %v", grad.Dtype())
+			}
+		}
+	}
+
+	return out, nil
+}
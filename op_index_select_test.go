@@ -0,0 +1,111 @@
+package gop
+
+import (
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestIndexSelect tests the forward and backward pass of IndexSelect
+// when selecting rows and columns of a matrix, including repeated
+// indices whose gradients should accumulate
+func TestIndexSelect(t *testing.T) {
+	// The input is a (3, 4) matrix holding its own indices
+	in := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+
+	tests := []struct {
+		axis       int
+		indices    []int
+		shape      []int
+		target     []float64
+		gradTarget []float64
+	}{
+		{
+			axis:       0,
+			indices:    []int{2, 0, 2},
+			shape:      []int{3, 4},
+			target:     []float64{8, 9, 10, 11, 0, 1, 2, 3, 8, 9, 10, 11},
+			gradTarget: []float64{1, 1, 1, 1, 0, 0, 0, 0, 2, 2, 2, 2},
+		},
+		{
+			axis:       -1,
+			indices:    []int{1, 1},
+			shape:      []int{3, 2},
+			target:     []float64{1, 1, 5, 5, 9, 9},
+			gradTarget: []float64{0, 2, 0, 0, 0, 2, 0, 0, 0, 2, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		x := G.NewMatrix(g, tensor.Float64, G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{3, 4},
+				tensor.WithBacking(append([]float64{}, in...)))))
+		indices := G.NewVector(g, tensor.Int, G.WithName("indices"),
+			G.WithValue(tensor.NewDense(tensor.Int, []int{len(test.indices)},
+				tensor.WithBacking(test.indices))))
+
+		selected, err := IndexSelect(x, test.axis, indices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var selectedVal G.Value
+		G.Read(selected, &selectedVal)
+
+		grad, err := G.Grad(G.Must(G.Sum(selected)), x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !selectedVal.Shape().Eq(tensor.Shape(test.shape)) {
+			t.Errorf("expected output shape %v but got %v", test.shape,
+				selectedVal.Shape())
+			continue
+		}
+
+		for i, v := range selectedVal.Data().([]float64) {
+			if v != test.target[i] {
+				t.Errorf("incorrect value at index %d selecting %v along "+
+					"axis %v \nexpected: %v \nreceived: %v", i,
+					test.indices, test.axis, test.target[i], v)
+			}
+		}
+		for i, v := range gradVal.Data().([]float64) {
+			if v != test.gradTarget[i] {
+				t.Errorf("incorrect gradient at index %d selecting %v "+
+					"along axis %v \nexpected: %v \nreceived: %v", i,
+					test.indices, test.axis, test.gradTarget[i], v)
+			}
+		}
+	}
+}
+
+// TestIndexSelectOutOfRange tests that IndexSelect returns an error
+// when an index is out of range of the axis
+func TestIndexSelectOutOfRange(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewMatrix(g, tensor.Float64, G.WithName("x"), G.WithShape(2, 3),
+		G.WithInit(G.Zeroes()))
+	indices := G.NewVector(g, tensor.Int, G.WithName("indices"),
+		G.WithValue(tensor.NewDense(tensor.Int, []int{2},
+			tensor.WithBacking([]int{0, 2}))))
+
+	if _, err := IndexSelect(x, 0, indices); err != nil {
+		t.Fatal(err)
+	}
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err == nil {
+		t.Error("expected an error selecting an out of range index")
+	}
+}
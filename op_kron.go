@@ -0,0 +1,287 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// kronOp implements the Kronecker product of two matrices. If the
+// first matrix has shape (p, q) and the second has shape (r, s), then
+// the output has shape (p*r, q*s) and consists of p x q blocks, where
+// block (i, j) is the second matrix scaled by element (i, j) of the
+// first matrix.
+type kronOp struct{}
+
+// newKronOp returns a new kronOp
+func newKronOp() *kronOp {
+	return &kronOp{}
+}
+
+// DiffWRT implements the gorgonia.SDOp interface
+func (k *kronOp) DiffWRT(inputs int) []bool {
+	return []bool{true, true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (k *kronOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	err := CheckArity(k, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	nodes := make(G.Nodes, 2)
+	for i := range nodes {
+		nodes[i], err = G.ApplyOp(&kronDiffOp{i}, inputs[0], inputs[1],
+			grad)
+		if err != nil {
+			return nil, fmt.Errorf("symDiff: %v", err)
+		}
+	}
+
+	return nodes, nil
+}
+
+// Arity implements the gorgonia.Op interface
+func (k *kronOp) Arity() int { return 2 }
+
+// Type implements the gorgonia.Op interface
+func (k *kronOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: 2,
+		Of:   hm.TypeVariable('a'),
+	}
+
+	return hm.NewFnType(tt, tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (k *kronOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (k *kronOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (k *kronOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (k *kronOp) String() string { return "Kron()" }
+
+// WriteHash implements the gorgonia.Op interface
+func (k *kronOp) WriteHash(h hash.Hash) { fmt.Fprint(h, k.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (k *kronOp) Hashcode() uint32 { return SimpleHash(k) }
+
+// InferShape implements the gorgonia.Op interface
+func (k *kronOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(k, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return tensor.Shape{shapes[0][0] * shapes[1][0],
+		shapes[0][1] * shapes[1][1]}, nil
+}
+
+// Do implements the gorgonia.Op interface
+func (k *kronOp) Do(inputs ...G.Value) (G.Value, error) {
+	a, b, err := checkKronInputs(k, inputs...)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	aShape, bShape := a.Shape(), b.Shape()
+	out := tensor.New(tensor.Of(a.Dtype()), tensor.WithShape(
+		aShape[0]*bShape[0], aShape[1]*bShape[1]))
+
+	switch outData := out.Data().(type) {
+	case []float64:
+		aData, bData := a.Data().([]float64), b.Data().([]float64)
+		kronIndices(aShape, bShape, func(ai, bi, oi int) {
+			outData[oi] = aData[ai] * bData[bi]
+		})
+	case []float32:
+		aData, bData := a.Data().([]float32), b.Data().([]float32)
+		kronIndices(aShape, bShape, func(ai, bi, oi int) {
+			outData[oi] = aData[ai] * bData[bi]
+		})
+	default:
+		return nil, fmt.Errorf("do: cannot compute Kronecker product of "+
+			"type %v", a.Dtype())
+	}
+
+	return out, nil
+}
+
+// kronIndices calls f for each pair of elements of two matrices of
+// shapes aShape and bShape which are multiplied in their Kronecker
+// product, with the indices of the elements in each matrix and the
+// index of their product in the output
+func kronIndices(aShape, bShape tensor.Shape, f func(ai, bi, oi int)) {
+	p, q := aShape[0], aShape[1]
+	r, s := bShape[0], bShape[1]
+
+	for i := 0; i < p; i++ {
+		for j := 0; j < q; j++ {
+			for k := 0; k < r; k++ {
+				for l := 0; l < s; l++ {
+					f(i*q+j, k*s+l, (i*r+k)*q*s+j*s+l)
+				}
+			}
+		}
+	}
+}
+
+// checkKronInputs returns an error if inputs is not a valid input to
+// op, the Kronecker product or its derivative. The first two inputs
+// must be the matrices to multiply, and these are returned with views
+// materialized.
+func checkKronInputs(op ariter, inputs ...G.Value) (tensor.Tensor,
+	tensor.Tensor, error) {
+	if err := CheckArity(op, len(inputs)); err != nil {
+		return nil, nil, err
+	}
+
+	matrices := make([]tensor.Tensor, 2)
+	for i := range matrices {
+		t, ok := inputs[i].(tensor.Tensor)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected tensor, received %T",
+				inputs[i])
+		} else if t == nil {
+			return nil, nil, fmt.Errorf("cannot multiply nil tensor")
+		} else if t.Dims() != 2 {
+			return nil, nil, fmt.Errorf("expected matrix but got shape %v",
+				t.Shape())
+		}
+
+		if v, ok := t.(tensor.View); ok && v.IsMaterializable() {
+			t = v.Materialize()
+		}
+		matrices[i] = t
+	}
+
+	if matrices[0].Dtype() != matrices[1].Dtype() {
+		return nil, nil, fmt.Errorf("expected inputs to have the same "+
+			"type but got %v and %v", matrices[0].Dtype(),
+			matrices[1].Dtype())
+	}
+
+	return matrices[0], matrices[1], nil
+}
+
+// kronDiffOp is the derivative of the Kronecker product with respect
+// to one of its inputs. The inputs to the op are the inputs of the
+// kronOp followed by the gradient of its output. The gradient of each
+// element of one matrix is the sum over its block of the gradient
+// weighted by the other matrix.
+type kronDiffOp struct {
+	wrt int // Index of the input to differentiate with respect to
+}
+
+// Arity implements the gorgonia.Op interface
+func (k *kronDiffOp) Arity() int { return 3 }
+
+// Type implements the gorgonia.Op interface
+func (k *kronDiffOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: 2,
+		Of:   hm.TypeVariable('a'),
+	}
+
+	return hm.NewFnType(tt, tt, tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (k *kronDiffOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (k *kronDiffOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (k *kronDiffOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (k *kronDiffOp) String() string {
+	return fmt.Sprintf("KronDiff{wrt=%v}()", k.wrt)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (k *kronDiffOp) WriteHash(h hash.Hash) { fmt.Fprint(h, k.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (k *kronDiffOp) Hashcode() uint32 { return SimpleHash(k) }
+
+// InferShape implements the gorgonia.Op interface
+func (k *kronDiffOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(k, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[k.wrt].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (k *kronDiffOp) Do(inputs ...G.Value) (G.Value, error) {
+	a, b, err := checkKronInputs(k, inputs...)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	grad, ok := inputs[2].(tensor.Tensor)
+	if !ok {
+		return nil, fmt.Errorf("do: expected gradient to be a tensor but "+
+			"got %T", inputs[2])
+	}
+	if v, ok := grad.(tensor.View); ok && v.IsMaterializable() {
+		grad = v.Materialize()
+	}
+
+	aShape, bShape := a.Shape(), b.Shape()
+	out := tensor.New(tensor.Of(a.Dtype()),
+		tensor.WithShape(inputs[k.wrt].Shape()...))
+
+	switch outData := out.Data().(type) {
+	case []float64:
+		aData, bData := a.Data().([]float64), b.Data().([]float64)
+		gradData := grad.Data().([]float64)
+		kronIndices(aShape, bShape, func(ai, bi, oi int) {
+			if k.wrt == 0 {
+				outData[ai] += gradData[oi] * bData[bi]
+			} else {
+				outData[bi] += gradData[oi] * aData[ai]
+			}
+		})
+	case []float32:
+		aData, bData := a.Data().([]float32), b.Data().([]float32)
+		gradData := grad.Data().([]float32)
+		kronIndices(aShape, bShape, func(ai, bi, oi int) {
+			if k.wrt == 0 {
+				outData[ai] += gradData[oi] * bData[bi]
+			} else {
+				outData[bi] += gradData[oi] * aData[ai]
+			}
+		})
+	default:
+		return nil, fmt.Errorf("do: cannot compute gradient of type %v",
+			a.Dtype())
+	}
+
+	return out, nil
+}
@@ -0,0 +1,104 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestKron(t *testing.T) {
+	const threshold float64 = 1e-6 // Threshold to consider floats equal
+	const h float64 = 1e-5         // Step size for finite differences
+	const tests int = 10           // Number of random tests to run
+	const maxSize int = 4          // Maximum size of each matrix dimension
+	rand.Seed(time.Now().UnixNano())
+
+	// kron computes the Kronecker product of a with shape (p, q) and b
+	// with shape (r, s) using gonum
+	kron := func(a, b []float64, p, q, r, s int) []float64 {
+		var out mat.Dense
+		out.Kronecker(mat.NewDense(p, q, a), mat.NewDense(r, s, b))
+		return out.RawMatrix().Data
+	}
+
+	for i := 0; i < tests; i++ {
+		p, q := 1+rand.Intn(maxSize), 1+rand.Intn(maxSize)
+		r, s := 1+rand.Intn(maxSize), 1+rand.Intn(maxSize)
+		aBacking := randF64(p*q, -5, 5)
+		bBacking := randF64(r*s, -5, 5)
+		weights := randF64(p*r*q*s, -2, 2)
+		target := kron(aBacking, bBacking, p, q, r, s)
+
+		g := G.NewGraph()
+		a := G.NewMatrix(g, tensor.Float64, G.WithName("a"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{p, q},
+				tensor.WithBacking(append([]float64{}, aBacking...)))))
+		b := G.NewMatrix(g, tensor.Float64, G.WithName("b"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{r, s},
+				tensor.WithBacking(append([]float64{}, bBacking...)))))
+		w := G.NewMatrix(g, tensor.Float64, G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, []int{p * r, q * s},
+				tensor.WithBacking(append([]float64{}, weights...)))))
+
+		prod, err := Kron(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var prodVal G.Value
+		G.Read(prod, &prodVal)
+
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(prod, w))))
+		grads, err := G.Grad(loss, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var aGradVal, bGradVal G.Value
+		G.Read(grads[0], &aGradVal)
+		G.Read(grads[1], &bGradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !prodVal.Shape().Eq(tensor.Shape{p * r, q * s}) {
+			t.Fatalf("incorrect shape \nexpected: %v \nreceived: %v",
+				tensor.Shape{p * r, q * s}, prodVal.Shape())
+		}
+		for j, v := range prodVal.Data().([]float64) {
+			if math.Abs(v-target[j]) > threshold {
+				t.Errorf("incorrect Kronecker product at index %d "+
+					"\nexpected: %v \nreceived: %v", j, target[j], v)
+			}
+		}
+
+		// Check gradients with respect to both inputs
+		inputs := [][]float64{aBacking, bBacking}
+		gradVals := []G.Value{aGradVal, bGradVal}
+		for k, input := range inputs {
+			for j, computed := range gradVals[k].Data().([]float64) {
+				orig := input[j]
+				input[j] = orig + h
+				upper := weightedSum(kron(aBacking, bBacking, p, q, r, s),
+					weights)
+				input[j] = orig - h
+				lower := weightedSum(kron(aBacking, bBacking, p, q, r, s),
+					weights)
+				input[j] = orig
+
+				gradTarget := (upper - lower) / (2 * h)
+				if math.Abs(computed-gradTarget) > threshold {
+					t.Errorf("incorrect gradient for input %d at index %d "+
+						"\nexpected: %v \nreceived: %v", k, j, gradTarget,
+						computed)
+				}
+			}
+		}
+	}
+}
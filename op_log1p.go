@@ -0,0 +1,29 @@
+package gop
+
+import "math"
+
+// newLog1pOp returns a new pointwise operation which computes
+// log(1 + x), accurately even when x is near 0. The derivative of the
+// log1p function is 1 / (1 + x).
+func newLog1pOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Log1p",
+		f64:  math.Log1p,
+		f32:  func(x float32) float32 { return float32(math.Log1p(float64(x))) },
+		df64: func(x float64) float64 { return 1 / (1 + x) },
+		df32: func(x float32) float32 { return 1 / (1 + x) },
+	}
+}
+
+// newExpm1Op returns a new pointwise operation which computes
+// exp(x) - 1, accurately even when x is near 0. The derivative of the
+// expm1 function is exp(x).
+func newExpm1Op() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Expm1",
+		f64:  math.Expm1,
+		f32:  func(x float32) float32 { return float32(math.Expm1(float64(x))) },
+		df64: math.Exp,
+		df32: func(x float32) float32 { return float32(math.Exp(float64(x))) },
+	}
+}
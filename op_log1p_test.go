@@ -0,0 +1,21 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLog1p(t *testing.T) {
+	df := func(x float64) float64 { return finiteDifference(math.Log1p, x) }
+	input := func() float64 { return -0.9 + rand.Float64()*10.0 }
+
+	testPointwise(t, "Log1p", Log1p, math.Log1p, df, input)
+}
+
+func TestExpm1(t *testing.T) {
+	df := func(x float64) float64 { return finiteDifference(math.Expm1, x) }
+	input := func() float64 { return (rand.Float64() - 0.5) * 6.0 }
+
+	testPointwise(t, "Expm1", Expm1, math.Expm1, df, input)
+}
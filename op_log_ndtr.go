@@ -0,0 +1,48 @@
+package gop
+
+import "math"
+
+// newLogNdtrOp returns a new pointwise operation which computes the
+// log of the standard normal cumulative distribution function, log(Φ(x)).
+// The derivative of the log ndtr function is φ(x) / Φ(x), where φ is
+// the standard normal density.
+func newLogNdtrOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "LogNdtr",
+		f64:  logNdtr,
+		f32:  func(x float32) float32 { return float32(logNdtr(float64(x))) },
+		df64: logNdtrDiff,
+		df32: func(x float32) float32 {
+			return float32(logNdtrDiff(float64(x)))
+		},
+	}
+}
+
+// logNdtr returns log(Φ(x)). In the left tail, Φ(x) underflows, and so
+// the scaled complementary error function is used:
+//
+//		log(Φ(x)) = log(erfcx(-x/√2) / 2) - x²/2
+//
+// In the right tail, Φ(x) rounds to 1, and so log1p is used:
+//
+//		log(Φ(x)) = log1p(-erfc(x/√2) / 2)
+func logNdtr(x float64) float64 {
+	if x < 0 {
+		return math.Log(0.5*erfcx(-x/math.Sqrt2)) - 0.5*x*x
+	}
+	return math.Log1p(-0.5 * math.Erfc(x/math.Sqrt2))
+}
+
+// logNdtrDiff returns the derivative of log(Φ(x)), φ(x) / Φ(x). In the
+// left tail, φ(x) and Φ(x) both underflow, and so the exponential terms
+// are cancelled analytically:
+//
+//		φ(x) / Φ(x) = √(2/π) / erfcx(-x/√2)
+func logNdtrDiff(x float64) float64 {
+	if x < 0 {
+		return math.Sqrt(2/math.Pi) / erfcx(-x/math.Sqrt2)
+	}
+
+	pdf := math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi)
+	return pdf / (1 - 0.5*math.Erfc(x/math.Sqrt2))
+}
@@ -0,0 +1,72 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestLogNdtr(t *testing.T) {
+	f := func(x float64) float64 {
+		return math.Log(0.5 * math.Erfc(-x/math.Sqrt2))
+	}
+	df := func(x float64) float64 { return finiteDifference(f, x) }
+	input := func() float64 { return (rand.Float64() - 0.5) * 16.0 }
+
+	testPointwise(t, "LogNdtr", LogNdtr, f, df, input)
+}
+
+// TestLogNdtrTail tests the LogNdtr function far in the left tail,
+// where Φ(x) underflows, against the asymptotic expansion
+//
+//		log(Φ(x)) ≈ -x²/2 - log(-x√(2π)) + log(1 - 1/x² + 3/x⁴ - 15/x⁶)
+func TestLogNdtrTail(t *testing.T) {
+	const tolerance float64 = 1e-8 // Relative tolerance
+	const size int = 20            // Number of elements to test
+
+	backing := make([]float64, size)
+	for i := range backing {
+		backing[i] = -40.0 - rand.Float64()*100.0
+	}
+
+	if naive := math.Log(0.5 * math.Erfc(-backing[0]/math.Sqrt2)); !math.IsInf(
+		naive, -1) {
+		t.Fatalf("expected log(Φ(x)) to underflow at %v but got %v",
+			backing[0], naive)
+	}
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(tensor.Float64, []int{size},
+		tensor.WithBacking(append([]float64{}, backing...)))
+	in := G.NewVector(g, tensor.Float64, G.WithValue(inTensor),
+		G.WithName("x"))
+
+	out, err := LogNdtr(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal G.Value
+	G.Read(out, &outVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, computed := range outVal.Data().([]float64) {
+		x := backing[i]
+		x2 := x * x
+		series := 1 - 1/x2 + 3/(x2*x2) - 15/(x2*x2*x2)
+		target := -0.5*x2 - math.Log(-x*math.Sqrt(2*math.Pi)) +
+			math.Log(series)
+
+		if math.Abs(computed-target)/math.Abs(target) > tolerance {
+			t.Errorf("incorrect value at %v\nexpected: %v\nreceived: %v", x,
+				target, computed)
+		}
+	}
+}
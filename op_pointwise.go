@@ -0,0 +1,316 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// pointwiseOp is a generic element-wise unary operation y = f(x)
+// whose derivative dy/dx can be computed from x alone. The pointwiseOp
+// is differentiable if and only if its derivative functions are
+// non-nil.
+type pointwiseOp struct {
+	name string
+
+	f64 func(float64) float64
+	f32 func(float32) float32
+
+	// Derivatives of f64 and f32 with respect to their inputs
+	df64 func(float64) float64
+	df32 func(float32) float32
+
+	// domain, if non-nil, returns an error if an input element lies
+	// outside the domain of f64 and f32
+	domain func(float64) error
+}
+
+// Arity implements the gorgonia.Op interface
+func (p *pointwiseOp) Arity() int { return 1 }
+
+// Type implements the gorgonia.Op interface
+func (p *pointwiseOp) Type() hm.Type {
+	// All pointwise unary operations have this type:
+	// op :: (Arithable a) => a -> a
+	a := hm.TypeVariable('a')
+	return hm.NewFnType(a, a)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (p *pointwiseOp) InferShape(inputs ...G.DimSizer) (tensor.Shape,
+	error) {
+	return pointwiseInferShape(p, inputs...)
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (p *pointwiseOp) ReturnsPtr() bool { return true }
+
+// CallsExtern implements the gorgonia.Op interface
+func (p *pointwiseOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (p *pointwiseOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (p *pointwiseOp) String() string { return p.name }
+
+// WriteHash implements the gorgonia.Op interface
+func (p *pointwiseOp) WriteHash(h hash.Hash) { fmt.Fprint(h, p.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (p *pointwiseOp) Hashcode() uint32 { return SimpleHash(p) }
+
+// Do implements the gorgonia.Op interface
+func (p *pointwiseOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := CheckArity(p, len(inputs)); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	if p.domain != nil {
+		if err := checkDomain(inputs[0], p.domain); err != nil {
+			return nil, fmt.Errorf("do: %v: %v", p, err)
+		}
+	}
+
+	out, err := applyPointwise(inputs[0], p.f64, p.f32)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	return out, nil
+}
+
+// DiffWRT implements the gorgonia.SDOp interface
+func (p *pointwiseOp) DiffWRT(inputs int) []bool {
+	if inputs != 1 {
+		panic(fmt.Sprintf("%v operator only supports one input, got %d "+
+			"instead", p, inputs))
+	}
+	return []bool{p.df64 != nil && p.df32 != nil}
+}
+
+// SymDiff implements the gorgonia.SDOp interface
+func (p *pointwiseOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	if err := CheckArity(p, len(inputs)); err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	if p.df64 == nil || p.df32 == nil {
+		return nil, fmt.Errorf("symDiff: %v is not differentiable", p)
+	}
+
+	diffOp := &pointwiseDiffOp{p}
+	nodes := make(G.Nodes, 1)
+
+	var err error
+	nodes[0], err = G.ApplyOp(diffOp, inputs[0], grad)
+
+	return nodes, err
+}
+
+// pointwiseDiffOp is the derivative of a pointwiseOp. Given the input
+// x to the pointwiseOp and the gradient of some cost with respect to
+// the pointwiseOp's output, it computes grad * f'(x).
+type pointwiseDiffOp struct {
+	op *pointwiseOp
+}
+
+// Arity implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) Arity() int { return 2 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) ReturnsPtr() bool { return true }
+
+// CallsExtern implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) OverwritesInput() int { return -1 }
+
+// Type implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) Type() hm.Type {
+	a := hm.TypeVariable('a')
+	return hm.NewFnType(a, a, a)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) InferShape(inputs ...G.DimSizer) (tensor.Shape,
+	error) {
+	return pointwiseInferShape(p, inputs...)
+}
+
+// String implements the fmt.Stringer interface
+func (p *pointwiseDiffOp) String() string {
+	return fmt.Sprintf("%vDiff()", p.op.name)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, p.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) Hashcode() uint32 { return SimpleHash(p) }
+
+// Do implements the gorgonia.Op interface
+func (p *pointwiseDiffOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := CheckArity(p, len(inputs)); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	diff, err := applyPointwise(inputs[0], p.op.df64, p.op.df32)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+
+	// Chain rule
+	switch d := diff.(type) {
+	case *G.F64:
+		grad, ok := inputs[1].(*G.F64)
+		if !ok {
+			return nil, fmt.Errorf("do: expected gradient to be %T but "+
+				"got %T", d, inputs[1])
+		}
+		return G.NewF64(float64(*d) * float64(*grad)), nil
+
+	case *G.F32:
+		grad, ok := inputs[1].(*G.F32)
+		if !ok {
+			return nil, fmt.Errorf("do: expected gradient to be %T but "+
+				"got %T", d, inputs[1])
+		}
+		return G.NewF32(float32(*d) * float32(*grad)), nil
+	}
+
+	grad, ok := inputs[1].(tensor.Tensor)
+	if !ok {
+		return nil, fmt.Errorf("do: expected gradient to be a tensor but "+
+			"got %T", inputs[1])
+	}
+
+	out, err := tensor.Mul(diff.(tensor.Tensor), grad, tensor.UseUnsafe())
+	if err != nil {
+		return nil, fmt.Errorf("do: could not apply chain rule: %v", err)
+	}
+
+	return out, nil
+}
+
+// pointwiseInferShape returns the shape of the output of a pointwise
+// operation op, which is the shape of its first input
+func pointwiseInferShape(op G.Op, inputs ...G.DimSizer) (tensor.Shape,
+	error) {
+	if err := CheckArity(op, len(inputs)); err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	if inputs[0] == nil {
+		return nil, fmt.Errorf("inferShape: nil input")
+	}
+
+	shapes, err := G.DimSizersToShapes(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	return shapes[0], nil
+}
+
+// applyPointwise applies f64 or f32, depending on the data type of
+// value, to each element of value and returns the result as a new
+// value
+func applyPointwise(value G.Value, f64 func(float64) float64,
+	f32 func(float32) float32) (G.Value, error) {
+	switch v := value.(type) {
+	case *G.F64:
+		return G.NewF64(f64(float64(*v))), nil
+
+	case *G.F32:
+		return G.NewF32(f32(float32(*v))), nil
+
+	case tensor.Tensor:
+		if v.Size() == 0 {
+			return nil, fmt.Errorf("tensor does not have any elements")
+		}
+
+		// Materialize views so that the backing slice holds exactly
+		// the elements of the tensor in order
+		if view, ok := v.(tensor.View); ok && view.IsMaterializable() {
+			v = view.Materialize()
+		}
+
+		switch data := v.Data().(type) {
+		case []float64:
+			backing := make([]float64, len(data))
+			for i := range data {
+				backing[i] = f64(data[i])
+			}
+			return tensor.NewDense(v.Dtype(), v.Shape().Clone(),
+				tensor.WithBacking(backing)), nil
+
+		case []float32:
+			backing := make([]float32, len(data))
+			for i := range data {
+				backing[i] = f32(data[i])
+			}
+			return tensor.NewDense(v.Dtype(), v.Shape().Clone(),
+				tensor.WithBacking(backing)), nil
+
+		case float64:
+			return tensor.New(tensor.FromScalar(f64(data))), nil
+
+		case float32:
+			return tensor.New(tensor.FromScalar(f32(data))), nil
+
+		default:
+			return nil, DtypeErrorf("data type %v unsupported", v.Dtype())
+		}
+
+	default:
+		return nil, DtypeErrorf("expected input to be a float64 or "+
+			"float32 scalar or tensor, got %T", value)
+	}
+}
+
+// checkDomain returns the first error returned by domain on the
+// elements of value
+func checkDomain(value G.Value, domain func(float64) error) error {
+	switch v := value.(type) {
+	case *G.F64:
+		return domain(float64(*v))
+
+	case *G.F32:
+		return domain(float64(*v))
+
+	case tensor.Tensor:
+		if view, ok := v.(tensor.View); ok && view.IsMaterializable() {
+			v = view.Materialize()
+		}
+
+		switch data := v.Data().(type) {
+		case []float64:
+			for _, x := range data {
+				if err := domain(x); err != nil {
+					return err
+				}
+			}
+
+		case []float32:
+			for _, x := range data {
+				if err := domain(float64(x)); err != nil {
+					return err
+				}
+			}
+
+		case float64:
+			return domain(data)
+
+		case float32:
+			return domain(float64(data))
+		}
+	}
+
+	return nil
+}
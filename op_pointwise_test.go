@@ -0,0 +1,124 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// testPointwise tests that the element-wise operation op computes f
+// and has gradient df on random float64 tensors and float32 scalars,
+// where the inputs are generated by input.
+func testPointwise(t *testing.T, name string,
+	op func(*G.Node) (*G.Node, error), f, df func(float64) float64,
+	input func() float64) {
+	t.Helper()
+	const tolerance float64 = 0.0001
+	const tests int = 5
+	const maxDims int = 4
+	const maxDimSize int = 6
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+
+		backing := make([]float64, size)
+		out := make([]float64, size)
+		grad := make([]float64, size)
+		for j := range backing {
+			backing[j] = input()
+			out[j] = f(backing[j])
+			grad[j] = df(backing[j]) / float64(size)
+		}
+
+		g := G.NewGraph()
+		inTensor := tensor.NewDense(
+			tensor.Float64,
+			shape,
+			tensor.WithBacking(backing),
+		)
+		in := G.NewTensor(g, tensor.Float64, len(shape),
+			G.WithValue(inTensor))
+
+		computedNode, err := op(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var computed G.Value
+		G.Read(computedNode, &computed)
+
+		mean := G.Must(G.Mean(computedNode))
+		diff, err := G.Grad(mean, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var computedDiff G.Value
+		G.Read(diff[0], &computedDiff)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		output := computed.Data().([]float64)
+		outGrad := computedDiff.Data().([]float64)
+		for j := range out {
+			if math.Abs(out[j]-output[j]) > tolerance {
+				t.Errorf("%v: incorrect value at %v\nexpected: %v\n"+
+					"received: %v", name, backing[j], out[j], output[j])
+			}
+			if math.Abs(grad[j]-outGrad[j]) > tolerance {
+				t.Errorf("%v: incorrect gradient at %v\nexpected: %v\n"+
+					"received: %v", name, backing[j], grad[j], outGrad[j])
+			}
+		}
+
+		vm.Close()
+	}
+
+	// Float32 scalar
+	x := input()
+	g := G.NewGraph()
+	in := G.NewScalar(g, tensor.Float32, G.WithValue(float32(x)))
+	computedNode, err := op(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var computed G.Value
+	G.Read(computedNode, &computed)
+
+	diff, err := G.Grad(computedNode, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var computedDiff G.Value
+	G.Read(diff[0], &computedDiff)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(float64(computed.Data().(float32))-f(x)) > tolerance {
+		t.Errorf("%v: incorrect float32 value at %v\nexpected: %v\n"+
+			"received: %v", name, x, f(x), computed.Data())
+	}
+	if math.Abs(float64(computedDiff.Data().(float32))-df(x)) > tolerance {
+		t.Errorf("%v: incorrect float32 gradient at %v\nexpected: %v\n"+
+			"received: %v", name, x, df(x), computedDiff.Data())
+	}
+}
+
+// finiteDifference returns the central finite difference approximation
+// of the derivative of f at x
+func finiteDifference(f func(float64) float64, x float64) float64 {
+	const h float64 = 1e-5
+	return (f(x+h) - f(x-h)) / (2 * h)
+}
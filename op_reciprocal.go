@@ -0,0 +1,31 @@
+package gop
+
+import (
+	"math"
+
+	"github.com/samuelfneumann/math32"
+)
+
+// newReciprocalOp returns a new pointwise operation which computes
+// 1 / x. The derivative of the reciprocal function is -1 / x².
+func newReciprocalOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Reciprocal",
+		f64:  func(x float64) float64 { return 1 / x },
+		f32:  func(x float32) float32 { return 1 / x },
+		df64: func(x float64) float64 { return -1 / (x * x) },
+		df32: func(x float32) float32 { return -1 / (x * x) },
+	}
+}
+
+// newRsqrtOp returns a new pointwise operation which computes 1 / √x.
+// The derivative of the rsqrt function is -0.5 x^(-3/2).
+func newRsqrtOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Rsqrt",
+		f64:  func(x float64) float64 { return 1 / math.Sqrt(x) },
+		f32:  func(x float32) float32 { return 1 / math32.Sqrt(x) },
+		df64: func(x float64) float64 { return -0.5 / (x * math.Sqrt(x)) },
+		df32: func(x float32) float32 { return -0.5 / (x * math32.Sqrt(x)) },
+	}
+}
@@ -0,0 +1,146 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestReciprocal(t *testing.T) {
+	f := func(x float64) float64 { return 1 / x }
+	df := func(x float64) float64 { return finiteDifference(f, x) }
+
+	// Inputs bounded away from 0 in [-5, -0.5) ∪ [0.5, 5)
+	input := func() float64 {
+		x := 0.5 + rand.Float64()*4.5
+		if rand.Intn(2) == 0 {
+			return -x
+		}
+		return x
+	}
+
+	testPointwise(t, "Reciprocal", Reciprocal, f, df, input)
+}
+
+func TestRsqrt(t *testing.T) {
+	f := func(x float64) float64 { return 1 / math.Sqrt(x) }
+	df := func(x float64) float64 { return finiteDifference(f, x) }
+	input := func() float64 { return 0.5 + rand.Float64()*4.5 }
+
+	testPointwise(t, "Rsqrt", Rsqrt, f, df, input)
+}
+
+// TestReciprocalF32 tests Reciprocal and Rsqrt and their gradients on
+// float32 tensors
+func TestReciprocalF32(t *testing.T) {
+	const tolerance float64 = 1e-5 // Relative tolerance
+	in := []float32{0.25, 0.5, 1, 2, 4, 9}
+
+	ops := []struct {
+		name  string
+		op    func(*G.Node) (*G.Node, error)
+		f, df func(float64) float64
+	}{
+		{"Reciprocal", Reciprocal, func(x float64) float64 { return 1 / x },
+			func(x float64) float64 { return -1 / (x * x) }},
+		{"Rsqrt", Rsqrt, func(x float64) float64 { return 1 / math.Sqrt(x) },
+			func(x float64) float64 { return -0.5 * math.Pow(x, -1.5) }},
+	}
+
+	for _, test := range ops {
+		g := G.NewGraph()
+		inTensor := tensor.NewDense(tensor.Float32, []int{len(in)},
+			tensor.WithBacking(append([]float32{}, in...)))
+		x := G.NewVector(g, tensor.Float32, G.WithValue(inTensor),
+			G.WithName("x"))
+
+		out, err := test.op(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		grad, err := G.Grad(G.Must(G.Sum(out)), x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		outData := outVal.Data().([]float32)
+		gradData := gradVal.Data().([]float32)
+		for i := range in {
+			target := test.f(float64(in[i]))
+			diff := math.Abs(float64(outData[i]) - target)
+			if diff > tolerance*math.Abs(target) {
+				t.Errorf("%v: incorrect value at %v\nexpected: %v\n"+
+					"received: %v", test.name, in[i], target, outData[i])
+			}
+
+			target = test.df(float64(in[i]))
+			diff = math.Abs(float64(gradData[i]) - target)
+			if diff > tolerance*math.Abs(target) {
+				t.Errorf("%v: incorrect gradient at %v\nexpected: %v\n"+
+					"received: %v", test.name, in[i], target, gradData[i])
+			}
+		}
+	}
+}
+
+// TestReciprocalZero tests that Reciprocal and Rsqrt propagate Inf and
+// NaN at and below 0 rather than returning an error
+func TestReciprocalZero(t *testing.T) {
+	in := []float64{0, -1}
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(tensor.Float64, []int{len(in)},
+		tensor.WithBacking(in))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(inTensor),
+		G.WithName("x"))
+
+	reciprocal, err := Reciprocal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsqrt, err := Rsqrt(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reciprocalVal, rsqrtVal G.Value
+	G.Read(reciprocal, &reciprocalVal)
+	G.Read(rsqrt, &rsqrtVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	reciprocalData := reciprocalVal.Data().([]float64)
+	if !math.IsInf(reciprocalData[0], 1) {
+		t.Errorf("expected reciprocal of 0 to be +Inf but got %v",
+			reciprocalData[0])
+	}
+	if reciprocalData[1] != -1 {
+		t.Errorf("expected reciprocal of -1 to be -1 but got %v",
+			reciprocalData[1])
+	}
+
+	rsqrtData := rsqrtVal.Data().([]float64)
+	if !math.IsInf(rsqrtData[0], 1) {
+		t.Errorf("expected rsqrt of 0 to be +Inf but got %v", rsqrtData[0])
+	}
+	if !math.IsNaN(rsqrtData[1]) {
+		t.Errorf("expected rsqrt of -1 to be NaN but got %v", rsqrtData[1])
+	}
+}
@@ -0,0 +1,219 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// reduceAddOp is the fused sum operation, which sums the elements of
+// a tensor along an axis in a single operation and removes the axis
+type reduceAddOp struct {
+	axis int
+	dims int // The number of dimensions in the input tensor
+}
+
+// newReduceAddOp returns a new reduceAddOp
+func newReduceAddOp(axis, dims int) (*reduceAddOp, error) {
+	axis, err := normalizeAxis(axis, dims)
+	if err != nil {
+		return nil, fmt.Errorf("newReduceAddOp: %w", err)
+	}
+
+	return &reduceAddOp{
+		axis: axis,
+		dims: dims,
+	}, nil
+}
+
+// DiffWRT implements the gorgonia.SDOp interface
+func (r *reduceAddOp) DiffWRT(inputs int) []bool {
+	return []bool{true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface. Each element along
+// the reduced axis contributes to the sum with a weight of 1, and so
+// the gradient is the incoming gradient repeated along the axis.
+func (r *reduceAddOp) SymDiff(inputs G.Nodes, output, grad *G.Node) (G.Nodes,
+	error) {
+	err := CheckArity(r, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	shape := inputs[0].Shape().Clone()
+	length := shape[r.axis]
+	shape[r.axis] = 1
+
+	grad, err = G.Reshape(grad, shape)
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: could not reshape gradient: %v",
+			err)
+	}
+
+	nodes := make(G.Nodes, 1)
+	if length == 1 {
+		nodes[0] = grad
+		return nodes, nil
+	}
+
+	nodes[0], err = Repeat(grad, r.axis, length)
+
+	return nodes, err
+}
+
+// Arity implements the gorgonia.Op interface
+func (r *reduceAddOp) Arity() int { return 1 }
+
+// Type implements the gorgonia.Op interface
+func (r *reduceAddOp) Type() hm.Type {
+	a := hm.TypeVariable('a')
+	in := G.TensorType{
+		Dims: r.dims,
+		Of:   a,
+	}
+
+	if r.dims == 1 {
+		return hm.NewFnType(in, a)
+	}
+	out := G.TensorType{
+		Dims: r.dims - 1,
+		Of:   a,
+	}
+	return hm.NewFnType(in, out)
+}
+
+// InferShape implements the gorgonia.Op interface
+func (r *reduceAddOp) InferShape(inputs ...G.DimSizer) (tensor.Shape,
+	error) {
+	err := CheckArity(r, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	if inputs[0] == nil {
+		return nil, fmt.Errorf("inferShape: nil input")
+	}
+
+	shapes, err := G.DimSizersToShapes(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+	return r.outShape(shapes[0]), nil
+}
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (r *reduceAddOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (r *reduceAddOp) CallsExtern() bool { return false }
+
+// OverwritesInput implements the gorgonia.Op interface
+func (r *reduceAddOp) OverwritesInput() int { return -1 }
+
+// String implements the fmt.Stringer interface
+func (r *reduceAddOp) String() string {
+	return fmt.Sprintf("ReduceAdd{axis=%v}()", r.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (r *reduceAddOp) WriteHash(h hash.Hash) { fmt.Fprint(h, r.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (r *reduceAddOp) Hashcode() uint32 { return SimpleHash(r) }
+
+// Do implements the gorgonia.Op interface
+func (r *reduceAddOp) Do(values ...G.Value) (G.Value, error) {
+	err := r.checkInputs(values...)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := values[0].(tensor.Tensor)
+	if v, ok := input.(tensor.View); ok && v.IsMaterializable() {
+		input = v.Materialize()
+	}
+
+	// Summing some tensors produced by Gorgonia, such as the output of
+	// a reshape, along an axis can index past the end of their backing,
+	// so the sum is always computed over a new 3-tensor
+	// (outer, input.Shape()[axis], inner) sharing the input's backing.
+	// Views were materialized above, so the backing must hold exactly
+	// the elements of the input in order.
+	shape := input.Shape()
+	if input.DataSize() != shape.TotalSize() {
+		return nil, fmt.Errorf("do: expected input of shape %v to have %v "+
+			"elements in its backing but got %v", shape, shape.TotalSize(),
+			input.DataSize())
+	}
+	outer := tensor.ProdInts(shape[:r.axis])
+	inner := tensor.ProdInts(shape[r.axis+1:])
+	in := tensor.New(tensor.WithShape(outer, shape[r.axis], inner),
+		tensor.WithBacking(input.Data()))
+
+	out, err := in.Sum(1)
+	if err != nil {
+		return nil, fmt.Errorf("do: could not sum: %v", err)
+	}
+
+	if r.dims == 1 {
+		return scalarValue(out.Get(0))
+	}
+	if err := out.Reshape(r.outShape(shape)...); err != nil {
+		return nil, fmt.Errorf("do: could not reshape output: %v", err)
+	}
+	return out, nil
+}
+
+// outShape returns the shape of the output of the receiver for an
+// input of shape shape
+func (r *reduceAddOp) outShape(shape tensor.Shape) tensor.Shape {
+	out := make(tensor.Shape, 0, len(shape)-1)
+	out = append(out, shape[:r.axis]...)
+	return append(out, shape[r.axis+1:]...)
+}
+
+// checkInputs returns an error if the input to the receiver is invalid
+func (r *reduceAddOp) checkInputs(inputs ...G.Value) error {
+	if err := CheckArity(r, len(inputs)); err != nil {
+		return err
+	}
+
+	t, ok := inputs[0].(tensor.Tensor)
+	if !ok {
+		return fmt.Errorf("expected input to be a tensor, got %T", inputs[0])
+	}
+
+	if len(t.Shape()) <= 0 || t.Size() == 0 {
+		return fmt.Errorf("tensor does not have any elements")
+	}
+
+	if len(t.Shape()) <= r.axis {
+		return fmt.Errorf("axis out of range [%v] with tensor shape %v",
+			r.axis, t.Shape())
+	}
+
+	return nil
+}
+
+// scalarValue returns v as a Gorgonia scalar value
+func scalarValue(v interface{}) (G.Value, error) {
+	switch v := v.(type) {
+	case float64:
+		return G.NewF64(v), nil
+	case float32:
+		return G.NewF32(v), nil
+	case int:
+		return G.NewI(v), nil
+	case int64:
+		return G.NewI64(v), nil
+	case int32:
+		return G.NewI32(v), nil
+	case uint8:
+		return G.NewU8(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported scalar type %T", v)
+	}
+}
@@ -0,0 +1,273 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestReduceAddOp tests that the fused sum used by ReduceAdd computes
+// the same values, shapes, and gradients as summing by slicing out
+// each row along the axis
+func TestReduceAddOp(t *testing.T) {
+	const threshold float64 = 0.000001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	sliced := func(x *G.Node, axis int, keepdims bool) (*G.Node, error) {
+		return reduceAlong(x, axis, keepdims, G.Add, true)
+	}
+
+	// reduce sums x along axis with f and returns the output value and
+	// the gradient of the sum of the output weighted by w
+	reduce := func(backing []float64, shape []int, axis int, keepdims bool,
+		f func(*G.Node, int, bool) (*G.Node, error)) (G.Value, []float64) {
+		g := G.NewGraph()
+		x := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, backing...)))))
+
+		out, err := f(x, axis, keepdims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		// Weight each output so that the gradient differs along the
+		// axes which are not reduced
+		var loss *G.Node
+		if out.IsScalar() {
+			loss = out
+		} else {
+			w := G.NewTensor(g, tensor.Float64, out.Dims(), G.WithName("w"),
+				G.WithValue(tensor.NewDense(tensor.Float64, out.Shape(),
+					tensor.WithBacking(tensor.Range(tensor.Float64, 1,
+						1+out.Shape().TotalSize())))))
+			loss = G.Must(G.Sum(G.Must(G.HadamardProd(out, w))))
+		}
+		grad, err := G.Grad(loss, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		defer vm.Close()
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		return outVal, append([]float64{}, gradVal.Data().([]float64)...)
+	}
+
+	shapes := [][]int{{5}, {1, 4}, {3, 1}, {2, 3}, {4, 1, 3}, {2, 3, 4},
+		{1, 3, 1, 2}}
+	for _, shape := range shapes {
+		backing := randF64(tensor.ProdInts(shape), -1, 1)
+		for axis := -len(shape); axis < len(shape); axis++ {
+			for _, keepdims := range []bool{true, false} {
+				fusedVal, fusedGrad := reduce(backing, shape, axis, keepdims,
+					ReduceAdd)
+				slicedVal, slicedGrad := reduce(backing, shape, axis,
+					keepdims, sliced)
+
+				if !fusedVal.Shape().Eq(slicedVal.Shape()) ||
+					len(fusedVal.Shape()) != len(slicedVal.Shape()) {
+					t.Errorf("shape %v axis %v keepdims %v: expected output "+
+						"shape %v but got %v", shape, axis, keepdims,
+						slicedVal.Shape(), fusedVal.Shape())
+					continue
+				}
+
+				var fused, sliced []float64
+				if fusedVal.Shape().IsScalar() {
+					fused = []float64{fusedVal.Data().(float64)}
+					sliced = []float64{slicedVal.Data().(float64)}
+				} else {
+					fused = fusedVal.Data().([]float64)
+					sliced = slicedVal.Data().([]float64)
+				}
+				for i := range sliced {
+					if math.Abs(fused[i]-sliced[i]) > threshold {
+						t.Errorf("shape %v axis %v: expected: %v received: "+
+							"%v", shape, axis, sliced[i], fused[i])
+					}
+				}
+				for i := range slicedGrad {
+					if math.Abs(fusedGrad[i]-slicedGrad[i]) > threshold {
+						t.Errorf("shape %v axis %v: expected gradient: %v "+
+							"received: %v", shape, axis, slicedGrad[i],
+							fusedGrad[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestReduceAddOpDtypes tests the forward pass of the fused sum on
+// float32 and integer tensors
+func TestReduceAddOpDtypes(t *testing.T) {
+	tests := []struct {
+		dt     tensor.Dtype
+		in     interface{}
+		target interface{}
+		scalar interface{}
+	}{
+		{tensor.Float32, []float32{1, 2, 3, 4, 5, 6}, []float32{5, 7, 9},
+			float32(21)},
+		{tensor.Int, []int{1, 2, 3, 4, 5, 6}, []int{5, 7, 9}, 21},
+		{tensor.Int64, []int64{1, 2, 3, 4, 5, 6}, []int64{5, 7, 9},
+			int64(21)},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		x := G.NewMatrix(g, test.dt, G.WithName("x"),
+			G.WithValue(tensor.New(tensor.WithShape(2, 3),
+				tensor.WithBacking(test.in))))
+		out, err := ReduceAdd(x, 0, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all, err := ReduceAdd(G.Must(G.Reshape(x, []int{6})), 0, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		data := out.Value().Data()
+		for i := 0; i < 3; i++ {
+			got := tensor.New(tensor.WithBacking(data)).Get(i)
+			want := tensor.New(tensor.WithBacking(test.target)).Get(i)
+			if got != want {
+				t.Errorf("%v: expected: %v received: %v", test.dt, want, got)
+			}
+		}
+		if got := all.Value().Data(); got != test.scalar {
+			t.Errorf("%v: expected: %v received: %v", test.dt, test.scalar,
+				got)
+		}
+	}
+}
+
+// TestReduceAddOpViews tests the forward pass of the fused sum on
+// sliced and transposed views of a tensor, whose backing does not hold
+// exactly the elements of the view in order
+func TestReduceAddOpViews(t *testing.T) {
+	full := tensor.New(tensor.WithShape(3, 3),
+		tensor.WithBacking([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}))
+
+	tests := []struct {
+		name   string
+		view   func() (tensor.Tensor, error)
+		axis   int
+		target []float64
+	}{
+		{
+			name: "Rows",
+			view: func() (tensor.Tensor, error) {
+				return full.Slice(G.S(1, 3))
+			},
+			axis:   0,
+			target: []float64{11, 13, 15},
+		},
+		{
+			name: "Columns",
+			view: func() (tensor.Tensor, error) {
+				return full.Slice(nil, G.S(0, 2))
+			},
+			axis:   0,
+			target: []float64{12, 15},
+		},
+		{
+			name: "Columns",
+			view: func() (tensor.Tensor, error) {
+				return full.Slice(nil, G.S(1, 3))
+			},
+			axis:   1,
+			target: []float64{5, 11, 17},
+		},
+		{
+			name: "Transpose",
+			view: func() (tensor.Tensor, error) {
+				v, err := full.Slice(G.S(0, 2))
+				if err != nil {
+					return nil, err
+				}
+				return v, v.T()
+			},
+			axis:   0,
+			target: []float64{6, 15},
+		},
+	}
+
+	for _, test := range tests {
+		view, err := test.view()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		op, err := newReduceAddOp(test.axis, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := op.Do(view)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		computed := out.Data().([]float64)
+		if len(computed) != len(test.target) {
+			t.Errorf("%v: expected %v elements but got %v", test.name,
+				len(test.target), len(computed))
+			continue
+		}
+		for i := range computed {
+			if computed[i] != test.target[i] {
+				t.Errorf("%v along axis %v: \nexpected: %v \nreceived: %v",
+					test.name, test.axis, test.target, computed)
+				break
+			}
+		}
+	}
+}
+
+// BenchmarkReduceAddOp benchmarks the fused sum of ReduceAdd against
+// summing by slicing out the rows along the axis
+func BenchmarkReduceAddOp(b *testing.B) {
+	b.Run("Fused", func(b *testing.B) {
+		benchmarkReduce(b, ReduceAdd)
+	})
+	b.Run("Sliced", func(b *testing.B) {
+		benchmarkReduce(b, func(x *G.Node, axis int, keepdims bool) (
+			*G.Node, error) {
+			return reduceAlong(x, axis, keepdims, G.Add, true)
+		})
+	})
+}
+
+// BenchmarkReduceAddOpBuild benchmarks building the graph of the fused
+// sum of ReduceAdd against summing by slicing out the rows along the
+// axis
+func BenchmarkReduceAddOpBuild(b *testing.B) {
+	b.Run("Fused", func(b *testing.B) {
+		benchmarkReduceBuild(b, ReduceAdd)
+	})
+	b.Run("Sliced", func(b *testing.B) {
+		benchmarkReduceBuild(b, func(x *G.Node, axis int, keepdims bool) (
+			*G.Node, error) {
+			return reduceAlong(x, axis, keepdims, G.Add, true)
+		})
+	})
+}
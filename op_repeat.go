@@ -110,8 +110,34 @@ func (r *repeatOp) Do(inputs ...G.Value) (G.Value, error) {
 	}
 
 	input := inputs[0].(tensor.Tensor)
+	shape, err := r.InferShape(input.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("do: could not infer shape: %v", err)
+	}
+
+	// Gorgonia treats matrices with a single row or column as vectors
+	// and repeats them incorrectly, so the input is always repeated as
+	// a 3-tensor (outer, input.Shape()[axis], inner)
+	outer := tensor.ProdInts(input.Shape()[:r.axis])
+	inner := tensor.ProdInts(input.Shape()[r.axis+1:])
+
+	in := input.Clone().(tensor.Tensor)
+	if view, ok := in.(tensor.View); ok && view.IsMaterializable() {
+		in = view.Materialize()
+	}
+	if err := in.Reshape(outer, input.Shape()[r.axis], inner); err != nil {
+		return nil, fmt.Errorf("do: could not reshape input: %v", err)
+	}
+
+	out, err := tensor.Repeat(in, 1, r.repeats)
+	if err != nil {
+		return nil, fmt.Errorf("do: could not repeat: %v", err)
+	}
+	if err := out.Reshape(shape...); err != nil {
+		return nil, fmt.Errorf("do: could not reshape output: %v", err)
+	}
 
-	return tensor.Repeat(input, r.axis, r.repeats)
+	return out, nil
 }
 
 // checkInputs returns an error if inputs is not a valid input to the
@@ -205,39 +231,37 @@ func (r *repeatDiffOp) Do(inputs ...G.Value) (G.Value, error) {
 		return nil, fmt.Errorf("do: could not infer shape: %v", err)
 	}
 
+	// The gradient is only ever cloned, reshaped, and summed, and so
+	// no dtype-specific handling is needed
+	g := grad.Clone().(tensor.Tensor)
+	if view, ok := g.(tensor.View); ok && view.IsMaterializable() {
+		g = view.Materialize()
+	}
 	if r.op.repeats == 1 {
-		return grad.Clone().(*tensor.Dense), nil
+		return g, nil
 	}
 
-	outRows := make([]*tensor.Dense, shape[r.op.axis])
-
-	slices := make([]tensor.Slice, len(shape))
-	for i := 0; i < shape[r.op.axis]; i++ {
-		start := i * r.op.repeats
-		stop := start + r.op.repeats
-		slices[r.op.axis] = G.S(start, stop)
-
-		slice, err := grad.Slice(slices...)
-		if err != nil {
-			return nil, fmt.Errorf("do: could not slice grad: %v", err)
-		}
-
-		var outRow *tensor.Dense
-		if slice.Size() > 1 {
-			outRow, err = slice.(*tensor.Dense).Sum(r.op.axis)
-		} else {
-			outRow = slice.(*tensor.Dense)
-		}
-		if err != nil {
-			return nil, fmt.Errorf("do: could not sum grad: %v", err)
-		}
-		outRows[i] = outRow
+	// Each element along axis is repeated in a contiguous block, so
+	// flatten the gradient to (outer, repeats, inner) and sum the
+	// repeated blocks. Gorgonia may incorrectly sum along inner axes of
+	// higher-dimensional tensors, so the gradient is always summed as a
+	// 3-tensor.
+	outer := tensor.ProdInts(shape[:r.op.axis+1])
+	inner := tensor.ProdInts(shape[r.op.axis+1:])
+
+	if err := g.Reshape(outer, r.op.repeats, inner); err != nil {
+		return nil, fmt.Errorf("do: could not reshape grad: %v", err)
 	}
 
-	if shape[r.op.axis] > 1 {
-		return outRows[0].Stack(r.op.axis, outRows[1:]...)
+	out, err := tensor.Sum(g, 1)
+	if err != nil {
+		return nil, fmt.Errorf("do: could not sum grad: %v", err)
 	}
-	return outRows[0], nil
+	if err := out.Reshape(shape...); err != nil {
+		return nil, fmt.Errorf("do: could not reshape grad: %v", err)
+	}
+
+	return out, nil
 }
 
 // checkInputs returns an error if inputs is not a valid input to the
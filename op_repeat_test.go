@@ -51,11 +51,12 @@ func TestRepeat(t *testing.T) {
 			tensor.WithBacking(inBacking),
 		)
 
-		// Construct the target/correct gradient
-		repeatTarget, err := tensor.Repeat(inTensor, axis, repeats)
-		if err != nil {
-			t.Error(err)
-		}
+		// Construct the target/correct output
+		repeatTarget := tensor.NewDense(
+			tensor.Float64,
+			repeatShape(size, axis, repeats),
+			tensor.WithBacking(repeatData(inBacking, size, axis, repeats)),
+		)
 
 		// Construct the gradient target
 		gradTarget := make([]float64, inTensor.Size())
@@ -129,3 +130,272 @@ func TestRepeat(t *testing.T) {
 		vm.Reset()
 	}
 }
+
+// TestRepeatFloat32 runs tests to ensure the forward and backward pass
+// of Repeat act as expected on float32 tensors. The tests use
+// completely random data.
+func TestRepeatFloat32(t *testing.T) {
+	const numTests int = 20 // The number of random tests to run
+
+	// The maximum number of times an axis can be repeated in a test
+	const maxRepeats int = 32
+
+	// Threshold to determine equality of floating point numbers, needed
+	// due to machine precision errors
+	const threshold float64 = 0.0001
+
+	// Randomly generated input has number of dimensions between dimMin
+	// and dimMax. Each dimension of the randomly generated input has
+	// between sizeMin and sizeMax elements.
+	const sizeMin int = 1 // Input has min sizeMin rows per dim
+	const sizeMax int = 5 // Input has max sizeMax rows per dim
+	const dimMin int = 1  // Input has more than dimMin dims
+	const dimMax int = 10 // Input has up to dimMax dims
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < numTests; i++ {
+		size := randInt(dimMin+rand.Intn(dimMax-dimMin), sizeMin, sizeMax)
+		axis := rand.Intn(len(size))
+		repeats := rand.Intn(maxRepeats) + 1
+
+		// Construct input data, keeping a float64 copy to compute the
+		// target output with
+		inBacking := randF32(tensor.ProdInts(size), -1., 1.)
+		inBacking64 := make([]float64, len(inBacking))
+		for j := range inBacking {
+			inBacking64[j] = float64(inBacking[j])
+		}
+		repeatTarget := repeatData(inBacking64, size, axis, repeats)
+		gradTarget := 1.0 / float64(len(inBacking))
+
+		g := G.NewGraph()
+		in := G.NewTensor(g, tensor.Float32, len(size),
+			G.WithValue(tensor.NewDense(tensor.Float32, size,
+				tensor.WithBacking(inBacking))))
+
+		c, err := Repeat(in, axis, repeats)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cVal G.Value
+		G.Read(c, &cVal)
+
+		loss := G.Must(G.Mean(c))
+		grad, err := G.Grad(loss, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		shape := tensor.Shape(repeatShape(size, axis, repeats))
+		if !cVal.Shape().Eq(shape) {
+			t.Errorf("expected shape %v but got %v", shape, cVal.Shape())
+		}
+		if cVal.Dtype() != tensor.Float32 {
+			t.Errorf("expected output of type %v but got %v",
+				tensor.Float32, cVal.Dtype())
+			continue
+		}
+		for j, v := range cVal.Data().([]float32) {
+			if float64(v) != repeatTarget[j] {
+				t.Errorf("expected: \n%v \nreceived: \n%v\n", repeatTarget,
+					cVal)
+				break
+			}
+		}
+
+		if !gradVal.Shape().Eq(tensor.Shape(size)) {
+			t.Errorf("expected gradient shape %v but got %v", size,
+				gradVal.Shape())
+		}
+		if gradVal.Dtype() != tensor.Float32 {
+			t.Errorf("expected gradient of type %v but got %v",
+				tensor.Float32, gradVal.Dtype())
+			continue
+		}
+		gradData, ok := gradVal.Data().([]float32)
+		if !ok {
+			// Gradient has a single value
+			gradData = []float32{gradVal.Data().(float32)}
+		}
+		for j := range gradData {
+			if math.Abs(float64(gradData[j])-gradTarget) > threshold {
+				t.Errorf("expected gradient %v but got %v at index %v",
+					gradTarget, gradData[j], j)
+				break
+			}
+		}
+	}
+}
+
+// TestRepeatVectorLike tests Repeat on matrices with a single row or
+// column, which Gorgonia otherwise treats as vectors
+func TestRepeatVectorLike(t *testing.T) {
+	tests := []struct {
+		shape   []int
+		axis    int
+		repeats int
+	}{
+		{[]int{1, 4}, 0, 3},
+		{[]int{1, 4}, 1, 2},
+		{[]int{4, 1}, 0, 2},
+		{[]int{4, 1}, 1, 3},
+		{[]int{1, 1}, 0, 5},
+		{[]int{1, 1, 4}, 1, 3},
+	}
+
+	for _, test := range tests {
+		inBacking := randF64(tensor.ProdInts(test.shape), -1., 1.)
+		g := G.NewGraph()
+		in := G.NewTensor(g, tensor.Float64, len(test.shape),
+			G.WithValue(tensor.NewDense(tensor.Float64, test.shape,
+				tensor.WithBacking(inBacking))))
+
+		out, err := Repeat(in, test.axis, test.repeats)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		shape := repeatShape(test.shape, test.axis, test.repeats)
+		if !outVal.Shape().Eq(tensor.Shape(shape)) {
+			t.Errorf("expected shape %v but got %v", shape, outVal.Shape())
+			continue
+		}
+		target := repeatData(inBacking, test.shape, test.axis, test.repeats)
+		for i, v := range outVal.Data().([]float64) {
+			if v != target[i] {
+				t.Errorf("repeat %v along axis %v: expected %v but got %v",
+					test.shape, test.axis, target, outVal.Data())
+				break
+			}
+		}
+	}
+}
+
+// TestRepeatGradWeighted tests the gradient of Repeat with a weighted
+// loss, so that each element of the input receives the sum of the
+// weights of its own repeats, including along inner axes of
+// higher-dimensional tensors
+func TestRepeatGradWeighted(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	tests := []struct {
+		shape   []int
+		axis    int
+		repeats int
+	}{
+		{[]int{4}, 0, 3},
+		{[]int{2, 3}, 0, 2},
+		{[]int{2, 3}, 1, 4},
+		{[]int{2, 1, 3}, 1, 3},
+		{[]int{2, 3, 4}, 1, 2},
+		{[]int{3, 2, 2, 2}, 2, 3},
+		{[]int{2, 2, 3, 1}, 3, 5},
+	}
+
+	for _, test := range tests {
+		shape, axis, repeats := test.shape, test.axis, test.repeats
+		inBacking := randF64(tensor.ProdInts(shape), -1., 1.)
+		outShape := repeatShape(shape, axis, repeats)
+		weights := randF64(tensor.ProdInts(outShape), -1., 1.)
+
+		// Element (o, a, in) of the input is repeated at elements
+		// (o, a*repeats + r, in) of the output, where outer and inner
+		// are the number of elements before and after axis
+		outer := tensor.ProdInts(shape[:axis])
+		inner := tensor.ProdInts(shape[axis+1:])
+		length := shape[axis]
+		target := make([]float64, len(inBacking))
+		for o := 0; o < outer; o++ {
+			for a := 0; a < length; a++ {
+				for in := 0; in < inner; in++ {
+					for r := 0; r < repeats; r++ {
+						j := (o*length*repeats+a*repeats+r)*inner + in
+						target[(o*length+a)*inner+in] += weights[j]
+					}
+				}
+			}
+		}
+
+		g := G.NewGraph()
+		in := G.NewTensor(g, tensor.Float64, len(shape),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(inBacking))), G.WithName("in"))
+		w := G.NewTensor(g, tensor.Float64, len(outShape),
+			G.WithValue(tensor.NewDense(tensor.Float64, outShape,
+				tensor.WithBacking(weights))), G.WithName("w"))
+
+		out, err := Repeat(in, axis, repeats)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(out, w))))
+		grad, err := G.Grad(loss, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !gradVal.Shape().Eq(tensor.Shape(shape)) {
+			t.Errorf("shape %v axis %v: expected gradient shape %v but got "+
+				"%v", shape, axis, shape, gradVal.Shape())
+			continue
+		}
+		for i, v := range gradVal.Data().([]float64) {
+			if math.Abs(v-target[i]) > threshold {
+				t.Errorf("shape %v axis %v: incorrect gradient at index %v "+
+					"\nexpected: %v \nreceived: %v", shape, axis, i,
+					target[i], v)
+			}
+		}
+	}
+}
+
+// repeatShape returns the shape of a tensor of shape shape with each
+// element repeated repeats times along axis
+func repeatShape(shape []int, axis, repeats int) []int {
+	out := append([]int{}, shape...)
+	out[axis] *= repeats
+	return out
+}
+
+// repeatData returns the row-major data of a tensor with row-major data
+// x and shape shape with each element repeated repeats times along axis
+func repeatData(x []float64, shape []int, axis, repeats int) []float64 {
+	outer := tensor.ProdInts(shape[:axis])
+	inner := tensor.ProdInts(shape[axis+1:])
+
+	out := make([]float64, 0, len(x)*repeats)
+	for o := 0; o < outer; o++ {
+		for a := 0; a < shape[axis]; a++ {
+			row := x[(o*shape[axis]+a)*inner : (o*shape[axis]+a+1)*inner]
+			for r := 0; r < repeats; r++ {
+				out = append(out, row...)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// rollOp implements the roll operation, which circularly shifts the
+// elements of a tensor along an axis. Elements shifted beyond the last
+// position along the axis are re-introduced at the first position.
+type rollOp struct {
+	axis  int // Axis along which to roll
+	dims  int // Number of dimensions in the input node
+	shift int // Number of positions to shift elements by
+}
+
+// newRollOp returns a new rollOp
+func newRollOp(axis, dims, shift int) (*rollOp, error) {
+	axis, err := normalizeAxis(axis, dims)
+	if err != nil {
+		return nil, fmt.Errorf("newRollOp: %w", err)
+	}
+
+	return &rollOp{
+		axis:  axis,
+		dims:  dims,
+		shift: shift,
+	}, nil
+}
+
+// DiffWRT implements the gorgonia.SDOp interface
+func (r *rollOp) DiffWRT(inputs int) []bool {
+	return []bool{true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface. The gradient of the
+// roll operation is the incoming gradient rolled in the opposite
+// direction.
+func (r *rollOp) SymDiff(inputs G.Nodes, output, grad *G.Node) (G.Nodes,
+	error) {
+	err := CheckArity(r, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	diffOp := &rollOp{axis: r.axis, dims: r.dims, shift: -r.shift}
+	nodes := make(G.Nodes, 1)
+
+	nodes[0], err = G.ApplyOp(diffOp, grad)
+
+	return nodes, err
+}
+
+// Arity implements the gorgonia.Op interface
+func (r *rollOp) Arity() int { return 1 }
+
+// Type implements the gorgonia.Op interface
+func (r *rollOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: r.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+
+	return hm.NewFnType(tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (r *rollOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (r *rollOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (r *rollOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (r *rollOp) String() string {
+	return fmt.Sprintf("Roll{axis=%v, shift=%v}()", r.axis, r.shift)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (r *rollOp) WriteHash(h hash.Hash) { fmt.Fprint(h, r.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (r *rollOp) Hashcode() uint32 { return SimpleHash(r) }
+
+// InferShape implements the gorgonia.Op interface
+func (r *rollOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(r, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[0].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (r *rollOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := r.checkInputs(inputs...); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	input := inputs[0].(tensor.Tensor)
+	length := input.Shape()[r.axis]
+
+	shift := r.shift % length
+	if shift < 0 {
+		shift += length
+	}
+
+	return permuteAxis(input, r.axis, func(k int) int {
+		return (k + shift) % length
+	}), nil
+}
+
+// checkInputs returns an error if inputs is not a valid input to the
+// receiver
+func (r *rollOp) checkInputs(inputs ...G.Value) error {
+	if err := CheckArity(r, len(inputs)); err != nil {
+		return err
+	}
+
+	t, ok := inputs[0].(tensor.Tensor)
+	if !ok {
+		return fmt.Errorf("expected tensor, received %T", inputs[0])
+	} else if t == nil {
+		return fmt.Errorf("cannot roll nil tensor")
+	} else if t.Size() == 0 {
+		return fmt.Errorf("cannot roll empty tensor")
+	} else if r.axis >= len(t.Shape()) {
+		return fmt.Errorf("axis [%v] out of range for tensor with shape %v",
+			r.axis, t.Shape())
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package gop
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestRollVector tests rolling a vector by positive and negative
+// shifts, and that rolling back in the opposite direction recovers the
+// original vector
+func TestRollVector(t *testing.T) {
+	in := []float64{0, 1, 2, 3, 4, 5}
+	tests := []struct {
+		shift  int
+		target []float64
+	}{
+		{2, []float64{4, 5, 0, 1, 2, 3}},
+		{-1, []float64{1, 2, 3, 4, 5, 0}},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		inTensor := tensor.NewDense(tensor.Float64, []int{len(in)},
+			tensor.WithBacking(append([]float64{}, in...)))
+		x := G.NewVector(g, tensor.Float64, G.WithValue(inTensor),
+			G.WithName("x"))
+
+		rolled, err := Roll(x, 0, test.shift)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := Roll(rolled, 0, -test.shift)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rolledVal, backVal G.Value
+		G.Read(rolled, &rolledVal)
+		G.Read(back, &backVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		rolledData := rolledVal.Data().([]float64)
+		backData := backVal.Data().([]float64)
+		for i := range in {
+			if rolledData[i] != test.target[i] {
+				t.Errorf("incorrect roll by %v at index %d \nexpected: %v "+
+					"\nreceived: %v", test.shift, i, test.target[i],
+					rolledData[i])
+			}
+			if backData[i] != in[i] {
+				t.Errorf("rolling by %v and back did not recover input at "+
+					"index %d \nexpected: %v \nreceived: %v", test.shift, i,
+					in[i], backData[i])
+			}
+		}
+	}
+}
+
+// TestRoll tests the forward and backward pass of Roll on random
+// tensors, axes, and shifts
+func TestRoll(t *testing.T) {
+	const numTests int = 20 // The number of random tests to run
+	const maxShift int = 10 // Shifts are in [-maxShift, maxShift]
+
+	// Randomly generated input has number of dimensions between dimMin
+	// and dimMax. Each dimension of the randomly generated input has
+	// between sizeMin and sizeMax elements.
+	const sizeMin int = 1
+	const sizeMax int = 5
+	const dimMin int = 1
+	const dimMax int = 5
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < numTests; i++ {
+		shape := randInt(dimMin+rand.Intn(dimMax-dimMin), sizeMin, sizeMax)
+		axis := rand.Intn(len(shape))
+		shift := rand.Intn(2*maxShift+1) - maxShift
+
+		numElems := tensor.ProdInts(shape)
+		inBacking := randF64(numElems, -1, 1)
+		upstream := randF64(numElems, -1, 1)
+
+		g := G.NewGraph()
+		x := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, inBacking...)))))
+		w := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, upstream...)))))
+
+		// Use a negative axis half of the time
+		rollAxis := axis
+		if rand.Intn(2) == 0 {
+			rollAxis -= len(shape)
+		}
+		rolled, err := Roll(x, rollAxis, shift)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rolledVal G.Value
+		G.Read(rolled, &rolledVal)
+
+		// The upstream gradient of the rolled tensor is w
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(rolled, w))))
+		grad, err := G.Grad(loss, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		length := shape[axis]
+		inner := tensor.ProdInts(shape[axis+1:])
+		rolledData := rolledVal.Data().([]float64)
+		gradData := gradVal.Data().([]float64)
+		for j := 0; j < numElems; j++ {
+			// Compute the index that element j is rolled to
+			k := (j / inner) % length
+			target := ((k+shift)%length + length) % length
+			dst := j + (target-k)*inner
+
+			if rolledData[dst] != inBacking[j] {
+				t.Errorf("expected element %d to be rolled to %d "+
+					"\nexpected: %v \nreceived: %v", j, dst, inBacking[j],
+					rolledData[dst])
+			}
+			if gradData[j] != upstream[dst] {
+				t.Errorf("incorrect gradient at index %d \nexpected: %v "+
+					"\nreceived: %v", j, upstream[dst], gradData[j])
+			}
+		}
+	}
+}
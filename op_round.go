@@ -0,0 +1,49 @@
+package gop
+
+import "math"
+
+// straightThrough64 and straightThrough32 are the straight-through
+// gradient estimators of piecewise constant functions, which pass the
+// gradient through the function unchanged
+func straightThrough64(float64) float64 { return 1 }
+func straightThrough32(float32) float32 { return 1 }
+
+// newRoundOp returns a new pointwise operation which rounds its input
+// to the nearest integer, rounding half away from zero. The derivative
+// of the round function is 0 almost everywhere, so the straight-through
+// estimator is used instead, with a derivative of 1 everywhere.
+func newRoundOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Round",
+		f64:  math.Round,
+		f32:  func(x float32) float32 { return float32(math.Round(float64(x))) },
+		df64: straightThrough64,
+		df32: straightThrough32,
+	}
+}
+
+// newFloorOp returns a new pointwise operation which computes the
+// greatest integer less than or equal to its input. The straight-through
+// estimator is used for the derivative, which is 1 everywhere.
+func newFloorOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Floor",
+		f64:  math.Floor,
+		f32:  func(x float32) float32 { return float32(math.Floor(float64(x))) },
+		df64: straightThrough64,
+		df32: straightThrough32,
+	}
+}
+
+// newCeilOp returns a new pointwise operation which computes the least
+// integer greater than or equal to its input. The straight-through
+// estimator is used for the derivative, which is 1 everywhere.
+func newCeilOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Ceil",
+		f64:  math.Ceil,
+		f32:  func(x float32) float32 { return float32(math.Ceil(float64(x))) },
+		df64: straightThrough64,
+		df32: straightThrough32,
+	}
+}
@@ -0,0 +1,89 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestRound(t *testing.T) {
+	df := func(float64) float64 { return 1 }
+	input := func() float64 { return (rand.Float64() - 0.5) * 10.0 }
+
+	testPointwise(t, "Round", Round, math.Round, df, input)
+}
+
+func TestFloor(t *testing.T) {
+	df := func(float64) float64 { return 1 }
+	input := func() float64 { return (rand.Float64() - 0.5) * 10.0 }
+
+	testPointwise(t, "Floor", Floor, math.Floor, df, input)
+}
+
+func TestCeil(t *testing.T) {
+	df := func(float64) float64 { return 1 }
+	input := func() float64 { return (rand.Float64() - 0.5) * 10.0 }
+
+	testPointwise(t, "Ceil", Ceil, math.Ceil, df, input)
+}
+
+// TestRoundStraightThrough tests Round, Floor, and Ceil on float32
+// tensors holding integers and half-integers, and ensures that the
+// straight-through gradient is all ones
+func TestRoundStraightThrough(t *testing.T) {
+	in := []float32{-2.5, -1.5, -1, -0.5, 0, 0.5, 1.5, 2, 2.5}
+	ops := []struct {
+		name   string
+		op     func(*G.Node) (*G.Node, error)
+		target func(float64) float64
+	}{
+		{"Round", Round, math.Round},
+		{"Floor", Floor, math.Floor},
+		{"Ceil", Ceil, math.Ceil},
+	}
+
+	for _, test := range ops {
+		g := G.NewGraph()
+		inTensor := tensor.NewDense(tensor.Float32, []int{len(in)},
+			tensor.WithBacking(append([]float32{}, in...)))
+		x := G.NewVector(g, tensor.Float32, G.WithValue(inTensor),
+			G.WithName("x"))
+
+		out, err := test.op(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		grad, err := G.Grad(G.Must(G.Sum(out)), x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		for i, v := range outVal.Data().([]float32) {
+			target := float32(test.target(float64(in[i])))
+			if v != target {
+				t.Errorf("%v: incorrect value at %v\nexpected: %v\n"+
+					"received: %v", test.name, in[i], target, v)
+			}
+		}
+		for i, v := range gradVal.Data().([]float32) {
+			if v != 1 {
+				t.Errorf("%v: expected gradient of 1 at %v but got %v",
+					test.name, in[i], v)
+			}
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// scatterAddOp implements the scatter-add operation, which adds each
+// element of a tensor of updates into a tensor x at the position given
+// by the corresponding index along an axis. For a 2-D x and axis 0:
+//
+//		out[indices[i][j]][j] += updates[i][j]
+//
+// The indices and updates must have the same shape, which must match
+// the shape of x at all dimensions except axis. Repeated indices
+// accumulate their updates.
+type scatterAddOp struct {
+	axis int // Axis along which to scatter
+	dims int // Number of dimensions in the input nodes
+}
+
+// newScatterAddOp returns a new scatterAddOp
+func newScatterAddOp(axis, dims int) (*scatterAddOp, error) {
+	axis, err := normalizeAxis(axis, dims)
+	if err != nil {
+		return nil, fmt.Errorf("newScatterAddOp: %w", err)
+	}
+
+	return &scatterAddOp{
+		axis: axis,
+		dims: dims,
+	}, nil
+}
+
+// DiffWRT implements the gorgonia.SDOp interface. The scatterAddOp is
+// differentiable with respect to x and the updates, but not the
+// indices.
+func (s *scatterAddOp) DiffWRT(inputs int) []bool {
+	return []bool{true, false, true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface. The gradient with
+// respect to x is the incoming gradient, and the gradient with respect
+// to the updates is the incoming gradient gathered at the indices.
+func (s *scatterAddOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	err := CheckArity(s, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	diffOp := &scatterAddDiffOp{s}
+	nodes := make(G.Nodes, 3)
+	nodes[0] = grad
+
+	nodes[2], err = G.ApplyOp(diffOp, grad, inputs[1])
+
+	return nodes, err
+}
+
+// Arity implements the gorgonia.Op interface
+func (s *scatterAddOp) Arity() int { return 3 }
+
+// Type implements the gorgonia.Op interface
+func (s *scatterAddOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: s.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+	indices := G.TensorType{
+		Dims: s.dims,
+		Of:   tensor.Int,
+	}
+
+	return hm.NewFnType(tt, indices, tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (s *scatterAddOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (s *scatterAddOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (s *scatterAddOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (s *scatterAddOp) String() string {
+	return fmt.Sprintf("ScatterAdd{axis=%v}()", s.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (s *scatterAddOp) WriteHash(h hash.Hash) { fmt.Fprint(h, s.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (s *scatterAddOp) Hashcode() uint32 { return SimpleHash(s) }
+
+// InferShape implements the gorgonia.Op interface
+func (s *scatterAddOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(s, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[0].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (s *scatterAddOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := CheckArity(s, len(inputs)); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	x, ok := inputs[0].(tensor.Tensor)
+	if !ok || x == nil {
+		return nil, fmt.Errorf("do: expected x to be a tensor but got %T",
+			inputs[0])
+	}
+	updates, ok := inputs[2].(tensor.Tensor)
+	if !ok || updates == nil {
+		return nil, fmt.Errorf("do: expected updates to be a tensor but "+
+			"got %T", inputs[2])
+	} else if updates.Dtype() != x.Dtype() {
+		return nil, fmt.Errorf("do: expected updates to have type %v but "+
+			"got %v", x.Dtype(), updates.Dtype())
+	} else if !updates.Shape().Eq(inputs[1].Shape()) {
+		return nil, fmt.Errorf("do: expected updates to have the shape of "+
+			"indices %v but got %v", inputs[1].Shape(), updates.Shape())
+	}
+
+	positions, err := scatterPositions(x.Shape(), inputs[1], s.axis)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	out := x.Clone().(tensor.Tensor)
+	if v, ok := out.(tensor.View); ok && v.IsMaterializable() {
+		out = v.Materialize()
+	}
+	if v, ok := updates.(tensor.View); ok && v.IsMaterializable() {
+		updates = v.Materialize()
+	}
+
+	switch outData := out.Data().(type) {
+	case []float64:
+		updateData := updates.Data().([]float64)
+		for p, pos := range positions {
+			outData[pos] += updateData[p]
+		}
+	case []float32:
+		updateData := updates.Data().([]float32)
+		for p, pos := range positions {
+			outData[pos] += updateData[p]
+		}
+	case []int:
+		updateData := updates.Data().([]int)
+		for p, pos := range positions {
+			outData[pos] += updateData[p]
+		}
+	default:
+		return nil, fmt.Errorf("do: cannot scatter-add tensor of type %v",
+			x.Dtype())
+	}
+
+	return out, nil
+}
+
+// scatterPositions returns the flat position in a tensor of shape
+// shape that each element of indices refers to, where indices holds
+// positions along axis. The indices must have the same shape as shape
+// at all dimensions except axis.
+func scatterPositions(shape tensor.Shape, indices G.Value,
+	axis int) ([]int, error) {
+	indexTensor, ok := indices.(tensor.Tensor)
+	if !ok || indexTensor == nil {
+		return nil, fmt.Errorf("expected indices to be a tensor but got %T",
+			indices)
+	}
+	if v, ok := indexTensor.(tensor.View); ok && v.IsMaterializable() {
+		indexTensor = v.Materialize()
+	}
+
+	data, ok := indexTensor.Data().([]int)
+	if !ok {
+		return nil, fmt.Errorf("expected indices to have type %v but got %v",
+			tensor.Int, indexTensor.Dtype())
+	}
+
+	indexShape := indexTensor.Shape()
+	if len(indexShape) != len(shape) {
+		return nil, fmt.Errorf("expected indices to have %v dimensions but "+
+			"got %v", len(shape), len(indexShape))
+	}
+	for i := range shape {
+		if i != axis && indexShape[i] != shape[i] {
+			return nil, fmt.Errorf("expected indices to have shape %v at "+
+				"all dimensions except %v but got %v", shape, axis,
+				indexShape)
+		}
+	}
+
+	// Elements of indices are laid out as (outer, indexLength, inner),
+	// and are scattered to (outer, length, inner)
+	length := shape[axis]
+	indexLength := indexShape[axis]
+	inner := tensor.ProdInts(shape[axis+1:])
+
+	positions := make([]int, len(data))
+	for p, index := range data {
+		if index < 0 || index >= length {
+			return nil, fmt.Errorf("index %v out of range for axis %v with "+
+				"length %v", index, axis, length)
+		}
+
+		o := p / (indexLength * inner)
+		e := p % inner
+		positions[p] = (o*length+index)*inner + e
+	}
+
+	return positions, nil
+}
+
+// scatterAddDiffOp is the derivative of the scatter-add operation with
+// respect to the updates. The inputs to the op are the gradient of the
+// output of the scatterAddOp and the indices, and the op gathers the
+// gradient at the indices.
+type scatterAddDiffOp struct {
+	op *scatterAddOp
+}
+
+// Arity implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) Arity() int { return 2 }
+
+// Type implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: s.op.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+	indices := G.TensorType{
+		Dims: s.op.dims,
+		Of:   tensor.Int,
+	}
+
+	return hm.NewFnType(tt, indices, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (s *scatterAddDiffOp) String() string {
+	return fmt.Sprintf("ScatterAddDiff{axis=%v}()", s.op.axis)
+}
+
+// WriteHash implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) WriteHash(h hash.Hash) {
+	fmt.Fprint(h, s.String())
+}
+
+// Hashcode implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) Hashcode() uint32 { return SimpleHash(s) }
+
+// InferShape implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) InferShape(in ...G.DimSizer) (tensor.Shape,
+	error) {
+	err := CheckArity(s, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[1].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (s *scatterAddDiffOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := CheckArity(s, len(inputs)); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	grad, ok := inputs[0].(tensor.Tensor)
+	if !ok || grad == nil {
+		return nil, fmt.Errorf("do: expected gradient to be a tensor but "+
+			"got %T", inputs[0])
+	}
+	if v, ok := grad.(tensor.View); ok && v.IsMaterializable() {
+		grad = v.Materialize()
+	}
+
+	positions, err := scatterPositions(grad.Shape(), inputs[1], s.op.axis)
+	if err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	shape := inputs[1].Shape().Clone()
+	switch gradData := grad.Data().(type) {
+	case []float64:
+		out := make([]float64, len(positions))
+		for p, pos := range positions {
+			out[p] = gradData[pos]
+		}
+		return tensor.New(tensor.WithShape(shape...),
+			tensor.WithBacking(out)), nil
+	case []float32:
+		out := make([]float32, len(positions))
+		for p, pos := range positions {
+			out[p] = gradData[pos]
+		}
+		return tensor.New(tensor.WithShape(shape...),
+			tensor.WithBacking(out)), nil
+	}
+
+	return nil, fmt.Errorf("do: cannot compute gradient of type %v",
+		grad.Dtype())
+}
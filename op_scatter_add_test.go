@@ -0,0 +1,108 @@
+package gop
+
+import (
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestScatterAdd tests the forward and backward pass of ScatterAdd
+// along both axes of a matrix, with repeated indices whose updates
+// should accumulate
+func TestScatterAdd(t *testing.T) {
+	tests := []struct {
+		axis         int
+		xShape       []int
+		x            []float64
+		indexShape   []int
+		indices      []int
+		updates      []float64
+		target       []float64
+		upstream     []float64
+		updateTarget []float64
+	}{
+		{
+			axis:         0,
+			xShape:       []int{3, 2},
+			x:            []float64{1, 2, 3, 4, 5, 6},
+			indexShape:   []int{3, 2},
+			indices:      []int{0, 1, 2, 0, 0, 0},
+			updates:      []float64{10, 20, 30, 40, 50, 60},
+			target:       []float64{61, 102, 3, 24, 35, 6},
+			upstream:     []float64{1, 2, 3, 4, 5, 6},
+			updateTarget: []float64{1, 4, 5, 2, 1, 2},
+		},
+		{
+			axis:         -1,
+			xShape:       []int{2, 3},
+			x:            []float64{0, 0, 0, 0, 0, 0},
+			indexShape:   []int{2, 2},
+			indices:      []int{2, 2, 0, 1},
+			updates:      []float64{1, 2, 3, 4},
+			target:       []float64{0, 0, 3, 3, 4, 0},
+			upstream:     []float64{1, 2, 3, 4, 5, 6},
+			updateTarget: []float64{3, 3, 4, 5},
+		},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		x := G.NewMatrix(g, tensor.Float64, G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, test.xShape,
+				tensor.WithBacking(append([]float64{}, test.x...)))))
+		indices := G.NewMatrix(g, tensor.Int, G.WithName("indices"),
+			G.WithValue(tensor.NewDense(tensor.Int, test.indexShape,
+				tensor.WithBacking(test.indices))))
+		updates := G.NewMatrix(g, tensor.Float64, G.WithName("updates"),
+			G.WithValue(tensor.NewDense(tensor.Float64, test.indexShape,
+				tensor.WithBacking(append([]float64{}, test.updates...)))))
+		w := G.NewMatrix(g, tensor.Float64, G.WithName("w"),
+			G.WithValue(tensor.NewDense(tensor.Float64, test.xShape,
+				tensor.WithBacking(append([]float64{}, test.upstream...)))))
+
+		out, err := ScatterAdd(x, indices, updates, test.axis)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		// The upstream gradient of the output is w
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(out, w))))
+		grads, err := G.Grad(loss, x, updates)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var xGrad, updatesGrad G.Value
+		G.Read(grads[0], &xGrad)
+		G.Read(grads[1], &updatesGrad)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		for i, v := range outVal.Data().([]float64) {
+			if v != test.target[i] {
+				t.Errorf("incorrect value at index %d along axis %v "+
+					"\nexpected: %v \nreceived: %v", i, test.axis,
+					test.target[i], v)
+			}
+		}
+		for i, v := range xGrad.Data().([]float64) {
+			if v != test.upstream[i] {
+				t.Errorf("incorrect gradient of x at index %d \nexpected: "+
+					"%v \nreceived: %v", i, test.upstream[i], v)
+			}
+		}
+		for i, v := range updatesGrad.Data().([]float64) {
+			if v != test.updateTarget[i] {
+				t.Errorf("incorrect gradient of updates at index %d "+
+					"\nexpected: %v \nreceived: %v", i, test.updateTarget[i],
+					v)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package gop
+
+// newSignOp returns a new pointwise operation which computes the sign
+// of its input. Since the sign function is piecewise constant, its
+// derivative is defined to be 0 everywhere, including at 0 where the
+// sign function is not differentiable.
+func newSignOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Sign",
+		f64:  sign,
+		f32:  func(x float32) float32 { return float32(sign(float64(x))) },
+		df64: func(float64) float64 { return 0 },
+		df32: func(float32) float32 { return 0 },
+	}
+}
+
+// sign returns -1 if x < 0, 1 if x > 0, and x otherwise, so that the
+// sign of ±0 is ±0 and the sign of NaN is NaN
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	} else if x > 0 {
+		return 1
+	}
+	return x
+}
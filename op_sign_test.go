@@ -0,0 +1,60 @@
+package gop
+
+import (
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestSign(t *testing.T) {
+	df := func(float64) float64 { return 0 }
+	input := func() float64 { return (rand.Float64() - 0.5) * 10.0 }
+
+	testPointwise(t, "Sign", Sign, sign, df, input)
+}
+
+// TestSignZero tests Sign on a tensor spanning negative, zero, and
+// positive values and ensures the gradient is all zeros
+func TestSignZero(t *testing.T) {
+	in := []float64{-3.5, -1e-10, 0, 0, 1e-10, 2, 100}
+	target := []float64{-1, -1, 0, 0, 1, 1, 1}
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(tensor.Float64, []int{len(in)},
+		tensor.WithBacking(in))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(inTensor))
+
+	out, err := Sign(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal G.Value
+	G.Read(out, &outVal)
+
+	grad, err := G.Grad(G.Must(G.Sum(out)), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gradVal G.Value
+	G.Read(grad[0], &gradVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range outVal.Data().([]float64) {
+		if v != target[i] {
+			t.Errorf("incorrect sign of %v\nexpected: %v\nreceived: %v",
+				in[i], target[i], v)
+		}
+	}
+	for i, v := range gradVal.Data().([]float64) {
+		if v != 0 {
+			t.Errorf("expected zero gradient at %v but got %v", in[i], v)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package gop
+
+import "math"
+
+// newSoftplusOp returns a new pointwise operation which computes the
+// softplus function, log(1 + exp(x)). The derivative of the softplus
+// function is the sigmoid function.
+func newSoftplusOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Softplus",
+		f64:  softplus,
+		f32:  func(x float32) float32 { return float32(softplus(float64(x))) },
+		df64: sigmoid,
+		df32: func(x float32) float32 { return float32(sigmoid(float64(x))) },
+	}
+}
+
+// softplus returns log(1 + exp(x)), computed as
+// log1p(exp(-|x|)) + max(x, 0) so that exp(x) does not overflow for
+// large x
+func softplus(x float64) float64 {
+	return math.Log1p(math.Exp(-math.Abs(x))) + math.Max(x, 0)
+}
+
+// sigmoid returns 1 / (1 + exp(-x)), computed so that exp does not
+// overflow for inputs of large magnitude
+func sigmoid(x float64) float64 {
+	if x >= 0 {
+		return 1 / (1 + math.Exp(-x))
+	}
+	exp := math.Exp(x)
+	return exp / (1 + exp)
+}
@@ -0,0 +1,68 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestSoftplus(t *testing.T) {
+	f := func(x float64) float64 { return math.Log1p(math.Exp(x)) }
+	df := func(x float64) float64 { return finiteDifference(f, x) }
+	input := func() float64 { return (rand.Float64() - 0.5) * 20.0 }
+
+	testPointwise(t, "Softplus", Softplus, f, df, input)
+}
+
+// TestSoftplusOverflow tests that Softplus and its gradient do not
+// overflow for inputs of large magnitude
+func TestSoftplusOverflow(t *testing.T) {
+	const tolerance float64 = 0.0001 // Threshold to consider floats equal
+
+	in := []float64{-100, 100, 1000}
+	target := []float64{math.Exp(-100), 100, 1000}
+	targetGrad := []float64{0, 1, 1}
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(tensor.Float64, []int{len(in)},
+		tensor.WithBacking(in))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(inTensor))
+
+	out, err := Softplus(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal G.Value
+	G.Read(out, &outVal)
+
+	grad, err := G.Grad(G.Must(G.Sum(out)), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gradVal G.Value
+	G.Read(grad[0], &gradVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range outVal.Data().([]float64) {
+		if math.IsInf(v, 0) || math.IsNaN(v) ||
+			math.Abs(v-target[i]) > tolerance {
+			t.Errorf("incorrect softplus of %v\nexpected: %v\nreceived: %v",
+				in[i], target[i], v)
+		}
+	}
+	for i, v := range gradVal.Data().([]float64) {
+		if math.IsInf(v, 0) || math.IsNaN(v) ||
+			math.Abs(v-targetGrad[i]) > tolerance {
+			t.Errorf("incorrect softplus gradient at %v\nexpected: %v\n"+
+				"received: %v", in[i], targetGrad[i], v)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package gop
+
+// newSquareOp returns a new pointwise operation which computes x². The
+// derivative of the square function is 2x.
+func newSquareOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "Square",
+		f64:  func(x float64) float64 { return x * x },
+		f32:  func(x float32) float32 { return x * x },
+		df64: func(x float64) float64 { return 2 * x },
+		df32: func(x float32) float32 { return 2 * x },
+	}
+}
@@ -0,0 +1,76 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestSquare(t *testing.T) {
+	f := func(x float64) float64 { return x * x }
+	df := func(x float64) float64 { return 2 * x }
+	input := func() float64 { return (rand.Float64() - 0.5) * 10.0 }
+
+	testPointwise(t, "Square", Square, f, df, input)
+	testPointwise(t, "Pow2", Pow2, f, df, input)
+}
+
+// TestSquarePow tests that Square is at least as accurate as Pow(x, 2)
+// on values near zero, where the square is exactly representable, and
+// that its gradient is exactly 2x
+func TestSquarePow(t *testing.T) {
+	in := []float64{0, 1e-160, -1e-160, 1e-100, -3e-8, 1e-5, 0.5, -0.75}
+
+	g := G.NewGraph()
+	inTensor := tensor.NewDense(tensor.Float64, []int{len(in)},
+		tensor.WithBacking(in))
+	x := G.NewVector(g, tensor.Float64, G.WithValue(inTensor),
+		G.WithName("x"))
+
+	square, err := Square(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pow, err := G.Pow(x, G.NewConstant(2.0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var squareVal, powVal G.Value
+	G.Read(square, &squareVal)
+	G.Read(pow, &powVal)
+
+	grad, err := G.Grad(G.Must(G.Sum(square)), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gradVal G.Value
+	G.Read(grad[0], &gradVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	squareData := squareVal.Data().([]float64)
+	powData := powVal.Data().([]float64)
+	gradData := gradVal.Data().([]float64)
+	for i := range in {
+		target := in[i] * in[i]
+		if squareData[i] != target {
+			t.Errorf("incorrect square of %v\nexpected: %v\nreceived: %v",
+				in[i], target, squareData[i])
+		}
+		if math.Abs(squareData[i]-target) > math.Abs(powData[i]-target) {
+			t.Errorf("square of %v less accurate than Pow: %v vs %v",
+				in[i], squareData[i], powData[i])
+		}
+		if gradData[i] != 2*in[i] {
+			t.Errorf("incorrect gradient at %v\nexpected: %v\nreceived: %v",
+				in[i], 2*in[i], gradData[i])
+		}
+	}
+}
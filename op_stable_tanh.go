@@ -0,0 +1,29 @@
+package gop
+
+import (
+	"fmt"
+	"math"
+)
+
+// newStableTanhOp returns a new pointwise operation which computes the
+// hyperbolic tangent clamped to [-1+eps, 1-eps]. The derivative is
+// computed as 1 - y², where y is the clamped output, rather than from
+// the unclamped tanh. It is therefore at least 2eps - eps² > 0, and
+// saturated elements still receive a gradient.
+func newStableTanhOp(eps float64) *pointwiseOp {
+	f64 := func(x float64) float64 {
+		return math.Max(-1+eps, math.Min(1-eps, math.Tanh(x)))
+	}
+	df64 := func(x float64) float64 {
+		y := f64(x)
+		return 1 - y*y
+	}
+
+	return &pointwiseOp{
+		name: fmt.Sprintf("StableTanh{eps=%v}", eps),
+		f64:  f64,
+		f32:  func(x float32) float32 { return float32(f64(float64(x))) },
+		df64: df64,
+		df32: func(x float32) float32 { return float32(df64(float64(x))) },
+	}
+}
@@ -0,0 +1,121 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// TestStableTanh tests that the outputs of StableTanh never reach ±1
+// and that its gradients remain finite and non-zero for large-magnitude
+// inputs
+func TestStableTanh(t *testing.T) {
+	const eps float64 = 1e-6
+	const size int = 50
+
+	for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+		backing64 := make([]float64, size)
+		for i := range backing64 {
+			// Large-magnitude inputs of both signs, for which Gorgonia's
+			// tanh saturates
+			backing64[i] = (rand.Float64() - 0.5) * 2000.0
+		}
+		backing64[0], backing64[1], backing64[2] = 0, 1e6, -1e6
+		backing64[3], backing64[4] = 0.5, -2
+
+		var inTensor *tensor.Dense
+		if dt == tensor.Float64 {
+			inTensor = tensor.NewDense(dt, []int{size},
+				tensor.WithBacking(backing64))
+		} else {
+			backing32 := make([]float32, size)
+			for i := range backing32 {
+				backing32[i] = float32(backing64[i])
+			}
+			inTensor = tensor.NewDense(dt, []int{size},
+				tensor.WithBacking(backing32))
+		}
+
+		g := G.NewGraph()
+		in := G.NewVector(g, dt, G.WithValue(inTensor))
+
+		out, err := StableTanh(in, eps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		grad, err := G.Grad(G.Must(G.Sum(out)), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		outData := make([]float64, size)
+		gradData := make([]float64, size)
+		switch dt {
+		case tensor.Float64:
+			copy(outData, outVal.Data().([]float64))
+			copy(gradData, gradVal.Data().([]float64))
+		case tensor.Float32:
+			for i := 0; i < size; i++ {
+				outData[i] = float64(outVal.Data().([]float32)[i])
+				gradData[i] = float64(gradVal.Data().([]float32)[i])
+			}
+		}
+
+		for i := 0; i < size; i++ {
+			if outData[i] <= -1 || outData[i] >= 1 {
+				t.Errorf("%v: output %v at input %v reached ±1", dt,
+					outData[i], backing64[i])
+			}
+			if math.IsNaN(gradData[i]) || math.IsInf(gradData[i], 0) {
+				t.Errorf("%v: gradient %v at input %v is not finite", dt,
+					gradData[i], backing64[i])
+			}
+
+			// The gradient is 1 - y², which is at least 2eps - eps² for
+			// saturated inputs rather than exactly 0. Float32 outputs near
+			// ±1 are rounded, so y² is only compared loosely.
+			target := 1 - outData[i]*outData[i]
+			tolerance := 0.001
+			if dt == tensor.Float32 {
+				tolerance = 0.05
+			}
+			if gradData[i] <= 0 {
+				t.Errorf("%v: gradient %v at input %v is not positive", dt,
+					gradData[i], backing64[i])
+			} else if math.Abs(gradData[i]-target) > tolerance*target {
+				t.Errorf("%v: expected gradient %v at input %v but got %v",
+					dt, target, backing64[i], gradData[i])
+			}
+			if math.Abs(backing64[i]) > 100 &&
+				math.Abs(gradData[i]-(2*eps-eps*eps)) > 0.1*eps {
+				t.Errorf("%v: expected saturated gradient %v at input %v "+
+					"but got %v", dt, 2*eps-eps*eps, backing64[i],
+					gradData[i])
+			}
+		}
+
+		vm.Close()
+	}
+
+	// Illegal eps
+	g := G.NewGraph()
+	in := G.NewScalar(g, tensor.Float64, G.WithValue(1.0))
+	for _, eps := range []float64{0, -0.1, 1} {
+		if _, err := StableTanh(in, eps); err == nil {
+			t.Errorf("expected error for eps = %v", eps)
+		}
+	}
+}
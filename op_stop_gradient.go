@@ -0,0 +1,14 @@
+package gop
+
+// newStopGradientOp returns a new pointwise operation which returns its
+// input unchanged, but whose derivative is defined to be 0 everywhere,
+// so that no gradient flows back through it to its input
+func newStopGradientOp() *pointwiseOp {
+	return &pointwiseOp{
+		name: "StopGradient",
+		f64:  func(x float64) float64 { return x },
+		f32:  func(x float32) float32 { return x },
+		df64: func(float64) float64 { return 0 },
+		df32: func(float32) float32 { return 0 },
+	}
+}
@@ -0,0 +1,14 @@
+package gop
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStopGradient(t *testing.T) {
+	f := func(x float64) float64 { return x }
+	df := func(float64) float64 { return 0 }
+	input := func() float64 { return (rand.Float64() - 0.5) * 10.0 }
+
+	testPointwise(t, "StopGradient", StopGradient, f, df, input)
+}
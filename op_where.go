@@ -0,0 +1,151 @@
+package gop
+
+import (
+	"fmt"
+	"hash"
+
+	"github.com/chewxy/hm"
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+// whereOp implements the where operation, which selects each element
+// of its output from one of two tensors based on a condition:
+//
+//		out[i] = a[i] if cond[i] != 0 else b[i]
+//
+// Unlike masking with products, such as cond * a + (1 - cond) * b,
+// elements of the unselected tensor never contribute to the output, and
+// so infinite or NaN elements of the unselected tensor do not
+// propagate.
+type whereOp struct {
+	dims int // Number of dimensions in the input nodes
+}
+
+// newWhereOp returns a new whereOp
+func newWhereOp(dims int) *whereOp {
+	return &whereOp{dims: dims}
+}
+
+// DiffWRT implements the gorgonia.SDOp interface. The whereOp is
+// differentiable with respect to a and b, but not the condition.
+func (w *whereOp) DiffWRT(inputs int) []bool {
+	return []bool{false, true, true}
+}
+
+// SymDiff implements the gorgonia.SDOp interface. The gradient with
+// respect to a is the incoming gradient where the condition holds and
+// 0 elsewhere, and the gradient with respect to b is the remainder of
+// the incoming gradient.
+func (w *whereOp) SymDiff(inputs G.Nodes, output,
+	grad *G.Node) (G.Nodes, error) {
+	err := CheckArity(w, len(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+
+	nodes := make(G.Nodes, 3)
+	nodes[1], err = G.HadamardProd(grad, inputs[0])
+	if err != nil {
+		return nil, fmt.Errorf("symDiff: %v", err)
+	}
+	nodes[2], err = G.Sub(grad, nodes[1])
+
+	return nodes, err
+}
+
+// Arity implements the gorgonia.Op interface
+func (w *whereOp) Arity() int { return 3 }
+
+// Type implements the gorgonia.Op interface
+func (w *whereOp) Type() hm.Type {
+	tt := G.TensorType{
+		Dims: w.dims,
+		Of:   hm.TypeVariable('a'),
+	}
+
+	return hm.NewFnType(tt, tt, tt, tt)
+}
+
+// OverwritesInput implements the gorgonia.Op interface
+func (w *whereOp) OverwritesInput() int { return -1 }
+
+// ReturnsPtr implements the gorgonia.Op interface
+func (w *whereOp) ReturnsPtr() bool { return false }
+
+// CallsExtern implements the gorgonia.Op interface
+func (w *whereOp) CallsExtern() bool { return false }
+
+// String implements the fmt.Stringer interface
+func (w *whereOp) String() string { return "Where()" }
+
+// WriteHash implements the gorgonia.Op interface
+func (w *whereOp) WriteHash(h hash.Hash) { fmt.Fprint(h, w.String()) }
+
+// Hashcode implements the gorgonia.Op interface
+func (w *whereOp) Hashcode() uint32 { return SimpleHash(w) }
+
+// InferShape implements the gorgonia.Op interface
+func (w *whereOp) InferShape(in ...G.DimSizer) (tensor.Shape, error) {
+	err := CheckArity(w, len(in))
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	shapes, err := G.DimSizersToShapes(in)
+	if err != nil {
+		return nil, fmt.Errorf("inferShape: %v", err)
+	}
+
+	return shapes[1].Clone(), nil
+}
+
+// Do implements the gorgonia.Op interface
+func (w *whereOp) Do(inputs ...G.Value) (G.Value, error) {
+	if err := CheckArity(w, len(inputs)); err != nil {
+		return nil, fmt.Errorf("do: %v", err)
+	}
+
+	tensors := make([]tensor.Tensor, len(inputs))
+	for i, input := range inputs {
+		t, ok := input.(tensor.Tensor)
+		if !ok || t == nil {
+			return nil, fmt.Errorf("do: expected input %v to be a tensor "+
+				"but got %T", i, input)
+		}
+		if !t.Shape().Eq(inputs[1].Shape()) {
+			return nil, fmt.Errorf("do: expected input %v to have shape %v "+
+				"but got %v", i, inputs[1].Shape(), t.Shape())
+		}
+		if v, ok := t.(tensor.View); ok && v.IsMaterializable() {
+			t = v.Materialize()
+		}
+		tensors[i] = t
+	}
+	cond, a, b := tensors[0], tensors[1], tensors[2]
+
+	out := b.Clone().(tensor.Tensor)
+	switch outData := out.Data().(type) {
+	case []float64:
+		condData := cond.Data().([]float64)
+		aData := a.Data().([]float64)
+		for i := range outData {
+			if condData[i] != 0 {
+				outData[i] = aData[i]
+			}
+		}
+	case []float32:
+		condData := cond.Data().([]float32)
+		aData := a.Data().([]float32)
+		for i := range outData {
+			if condData[i] != 0 {
+				outData[i] = aData[i]
+			}
+		}
+	default:
+		return nil, fmt.Errorf("do: cannot select from tensor of type %v",
+			b.Dtype())
+	}
+
+	return out, nil
+}
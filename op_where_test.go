@@ -0,0 +1,218 @@
+package gop
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	G "gorgonia.org/gorgonia"
+	"gorgonia.org/tensor"
+)
+
+func TestWhere(t *testing.T) {
+	const tests int = 10     // Number of random tests to run
+	const maxDims int = 4    // Maximum number of dimensions
+	const maxDimSize int = 4 // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+
+		// Place infinities in a wherever it is not selected, which
+		// should not propagate to the output or gradients
+		condBacking := make([]float64, size)
+		aBacking := randF64(size, -5, 5)
+		bBacking := randF64(size, -5, 5)
+		weights := randF64(size, -2, 2)
+		for j := range condBacking {
+			if rand.Intn(2) == 0 {
+				condBacking[j] = 1
+			} else {
+				aBacking[j] = math.Inf(-1)
+			}
+		}
+
+		g := G.NewGraph()
+		newNode := func(backing []float64, name string) *G.Node {
+			return G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithName(name), G.WithValue(tensor.NewDense(tensor.Float64,
+					shape, tensor.WithBacking(backing))))
+		}
+		cond := newNode(condBacking, "cond")
+		a := newNode(aBacking, "a")
+		b := newNode(bBacking, "b")
+		w := newNode(weights, "w")
+
+		out, err := Where(cond, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		loss := G.Must(G.Sum(G.Must(G.HadamardProd(out, w))))
+		grads, err := G.Grad(loss, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var aGradVal, bGradVal G.Value
+		G.Read(grads[0], &aGradVal)
+		G.Read(grads[1], &bGradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !outVal.Shape().Eq(tensor.Shape(shape)) {
+			t.Fatalf("incorrect shape \nexpected: %v \nreceived: %v", shape,
+				outVal.Shape())
+		}
+
+		computed := outVal.Data().([]float64)
+		aGrad := aGradVal.Data().([]float64)
+		bGrad := bGradVal.Data().([]float64)
+		for j := range computed {
+			target, aTarget, bTarget := bBacking[j], 0.0, weights[j]
+			if condBacking[j] != 0 {
+				target, aTarget, bTarget = aBacking[j], weights[j], 0.0
+			}
+
+			if computed[j] != target {
+				t.Errorf("incorrect output at index %d \nexpected: %v "+
+					"\nreceived: %v", j, target, computed[j])
+			}
+			if aGrad[j] != aTarget {
+				t.Errorf("incorrect gradient for a at index %d \nexpected: "+
+					"%v \nreceived: %v", j, aTarget, aGrad[j])
+			}
+			if bGrad[j] != bTarget {
+				t.Errorf("incorrect gradient for b at index %d \nexpected: "+
+					"%v \nreceived: %v", j, bTarget, bGrad[j])
+			}
+		}
+	}
+}
+
+// TestWhereShape tests that Where returns an error when its inputs do
+// not have the same shape or type
+func TestWhereShape(t *testing.T) {
+	g := G.NewGraph()
+	newNode := func(dt tensor.Dtype, shape ...int) *G.Node {
+		return G.NewTensor(g, dt, len(shape), G.WithShape(shape...),
+			G.WithInit(G.Zeroes()))
+	}
+
+	tests := [][3]*G.Node{
+		{newNode(tensor.Float64, 2, 3), newNode(tensor.Float64, 2, 3),
+			newNode(tensor.Float64, 3, 2)},
+		{newNode(tensor.Float64, 2), newNode(tensor.Float64, 3),
+			newNode(tensor.Float64, 3)},
+		{newNode(tensor.Float32, 3), newNode(tensor.Float64, 3),
+			newNode(tensor.Float64, 3)},
+	}
+
+	for _, test := range tests {
+		if _, err := Where(test[0], test[1], test[2]); err == nil {
+			t.Errorf("expected error for inputs of shapes %v, %v, %v and "+
+				"types %v, %v, %v", test[0].Shape(), test[1].Shape(),
+				test[2].Shape(), test[0].Dtype(), test[1].Dtype(),
+				test[2].Dtype())
+		}
+	}
+}
+
+// TestPiecewise tests Piecewise on the piecewise linear function
+//
+//	f(x) = 2x + 1    if x < 0
+//	f(x) = 1 - 3x    otherwise
+//
+// checking its values and gradient on both sides of the boundary
+func TestPiecewise(t *testing.T) {
+	const tolerance float64 = 0.00001
+	in := []float64{-2, -0.5, -1e-3, 0, 1e-3, 0.5, 2}
+
+	g := G.NewGraph()
+	x := G.NewVector(g, tensor.Float64, G.WithName("x"),
+		G.WithValue(tensor.NewDense(tensor.Float64, []int{len(in)},
+			tensor.WithBacking(in))))
+	zero := G.NewConstant(0.0)
+	one := G.NewConstant(1.0)
+
+	cond, err := G.Lt(x, zero, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Piecewise(cond, func() (*G.Node, error) {
+		return G.Add(G.Must(G.HadamardProd(G.NewConstant(2.0), x)), one)
+	}, func() (*G.Node, error) {
+		return G.Sub(one, G.Must(G.HadamardProd(G.NewConstant(3.0), x)))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outVal G.Value
+	G.Read(out, &outVal)
+
+	grads, err := G.Grad(G.Must(G.Sum(out)), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gradVal G.Value
+	G.Read(grads[0], &gradVal)
+
+	vm := G.NewTapeMachine(g)
+	defer vm.Close()
+	if err := vm.RunAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	computed := outVal.Data().([]float64)
+	grad := gradVal.Data().([]float64)
+	for i, v := range in {
+		target, gradTarget := 1-3*v, -3.0
+		if v < 0 {
+			target, gradTarget = 2*v+1, 2.0
+		}
+
+		if math.Abs(computed[i]-target) > tolerance {
+			t.Errorf("incorrect value at %v \nexpected: %v \nreceived: %v",
+				v, target, computed[i])
+		}
+		if math.Abs(grad[i]-gradTarget) > tolerance {
+			t.Errorf("incorrect gradient at %v \nexpected: %v \nreceived: %v",
+				v, gradTarget, grad[i])
+		}
+	}
+}
+
+// TestPiecewiseError tests that Piecewise returns the errors of its
+// branches
+func TestPiecewiseError(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewVector(g, tensor.Float64, G.WithShape(3),
+		G.WithInit(G.Zeroes()))
+	y := G.NewVector(g, tensor.Float64, G.WithShape(2),
+		G.WithInit(G.Zeroes()))
+
+	branch := func() (*G.Node, error) { return x, nil }
+	badBranch := func() (*G.Node, error) { return G.Add(x, y) }
+
+	if _, err := Piecewise(x, branch, badBranch); err == nil {
+		t.Error("expected error from the false branch")
+	}
+	if _, err := Piecewise(x, badBranch, branch); err == nil {
+		t.Error("expected error from the true branch")
+	}
+	if _, err := Piecewise(x, branch, func() (*G.Node, error) {
+		return y, nil
+	}); err == nil {
+		t.Error("expected error for branches with different shapes")
+	}
+}
@@ -1,6 +1,7 @@
 package gop
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
@@ -703,8 +704,13 @@ func TestSqueeze(t *testing.T) {
 			shape[i] = 1 + rand.Intn(maxDimSize-1) // Avoid dimension size 0
 		}
 
-		// Get a random axis to squeeze
-		axis := rand.Intn(len(shape))
+		// Get a random axis to squeeze, which may be negative, as well
+		// as its non-negative equivalent
+		squeezeAxis := rand.Intn(2*len(shape)) - len(shape)
+		axis := squeezeAxis
+		if axis < 0 {
+			axis += len(shape)
+		}
 
 		// Create the backing tensor
 		backing := make([]float64, tensor.ProdInts(shape))
@@ -727,9 +733,17 @@ func TestSqueeze(t *testing.T) {
 			len(shape),
 			G.WithValue(inTensor),
 		)
-		computedNode, err := Squeeze(in, axis)
+		computedNode, err := Squeeze(in, squeezeAxis)
 		if err != nil {
-			t.Error(err)
+			t.Fatal(err)
+		}
+
+		// Out of range axes should return an error rather than panic
+		for _, outOfRange := range []int{len(shape), -len(shape) - 1} {
+			if _, err := Squeeze(in, outOfRange); err == nil {
+				t.Errorf("expected error squeezing axis %v of shape %v",
+					outOfRange, shape)
+			}
 		}
 		var computed G.Value
 		G.Read(computedNode, &computed)
@@ -773,6 +787,55 @@ func TestSqueeze(t *testing.T) {
 	}
 }
 
+// TestSqueezeAllScalar tests that SqueezeAll squeezes a tensor whose
+// dimensions all have length 1 to a scalar, preserving its value
+func TestSqueezeAllScalar(t *testing.T) {
+	shapes := [][]int{{1}, {1, 1}, {1, 1, 1}}
+	rand.Seed(time.Now().UnixNano())
+
+	for _, shape := range shapes {
+		value := rand.Float64()
+
+		g := G.NewGraph()
+		in := G.NewTensor(g, tensor.Float64, len(shape),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking([]float64{value}))))
+
+		out, err := SqueezeAll(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !out.IsScalar() {
+			t.Errorf("expected shape %v to squeeze to a scalar but got "+
+				"shape %v", shape, out.Shape())
+		}
+		var outVal G.Value
+		G.Read(out, &outVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Close()
+
+		if !outVal.Shape().IsScalar() {
+			t.Errorf("expected shape %v to squeeze to a scalar value but "+
+				"got shape %v", shape, outVal.Shape())
+		}
+		if outVal.Data().(float64) != value {
+			t.Errorf("expected value %v but got %v", value, outVal.Data())
+		}
+
+		// Squeezing a scalar is a no-op
+		scalar, err := SqueezeAll(out)
+		if err != nil {
+			t.Error(err)
+		} else if scalar != out {
+			t.Errorf("expected squeezing a scalar to be a no-op")
+		}
+	}
+}
+
 // TestUnsqueeze tests the Unsqueeze function
 func TestUnsqueeze(t *testing.T) {
 	// Test parameters
@@ -855,3 +918,1543 @@ func TestUnsqueeze(t *testing.T) {
 		vm.Close()
 	}
 }
+
+// TestNegativeAxis tests that the reduce, Squeeze, and Unsqueeze
+// functions treat negative axes as counting from the last dimension
+// by comparing against their positive-axis equivalents.
+func TestNegativeAxis(t *testing.T) {
+	const tolerance float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 20              // Number of tests to run
+	const maxDims int = 5             // Maximum number of tensor dimensions
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	type reduction struct {
+		name string
+		f    func(*G.Node, int) (*G.Node, error)
+		dims func(int) int // Number of dims of the valid axis range
+	}
+	reductions := []reduction{
+		{"ReduceMean", func(x *G.Node, axis int) (*G.Node, error) {
+			return ReduceMean(x, axis, true)
+		}, func(d int) int { return d }},
+		{"ReduceAdd", func(x *G.Node, axis int) (*G.Node, error) {
+			return ReduceAdd(x, axis, true)
+		}, func(d int) int { return d }},
+		{"ReduceProd", func(x *G.Node, axis int) (*G.Node, error) {
+			return ReduceProd(x, axis, false)
+		}, func(d int) int { return d }},
+		{"Squeeze", Squeeze, func(d int) int { return d }},
+		{"Unsqueeze", Unsqueeze, func(d int) int { return d + 1 }},
+	}
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+
+		for _, r := range reductions {
+			dims := r.dims(len(shape))
+			negAxis := -(1 + rand.Intn(dims))
+			posAxis := negAxis + dims
+
+			g := G.NewGraph()
+			inTensor := tensor.NewDense(
+				tensor.Float64,
+				shape,
+				tensor.WithBacking(randF64(tensor.ProdInts(shape), -1, 1)),
+			)
+			in := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithValue(inTensor))
+
+			negNode, err := r.f(in, negAxis)
+			if err != nil {
+				t.Fatalf("%v: %v", r.name, err)
+			}
+			posNode, err := r.f(in, posAxis)
+			if err != nil {
+				t.Fatalf("%v: %v", r.name, err)
+			}
+			var negVal, posVal G.Value
+			G.Read(negNode, &negVal)
+			G.Read(posNode, &posVal)
+
+			if _, err := r.f(in, -dims-1); err == nil {
+				t.Errorf("%v: expected error for axis %v with %v dims",
+					r.name, -dims-1, len(shape))
+			}
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !negVal.Shape().Eq(posVal.Shape()) {
+				t.Errorf("%v: shape with axis %v: %v but with axis %v: %v",
+					r.name, negAxis, negVal.Shape(), posAxis, posVal.Shape())
+			}
+
+			var negData, posData []float64
+			switch data := negVal.Data().(type) {
+			case float64:
+				negData = []float64{data}
+				posData = []float64{posVal.Data().(float64)}
+			case []float64:
+				negData = data
+				posData = posVal.Data().([]float64)
+			}
+			for j := range negData {
+				if math.Abs(negData[j]-posData[j]) > tolerance {
+					t.Errorf("%v: value with axis %v: %v but with axis "+
+						"%v: %v", r.name, negAxis, negData[j], posAxis,
+						posData[j])
+				}
+			}
+
+			vm.Close()
+		}
+	}
+}
+
+// TestReduceAxes tests that reducing a 4-D tensor over axes {1, 3} in
+// one call with ReduceAddAxes, ReduceMeanAxes, and ReduceProdAxes is
+// the same as sequential single-axis reductions.
+func TestReduceAxes(t *testing.T) {
+	const tolerance float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 10              // Number of tests to run
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	type reduction struct {
+		name   string
+		axes   func(*G.Node, []int, bool) (*G.Node, error)
+		single func(*G.Node, int, bool) (*G.Node, error)
+	}
+	reductions := []reduction{
+		{"ReduceAddAxes", ReduceAddAxes, ReduceAdd},
+		{"ReduceMeanAxes", ReduceMeanAxes, ReduceMean},
+		{"ReduceProdAxes", ReduceProdAxes, ReduceProd},
+	}
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 4)
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+
+		for _, r := range reductions {
+			for _, keepdims := range []bool{true, false} {
+				g := G.NewGraph()
+				inTensor := tensor.NewDense(
+					tensor.Float64,
+					shape,
+					tensor.WithBacking(randF64(tensor.ProdInts(shape), -1, 1)),
+				)
+				in := G.NewTensor(g, tensor.Float64, len(shape),
+					G.WithValue(inTensor))
+
+				computedNode, err := r.axes(in, []int{1, 3}, keepdims)
+				if err != nil {
+					t.Fatalf("%v: %v", r.name, err)
+				}
+
+				// Reduce axis 3 first, so that axis 1 is not shifted
+				targetNode, err := r.single(in, 3, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				targetNode, err = r.single(targetNode, 1, keepdims)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var computed, target G.Value
+				G.Read(computedNode, &computed)
+				G.Read(targetNode, &target)
+
+				vm := G.NewTapeMachine(g)
+				if err := vm.RunAll(); err != nil {
+					t.Fatal(err)
+				}
+
+				if keepdims && !computed.Shape().Eq(tensor.Shape{shape[0],
+					shape[2]}) {
+					t.Errorf("%v: expected shape %v but got %v", r.name,
+						tensor.Shape{shape[0], shape[2]}, computed.Shape())
+				}
+				if !computed.Shape().Eq(target.Shape()) {
+					t.Errorf("%v: expected shape %v but got %v", r.name,
+						target.Shape(), computed.Shape())
+				}
+
+				var computedData, targetData []float64
+				switch data := computed.Data().(type) {
+				case float64:
+					computedData = []float64{data}
+					targetData = []float64{target.Data().(float64)}
+				case []float64:
+					computedData = data
+					targetData = target.Data().([]float64)
+				}
+				for j := range computedData {
+					if math.Abs(computedData[j]-targetData[j]) > tolerance {
+						t.Errorf("%v: expected: %v received: %v", r.name,
+							targetData[j], computedData[j])
+					}
+				}
+
+				vm.Close()
+			}
+		}
+	}
+}
+
+// TestReduceLogSumExp tests the ReduceLogSumExp function against the
+// manual max + log(sum(exp(x-max))) with and without keepdims
+func TestReduceLogSumExp(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 20              // Number of tests to run
+	const maxDims int = 4             // Maximum number of dimensions
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+		data := randF64(size, -10, 10)
+
+		for axis := range shape {
+			// Compute the target log-sum-exp, where outer and inner are
+			// the number of elements before and after axis respectively
+			outer := tensor.ProdInts(shape[:axis])
+			inner := tensor.ProdInts(shape[axis+1:])
+			target := make([]float64, outer*inner)
+			for o := 0; o < outer; o++ {
+				for in := 0; in < inner; in++ {
+					max := math.Inf(-1)
+					for k := 0; k < shape[axis]; k++ {
+						max = math.Max(max, data[(o*shape[axis]+k)*inner+in])
+					}
+					sum := 0.0
+					for k := 0; k < shape[axis]; k++ {
+						sum += math.Exp(data[(o*shape[axis]+k)*inner+in] - max)
+					}
+					target[o*inner+in] = max + math.Log(sum)
+				}
+			}
+
+			for _, keepdims := range []bool{true, false} {
+				targetShape := make([]int, 0, len(shape)-1)
+				for j := range shape {
+					if j != axis && (keepdims || shape[j] != 1) {
+						targetShape = append(targetShape, shape[j])
+					}
+				}
+
+				g := G.NewGraph()
+				inTensor := tensor.NewDense(tensor.Float64, shape,
+					tensor.WithBacking(append([]float64{}, data...)))
+				in := G.NewTensor(g, tensor.Float64, len(shape),
+					G.WithValue(inTensor))
+
+				out, err := ReduceLogSumExp(in, axis, keepdims)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var outVal G.Value
+				G.Read(out, &outVal)
+
+				vm := G.NewTapeMachine(g)
+				if err := vm.RunAll(); err != nil {
+					t.Fatal(err)
+				}
+
+				if !outVal.Shape().Eq(tensor.Shape(targetShape)) {
+					t.Errorf("shape %v axis %v keepdims %v: expected shape "+
+						"%v but got %v", shape, axis, keepdims, targetShape,
+						outVal.Shape())
+				}
+
+				var computed []float64
+				switch d := outVal.Data().(type) {
+				case float64:
+					computed = []float64{d}
+				case []float64:
+					computed = d
+				}
+				for j := range target {
+					if math.Abs(computed[j]-target[j]) > threshold {
+						t.Errorf("expected: %v received: %v", target[j],
+							computed[j])
+					}
+				}
+
+				vm.Close()
+			}
+		}
+	}
+}
+
+// TestSoftmax tests the Softmax and LogSoftmax functions against the
+// manual stable softmax along each axis
+func TestSoftmax(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 15              // Number of tests to run
+	const maxDims int = 4             // Maximum number of dimensions
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+		data := randF64(size, -10, 10)
+
+		for axis := range shape {
+			// Compute the target log-softmax, where outer and inner are
+			// the number of elements before and after axis respectively
+			outer := tensor.ProdInts(shape[:axis])
+			inner := tensor.ProdInts(shape[axis+1:])
+			length := shape[axis]
+			target := make([]float64, size)
+			for o := 0; o < outer; o++ {
+				for in := 0; in < inner; in++ {
+					max := math.Inf(-1)
+					for k := 0; k < length; k++ {
+						max = math.Max(max, data[(o*length+k)*inner+in])
+					}
+					sum := 0.0
+					for k := 0; k < length; k++ {
+						sum += math.Exp(data[(o*length+k)*inner+in] - max)
+					}
+					for k := 0; k < length; k++ {
+						index := (o*length+k)*inner + in
+						target[index] = data[index] - max - math.Log(sum)
+					}
+				}
+			}
+
+			g := G.NewGraph()
+			inTensor := tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, data...)))
+			in := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithValue(inTensor))
+
+			logSoftmax, err := LogSoftmax(in, axis)
+			if err != nil {
+				t.Fatal(err)
+			}
+			softmax, err := Softmax(in, axis)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var logSoftmaxVal, softmaxVal G.Value
+			G.Read(logSoftmax, &logSoftmaxVal)
+			G.Read(softmax, &softmaxVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			for _, val := range []G.Value{logSoftmaxVal, softmaxVal} {
+				if !val.Shape().Eq(tensor.Shape(shape)) {
+					t.Errorf("axis %v: expected shape %v but got %v", axis,
+						shape, val.Shape())
+				}
+			}
+
+			computedLog := logSoftmaxVal.Data().([]float64)
+			computed := softmaxVal.Data().([]float64)
+			for j := range target {
+				if math.Abs(computedLog[j]-target[j]) > threshold {
+					t.Errorf("incorrect log-softmax \nexpected: %v "+
+						"\nreceived: %v", target[j], computedLog[j])
+				}
+				if math.Abs(computed[j]-math.Exp(target[j])) > threshold {
+					t.Errorf("incorrect softmax \nexpected: %v "+
+						"\nreceived: %v", math.Exp(target[j]), computed[j])
+				}
+			}
+		}
+	}
+}
+
+// TestReduceVarStd tests the ReduceVar and ReduceStd functions, with
+// and without Bessel's correction and keepdims
+func TestReduceVarStd(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 15              // Number of tests to run
+	const maxDims int = 4             // Maximum number of dimensions
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		size := tensor.ProdInts(shape)
+		data := randF64(size, -10, 10)
+
+		for axis := range shape {
+			for _, unbiased := range []bool{true, false} {
+				// Compute the target variance, where outer and inner are
+				// the number of elements before and after axis
+				outer := tensor.ProdInts(shape[:axis])
+				inner := tensor.ProdInts(shape[axis+1:])
+				length := shape[axis]
+				target := make([]float64, outer*inner)
+				for o := 0; o < outer; o++ {
+					for in := 0; in < inner; in++ {
+						mean := 0.0
+						for k := 0; k < length; k++ {
+							mean += data[(o*length+k)*inner+in]
+						}
+						mean /= float64(length)
+
+						sum := 0.0
+						for k := 0; k < length; k++ {
+							dev := data[(o*length+k)*inner+in] - mean
+							sum += dev * dev
+						}
+						if unbiased {
+							target[o*inner+in] = sum / float64(length-1)
+						} else {
+							target[o*inner+in] = sum / float64(length)
+						}
+					}
+				}
+
+				for _, keepdims := range []bool{true, false} {
+					targetShape := make([]int, 0, len(shape)-1)
+					for j := range shape {
+						if j != axis && (keepdims || shape[j] != 1) {
+							targetShape = append(targetShape, shape[j])
+						}
+					}
+
+					g := G.NewGraph()
+					inTensor := tensor.NewDense(tensor.Float64, shape,
+						tensor.WithBacking(append([]float64{}, data...)))
+					in := G.NewTensor(g, tensor.Float64, len(shape),
+						G.WithValue(inTensor))
+
+					variance, err := ReduceVar(in, axis, keepdims, unbiased)
+					if err != nil {
+						t.Fatal(err)
+					}
+					std, err := ReduceStd(in, axis, keepdims, unbiased)
+					if err != nil {
+						t.Fatal(err)
+					}
+					var varVal, stdVal G.Value
+					G.Read(variance, &varVal)
+					G.Read(std, &stdVal)
+
+					vm := G.NewTapeMachine(g)
+					if err := vm.RunAll(); err != nil {
+						t.Fatal(err)
+					}
+					vm.Close()
+
+					for _, val := range []G.Value{varVal, stdVal} {
+						if !val.Shape().Eq(tensor.Shape(targetShape)) {
+							t.Errorf("shape %v axis %v keepdims %v: expected "+
+								"shape %v but got %v", shape, axis, keepdims,
+								targetShape, val.Shape())
+						}
+					}
+
+					var computedVar, computedStd []float64
+					switch d := varVal.Data().(type) {
+					case float64:
+						computedVar = []float64{d}
+						computedStd = []float64{stdVal.Data().(float64)}
+					case []float64:
+						computedVar = d
+						computedStd = stdVal.Data().([]float64)
+					}
+					for j := range target {
+						if unbiased && length == 1 {
+							if !math.IsNaN(computedVar[j]) {
+								t.Errorf("expected unbiased variance along "+
+									"axis of length 1 to be NaN but got %v",
+									computedVar[j])
+							}
+							continue
+						}
+
+						if math.Abs(computedVar[j]-target[j]) > threshold {
+							t.Errorf("incorrect variance \nexpected: %v "+
+								"\nreceived: %v", target[j], computedVar[j])
+						}
+						targetStd := math.Sqrt(target[j])
+						if math.Abs(computedStd[j]-targetStd) > threshold {
+							t.Errorf("incorrect standard deviation "+
+								"\nexpected: %v \nreceived: %v", targetStd,
+								computedStd[j])
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestReduceVarStdGrad tests the gradients of ReduceVar and ReduceStd,
+// with and without Bessel's correction and keepdims, including along
+// an inner axis of a 4-D tensor and for a vector, where the standard
+// deviation is a 0-dim scalar
+func TestReduceVarStdGrad(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	tests := []struct {
+		shape []int
+		axis  int
+	}{
+		{[]int{5}, 0},
+		{[]int{2, 3}, 1},
+		{[]int{1, 2, 3, 1}, 2},
+		{[]int{2, 2, 3, 2}, 2},
+	}
+
+	for _, test := range tests {
+		for _, opts := range [][2]bool{{false, false}, {true, false},
+			{false, true}, {true, true}} {
+			testReduceVarStdGrad(t, test.shape, test.axis, opts[0], opts[1],
+				threshold)
+		}
+	}
+}
+
+// testReduceVarStdGrad tests the gradients of ReduceVar and ReduceStd
+// along axis of a random tensor of shape shape
+func testReduceVarStdGrad(t *testing.T, shape []int, axis int, keepdims,
+	unbiased bool, threshold float64) {
+	t.Helper()
+
+	data := randF64(tensor.ProdInts(shape), -10, 10)
+
+	// The gradient of Σw⋅var along axis with respect to x is
+	// 2w(x - mean) / d, and that of Σw⋅std is w(x - mean) / (d⋅std),
+	// where d is n, or n-1 with Bessel's correction, and outer and
+	// inner are the number of elements before and after axis
+	outer := tensor.ProdInts(shape[:axis])
+	inner := tensor.ProdInts(shape[axis+1:])
+	length := shape[axis]
+	d := float64(length)
+	if unbiased {
+		d--
+	}
+	weights := randF64(outer*inner, -1, 1)
+	if len(shape) == 1 {
+		weights[0] = 1
+	}
+	varTarget := make([]float64, len(data))
+	stdTarget := make([]float64, len(data))
+	for o := 0; o < outer; o++ {
+		for in := 0; in < inner; in++ {
+			mean, sumSq := 0.0, 0.0
+			for k := 0; k < length; k++ {
+				mean += data[(o*length+k)*inner+in]
+			}
+			mean /= float64(length)
+			for k := 0; k < length; k++ {
+				dev := data[(o*length+k)*inner+in] - mean
+				sumSq += dev * dev
+			}
+			std := math.Sqrt(sumSq / d)
+
+			w := weights[o*inner+in]
+			for k := 0; k < length; k++ {
+				j := (o*length+k)*inner + in
+				dev := data[j] - mean
+				varTarget[j] = 2 * w * dev / d
+				stdTarget[j] = w * dev / (d * std)
+			}
+		}
+	}
+
+	for _, reduce := range []struct {
+		name   string
+		f      func(*G.Node, int, bool, bool) (*G.Node, error)
+		target []float64
+	}{
+		{"ReduceVar", ReduceVar, varTarget},
+		{"ReduceStd", ReduceStd, stdTarget},
+	} {
+		g := G.NewGraph()
+		in := G.NewTensor(g, tensor.Float64, len(shape),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, data...)))),
+			G.WithName("in"))
+
+		out, err := reduce.f(in, axis, keepdims, unbiased)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The output of a vector is a 0-dim tensor, which Gorgonia
+		// cannot multiply by a scalar, and so its weight is fixed at 1
+		loss := out
+		if !out.IsScalar() {
+			w := G.NewTensor(g, tensor.Float64, out.Dims(),
+				G.WithValue(tensor.NewDense(tensor.Float64, out.Shape(),
+					tensor.WithBacking(append([]float64{}, weights...)))),
+				G.WithName("w"))
+			loss = G.Must(G.Sum(G.Must(G.HadamardProd(out, w))))
+		}
+
+		grad, err := G.Grad(loss, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gradVal G.Value
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		if err := vm.RunAll(); err != nil {
+			t.Fatalf("%v: %v", reduce.name, err)
+		}
+		vm.Close()
+
+		computed := gradVal.Data().([]float64)
+		for j := range reduce.target {
+			if math.Abs(computed[j]-reduce.target[j]) > threshold {
+				t.Errorf("%v shape %v axis %v keepdims %v unbiased %v: "+
+					"incorrect gradient at index %v \nexpected: %v "+
+					"\nreceived: %v", reduce.name, shape, axis, keepdims,
+					unbiased, j, reduce.target[j], computed[j])
+			}
+		}
+	}
+}
+
+// TestStandardize tests that Standardize returns values with zero mean
+// and unit standard deviation along axis, and that its gradient flows
+// through both the mean and the standard deviation. Since the sum of
+// the standardized values and the sum of their squares along axis are
+// constant, the gradients of both with respect to the input are 0.
+func TestStandardize(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	const tests int = 15              // Number of tests to run
+	const maxDims int = 4             // Maximum number of dimensions
+	const maxDimSize int = 4          // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	// The sums are only constant when eps is 0, and the random inputs
+	// never have zero standard deviation
+	const eps float64 = 0.0
+
+	losses := []struct {
+		name string
+		loss func(*G.Node) (*G.Node, error)
+	}{
+		{"sum", func(z *G.Node) (*G.Node, error) { return G.Sum(z) }},
+		{"sum of squares", func(z *G.Node) (*G.Node, error) {
+			sq, err := Square(z)
+			if err != nil {
+				return nil, err
+			}
+			return G.Sum(sq)
+		}},
+	}
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		axis := rand.Intn(len(shape))
+		shape[axis] = 2 + rand.Intn(maxDimSize)
+		data := randF64(tensor.ProdInts(shape), -10, 10)
+
+		// Use a negative axis for half the tests
+		inAxis := axis
+		if i%2 == 1 {
+			inAxis = axis - len(shape)
+		}
+
+		for _, l := range losses {
+			g := G.NewGraph()
+			inTensor := tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, data...)))
+			in := G.NewTensor(g, tensor.Float64, len(shape),
+				G.WithValue(inTensor), G.WithName("in"))
+
+			z, err := Standardize(in, inAxis, eps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var zVal G.Value
+			G.Read(z, &zVal)
+
+			loss, err := l.loss(z)
+			if err != nil {
+				t.Fatal(err)
+			}
+			grad, err := G.Grad(loss, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gradVal G.Value
+			G.Read(grad[0], &gradVal)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatal(err)
+			}
+			vm.Close()
+
+			if !zVal.Shape().Eq(tensor.Shape(shape)) {
+				t.Errorf("expected shape %v but got %v", shape, zVal.Shape())
+			}
+
+			// Check the mean and standard deviation along axis, where
+			// outer and inner are the number of elements before and
+			// after axis
+			computed := zVal.Data().([]float64)
+			outer := tensor.ProdInts(shape[:axis])
+			inner := tensor.ProdInts(shape[axis+1:])
+			length := shape[axis]
+			for o := 0; o < outer; o++ {
+				for in := 0; in < inner; in++ {
+					mean, sumSq := 0.0, 0.0
+					for k := 0; k < length; k++ {
+						v := computed[(o*length+k)*inner+in]
+						mean += v
+						sumSq += v * v
+					}
+					mean /= float64(length)
+					std := math.Sqrt(sumSq/float64(length) - mean*mean)
+
+					if math.Abs(mean) > threshold {
+						t.Errorf("shape %v axis %v: expected zero mean but "+
+							"got %v", shape, axis, mean)
+					}
+					if math.Abs(std-1) > threshold {
+						t.Errorf("shape %v axis %v: expected unit standard "+
+							"deviation but got %v", shape, axis, std)
+					}
+				}
+			}
+
+			for j, g := range gradVal.Data().([]float64) {
+				if math.Abs(g) > threshold {
+					t.Errorf("shape %v axis %v: expected zero gradient of "+
+						"%v at index %v but got %v", shape, axis, l.name, j, g)
+				}
+			}
+		}
+	}
+}
+
+// TestStandardizeError tests that Standardize returns an error on
+// invalid inputs
+func TestStandardizeError(t *testing.T) {
+	g := G.NewGraph()
+	x := G.NewMatrix(g, tensor.Float64, G.WithShape(2, 3), G.WithName("x"))
+	scalar := G.NewScalar(g, tensor.Float64, G.WithName("scalar"))
+
+	if _, err := Standardize(scalar, 0, 1e-8); err == nil {
+		t.Error("expected error standardizing scalar but got nil")
+	}
+	if _, err := Standardize(x, 2, 1e-8); err == nil {
+		t.Error("expected error standardizing along out of range axis " +
+			"but got nil")
+	}
+	if _, err := Standardize(x, 0, -1); err == nil {
+		t.Error("expected error with negative eps but got nil")
+	}
+}
+
+// TestReduceInt tests the ReduceAdd, ReduceSub, and ReduceProd
+// functions on tensors of type tensor.Int, both with and without
+// keepdims
+func TestReduceInt(t *testing.T) {
+	// Test parameters
+	rand.Seed(time.Now().UnixNano())
+
+	const tests int = 20     // Number of tests to run
+	const maxDims int = 5    // Maximum number of tensor dimensions to test on
+	const maxDimSize int = 5 // Maximum number of elements per dimension
+
+	type reduction struct {
+		name   string
+		reduce func(*G.Node, int, bool) (*G.Node, error)
+		f      func(int, int) int
+	}
+	reductions := []reduction{
+		{"ReduceAdd", ReduceAdd, func(a, b int) int { return a + b }},
+		{"ReduceSub", ReduceSub, func(a, b int) int { return a - b }},
+		{"ReduceProd", ReduceProd, func(a, b int) int { return a * b }},
+	}
+
+	for i := 0; i < tests; i++ {
+		// Get a random shape and axis to reduce along
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		axis := rand.Intn(len(shape))
+
+		backing := make([]int, tensor.ProdInts(shape))
+		for j := range backing {
+			backing[j] = rand.Intn(7) - 3 // ∈ [-3, 3]
+		}
+
+		keepdims := rand.Intn(2) == 0
+
+		// Calculate target shape
+		targetShape := make(tensor.Shape, 0, len(shape)-1)
+		for j := range shape {
+			if j != axis && (keepdims || shape[j] != 1) {
+				targetShape = append(targetShape, shape[j])
+			}
+		}
+
+		for _, r := range reductions {
+			// Calculate target, where outer and inner are the number of
+			// elements before and after axis respectively
+			outer := tensor.ProdInts(shape[:axis])
+			inner := tensor.ProdInts(shape[axis+1:])
+			target := make([]int, outer*inner)
+			for o := 0; o < outer; o++ {
+				for in := 0; in < inner; in++ {
+					target[o*inner+in] = backing[o*shape[axis]*inner+in]
+					for k := 1; k < shape[axis]; k++ {
+						next := backing[(o*shape[axis]+k)*inner+in]
+						target[o*inner+in] = r.f(target[o*inner+in], next)
+					}
+				}
+			}
+
+			// Create the computational graph
+			g := G.NewGraph()
+			inTensor := tensor.NewDense(tensor.Int, shape,
+				tensor.WithBacking(append([]int{}, backing...)))
+			in := G.NewTensor(g, tensor.Int, len(shape),
+				G.WithValue(inTensor))
+
+			computedNode, err := r.reduce(in, axis, keepdims)
+			if err != nil {
+				t.Fatalf("%v: %v", r.name, err)
+			}
+			var computed G.Value
+			G.Read(computedNode, &computed)
+
+			vm := G.NewTapeMachine(g)
+			if err := vm.RunAll(); err != nil {
+				t.Fatalf("%v: %v", r.name, err)
+			}
+
+			// Ensure the output has the correct shape
+			if !targetShape.Eq(computed.Shape()) {
+				t.Errorf("%v: expected shape: %v \nreceived shape: %v\n",
+					r.name, targetShape, computed.Shape())
+			}
+
+			// Ensure the output has the correct values computed
+			var computedBacking []int
+			switch data := computed.Data().(type) {
+			case int:
+				computedBacking = []int{data}
+			case []int:
+				computedBacking = data
+			default:
+				t.Fatalf("%v: expected output of type int but got %T",
+					r.name, data)
+			}
+			for j := range target {
+				if target[j] != computedBacking[j] {
+					t.Errorf("%v: incorrect result computed \n\texpected: %v "+
+						"\n\treceived: %v\n", r.name, target[j],
+						computedBacking[j])
+				}
+			}
+
+			vm.Close()
+		}
+	}
+}
+
+// TestReduceShape tests that each reduction returns the documented
+// output shape for every axis, both with and without keepdims.
+func TestReduceShape(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	const tests int = 20     // Number of tests to run
+	const maxDims int = 4    // Maximum number of tensor dimensions to test on
+	const maxDimSize int = 3 // Maximum number of elements per dimension
+
+	reductions := map[string]func(*G.Node, int, bool) (*G.Node, error){
+		"ReduceMean":      ReduceMean,
+		"ReduceAdd":       ReduceAdd,
+		"ReduceSub":       ReduceSub,
+		"ReduceProd":      ReduceProd,
+		"ReduceDiv":       ReduceDiv,
+		"ReduceLogSumExp": ReduceLogSumExp,
+	}
+
+	// Scalars should be returned as is
+	for name, reduce := range reductions {
+		g := G.NewGraph()
+		in := G.NewScalar(g, tensor.Float64, G.WithValue(1.0))
+		for _, keepdims := range []bool{true, false} {
+			out, err := reduce(in, 0, keepdims)
+			if err != nil {
+				t.Fatalf("%v: %v", name, err)
+			}
+			if out.Dims() != 0 {
+				t.Errorf("%v: expected scalar output for scalar input but "+
+					"got shape %v", name, out.Shape())
+			}
+		}
+	}
+
+	for i := 0; i < tests; i++ {
+		// Dimensions of length 1 are common, so that squeezing is tested
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+
+		for axis := range shape {
+			for _, keepdims := range []bool{true, false} {
+				targetShape := make(tensor.Shape, 0, len(shape)-1)
+				for j := range shape {
+					if j != axis && (keepdims || shape[j] != 1) {
+						targetShape = append(targetShape, shape[j])
+					}
+				}
+
+				for name, reduce := range reductions {
+					g := G.NewGraph()
+					inTensor := tensor.NewDense(tensor.Float64, shape,
+						tensor.WithBacking(randF64(tensor.ProdInts(shape), 1, 2)))
+					in := G.NewTensor(g, tensor.Float64, len(shape),
+						G.WithValue(inTensor))
+
+					out, err := reduce(in, axis, keepdims)
+					if err != nil {
+						t.Fatalf("%v: %v", name, err)
+					}
+					var outVal G.Value
+					G.Read(out, &outVal)
+
+					vm := G.NewTapeMachine(g)
+					if err := vm.RunAll(); err != nil {
+						t.Fatalf("%v: %v", name, err)
+					}
+
+					if !out.Shape().Eq(targetShape) {
+						t.Errorf("%v(shape=%v, axis=%v, keepdims=%v): "+
+							"expected node shape %v but got %v", name, shape,
+							axis, keepdims, targetShape, out.Shape())
+					}
+					if !outVal.Shape().Eq(targetShape) {
+						t.Errorf("%v(shape=%v, axis=%v, keepdims=%v): "+
+							"expected value shape %v but got %v", name, shape,
+							axis, keepdims, targetShape, outVal.Shape())
+					}
+
+					vm.Close()
+				}
+			}
+		}
+	}
+}
+
+// weightedMean computes the weighted mean of data with the given shape
+// along axis, where weights has the same number of dimensions as shape
+// with each dimension equal to that of shape or 1
+func weightedMean(data, weights []float64, shape, weightShape []int,
+	axis int) []float64 {
+	outer := tensor.ProdInts(shape[:axis])
+	inner := tensor.ProdInts(shape[axis+1:])
+	length := shape[axis]
+
+	// weightIndex returns the index into weights of the element of data
+	// at the flat index i
+	weightIndex := func(i int) int {
+		index := 0
+		for d := len(shape) - 1; d >= 0; d-- {
+			coord := i % shape[d]
+			i /= shape[d]
+			if weightShape[d] == 1 {
+				coord = 0
+			}
+			index += coord * tensor.ProdInts(weightShape[d+1:])
+		}
+		return index
+	}
+
+	out := make([]float64, outer*inner)
+	for o := 0; o < outer; o++ {
+		for in := 0; in < inner; in++ {
+			num, denom := 0.0, 0.0
+			for k := 0; k < length; k++ {
+				i := (o*length+k)*inner + in
+				w := weights[weightIndex(i)]
+				num += w * data[i]
+				denom += w
+			}
+			out[o*inner+in] = num / denom
+		}
+	}
+	return out
+}
+
+// TestReduceWeightedMean tests the ReduceWeightedMean function and its
+// gradients with respect to both x and the weights, with vector,
+// broadcast, and full weights
+func TestReduceWeightedMean(t *testing.T) {
+	const threshold float64 = 1e-5 // Threshold to consider floats equal
+	const h float64 = 1e-5         // Step size for finite differences
+	const tests int = 15           // Number of tests to run
+	const maxDims int = 4          // Maximum number of dimensions
+	const maxDimSize int = 4       // Maximum number of elements per dimension
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		data := randF64(tensor.ProdInts(shape), -10, 10)
+
+		for axis := range shape {
+			for _, kind := range []string{"vector", "broadcast", "full"} {
+				// Construct the shape of the weights node and the shape
+				// the weights take when broadcast against x
+				var weightShape, broadcastShape []int
+				switch kind {
+				case "vector":
+					weightShape = []int{shape[axis]}
+					broadcastShape = make([]int, len(shape))
+					for j := range broadcastShape {
+						broadcastShape[j] = 1
+					}
+					broadcastShape[axis] = shape[axis]
+				case "broadcast":
+					weightShape = make([]int, len(shape))
+					for j := range weightShape {
+						weightShape[j] = shape[j]
+						if rand.Intn(2) == 0 {
+							weightShape[j] = 1
+						}
+					}
+					broadcastShape = weightShape
+				case "full":
+					weightShape = append([]int{}, shape...)
+					broadcastShape = weightShape
+				}
+				weights := randF64(tensor.ProdInts(weightShape), 0.5, 2)
+
+				target := weightedMean(data, weights, shape, broadcastShape,
+					axis)
+				scale := randF64(len(target), -2, 2)
+
+				for _, keepdims := range []bool{true, false} {
+					targetShape := make([]int, 0, len(shape)-1)
+					for j := range shape {
+						if j != axis && (keepdims || shape[j] != 1) {
+							targetShape = append(targetShape, shape[j])
+						}
+					}
+
+					g := G.NewGraph()
+					x := G.NewTensor(g, tensor.Float64, len(shape),
+						G.WithName("x"), G.WithValue(tensor.NewDense(
+							tensor.Float64, shape, tensor.WithBacking(
+								append([]float64{}, data...)))))
+					w := G.NewTensor(g, tensor.Float64, len(weightShape),
+						G.WithName("w"), G.WithValue(tensor.NewDense(
+							tensor.Float64, weightShape, tensor.WithBacking(
+								append([]float64{}, weights...)))))
+
+					out, err := ReduceWeightedMean(x, w, axis, keepdims)
+					if err != nil {
+						t.Fatal(err)
+					}
+					var outVal G.Value
+					G.Read(out, &outVal)
+
+					// Weight the output so that each element contributes
+					// differently to the gradient
+					s := G.NewVector(g, tensor.Float64, G.WithName("s"),
+						G.WithValue(tensor.NewDense(tensor.Float64,
+							[]int{len(scale)}, tensor.WithBacking(
+								append([]float64{}, scale...)))))
+					flat := G.Must(G.Reshape(out, []int{len(scale)}))
+					loss := G.Must(G.Sum(G.Must(G.HadamardProd(flat, s))))
+					grads, err := G.Grad(loss, x, w)
+					if err != nil {
+						t.Fatal(err)
+					}
+					var xGradVal, wGradVal G.Value
+					G.Read(grads[0], &xGradVal)
+					G.Read(grads[1], &wGradVal)
+
+					vm := G.NewTapeMachine(g)
+					if err := vm.RunAll(); err != nil {
+						t.Fatal(err)
+					}
+					vm.Close()
+
+					if !outVal.Shape().Eq(tensor.Shape(targetShape)) {
+						t.Errorf("shape %v axis %v keepdims %v: expected "+
+							"shape %v but got %v", shape, axis, keepdims,
+							targetShape, outVal.Shape())
+					}
+
+					var computed []float64
+					switch d := outVal.Data().(type) {
+					case float64:
+						computed = []float64{d}
+					case []float64:
+						computed = d
+					}
+					for j := range target {
+						if math.Abs(computed[j]-target[j]) > threshold {
+							t.Errorf("%v weights: incorrect weighted mean "+
+								"\nexpected: %v \nreceived: %v", kind,
+								target[j], computed[j])
+						}
+					}
+
+					// Check gradients with respect to x and the weights
+					inputs := [][]float64{data, weights}
+					gradVals := []G.Value{xGradVal, wGradVal}
+					for k, input := range inputs {
+						var gradData []float64
+						switch d := gradVals[k].Data().(type) {
+						case float64:
+							gradData = []float64{d}
+						case []float64:
+							gradData = d
+						}
+						for j, computed := range gradData {
+							orig := input[j]
+							input[j] = orig + h
+							upper := weightedSum(weightedMean(data, weights,
+								shape, broadcastShape, axis), scale)
+							input[j] = orig - h
+							lower := weightedSum(weightedMean(data, weights,
+								shape, broadcastShape, axis), scale)
+							input[j] = orig
+
+							gradTarget := (upper - lower) / (2 * h)
+							if math.Abs(computed-gradTarget) > threshold {
+								t.Errorf("%v weights: incorrect gradient "+
+									"for input %d at index %d \nexpected: "+
+									"%v \nreceived: %v", kind, k, j,
+									gradTarget, computed)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestReduceWeightedMeanShape tests that ReduceWeightedMean returns an
+// error when the weights cannot be broadcast against x
+func TestReduceWeightedMeanShape(t *testing.T) {
+	tests := []struct {
+		xShape, wShape []int
+		axis           int
+	}{
+		{[]int{3, 4}, []int{3}, 1},
+		{[]int{3, 4}, []int{4, 3}, 0},
+		{[]int{3, 4}, []int{2, 4}, 0},
+		{[]int{2, 3, 4}, []int{3, 4}, 1},
+		{[]int{3, 1}, []int{3, 2}, 0},
+	}
+
+	for _, test := range tests {
+		g := G.NewGraph()
+		x := G.NewTensor(g, tensor.Float64, len(test.xShape),
+			G.WithShape(test.xShape...), G.WithInit(G.Zeroes()))
+		w := G.NewTensor(g, tensor.Float64, len(test.wShape),
+			G.WithShape(test.wShape...), G.WithInit(G.Zeroes()))
+
+		if _, err := ReduceWeightedMean(x, w, test.axis, false); err == nil {
+			t.Errorf("expected error for x of shape %v and weights of "+
+				"shape %v along axis %v", test.xShape, test.wShape,
+				test.axis)
+		}
+	}
+}
+
+// TestReduceAllAny tests ReduceAll and ReduceAny on random binary
+// tensors of data type Float64 and Float32, where true elements are
+// random nonzero values, against a naive logical and/or along the
+// reduced axis. Both values of keepdims are tested, including on 1-D
+// tensors, where the result is a scalar.
+func TestReduceAllAny(t *testing.T) {
+	const tests int = 20     // Number of tests to run
+	const maxDims int = 4    // Maximum number of tensor dimensions
+	const maxDimSize int = 4 // Maximum number of elements per dimension
+	const pTrue float64 = 0.7
+	rand.Seed(time.Now().UnixNano())
+
+	for i := 0; i < tests; i++ {
+		shape := make([]int, 1+rand.Intn(maxDims))
+		for j := range shape {
+			shape[j] = 1 + rand.Intn(maxDimSize)
+		}
+		axis := rand.Intn(len(shape))
+
+		backing := make([]float64, tensor.ProdInts(shape))
+		for j := range backing {
+			if rand.Float64() < pTrue {
+				backing[j] = (rand.Float64() - 0.5) * 2.0
+			}
+		}
+
+		// Compute the targets by a naive logical and/or along axis
+		outer := tensor.ProdInts(shape[:axis])
+		inner := tensor.ProdInts(shape[axis+1:])
+		allTarget := make([]float64, outer*inner)
+		anyTarget := make([]float64, outer*inner)
+		for o := 0; o < outer; o++ {
+			for in := 0; in < inner; in++ {
+				all, any := 1.0, 0.0
+				for a := 0; a < shape[axis]; a++ {
+					if backing[(o*shape[axis]+a)*inner+in] != 0 {
+						any = 1.0
+					} else {
+						all = 0.0
+					}
+				}
+				allTarget[o*inner+in] = all
+				anyTarget[o*inner+in] = any
+			}
+		}
+
+		for _, dt := range []tensor.Dtype{tensor.Float64, tensor.Float32} {
+			var inTensor *tensor.Dense
+			if dt == tensor.Float64 {
+				inTensor = tensor.NewDense(dt, shape,
+					tensor.WithBacking(backing))
+			} else {
+				backing32 := make([]float32, len(backing))
+				for j := range backing {
+					backing32[j] = float32(backing[j])
+				}
+				inTensor = tensor.NewDense(dt, shape,
+					tensor.WithBacking(backing32))
+			}
+
+			for _, keepdims := range []bool{true, false} {
+				// With keepdims, only axis is removed, otherwise all axes
+				// of length 1 are also squeezed
+				var targetShape tensor.Shape
+				for j, dim := range shape {
+					if j != axis && (keepdims || dim != 1) {
+						targetShape = append(targetShape, dim)
+					}
+				}
+
+				g := G.NewGraph()
+				x := G.NewTensor(g, dt, len(shape),
+					G.WithValue(inTensor))
+
+				allNode, err := ReduceAll(x, axis, keepdims)
+				if err != nil {
+					t.Fatal(err)
+				}
+				anyNode, err := ReduceAny(x, axis, keepdims)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var allVal, anyVal G.Value
+				G.Read(allNode, &allVal)
+				G.Read(anyNode, &anyVal)
+
+				vm := G.NewTapeMachine(g)
+				if err := vm.RunAll(); err != nil {
+					t.Fatal(err)
+				}
+				vm.Close()
+
+				for _, test := range []struct {
+					name     string
+					computed G.Value
+					target   []float64
+				}{
+					{"ReduceAll", allVal, allTarget},
+					{"ReduceAny", anyVal, anyTarget},
+				} {
+					if !test.computed.Shape().Eq(targetShape) {
+						t.Errorf("%v: shape %v along axis %v with keepdims "+
+							"%v: expected shape %v but got %v", test.name,
+							shape, axis, keepdims, targetShape,
+							test.computed.Shape())
+					}
+					if test.computed.Dtype() != dt {
+						t.Errorf("%v: expected data type %v but got %v",
+							test.name, dt, test.computed.Dtype())
+					}
+
+					var computed []float64
+					switch data := test.computed.Data().(type) {
+					case float64:
+						computed = []float64{data}
+					case []float64:
+						computed = data
+					case float32:
+						computed = []float64{float64(data)}
+					case []float32:
+						for _, v := range data {
+							computed = append(computed, float64(v))
+						}
+					}
+
+					if len(computed) != len(test.target) {
+						t.Errorf("%v: expected %v elements but got %v",
+							test.name, len(test.target), len(computed))
+						continue
+					}
+					for j := range computed {
+						if computed[j] != test.target[j] {
+							t.Errorf("%v: shape %v along axis %v with "+
+								"data type %v and keepdims %v \nexpected: "+
+								"%v \nreceived: %v", test.name, shape, axis,
+								dt, keepdims, test.target, computed)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestReduceTree tests that the pairwise tree reduction used for
+// associative functions computes the same values and gradients as the
+// sequential reduction of ReduceAlong, while adding fewer nodes to the
+// graph
+func TestReduceTree(t *testing.T) {
+	const threshold float64 = 0.00001 // Threshold to consider floats equal
+	rand.Seed(time.Now().UnixNano())
+
+	fs := []func(*G.Node, *G.Node) (*G.Node, error){G.Add, G.HadamardProd}
+
+	// reduce builds a graph reducing x along axis 1 and returns the
+	// output, the gradient of its sum with respect to x, and the number
+	// of nodes added to the graph by the reduction
+	reduce := func(backing []float64, shape []int, keepdims bool,
+		f func(*G.Node, *G.Node) (*G.Node, error),
+		associative bool) ([]float64, []float64, int) {
+		g := G.NewGraph()
+		x := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("x"),
+			G.WithValue(tensor.NewDense(tensor.Float64, shape,
+				tensor.WithBacking(append([]float64{}, backing...)))))
+		nodes := len(g.AllNodes())
+
+		out, err := reduceAlong(x, 1, keepdims, f, associative)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes = len(g.AllNodes()) - nodes
+
+		var outVal, gradVal G.Value
+		G.Read(out, &outVal)
+		loss := G.Must(G.Sum(out))
+		grad, err := G.Grad(loss, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		G.Read(grad[0], &gradVal)
+
+		vm := G.NewTapeMachine(g)
+		defer vm.Close()
+		if err := vm.RunAll(); err != nil {
+			t.Fatal(err)
+		}
+
+		return append([]float64{}, outVal.Data().([]float64)...),
+			append([]float64{}, gradVal.Data().([]float64)...), nodes
+	}
+
+	for length := 1; length <= 17; length++ {
+		for _, shape := range [][]int{{3, length, 2}, {2, length}} {
+			backing := make([]float64, tensor.ProdInts(shape))
+			for i := range backing {
+				backing[i] = 0.5 + rand.Float64()
+			}
+
+			for _, f := range fs {
+				for _, keepdims := range []bool{true, false} {
+					seqOut, seqGrad, seqNodes := reduce(backing, shape,
+						keepdims, f, false)
+					treeOut, treeGrad, treeNodes := reduce(backing, shape,
+						keepdims, f, true)
+
+					if len(seqOut) != len(treeOut) {
+						t.Fatalf("expected %v outputs but got %v",
+							len(seqOut), len(treeOut))
+					}
+					for i := range seqOut {
+						if math.Abs(seqOut[i]-treeOut[i]) > threshold {
+							t.Errorf("shape %v: expected: %v received: %v",
+								shape, seqOut[i], treeOut[i])
+						}
+					}
+					for i := range seqGrad {
+						if math.Abs(seqGrad[i]-treeGrad[i]) > threshold {
+							t.Errorf("shape %v: expected gradient: %v "+
+								"received: %v", shape, seqGrad[i],
+								treeGrad[i])
+						}
+					}
+					if length >= 8 && treeNodes >= seqNodes {
+						t.Errorf("shape %v: expected tree reduction to add "+
+							"fewer than %v nodes but added %v", shape,
+							seqNodes, treeNodes)
+					}
+				}
+			}
+		}
+	}
+}
+
+// benchmarkShapes are the shapes of the random tensors reduced in the
+// reduction benchmarks, along their last axis
+var benchmarkShapes = [][]int{
+	{10000},
+	{100, 100},
+	{1000, 100},
+	{10, 100, 100},
+}
+
+// benchmarkReduce benchmarks running a graph which reduces a large
+// random tensor along its last axis with f, for each shape in
+// benchmarkShapes. The graph is built once and the VM is reset before
+// each run.
+func benchmarkReduce(b *testing.B, f func(*G.Node, int, bool) (*G.Node,
+	error)) {
+	for _, shape := range benchmarkShapes {
+		b.Run(fmt.Sprint(shape), func(b *testing.B) {
+			backing := make([]float64, tensor.ProdInts(shape))
+			for i := range backing {
+				backing[i] = rand.Float64()
+			}
+
+			g := G.NewGraph()
+			x := G.NewTensor(g, tensor.Float64, len(shape), G.WithName("x"),
+				G.WithValue(tensor.NewDense(tensor.Float64, shape,
+					tensor.WithBacking(backing))))
+			if _, err := f(x, len(shape)-1, false); err != nil {
+				b.Fatal(err)
+			}
+
+			vm := G.NewTapeMachine(g)
+			defer vm.Close()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				vm.Reset()
+				if err := vm.RunAll(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkReduceBuild benchmarks building a graph which reduces a
+// large tensor along its last axis with f, for each shape in
+// benchmarkShapes
+func benchmarkReduceBuild(b *testing.B, f func(*G.Node, int, bool) (*G.Node,
+	error)) {
+	for _, shape := range benchmarkShapes {
+		b.Run(fmt.Sprint(shape), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g := G.NewGraph()
+				x := G.NewTensor(g, tensor.Float64, len(shape),
+					G.WithShape(shape...), G.WithName("x"))
+				if _, err := f(x, len(shape)-1, false); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReduceMean(b *testing.B) {
+	benchmarkReduce(b, ReduceMean)
+}
+
+func BenchmarkReduceAdd(b *testing.B) {
+	benchmarkReduce(b, ReduceAdd)
+}
+
+func BenchmarkReduceProd(b *testing.B) {
+	benchmarkReduce(b, ReduceProd)
+}
+
+func BenchmarkReduceLogSumExp(b *testing.B) {
+	benchmarkReduce(b, ReduceLogSumExp)
+}
+
+func BenchmarkReduceVar(b *testing.B) {
+	benchmarkReduce(b, func(x *G.Node, axis int, keepdims bool) (*G.Node,
+		error) {
+		return ReduceVar(x, axis, keepdims, false)
+	})
+}
+
+// BenchmarkReduceAlong benchmarks ReduceAlong against ReduceAdd, which
+// sums pairwise in a tree, and G.Sum, which sums along an axis with a
+// single node rather than slicing out each row along the axis
+func BenchmarkReduceAlong(b *testing.B) {
+	b.Run("ReduceAlong", func(b *testing.B) {
+		benchmarkReduce(b, func(x *G.Node, axis int, keepdims bool) (
+			*G.Node, error) {
+			return ReduceAlong(x, axis, keepdims, G.Add)
+		})
+	})
+	b.Run("ReduceAdd", func(b *testing.B) {
+		benchmarkReduce(b, ReduceAdd)
+	})
+	b.Run("Sum", func(b *testing.B) {
+		benchmarkReduce(b, func(x *G.Node, axis int, _ bool) (*G.Node,
+			error) {
+			return G.Sum(x, axis)
+		})
+	})
+}
+
+// BenchmarkReduceAlongBuild benchmarks building the graph of
+// ReduceAlong, which adds a Slice node and a node for f for each row
+// along the reduced axis, against ReduceAdd and G.Sum
+func BenchmarkReduceAlongBuild(b *testing.B) {
+	b.Run("ReduceAlong", func(b *testing.B) {
+		benchmarkReduceBuild(b, func(x *G.Node, axis int, keepdims bool) (
+			*G.Node, error) {
+			return ReduceAlong(x, axis, keepdims, G.Add)
+		})
+	})
+	b.Run("ReduceAdd", func(b *testing.B) {
+		benchmarkReduceBuild(b, ReduceAdd)
+	})
+	b.Run("Sum", func(b *testing.B) {
+		benchmarkReduceBuild(b, func(x *G.Node, axis int, _ bool) (*G.Node,
+			error) {
+			return G.Sum(x, axis)
+		})
+	})
+}
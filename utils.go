@@ -5,6 +5,7 @@ import (
 	"hash"
 	"hash/fnv"
 	"math/rand"
+	"reflect"
 
 	"gorgonia.org/tensor"
 )
@@ -76,3 +77,85 @@ func countOnesBefore(shape tensor.Shape, axis int) int {
 	}
 	return count
 }
+
+// normalizeAxis converts a possibly negative axis index into a
+// non-negative axis index for a tensor with dims dimensions. Negative
+// axes count from the last dimension, so that -1 refers to the last
+// dimension. An error is returned if the axis is out of range.
+func normalizeAxis(axis, dims int) (int, error) {
+	if axis < 0 {
+		axis += dims
+	}
+
+	if axis < 0 || axis >= dims {
+		return 0, ShapeErrorf("axis %v out of range for tensor with %v "+
+			"dimensions", axis, dims)
+	}
+
+	return axis, nil
+}
+
+// checkFloatDtype returns a DtypeError if dt is not float64 or
+// float32, the data types supported by the operation op
+func checkFloatDtype(op string, dt tensor.Dtype) error {
+	if dt != tensor.Float64 && dt != tensor.Float32 {
+		return DtypeErrorf("%v: data type %v unsupported, expected %v or "+
+			"%v", op, dt, tensor.Float64, tensor.Float32)
+	}
+	return nil
+}
+
+// convertDtype returns a copy of t with its elements converted to data
+// type dt. If t already has data type dt, then t is returned.
+func convertDtype(t tensor.Tensor, dt tensor.Dtype) (tensor.Tensor, error) {
+	if t.Dtype() == dt {
+		return t, nil
+	}
+	if !t.Dtype().Type.ConvertibleTo(dt.Type) {
+		return nil, fmt.Errorf("cannot convert data type %v to %v",
+			t.Dtype(), dt)
+	}
+
+	if view, ok := t.(tensor.View); ok && view.IsMaterializable() {
+		t = view.Materialize()
+	}
+
+	data := reflect.ValueOf(t.Data())
+	converted := reflect.MakeSlice(reflect.SliceOf(dt.Type), data.Len(),
+		data.Len())
+	for i := 0; i < data.Len(); i++ {
+		converted.Index(i).Set(data.Index(i).Convert(dt.Type))
+	}
+
+	return tensor.New(tensor.WithShape(t.Shape().Clone()...),
+		tensor.WithBacking(converted.Interface())), nil
+}
+
+// permuteAxis returns a copy of t with the rows along axis permuted, so
+// that row k of t along axis is row index(k) of the output. The index
+// function must be a permutation of the rows along axis.
+func permuteAxis(t tensor.Tensor, axis int, index func(int) int) tensor.Tensor {
+	if view, ok := t.(tensor.View); ok && view.IsMaterializable() {
+		t = view.Materialize()
+	}
+	shape := t.Shape()
+
+	// Each row along the axis holds a contiguous block of inner
+	// elements, repeated for each of the outer indices
+	length := shape[axis]
+	inner := tensor.ProdInts(shape[axis+1:])
+	outer := tensor.ProdInts(shape[:axis])
+
+	in := reflect.ValueOf(t.Data())
+	out := reflect.MakeSlice(in.Type(), in.Len(), in.Len())
+	for o := 0; o < outer; o++ {
+		for k := 0; k < length; k++ {
+			src := (o*length + k) * inner
+			dst := (o*length + index(k)) * inner
+			reflect.Copy(out.Slice(dst, dst+inner), in.Slice(src, src+inner))
+		}
+	}
+
+	return tensor.New(tensor.WithShape(shape.Clone()...),
+		tensor.WithBacking(out.Interface()))
+}